@@ -13,21 +13,27 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-const ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
+const (
+	ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
+	blockIdentifierRegex = `^([0-9]+|0x[a-fA-F0-9]{64})$`
+)
 
 type Api struct {
 	log    log.Logger
 	Router *chi.Mux
 }
 
-func NewApi(logger log.Logger, bv database.BridgeTransfersView) *Api {
+func NewApi(logger log.Logger, bv database.BridgeTransfersView, cv database.CommitmentsView) *Api {
 	r := chi.NewRouter()
-	h := routes.NewRoutes(logger, bv, r)
+	h := routes.NewRoutes(logger, bv, cv, r)
 
 	r.Use(middleware.Heartbeat("/healthz"))
 
 	r.Get(fmt.Sprintf("/api/v0/deposits/{address:%s}", ethereumAddressRegex), h.L1DepositsHandler)
 	r.Get(fmt.Sprintf("/api/v0/withdrawals/{address:%s}", ethereumAddressRegex), h.L2WithdrawalsHandler)
+	r.Get(fmt.Sprintf("/api/v0/commitments/{address:%s}", ethereumAddressRegex), h.CommitmentsHandler)
+	r.Get(fmt.Sprintf("/api/v0/commitments/{address:%s}/fee-recipients", ethereumAddressRegex), h.FeeRecipientCommitmentsHandler)
+	r.Get(fmt.Sprintf("/api/v0/screenings/{block:%s}", blockIdentifierRegex), h.L2BlockScreeningHandler)
 	return &Api{log: logger, Router: r}
 }
 
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,9 +77,78 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.
 		},
 	}, nil
 }
+
+// MockCommitmentsView mocks the CommitmentsView interface
+type MockCommitmentsView struct{}
+
+var (
+	commitment = database.SequencerCommitment{
+		GUID:         uuid.New(),
+		Account:      common.HexToAddress(mockAddress),
+		CommitmentID: big.NewInt(1),
+	}
+
+	feeRecipientCommitment = database.FeeRecipientCommitment{
+		SequencerAddress: common.HexToAddress(mockAddress),
+		L2BlockNumber:    big.NewInt(420),
+		FeeRecipient:     common.HexToAddress("0x420"),
+	}
+
+	screening = database.L2BlockScreening{
+		L2BlockHash:      common.HexToHash("0x420"),
+		L2BlockNumber:    big.NewInt(420),
+		SequencerAddress: common.HexToAddress(mockAddress),
+		Satisfied:        true,
+	}
+)
+
+func (mcv *MockCommitmentsView) SequencerCommitment(guid uuid.UUID) (*database.SequencerCommitment, error) {
+	return &commitment, nil
+}
+
+func (mcv *MockCommitmentsView) SequencerCommitmentWithFilter(filter database.SequencerCommitment) (*database.SequencerCommitment, error) {
+	return &commitment, nil
+}
+
+func (mcv *MockCommitmentsView) SequencerCommitmentsByAccount(address common.Address, cursor string, limit int) (*database.SequencerCommitmentsResponse, error) {
+	return &database.SequencerCommitmentsResponse{
+		Commitments: []database.SequencerCommitmentWithTransactionHashes{
+			{
+				SequencerCommitment:      commitment,
+				CreatedL1TransactionHash: common.HexToHash("0x123"),
+			},
+		},
+	}, nil
+}
+
+func (mcv *MockCommitmentsView) FeeRecipientCommitment(sequencer common.Address, l2BlockNumber *big.Int) (*database.FeeRecipientCommitment, error) {
+	return &feeRecipientCommitment, nil
+}
+
+func (mcv *MockCommitmentsView) FeeRecipientCommitmentsBySequencer(sequencer common.Address, cursor string, limit int) (*database.FeeRecipientCommitmentsResponse, error) {
+	return &database.FeeRecipientCommitmentsResponse{
+		Commitments: []database.FeeRecipientCommitment{feeRecipientCommitment},
+	}, nil
+}
+
+func (mcv *MockCommitmentsView) L2BlockScreening(hash common.Hash) (*database.L2BlockScreening, error) {
+	return &screening, nil
+}
+
+func (mcv *MockCommitmentsView) L2BlockScreeningWithFilter(filter database.L2BlockScreening) (*database.L2BlockScreening, error) {
+	if filter.L2BlockNumber != nil && filter.L2BlockNumber.Cmp(screening.L2BlockNumber) != 0 {
+		return nil, nil
+	}
+	return &screening, nil
+}
+
+func (mcv *MockCommitmentsView) L2LatestBlockScreening() (*database.L2BlockScreening, error) {
+	return &screening, nil
+}
+
 func TestHealthz(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	api := NewApi(logger, &MockBridgeTransfersView{})
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockCommitmentsView{})
 	request, err := http.NewRequest("GET", "/healthz", nil)
 	assert.Nil(t, err)
 
@@ -89,7 +160,7 @@ func TestHealthz(t *testing.T) {
 
 func TestL1BridgeDepositsHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	api := NewApi(logger, &MockBridgeTransfersView{})
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockCommitmentsView{})
 	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/deposits/%s", mockAddress), nil)
 	assert.Nil(t, err)
 
@@ -101,7 +172,7 @@ func TestL1BridgeDepositsHandler(t *testing.T) {
 
 func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	api := NewApi(logger, &MockBridgeTransfersView{})
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockCommitmentsView{})
 	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/withdrawals/%s", mockAddress), nil)
 	assert.Nil(t, err)
 
@@ -110,3 +181,48 @@ func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, responseRecorder.Code)
 }
+
+func TestCommitmentsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockCommitmentsView{})
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/commitments/%s", mockAddress), nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestFeeRecipientCommitmentsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockCommitmentsView{})
+	request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/commitments/%s/fee-recipients", mockAddress), nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestL2BlockScreeningHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	api := NewApi(logger, &MockBridgeTransfersView{}, &MockCommitmentsView{})
+
+	for _, block := range []string{"420", screening.L2BlockHash.String()} {
+		request, err := http.NewRequest("GET", fmt.Sprintf("/api/v0/screenings/%s", block), nil)
+		assert.Nil(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.Router.ServeHTTP(responseRecorder, request)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	}
+
+	request, err := http.NewRequest("GET", "/api/v0/screenings/421", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.Router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
@@ -0,0 +1,187 @@
+package routes
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+)
+
+type CommitmentItem struct {
+	Guid         string      `json:"guid"`
+	Account      string      `json:"account"`
+	Target       string      `json:"target"`
+	CommitmentId string      `json:"commitmentId"`
+	Contract     string      `json:"contract"`
+	Active       bool        `json:"active"`
+	CreatedTx    Transaction `json:"createdTx"`
+	RemovedTx    Transaction `json:"removedTx"`
+}
+
+type CommitmentResponse struct {
+	Cursor      string           `json:"cursor"`
+	HasNextPage bool             `json:"hasNextPage"`
+	Items       []CommitmentItem `json:"items"`
+}
+
+type FeeRecipientCommitmentItem struct {
+	Sequencer     string `json:"sequencer"`
+	FeeRecipient  string `json:"feeRecipient"`
+	L2BlockNumber string `json:"l2BlockNumber"`
+	Timestamp     uint64 `json:"timestamp"`
+}
+
+type FeeRecipientCommitmentResponse struct {
+	Cursor      string                       `json:"cursor"`
+	HasNextPage bool                         `json:"hasNextPage"`
+	Items       []FeeRecipientCommitmentItem `json:"items"`
+}
+
+type ScreeningResponse struct {
+	Block        Block  `json:"block"`
+	Sequencer    string `json:"sequencer"`
+	FeeRecipient string `json:"feeRecipient"`
+	Satisfied    bool   `json:"satisfied"`
+	Timestamp    uint64 `json:"timestamp"`
+}
+
+func newCommitmentResponse(commitments *database.SequencerCommitmentsResponse) CommitmentResponse {
+	items := make([]CommitmentItem, 0, len(commitments.Commitments))
+	for _, commitment := range commitments.Commitments {
+		item := CommitmentItem{
+			Guid:         commitment.SequencerCommitment.GUID.String(),
+			Account:      commitment.SequencerCommitment.Account.String(),
+			Target:       commitment.SequencerCommitment.Target.String(),
+			CommitmentId: commitment.SequencerCommitment.CommitmentID.String(),
+			Contract:     commitment.SequencerCommitment.ContractAddress.String(),
+			Active:       commitment.SequencerCommitment.RemovedL1EventGUID == nil,
+			CreatedTx: Transaction{
+				TransactionHash: commitment.CreatedL1TransactionHash.String(),
+				Timestamp:       commitment.SequencerCommitment.Timestamp,
+			},
+		}
+		if commitment.SequencerCommitment.RemovedL1EventGUID != nil {
+			item.RemovedTx = Transaction{TransactionHash: commitment.RemovedL1TransactionHash.String()}
+		}
+		items = append(items, item)
+	}
+
+	return CommitmentResponse{
+		Cursor:      commitments.Cursor,
+		HasNextPage: commitments.HasNextPage,
+		Items:       items,
+	}
+}
+
+func newFeeRecipientCommitmentResponse(commitments *database.FeeRecipientCommitmentsResponse) FeeRecipientCommitmentResponse {
+	items := make([]FeeRecipientCommitmentItem, 0, len(commitments.Commitments))
+	for _, commitment := range commitments.Commitments {
+		items = append(items, FeeRecipientCommitmentItem{
+			Sequencer:     commitment.SequencerAddress.String(),
+			FeeRecipient:  commitment.FeeRecipient.String(),
+			L2BlockNumber: commitment.L2BlockNumber.String(),
+			Timestamp:     commitment.Timestamp,
+		})
+	}
+
+	return FeeRecipientCommitmentResponse{
+		Cursor:      commitments.Cursor,
+		HasNextPage: commitments.HasNextPage,
+		Items:       items,
+	}
+}
+
+func (h Routes) CommitmentsHandler(w http.ResponseWriter, r *http.Request) {
+	address := common.HexToAddress(chi.URLParam(r, "address"))
+	cursor := r.URL.Query().Get("cursor")
+	limit, ok := h.parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	commitments, err := h.CommitmentsView.SequencerCommitmentsByAccount(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading commitments", http.StatusInternalServerError)
+		h.Logger.Error("Unable to read commitments from DB", "err", err)
+		return
+	}
+
+	response := newCommitmentResponse(commitments)
+
+	jsonResponse(w, h.Logger, response, http.StatusOK)
+}
+
+func (h Routes) FeeRecipientCommitmentsHandler(w http.ResponseWriter, r *http.Request) {
+	address := common.HexToAddress(chi.URLParam(r, "address"))
+	cursor := r.URL.Query().Get("cursor")
+	limit, ok := h.parseLimit(w, r)
+	if !ok {
+		return
+	}
+
+	commitments, err := h.CommitmentsView.FeeRecipientCommitmentsBySequencer(address, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading fee recipient commitments", http.StatusInternalServerError)
+		h.Logger.Error("Unable to read fee recipient commitments from DB", "err", err)
+		return
+	}
+
+	response := newFeeRecipientCommitmentResponse(commitments)
+
+	jsonResponse(w, h.Logger, response, http.StatusOK)
+}
+
+// L2BlockScreeningHandler returns the screening outcome of an L2 block, identified by number or hash
+func (h Routes) L2BlockScreeningHandler(w http.ResponseWriter, r *http.Request) {
+	block := chi.URLParam(r, "block")
+
+	var filter database.L2BlockScreening
+	if number, ok := new(big.Int).SetString(block, 10); ok {
+		filter.L2BlockNumber = number
+	} else {
+		filter.L2BlockHash = common.HexToHash(block)
+	}
+
+	screening, err := h.CommitmentsView.L2BlockScreeningWithFilter(filter)
+	if err != nil {
+		http.Error(w, "Internal server error reading screening", http.StatusInternalServerError)
+		h.Logger.Error("Unable to read screening from DB", "err", err)
+		return
+	} else if screening == nil {
+		http.Error(w, "Block has not been screened", http.StatusNotFound)
+		return
+	}
+
+	response := ScreeningResponse{
+		Block: Block{
+			BlockNumber: screening.L2BlockNumber.Int64(),
+			BlockHash:   screening.L2BlockHash.String(),
+		},
+		Sequencer:    screening.SequencerAddress.String(),
+		FeeRecipient: screening.FeeRecipient.String(),
+		Satisfied:    screening.Satisfied,
+		Timestamp:    screening.Timestamp,
+	}
+
+	jsonResponse(w, h.Logger, response, http.StatusOK)
+}
+
+func (h Routes) parseLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	limitQuery := r.URL.Query().Get("limit")
+
+	defaultLimit := 100
+	if limitQuery == "" {
+		return defaultLimit, true
+	}
+
+	limit, err := strconv.Atoi(limitQuery)
+	if err != nil {
+		http.Error(w, "Limit could not be parsed into a number", http.StatusBadRequest)
+		h.Logger.Error("Invalid limit", "err", err)
+		return 0, false
+	}
+	return limit, true
+}
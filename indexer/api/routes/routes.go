@@ -9,13 +9,15 @@ import (
 type Routes struct {
 	Logger              log.Logger
 	BridgeTransfersView database.BridgeTransfersView
+	CommitmentsView     database.CommitmentsView
 	Router              *chi.Mux
 }
 
-func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, r *chi.Mux) Routes {
+func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, cv database.CommitmentsView, r *chi.Mux) Routes {
 	return Routes{
 		Logger:              logger,
 		BridgeTransfersView: bv,
+		CommitmentsView:     cv,
 		Router:              r,
 	}
 }
@@ -62,7 +62,7 @@ func runApi(ctx *cli.Context) error {
 	}
 	defer db.Close()
 
-	api := api.NewApi(log, db.BridgeTransfers)
+	api := api.NewApi(log, db.BridgeTransfers, db.Commitments)
 	return api.Listen(ctx.Context, cfg.HTTPServer.Port)
 }
 
@@ -69,6 +69,10 @@ type ChainConfig struct {
 	L1Contracts      L1Contracts `toml:"l1-contracts"`
 	L1StartingHeight uint        `toml:"l1-starting-height"`
 
+	// Optional sequencer commitment contracts. These are not part of
+	// the presets and are left unset for chains without commitments.
+	Commitments CommitmentsConfig `toml:"commitments"`
+
 	// These configuration options will be removed once
 	// native reorg handling is implemented
 	L1ConfirmationDepth uint `toml:"l1-confirmation-depth"`
@@ -81,6 +85,31 @@ type ChainConfig struct {
 	L2HeaderBufferSize uint `toml:"l2-header-buffer-size"`
 }
 
+// CommitmentsConfig configures the L1 contracts of the sequencer commitment layer
+type CommitmentsConfig struct {
+	// The sequencer whose commitments are used when screening indexed L2 blocks
+	Sequencer common.Address `toml:"sequencer"`
+
+	CommitmentManager      common.Address `toml:"commitment-manager"`
+	FeeRecipientCommitment common.Address `toml:"fee-recipient-commitment"`
+}
+
+// Enabled returns true if any of the commitment contracts are configured
+func (c *CommitmentsConfig) Enabled() bool {
+	return c.CommitmentManager != (common.Address{}) || c.FeeRecipientCommitment != (common.Address{})
+}
+
+// ContractsSlice returns the configured commitment contracts, omitting unset addresses
+func (c *CommitmentsConfig) ContractsSlice() []common.Address {
+	var contracts []common.Address
+	for _, addr := range []common.Address{c.CommitmentManager, c.FeeRecipientCommitment} {
+		if addr != (common.Address{}) {
+			contracts = append(contracts, addr)
+		}
+	}
+	return contracts
+}
+
 // RPCsConfig configures the RPC urls
 type RPCsConfig struct {
 	L1RPC string `toml:"l1-rpc"`
@@ -198,3 +198,37 @@ func Test_AsSliceSuccess(t *testing.T) {
 	require.Equal(t, slice[2].String(), testCfg.L1CrossDomainMessengerProxy.String())
 	require.Equal(t, slice[3].String(), testCfg.L1StandardBridgeProxy.String())
 }
+
+func Test_LoadConfig_Commitments(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test_commitments.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	testData := `
+	[chain]
+	preset = 420
+
+	[chain.commitments]
+	sequencer = "0x4204204204204204204204204204204204204204"
+	fee-recipient-commitment = "0x42042042042042042042042042042042042042fe"`
+
+	data := []byte(testData)
+	err = os.WriteFile(tmpfile.Name(), data, 0644)
+	require.NoError(t, err)
+
+	err = tmpfile.Close()
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	conf, err := LoadConfig(logger, tmpfile.Name())
+	require.NoError(t, err)
+
+	// presets do not override the commitment contracts
+	require.True(t, conf.Chain.Commitments.Enabled())
+	require.Equal(t, conf.Chain.Commitments.Sequencer, common.HexToAddress("0x4204204204204204204204204204204204204204"))
+
+	slice := conf.Chain.Commitments.ContractsSlice()
+	require.Len(t, slice, 1)
+	require.Equal(t, slice[0], common.HexToAddress("0x42042042042042042042042042042042042042fe"))
+}
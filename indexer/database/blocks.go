@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -72,6 +73,7 @@ type BlocksView interface {
 	L2BlockHeader(common.Hash) (*L2BlockHeader, error)
 	L2BlockHeaderWithFilter(BlockHeader) (*L2BlockHeader, error)
 	L2LatestBlockHeader() (*L2BlockHeader, error)
+	L2BlockHeadersInRange(*big.Int, *big.Int) ([]L2BlockHeader, error)
 
 	LatestCheckpointedOutput() (*OutputProposal, error)
 	OutputProposal(index *big.Int) (*OutputProposal, error)
@@ -215,6 +217,30 @@ func (db *blocksDB) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	return &l2Header, nil
 }
 
+// L2BlockHeadersInRange retrieves the indexed L2 block headers within the inclusive range, in ascending order
+func (db *blocksDB) L2BlockHeadersInRange(fromHeight, toHeight *big.Int) ([]L2BlockHeader, error) {
+	if fromHeight == nil {
+		fromHeight = big.NewInt(0)
+	}
+	if toHeight == nil {
+		return nil, errors.New("end height unspecified")
+	}
+	if fromHeight.Cmp(toHeight) > 0 {
+		return nil, fmt.Errorf("fromHeight %d is greater than toHeight %d", fromHeight, toHeight)
+	}
+
+	var l2Headers []L2BlockHeader
+	result := db.gorm.Where("number >= ? AND number <= ?", fromHeight, toHeight).Order("number ASC").Find(&l2Headers)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return l2Headers, nil
+}
+
 // Auxiliary Methods on both L1 & L2
 
 type Epoch struct {
@@ -0,0 +1,314 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"gorm.io/gorm"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * Types
+ */
+
+type SequencerCommitment struct {
+	GUID uuid.UUID `gorm:"primaryKey"`
+
+	Account         common.Address `gorm:"serializer:bytes"`
+	Target          common.Hash    `gorm:"serializer:bytes"`
+	CommitmentID    *big.Int       `gorm:"serializer:u256"`
+	ContractAddress common.Address `gorm:"serializer:bytes"`
+
+	CreatedL1EventGUID uuid.UUID
+	RemovedL1EventGUID *uuid.UUID
+
+	Timestamp uint64
+}
+
+type SequencerCommitmentWithTransactionHashes struct {
+	SequencerCommitment SequencerCommitment `gorm:"embedded"`
+
+	CreatedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+	RemovedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+}
+
+type FeeRecipientCommitment struct {
+	SequencerAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+	L2BlockNumber    *big.Int       `gorm:"primaryKey;serializer:u256"`
+	FeeRecipient     common.Address `gorm:"serializer:bytes"`
+
+	SetL1EventGUID uuid.UUID
+	Timestamp      uint64
+}
+
+// L2BlockScreening is the screening verdict of an L2 block. The verdict is recomputed when a
+// commitment for the block is indexed after the block was screened.
+type L2BlockScreening struct {
+	L2BlockHash      common.Hash    `gorm:"primaryKey;serializer:bytes"`
+	L2BlockNumber    *big.Int       `gorm:"serializer:u256"`
+	SequencerAddress common.Address `gorm:"serializer:bytes"`
+	FeeRecipient     common.Address `gorm:"serializer:bytes"`
+
+	Satisfied bool
+	Timestamp uint64
+}
+
+type CommitmentsView interface {
+	SequencerCommitment(uuid.UUID) (*SequencerCommitment, error)
+	SequencerCommitmentWithFilter(SequencerCommitment) (*SequencerCommitment, error)
+	SequencerCommitmentsByAccount(common.Address, string, int) (*SequencerCommitmentsResponse, error)
+
+	FeeRecipientCommitment(common.Address, *big.Int) (*FeeRecipientCommitment, error)
+	FeeRecipientCommitmentsBySequencer(common.Address, string, int) (*FeeRecipientCommitmentsResponse, error)
+
+	L2BlockScreening(common.Hash) (*L2BlockScreening, error)
+	L2BlockScreeningWithFilter(L2BlockScreening) (*L2BlockScreening, error)
+	L2LatestBlockScreening() (*L2BlockScreening, error)
+}
+
+type CommitmentsDB interface {
+	CommitmentsView
+
+	StoreSequencerCommitments([]SequencerCommitment) error
+	MarkSequencerCommitmentRemoved(common.Address, common.Hash, *big.Int, uuid.UUID) error
+
+	StoreFeeRecipientCommitments([]FeeRecipientCommitment) error
+	StoreL2BlockScreenings([]L2BlockScreening) error
+	UpdateL2BlockScreeningVerdict(common.Hash, bool) error
+}
+
+/**
+ * Implementation
+ */
+
+type commitmentsDB struct {
+	gorm *gorm.DB
+}
+
+func newCommitmentsDB(db *gorm.DB) CommitmentsDB {
+	return &commitmentsDB{gorm: db}
+}
+
+/**
+ * Commitments made through the CommitmentManager
+ */
+
+func (db *commitmentsDB) StoreSequencerCommitments(commitments []SequencerCommitment) error {
+	result := db.gorm.Create(&commitments)
+	return result.Error
+}
+
+func (db *commitmentsDB) SequencerCommitment(guid uuid.UUID) (*SequencerCommitment, error) {
+	return db.SequencerCommitmentWithFilter(SequencerCommitment{GUID: guid})
+}
+
+func (db *commitmentsDB) SequencerCommitmentWithFilter(filter SequencerCommitment) (*SequencerCommitment, error) {
+	var commitment SequencerCommitment
+	result := db.gorm.Where(&filter).Take(&commitment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &commitment, nil
+}
+
+// MarkSequencerCommitmentRemoved marks the active commitment identified by the account, target & id as removed
+func (db *commitmentsDB) MarkSequencerCommitmentRemoved(account common.Address, target common.Hash, id *big.Int, removedL1EventGUID uuid.UUID) error {
+	commitment, err := db.SequencerCommitmentWithFilter(SequencerCommitment{Account: account, Target: target, CommitmentID: id})
+	if err != nil {
+		return err
+	} else if commitment == nil {
+		return fmt.Errorf("commitment %d of account %s for target %s does not exist", id, account, target)
+	} else if commitment.RemovedL1EventGUID != nil {
+		return fmt.Errorf("commitment %d of account %s for target %s already removed", id, account, target)
+	}
+
+	commitment.RemovedL1EventGUID = &removedL1EventGUID
+	result := db.gorm.Save(commitment)
+	return result.Error
+}
+
+type SequencerCommitmentsResponse struct {
+	Commitments []SequencerCommitmentWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// SequencerCommitmentsByAccount retrieves the commitment history of the specified account, most recent first,
+// coupled with the L1 transaction hashes that created and, if applicable, removed each commitment.
+func (db *commitmentsDB) SequencerCommitmentsByAccount(account common.Address, cursor string, limit int) (*SequencerCommitmentsResponse, error) {
+	defaultLimit := 100
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	var cursorCommitment *SequencerCommitment
+	if cursor != "" {
+		guid, err := uuid.Parse(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse cursor %s: %w", cursor, err)
+		}
+		commitment, err := db.SequencerCommitment(guid)
+		if err != nil || commitment == nil {
+			return nil, fmt.Errorf("unable to find commitment with supplied cursor guid %s: %w", guid, err)
+		}
+		cursorCommitment = commitment
+	}
+
+	query := db.gorm.Model(&SequencerCommitment{}).Where(&SequencerCommitment{Account: account})
+	query = query.Joins("INNER JOIN l1_contract_events AS created_l1_events ON created_l1_events.guid = sequencer_commitments.created_l1_event_guid")
+	query = query.Joins("LEFT JOIN l1_contract_events AS removed_l1_events ON removed_l1_events.guid = sequencer_commitments.removed_l1_event_guid")
+	query = query.Select(`
+sequencer_commitments.*, created_l1_events.transaction_hash AS created_l1_transaction_hash,
+removed_l1_events.transaction_hash AS removed_l1_transaction_hash`)
+	// Commitments created in the same L1 block share a timestamp, the guid breaks ties so the cursor is unique
+	query = query.Order("sequencer_commitments.timestamp DESC, sequencer_commitments.guid DESC").Limit(limit + 1)
+	if cursorCommitment != nil {
+		query = query.Where("(sequencer_commitments.timestamp, sequencer_commitments.guid) <= (?, ?)", cursorCommitment.Timestamp, cursorCommitment.GUID)
+	}
+
+	commitments := []SequencerCommitmentWithTransactionHashes{}
+	result := query.Find(&commitments)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(commitments) > limit {
+		hasNextPage = true
+		nextCursor = commitments[limit].SequencerCommitment.GUID.String()
+		commitments = commitments[:limit]
+	}
+
+	response := &SequencerCommitmentsResponse{Commitments: commitments, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+/**
+ * Sample FeeRecipientCommitment
+ */
+
+func (db *commitmentsDB) StoreFeeRecipientCommitments(commitments []FeeRecipientCommitment) error {
+	result := db.gorm.Create(&commitments)
+	return result.Error
+}
+
+func (db *commitmentsDB) FeeRecipientCommitment(sequencer common.Address, l2BlockNumber *big.Int) (*FeeRecipientCommitment, error) {
+	var commitment FeeRecipientCommitment
+	result := db.gorm.Where(&FeeRecipientCommitment{SequencerAddress: sequencer, L2BlockNumber: l2BlockNumber}).Take(&commitment)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &commitment, nil
+}
+
+type FeeRecipientCommitmentsResponse struct {
+	Commitments []FeeRecipientCommitment
+	Cursor      string
+	HasNextPage bool
+}
+
+// FeeRecipientCommitmentsBySequencer retrieves the fee recipients committed to by the specified
+// sequencer, ordered by the committed L2 block number in descending order.
+func (db *commitmentsDB) FeeRecipientCommitmentsBySequencer(sequencer common.Address, cursor string, limit int) (*FeeRecipientCommitmentsResponse, error) {
+	defaultLimit := 100
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	query := db.gorm.Model(&FeeRecipientCommitment{}).Where(&FeeRecipientCommitment{SequencerAddress: sequencer})
+	if cursor != "" {
+		l2BlockNumber, ok := new(big.Int).SetString(cursor, 10)
+		if !ok {
+			return nil, fmt.Errorf("unable to parse cursor %s as a block number", cursor)
+		}
+		query = query.Where("l2_block_number <= ?", l2BlockNumber)
+	}
+	query = query.Order("l2_block_number DESC").Limit(limit + 1)
+
+	commitments := []FeeRecipientCommitment{}
+	result := query.Find(&commitments)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(commitments) > limit {
+		hasNextPage = true
+		nextCursor = commitments[limit].L2BlockNumber.String()
+		commitments = commitments[:limit]
+	}
+
+	response := &FeeRecipientCommitmentsResponse{Commitments: commitments, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+/**
+ * Screening outcomes of indexed L2 blocks
+ */
+
+func (db *commitmentsDB) StoreL2BlockScreenings(screenings []L2BlockScreening) error {
+	result := db.gorm.Create(&screenings)
+	return result.Error
+}
+
+// UpdateL2BlockScreeningVerdict updates the verdict of the screened L2 block with the specified hash
+func (db *commitmentsDB) UpdateL2BlockScreeningVerdict(hash common.Hash, satisfied bool) error {
+	result := db.gorm.Model(&L2BlockScreening{}).Where(&L2BlockScreening{L2BlockHash: hash}).Update("satisfied", satisfied)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return fmt.Errorf("screening of l2 block %s does not exist", hash)
+	}
+	return nil
+}
+
+func (db *commitmentsDB) L2BlockScreening(hash common.Hash) (*L2BlockScreening, error) {
+	return db.L2BlockScreeningWithFilter(L2BlockScreening{L2BlockHash: hash})
+}
+
+func (db *commitmentsDB) L2BlockScreeningWithFilter(filter L2BlockScreening) (*L2BlockScreening, error) {
+	var screening L2BlockScreening
+	result := db.gorm.Where(&filter).Take(&screening)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &screening, nil
+}
+
+func (db *commitmentsDB) L2LatestBlockScreening() (*L2BlockScreening, error) {
+	var screening L2BlockScreening
+	result := db.gorm.Order("l2_block_number DESC").Take(&screening)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &screening, nil
+}
@@ -1,4 +1,4 @@
-// Database module defines the data DB struct which wraps specific DB interfaces for L1/L2 block headers, contract events, bridging schemas.
+// Database module defines the data DB struct which wraps specific DB interfaces for L1/L2 block headers, contract events, bridging & commitment schemas.
 package database
 
 import (
@@ -20,6 +20,7 @@ type DB struct {
 	BridgeTransfers    BridgeTransfersDB
 	BridgeMessages     BridgeMessagesDB
 	BridgeTransactions BridgeTransactionsDB
+	Commitments        CommitmentsDB
 }
 
 func NewDB(dbConfig config.DBConfig) (*DB, error) {
@@ -51,6 +52,7 @@ func NewDB(dbConfig config.DBConfig) (*DB, error) {
 		BridgeTransfers:    newBridgeTransfersDB(gorm),
 		BridgeMessages:     newBridgeMessagesDB(gorm),
 		BridgeTransactions: newBridgeTransactionsDB(gorm),
+		Commitments:        newCommitmentsDB(gorm),
 	}
 
 	return db, nil
@@ -81,5 +83,6 @@ func dbFromGormTx(tx *gorm.DB) *DB {
 		BridgeTransfers:    newBridgeTransfersDB(tx),
 		BridgeMessages:     newBridgeMessagesDB(tx),
 		BridgeTransactions: newBridgeTransactionsDB(tx),
+		Commitments:        newCommitmentsDB(tx),
 	}
 }
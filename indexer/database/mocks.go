@@ -52,6 +52,11 @@ func (m *MockBlocksView) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
 }
 
+func (m *MockBlocksView) L2BlockHeadersInRange(*big.Int, *big.Int) ([]L2BlockHeader, error) {
+	args := m.Called()
+	return args.Get(0).([]L2BlockHeader), args.Error(1)
+}
+
 func (m *MockBlocksView) LatestCheckpointedOutput() (*OutputProposal, error) {
 	args := m.Called()
 	return args.Get(0).(*OutputProposal), args.Error(1)
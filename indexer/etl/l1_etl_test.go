@@ -10,7 +10,6 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
 
@@ -24,7 +23,7 @@ func Test_L1ETL_Construction(t *testing.T) {
 		db        *database.MockDB
 		client    *node.MockEthClient
 		start     *big.Int
-		contracts config.L1Contracts
+		contracts []common.Address
 	}
 
 	var tests = []struct {
@@ -54,7 +53,7 @@ func Test_L1ETL_Construction(t *testing.T) {
 					db:        db,
 					client:    client,
 					start:     testStart,
-					contracts: config.L1Contracts{},
+					contracts: []common.Address{},
 				}
 			},
 			assertion: func(etl *L1ETL, err error) {
@@ -84,7 +83,7 @@ func Test_L1ETL_Construction(t *testing.T) {
 					db:        db,
 					client:    client,
 					start:     testStart,
-					contracts: config.L1Contracts{},
+					contracts: []common.Address{},
 				}
 			},
 			assertion: func(etl *L1ETL, err error) {
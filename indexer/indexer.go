@@ -34,9 +34,10 @@ type Indexer struct {
 	metricsConfig   config.ServerConfig
 	metricsRegistry *prometheus.Registry
 
-	L1ETL           *etl.L1ETL
-	L2ETL           *etl.L2ETL
-	BridgeProcessor *processors.BridgeProcessor
+	L1ETL               *etl.L1ETL
+	L2ETL               *etl.L2ETL
+	BridgeProcessor     *processors.BridgeProcessor
+	CommitmentProcessor *processors.CommitmentProcessor
 }
 
 // NewIndexer initializes an instance of the Indexer
@@ -61,7 +62,12 @@ func NewIndexer(
 		ConfirmationDepth: big.NewInt(int64(chainConfig.L1ConfirmationDepth)),
 		StartHeight:       big.NewInt(int64(chainConfig.L1StartingHeight)),
 	}
-	l1Etl, err := etl.NewL1ETL(l1Cfg, log, db, etl.NewMetrics(metricsRegistry, "l1"), l1EthClient, chainConfig.L1Contracts)
+	l1Contracts, err := chainConfig.L1Contracts.AsSlice()
+	if err != nil {
+		return nil, err
+	}
+	l1Contracts = append(l1Contracts, chainConfig.Commitments.ContractsSlice()...)
+	l1Etl, err := etl.NewL1ETL(l1Cfg, log, db, etl.NewMetrics(metricsRegistry, "l1"), l1EthClient, l1Contracts)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +93,15 @@ func NewIndexer(
 		return nil, err
 	}
 
+	// Commitments (optional)
+	var commitmentProcessor *processors.CommitmentProcessor
+	if chainConfig.Commitments.Enabled() {
+		commitmentProcessor, err = processors.NewCommitmentProcessor(log, db, l1Etl, chainConfig.Commitments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	indexer := &Indexer{
 		log: log,
 		db:  db,
@@ -95,9 +110,10 @@ func NewIndexer(
 		metricsConfig:   metricsConfig,
 		metricsRegistry: metricsRegistry,
 
-		L1ETL:           l1Etl,
-		L2ETL:           l2Etl,
-		BridgeProcessor: bridgeProcessor,
+		L1ETL:               l1Etl,
+		L2ETL:               l2Etl,
+		BridgeProcessor:     bridgeProcessor,
+		CommitmentProcessor: commitmentProcessor,
 	}
 
 	return indexer, nil
@@ -135,7 +151,7 @@ func (i *Indexer) startMetricsServer(ctx context.Context) error {
 // Start starts the indexing service on L1 and L2 chains
 func (i *Indexer) Run(ctx context.Context) error {
 	var wg sync.WaitGroup
-	errCh := make(chan error, 5)
+	errCh := make(chan error, 6)
 
 	// if any goroutine halts, we stop the entire indexer
 	processCtx, processCancel := context.WithCancel(ctx)
@@ -161,6 +177,9 @@ func (i *Indexer) Run(ctx context.Context) error {
 	runProcess(i.L1ETL.Start)
 	runProcess(i.L2ETL.Start)
 	runProcess(i.BridgeProcessor.Start)
+	if i.CommitmentProcessor != nil {
+		runProcess(i.CommitmentProcessor.Start)
+	}
 	runProcess(i.startMetricsServer)
 	runProcess(i.startHttpServer)
 	wg.Wait()
@@ -0,0 +1,43 @@
+/**
+ * SEQUENCER COMMITMENT DATA
+ */
+
+-- CommitmentManager
+CREATE TABLE IF NOT EXISTS sequencer_commitments (
+    guid             VARCHAR PRIMARY KEY,
+    account          VARCHAR NOT NULL,
+    target           VARCHAR NOT NULL,
+    commitment_id    UINT256 NOT NULL,
+    contract_address VARCHAR NOT NULL,
+
+    created_l1_event_guid VARCHAR NOT NULL UNIQUE REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+    removed_l1_event_guid VARCHAR UNIQUE REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+
+    timestamp INTEGER NOT NULL CHECK (timestamp > 0),
+
+    UNIQUE (account, target, commitment_id)
+);
+CREATE INDEX IF NOT EXISTS sequencer_commitments_account_timestamp ON sequencer_commitments(account, timestamp, guid);
+
+-- FeeRecipientCommitment (sample commitment)
+CREATE TABLE IF NOT EXISTS fee_recipient_commitments (
+    sequencer_address VARCHAR NOT NULL,
+    l2_block_number   UINT256 NOT NULL,
+    fee_recipient     VARCHAR NOT NULL,
+
+    set_l1_event_guid VARCHAR NOT NULL UNIQUE REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+    timestamp         INTEGER NOT NULL CHECK (timestamp > 0),
+
+    PRIMARY KEY (sequencer_address, l2_block_number)
+);
+
+-- Screening outcome of each indexed L2 block against the indexed commitments
+CREATE TABLE IF NOT EXISTS l2_block_screenings (
+    l2_block_hash     VARCHAR PRIMARY KEY REFERENCES l2_block_headers(hash) ON DELETE CASCADE,
+    l2_block_number   UINT256 NOT NULL UNIQUE,
+    sequencer_address VARCHAR NOT NULL,
+    fee_recipient     VARCHAR NOT NULL,
+
+    satisfied BOOLEAN NOT NULL,
+    timestamp INTEGER NOT NULL CHECK (timestamp > 0)
+);
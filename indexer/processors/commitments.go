@@ -0,0 +1,126 @@
+package processors
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/etl"
+	"github.com/ethereum-optimism/optimism/indexer/processors/commitments"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type CommitmentProcessor struct {
+	log               log.Logger
+	db                *database.DB
+	l1Etl             *etl.L1ETL
+	commitmentsConfig config.CommitmentsConfig
+
+	LatestL1Header *types.Header
+	LatestL2Header *types.Header
+}
+
+func NewCommitmentProcessor(log log.Logger, db *database.DB, l1Etl *etl.L1ETL, commitmentsConfig config.CommitmentsConfig) (*CommitmentProcessor, error) {
+	log = log.New("processor", "commitments")
+
+	latestL1Header, err := commitments.L1LatestCommitmentEventHeader(db, commitmentsConfig)
+	if err != nil {
+		return nil, err
+	}
+	latestL2Header, err := commitments.L2LatestScreenedHeader(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike bridge events, commitment events on L1 are sparse. Every L2 block is
+	// screened, so indexed L2 state with no L1 commitment events is expected.
+	if latestL1Header == nil && latestL2Header == nil {
+		log.Info("no indexed state, starting from genesis")
+	} else {
+		log.Info("detected the latest indexed state", "l1_block_number", headerNumber(latestL1Header), "l2_block_number", headerNumber(latestL2Header))
+	}
+
+	return &CommitmentProcessor{log, db, l1Etl, commitmentsConfig, latestL1Header, latestL2Header}, nil
+}
+
+func (c *CommitmentProcessor) Start(ctx context.Context) error {
+	done := ctx.Done()
+
+	// Similar to the bridge processor, the latest indexed epoch serves as the shared
+	// marker between L1 and L2. All commitment events up to the L1 origin are indexed
+	// prior to screening the L2 blocks of the same range.
+
+	l1EtlUpdates := c.l1Etl.Notify()
+	c.log.Info("starting commitment processor...")
+	for {
+		select {
+		case <-done:
+			c.log.Info("stopping commitment processor")
+			return nil
+
+		case <-l1EtlUpdates:
+			latestEpoch, err := c.db.Blocks.LatestEpoch()
+			if err != nil {
+				return err
+			}
+			if latestEpoch == nil {
+				c.log.Warn("no indexed epochs. waiting...")
+				continue
+			}
+
+			if c.LatestL2Header != nil && latestEpoch.L2BlockHeader.Hash == c.LatestL2Header.Hash() {
+				c.log.Warn("all available epochs screened", "latest_epoch_number", latestEpoch.L1BlockHeader.Number)
+				continue
+			}
+
+			toL1Height, toL2Height := latestEpoch.L1BlockHeader.Number, latestEpoch.L2BlockHeader.Number
+			fromL1Height, fromL2Height := big.NewInt(0), big.NewInt(0)
+			if c.LatestL1Header != nil {
+				fromL1Height = new(big.Int).Add(c.LatestL1Header.Number, big.NewInt(1))
+			}
+			if c.LatestL2Header != nil {
+				fromL2Height = new(big.Int).Add(c.LatestL2Header.Number, big.NewInt(1))
+			}
+
+			batchLog := c.log.New("epoch_start_number", fromL1Height, "epoch_end_number", toL1Height)
+			batchLog.Info("scanning for new commitment events")
+			err = c.db.Transaction(func(tx *database.DB) error {
+				if fromL1Height.Cmp(toL1Height) <= 0 {
+					l1CommitmentsLog := c.log.New("from_l1_block_number", fromL1Height, "to_l1_block_number", toL1Height)
+					if err := commitments.L1ProcessCommitmentEvents(l1CommitmentsLog, tx, c.commitmentsConfig, fromL1Height, toL1Height); err != nil {
+						return err
+					}
+				}
+
+				if fromL2Height.Cmp(toL2Height) <= 0 {
+					l2ScreeningLog := c.log.New("from_l2_block_number", fromL2Height, "to_l2_block_number", toL2Height)
+					if err := commitments.L2ScreenBlocks(l2ScreeningLog, tx, c.commitmentsConfig, fromL2Height, toL2Height); err != nil {
+						return err
+					}
+				}
+
+				// a-ok
+				return nil
+			})
+
+			if err != nil {
+				// Try again on a subsequent interval
+				batchLog.Error("unable to index new commitment events", "err", err)
+			} else {
+				batchLog.Info("done indexing new commitment events", "latest_l1_block_number", toL1Height, "latest_l2_block_number", toL2Height)
+				c.LatestL1Header = latestEpoch.L1BlockHeader.RLPHeader.Header()
+				c.LatestL2Header = latestEpoch.L2BlockHeader.RLPHeader.Header()
+			}
+		}
+	}
+}
+
+func headerNumber(header *types.Header) *big.Int {
+	if header == nil {
+		return nil
+	}
+	return header.Number
+}
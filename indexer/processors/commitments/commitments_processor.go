@@ -0,0 +1,235 @@
+package commitments
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/processors/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// L1ProcessCommitmentEvents will query the database for commitment lifecycle events that have
+// been emitted between the specified block range. This covers:
+//  1. CommitmentManager (created commitments)
+//  2. CommitmentManager (removed commitments)
+//  3. FeeRecipientCommitment (sample commitment)
+//
+// Fee recipient commitments may be indexed after the L2 block they commit to was screened,
+// in which case the verdict of the screened block is recomputed against the new commitment.
+func L1ProcessCommitmentEvents(log log.Logger, db *database.DB, commitmentsConfig config.CommitmentsConfig, fromHeight *big.Int, toHeight *big.Int) error {
+	if commitmentsConfig.CommitmentManager != (common.Address{}) {
+		// (1) CommitmentManager (created)
+		createdCommitments, err := contracts.CommitmentManagerCommitmentCreatedEvents(commitmentsConfig.CommitmentManager, db, fromHeight, toHeight)
+		if err != nil {
+			return err
+		}
+
+		sequencerCommitments := make([]database.SequencerCommitment, len(createdCommitments))
+		for i := range createdCommitments {
+			created := createdCommitments[i]
+			sequencerCommitments[i] = database.SequencerCommitment{
+				GUID:               created.Event.GUID,
+				Account:            created.Account,
+				Target:             created.Target,
+				CommitmentID:       created.Id,
+				ContractAddress:    created.Commitment,
+				CreatedL1EventGUID: created.Event.GUID,
+				Timestamp:          created.Event.Timestamp,
+			}
+		}
+
+		if len(sequencerCommitments) > 0 {
+			log.Info("detected created commitments", "size", len(sequencerCommitments))
+			if err := db.Commitments.StoreSequencerCommitments(sequencerCommitments); err != nil {
+				return err
+			}
+		}
+
+		// (2) CommitmentManager (removed)
+		removedCommitments, err := contracts.CommitmentManagerCommitmentRemovedEvents(commitmentsConfig.CommitmentManager, db, fromHeight, toHeight)
+		if err != nil {
+			return err
+		}
+
+		for i := range removedCommitments {
+			removed := removedCommitments[i]
+			if err := db.Commitments.MarkSequencerCommitmentRemoved(removed.Account, removed.Target, removed.Id, removed.Event.GUID); err != nil {
+				log.Error("unable to mark removed commitment", "account", removed.Account, "id", removed.Id, "tx_hash", removed.Event.TransactionHash, "err", err)
+				return err
+			}
+		}
+
+		if len(removedCommitments) > 0 {
+			log.Info("detected removed commitments", "size", len(removedCommitments))
+		}
+	}
+
+	if commitmentsConfig.FeeRecipientCommitment != (common.Address{}) {
+		// (3) FeeRecipientCommitment
+		feeRecipientsSet, err := contracts.FeeRecipientCommitmentNewFeeRecipientSetEvents(commitmentsConfig.FeeRecipientCommitment, db, fromHeight, toHeight)
+		if err != nil {
+			return err
+		}
+
+		feeRecipientCommitments := make([]database.FeeRecipientCommitment, len(feeRecipientsSet))
+		for i := range feeRecipientsSet {
+			feeRecipientSet := feeRecipientsSet[i]
+			feeRecipientCommitments[i] = database.FeeRecipientCommitment{
+				SequencerAddress: feeRecipientSet.Sequencer,
+				L2BlockNumber:    new(big.Int).SetUint64(feeRecipientSet.BlockNumber),
+				FeeRecipient:     feeRecipientSet.FeeRecipient,
+				SetL1EventGUID:   feeRecipientSet.Event.GUID,
+				Timestamp:        feeRecipientSet.Event.Timestamp,
+			}
+		}
+
+		if len(feeRecipientCommitments) > 0 {
+			log.Info("detected fee recipient commitments", "size", len(feeRecipientCommitments))
+			if err := db.Commitments.StoreFeeRecipientCommitments(feeRecipientCommitments); err != nil {
+				return err
+			}
+		}
+
+		for i := range feeRecipientCommitments {
+			if err := rescreenL2Block(log, db, commitmentsConfig, feeRecipientCommitments[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// a-ok!
+	return nil
+}
+
+// L2ScreenBlocks screens every indexed L2 block within the specified range against the indexed
+// commitments of the configured sequencer. This mirrors FeeRecipientCommitment#commitmentIndicatorFun:
+// a block is satisfied if the sequencer did not commit to a fee recipient for it, or if the
+// block's fee recipient matches the committed one.
+func L2ScreenBlocks(log log.Logger, db *database.DB, commitmentsConfig config.CommitmentsConfig, fromHeight *big.Int, toHeight *big.Int) error {
+	l2BlockHeaders, err := db.Blocks.L2BlockHeadersInRange(fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	violations := 0
+	screenings := make([]database.L2BlockScreening, len(l2BlockHeaders))
+	for i := range l2BlockHeaders {
+		l2BlockHeader := l2BlockHeaders[i]
+		feeRecipient := l2BlockHeader.RLPHeader.Header().Coinbase
+
+		committed, err := db.Commitments.FeeRecipientCommitment(commitmentsConfig.Sequencer, l2BlockHeader.Number)
+		if err != nil {
+			return err
+		}
+
+		satisfied := committed == nil || committed.FeeRecipient == feeRecipient
+		if !satisfied {
+			violations++
+			log.Warn("detected commitment violation", "l2_block_number", l2BlockHeader.Number, "l2_block_hash", l2BlockHeader.Hash,
+				"fee_recipient", feeRecipient, "committed_fee_recipient", committed.FeeRecipient)
+		}
+
+		screenings[i] = database.L2BlockScreening{
+			L2BlockHash:      l2BlockHeader.Hash,
+			L2BlockNumber:    l2BlockHeader.Number,
+			SequencerAddress: commitmentsConfig.Sequencer,
+			FeeRecipient:     feeRecipient,
+			Satisfied:        satisfied,
+			Timestamp:        l2BlockHeader.Timestamp,
+		}
+	}
+
+	if len(screenings) > 0 {
+		log.Info("screened l2 blocks", "size", len(screenings), "violations", violations)
+		if err := db.Commitments.StoreL2BlockScreenings(screenings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rescreenL2Block recomputes the verdict of the L2 block the fee recipient commitment applies to,
+// if the block was already screened
+func rescreenL2Block(log log.Logger, db *database.DB, commitmentsConfig config.CommitmentsConfig, committed database.FeeRecipientCommitment) error {
+	if committed.SequencerAddress != commitmentsConfig.Sequencer {
+		return nil
+	}
+
+	screening, err := db.Commitments.L2BlockScreeningWithFilter(database.L2BlockScreening{L2BlockNumber: committed.L2BlockNumber})
+	if err != nil {
+		return err
+	} else if screening == nil {
+		return nil
+	}
+
+	satisfied := screening.FeeRecipient == committed.FeeRecipient
+	if satisfied == screening.Satisfied {
+		return nil
+	}
+
+	log.Warn("commitment indexed after screening, updating verdict", "l2_block_number", screening.L2BlockNumber, "l2_block_hash", screening.L2BlockHash,
+		"fee_recipient", screening.FeeRecipient, "committed_fee_recipient", committed.FeeRecipient, "satisfied", satisfied)
+	return db.Commitments.UpdateL2BlockScreeningVerdict(screening.L2BlockHash, satisfied)
+}
+
+// L1LatestCommitmentEventHeader returns the latest header for which a commitment lifecycle
+// event has been indexed on L1.
+func L1LatestCommitmentEventHeader(db *database.DB, commitmentsConfig config.CommitmentsConfig) (*types.Header, error) {
+	filters := []database.ContractEvent{
+		{ContractAddress: commitmentsConfig.CommitmentManager, EventSignature: contracts.CommitmentManagerABI.Events["CommitmentCreated"].ID},
+		{ContractAddress: commitmentsConfig.CommitmentManager, EventSignature: contracts.CommitmentManagerABI.Events["CommitmentRemoved"].ID},
+		{ContractAddress: commitmentsConfig.FeeRecipientCommitment, EventSignature: contracts.FeeRecipientCommitmentABI.Events["NewFeeRecipientSet"].ID},
+	}
+
+	var latestEvent *database.L1ContractEvent
+	for _, filter := range filters {
+		if filter.ContractAddress == (common.Address{}) {
+			continue
+		}
+
+		event, err := db.ContractEvents.L1LatestContractEventWithFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil && (latestEvent == nil || event.Timestamp > latestEvent.Timestamp) {
+			latestEvent = event
+		}
+	}
+
+	if latestEvent == nil {
+		return nil, nil
+	}
+
+	l1BlockHeader, err := db.Blocks.L1BlockHeader(latestEvent.BlockHash)
+	if err != nil {
+		return nil, err
+	} else if l1BlockHeader == nil {
+		return nil, nil
+	}
+
+	return l1BlockHeader.RLPHeader.Header(), nil
+}
+
+// L2LatestScreenedHeader returns the header of the latest screened L2 block
+func L2LatestScreenedHeader(db *database.DB) (*types.Header, error) {
+	screening, err := db.Commitments.L2LatestBlockScreening()
+	if err != nil {
+		return nil, err
+	} else if screening == nil {
+		return nil, nil
+	}
+
+	l2BlockHeader, err := db.Blocks.L2BlockHeader(screening.L2BlockHash)
+	if err != nil {
+		return nil, err
+	} else if l2BlockHeader == nil {
+		return nil, nil
+	}
+
+	return l2BlockHeader.RLPHeader.Header(), nil
+}
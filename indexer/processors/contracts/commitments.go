@@ -0,0 +1,160 @@
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The CommitmentManager (emily) and the sample commitments are not part of the generated
+// op-bindings. Only the events consumed by the indexer are declared here.
+const (
+	commitmentManagerABI = `[
+		{"anonymous":false,"type":"event","name":"CommitmentCreated","inputs":[
+			{"indexed":true,"name":"account","type":"address"},
+			{"indexed":true,"name":"target","type":"bytes32"},
+			{"indexed":true,"name":"id","type":"uint256"},
+			{"indexed":false,"name":"commitment","type":"address"}]},
+		{"anonymous":false,"type":"event","name":"CommitmentRemoved","inputs":[
+			{"indexed":true,"name":"account","type":"address"},
+			{"indexed":true,"name":"target","type":"bytes32"},
+			{"indexed":true,"name":"id","type":"uint256"}]}
+	]`
+
+	feeRecipientCommitmentABI = `[
+		{"anonymous":false,"type":"event","name":"NewFeeRecipientSet","inputs":[
+			{"indexed":false,"name":"sequencer","type":"address"},
+			{"indexed":false,"name":"feeRecipient","type":"address"},
+			{"indexed":false,"name":"blockNumber","type":"uint64"}]}
+	]`
+)
+
+var (
+	CommitmentManagerABI      = mustParseABI(commitmentManagerABI)
+	FeeRecipientCommitmentABI = mustParseABI(feeRecipientCommitmentABI)
+)
+
+func mustParseABI(definition string) *abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return &parsed
+}
+
+type CommitmentManagerCommitmentCreated struct {
+	Account    common.Address
+	Target     [32]byte
+	Id         *big.Int
+	Commitment common.Address
+	Raw        types.Log
+}
+
+type CommitmentManagerCommitmentRemoved struct {
+	Account common.Address
+	Target  [32]byte
+	Id      *big.Int
+	Raw     types.Log
+}
+
+type FeeRecipientCommitmentNewFeeRecipientSet struct {
+	Sequencer    common.Address
+	FeeRecipient common.Address
+	BlockNumber  uint64
+	Raw          types.Log
+}
+
+type CommitmentCreatedEvent struct {
+	*CommitmentManagerCommitmentCreated
+	Event *database.ContractEvent
+}
+
+type CommitmentRemovedEvent struct {
+	*CommitmentManagerCommitmentRemoved
+	Event *database.ContractEvent
+}
+
+type NewFeeRecipientSetEvent struct {
+	*FeeRecipientCommitmentNewFeeRecipientSet
+	Event *database.ContractEvent
+}
+
+func CommitmentManagerCommitmentCreatedEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]CommitmentCreatedEvent, error) {
+	commitmentCreatedEventAbi := CommitmentManagerABI.Events["CommitmentCreated"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: commitmentCreatedEventAbi.ID}
+	commitmentCreatedEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	createdCommitments := make([]CommitmentCreatedEvent, len(commitmentCreatedEvents))
+	for i := range commitmentCreatedEvents {
+		commitmentCreated := CommitmentManagerCommitmentCreated{Raw: *commitmentCreatedEvents[i].RLPLog}
+		err := UnpackLog(&commitmentCreated, commitmentCreatedEvents[i].RLPLog, commitmentCreatedEventAbi.Name, CommitmentManagerABI)
+		if err != nil {
+			return nil, err
+		}
+
+		createdCommitments[i] = CommitmentCreatedEvent{
+			CommitmentManagerCommitmentCreated: &commitmentCreated,
+			Event:                              &commitmentCreatedEvents[i].ContractEvent,
+		}
+	}
+
+	return createdCommitments, nil
+}
+
+func CommitmentManagerCommitmentRemovedEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]CommitmentRemovedEvent, error) {
+	commitmentRemovedEventAbi := CommitmentManagerABI.Events["CommitmentRemoved"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: commitmentRemovedEventAbi.ID}
+	commitmentRemovedEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	removedCommitments := make([]CommitmentRemovedEvent, len(commitmentRemovedEvents))
+	for i := range commitmentRemovedEvents {
+		commitmentRemoved := CommitmentManagerCommitmentRemoved{Raw: *commitmentRemovedEvents[i].RLPLog}
+		err := UnpackLog(&commitmentRemoved, commitmentRemovedEvents[i].RLPLog, commitmentRemovedEventAbi.Name, CommitmentManagerABI)
+		if err != nil {
+			return nil, err
+		}
+
+		removedCommitments[i] = CommitmentRemovedEvent{
+			CommitmentManagerCommitmentRemoved: &commitmentRemoved,
+			Event:                              &commitmentRemovedEvents[i].ContractEvent,
+		}
+	}
+
+	return removedCommitments, nil
+}
+
+func FeeRecipientCommitmentNewFeeRecipientSetEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]NewFeeRecipientSetEvent, error) {
+	newFeeRecipientSetEventAbi := FeeRecipientCommitmentABI.Events["NewFeeRecipientSet"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: newFeeRecipientSetEventAbi.ID}
+	newFeeRecipientSetEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	feeRecipientsSet := make([]NewFeeRecipientSetEvent, len(newFeeRecipientSetEvents))
+	for i := range newFeeRecipientSetEvents {
+		feeRecipientSet := FeeRecipientCommitmentNewFeeRecipientSet{Raw: *newFeeRecipientSetEvents[i].RLPLog}
+		err := UnpackLog(&feeRecipientSet, newFeeRecipientSetEvents[i].RLPLog, newFeeRecipientSetEventAbi.Name, FeeRecipientCommitmentABI)
+		if err != nil {
+			return nil, err
+		}
+
+		feeRecipientsSet[i] = NewFeeRecipientSetEvent{
+			FeeRecipientCommitmentNewFeeRecipientSet: &feeRecipientSet,
+			Event:                                    &newFeeRecipientSetEvents[i].ContractEvent,
+		}
+	}
+
+	return feeRecipientsSet, nil
+}
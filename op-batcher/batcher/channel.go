@@ -178,6 +178,21 @@ func (s *channel) PendingFrames() int {
 	return s.channelBuilder.PendingFrames()
 }
 
+// PendingBytes returns the estimated size of the channel data that is not confirmed on L1 yet.
+// The input of a channel that is still open is counted in full, since it isn't known yet how
+// much of it is already output in frames. For a full channel, the data that is still in the
+// compression pipeline, queued in frames or sent in unconfirmed transactions is counted.
+func (s *channel) PendingBytes() int {
+	if !s.IsFull() {
+		return s.InputBytes()
+	}
+	size := s.ReadyBytes() + s.channelBuilder.PendingFrameBytes()
+	for _, tx := range s.pendingTransactions {
+		size += tx.Len()
+	}
+	return size
+}
+
 func (s *channel) OutputFrames() error {
 	return s.channelBuilder.OutputFrames()
 }
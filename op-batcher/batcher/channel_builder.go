@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -58,6 +60,15 @@ type ChannelConfig struct {
 
 	// CompressorConfig contains the configuration for creating new compressors.
 	CompressorConfig compressor.Config
+
+	// BatchType indicates whether the channel uses singular or span batches.
+	BatchType uint
+	// L2GenesisTime and L2ChainID of the rollup are needed to encode span batches.
+	L2GenesisTime uint64
+	L2ChainID     *big.Int
+	// Forks is the network upgrade schedule of the rollup. Span batches are only submitted
+	// for blocks at or after the Delta upgrade, if a schedule is set.
+	Forks rollup.ForkSchedule
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -83,6 +94,16 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	switch cc.BatchType {
+	case derive.BatchV1Type:
+	case derive.SpanBatchType:
+		if cc.L2ChainID == nil {
+			return errors.New("span batches require the L2 chain ID")
+		}
+	default:
+		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
+	}
+
 	return nil
 }
 
@@ -132,7 +153,12 @@ func newChannelBuilder(cfg ChannelConfig) (*channelBuilder, error) {
 	if err != nil {
 		return nil, err
 	}
-	co, err := derive.NewChannelOut(c)
+	var co *derive.ChannelOut
+	if cfg.BatchType == derive.SpanBatchType {
+		co, err = derive.NewSpanChannelOut(c, derive.NewSpanBatchBuilder(cfg.L2GenesisTime, cfg.L2ChainID))
+	} else {
+		co, err = derive.NewChannelOut(c)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +225,7 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 		return l1info, fmt.Errorf("converting block to batch: %w", err)
 	}
 
-	if _, err = c.co.AddBatch(batch); errors.Is(err, derive.ErrTooManyRLPBytes) || errors.Is(err, derive.CompressorFullErr) {
+	if _, err = c.co.AddSingularBatch(batch, l1info.SequenceNumber); errors.Is(err, derive.ErrTooManyRLPBytes) || errors.Is(err, derive.CompressorFullErr) {
 		c.setFullErr(err)
 		return l1info, c.FullErr()
 	} else if err != nil {
@@ -418,6 +444,15 @@ func (c *channelBuilder) PendingFrames() int {
 	return len(c.frames)
 }
 
+// PendingFrameBytes returns the total data size of the pending frames in the frames queue.
+func (c *channelBuilder) PendingFrameBytes() int {
+	var size int
+	for _, f := range c.frames {
+		size += len(f.data)
+	}
+	return size
+}
+
 // NextFrame returns the next available frame.
 // HasFrame must be called prior to check if there's a next frame available.
 // Panics if called when there's no next frame.
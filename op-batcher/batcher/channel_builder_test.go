@@ -2,20 +2,26 @@ package batcher
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	dtest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/trie"
 
 	"github.com/stretchr/testify/require"
@@ -67,6 +73,22 @@ func TestChannelConfig_Check(t *testing.T) {
 			},
 		},
 	}
+	spanChannelConfig := defaultTestChannelConfig
+	spanChannelConfig.BatchType = derive.SpanBatchType
+	tests = append(tests, test{
+		input: spanChannelConfig,
+		assertion: func(output error) {
+			require.EqualError(t, output, "span batches require the L2 chain ID")
+		},
+	})
+	unknownBatchTypeConfig := defaultTestChannelConfig
+	unknownBatchTypeConfig.BatchType = 2
+	tests = append(tests, test{
+		input: unknownBatchTypeConfig,
+		assertion: func(output error) {
+			require.EqualError(t, output, "unrecognized batch type: 2")
+		},
+	})
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
 		smallChannelConfig.MaxFrameSize = uint64(i)
@@ -570,6 +592,129 @@ func TestChannelBuilder_AddBlock(t *testing.T) {
 	require.ErrorIs(t, addMiniBlock(cb), derive.CompressorFullErr)
 }
 
+// TestChannelBuilder_AddBlockSpanBatch tests that a channel builder with span batches
+// writes all of its blocks into a single span batch.
+func TestChannelBuilder_AddBlockSpanBatch(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.BatchType = derive.SpanBatchType
+	channelConfig.L2ChainID = big.NewInt(901)
+	require.NoError(t, channelConfig.Check())
+
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	prevInputBytes := 0
+	for i := 0; i < 3; i++ {
+		require.NoError(t, addMiniBlock(cb))
+		require.Greater(t, cb.co.InputBytes(), prevInputBytes)
+		prevInputBytes = cb.co.InputBytes()
+	}
+	require.Len(t, cb.Blocks(), 3)
+	require.NoError(t, cb.co.Close())
+
+	var buf bytes.Buffer
+	_, err = cb.co.OutputFrame(&buf, channelConfig.MaxFrameSize)
+	require.ErrorIs(t, err, io.EOF)
+	var frame derive.Frame
+	require.NoError(t, frame.UnmarshalBinary(&buf))
+	br, err := derive.BatchReader(bytes.NewReader(frame.Data), eth.L1BlockRef{})
+	require.NoError(t, err)
+	batch, err := br()
+	require.NoError(t, err)
+	require.True(t, batch.Batch.IsSpanBatch())
+	spanBatch, err := batch.Batch.RawSpanBatch.Derive(2, channelConfig.L2GenesisTime, channelConfig.L2ChainID)
+	require.NoError(t, err)
+	require.Equal(t, 3, spanBatch.GetBlockCount())
+	_, err = br()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+// frameQueue provides frames to the channel bank, all from the same L1 block.
+type frameQueue struct {
+	frames []derive.Frame
+	origin eth.L1BlockRef
+}
+
+func (q *frameQueue) NextFrame(ctx context.Context) (derive.Frame, error) {
+	if len(q.frames) == 0 {
+		return derive.Frame{}, io.EOF
+	}
+	f := q.frames[0]
+	q.frames = q.frames[1:]
+	return f, nil
+}
+
+func (q *frameQueue) Origin() eth.L1BlockRef {
+	return q.origin
+}
+
+// TestChannelBuilder_SpanBatchMultipleFrames tests that a span batch channel that spans
+// multiple frames is read back by the derivation pipeline.
+func TestChannelBuilder_SpanBatchMultipleFrames(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	channelConfig := defaultTestChannelConfig
+	channelConfig.BatchType = derive.SpanBatchType
+	channelConfig.L2ChainID = big.NewInt(901)
+	channelConfig.MaxFrameSize = 1000
+	channelConfig.CompressorConfig.TargetFrameSize = 1000
+	channelConfig.CompressorConfig.TargetNumFrames = 1000
+	require.NoError(t, channelConfig.Check())
+
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	// blocks of random transactions, which do not compress well
+	signer := types.NewLondonSigner(channelConfig.L2ChainID)
+	var batches []*derive.BatchV1
+	for i := 0; i < 20; i++ {
+		block := newMiniL2Block(0)
+		txs := block.Transactions()
+		for j := 0; j < 10; j++ {
+			txs = append(txs, testutils.RandomTx(rng, big.NewInt(10), signer))
+		}
+		block = types.NewBlock(block.Header(), txs, nil, nil, trie.NewStackTrie(nil))
+		batch, _, err := derive.BlockToBatch(block)
+		require.NoError(t, err)
+		batches = append(batches, &batch.BatchV1)
+
+		_, err = cb.AddBlock(block)
+		require.NoError(t, err)
+		require.NoError(t, cb.OutputFrames())
+	}
+	cb.Close()
+	require.NoError(t, cb.OutputFrames())
+	require.Greater(t, cb.PendingFrames(), 1, "channel must span multiple frames")
+
+	frames := &frameQueue{origin: eth.L1BlockRef{Number: 1}}
+	for cb.HasFrame() {
+		var frame derive.Frame
+		require.NoError(t, frame.UnmarshalBinary(bytes.NewReader(cb.NextFrame().data)))
+		frames.frames = append(frames.frames, frame)
+	}
+	bank := derive.NewChannelBank(testlog.Logger(t, log.LvlCrit), &rollup.Config{ChannelTimeout: 10}, frames, nil, metrics.NoopMetrics)
+	var data []byte
+	for data == nil {
+		data, err = bank.NextData(context.Background())
+		if err != nil {
+			require.ErrorIs(t, err, derive.NotEnoughData)
+		}
+	}
+
+	br, err := derive.BatchReader(bytes.NewReader(data), frames.origin)
+	require.NoError(t, err)
+	batch, err := br()
+	require.NoError(t, err)
+	require.True(t, batch.Batch.IsSpanBatch())
+	spanBatch, err := batch.Batch.RawSpanBatch.Derive(2, channelConfig.L2GenesisTime, channelConfig.L2ChainID)
+	require.NoError(t, err)
+	require.Equal(t, len(batches), spanBatch.GetBlockCount())
+	for i, element := range spanBatch.Batches {
+		require.Equal(t, batches[i].Transactions, element.Transactions, "transactions of block %d", i)
+	}
+	_, err = br()
+	require.ErrorIs(t, err, io.EOF)
+}
+
 // TestChannelBuilder_Reset tests the [Reset] function
 func TestChannelBuilder_Reset(t *testing.T) {
 	channelConfig := defaultTestChannelConfig
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
@@ -195,7 +196,15 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 		return nil
 	}
 
-	pc, err := newChannel(s.log, s.metr, s.cfg)
+	cfg := s.cfg
+	if cfg.BatchType == derive.SpanBatchType && cfg.Forks != nil && len(s.blocks) > 0 &&
+		!cfg.Forks.IsActive(rollup.Delta, s.blocks[0].Time()) {
+		s.log.Info("Using singular batches until the Delta upgrade activates",
+			"block", s.blocks[0].NumberU64(), "timestamp", s.blocks[0].Time(), "delta_time", cfg.Forks.ActivationTime(rollup.Delta))
+		cfg.BatchType = derive.BatchV1Type
+	}
+
+	pc, err := newChannel(s.log, s.metr, cfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -321,6 +330,21 @@ func (s *channelManager) AddL2Block(block *types.Block) error {
 	return nil
 }
 
+// PendingDABytes returns the estimated size of the batch data that is not confirmed on L1 yet:
+// the L2 blocks that are not added to a channel yet, and the pending data of the channels in the queue.
+func (s *channelManager) PendingDABytes() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var size uint64
+	for _, block := range s.blocks {
+		size += metrics.EstimateBatchSize(block)
+	}
+	for _, ch := range s.channelQueue {
+		size += uint64(ch.PendingBytes())
+	}
+	return size
+}
+
 func l2BlockRefFromBlockAndL1Info(block *types.Block, l1info derive.L1BlockInfo) eth.L2BlockRef {
 	return eth.L2BlockRef{
 		Hash:           block.Hash(),
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
@@ -345,3 +346,87 @@ func TestChannelManagerCloseAllTxsFailed(t *testing.T) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManager_SpanBatchBeforeDelta ensures that the channel manager
+// uses singular batches for channels that start before the Delta upgrade.
+func TestChannelManager_SpanBatchBeforeDelta(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	deltaTime := uint64(100)
+	cfg := defaultTestChannelConfig
+	cfg.BatchType = derive.SpanBatchType
+	cfg.L2ChainID = big.NewInt(901)
+	cfg.Forks = rollup.ForkSchedule{
+		{Name: rollup.Regolith, Time: new(uint64)},
+		{Name: rollup.Delta, Time: &deltaTime},
+	}
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg)
+
+	a := types.NewBlock(&types.Header{
+		Number: big.NewInt(0),
+		Time:   deltaTime - 2,
+	}, nil, nil, nil, nil)
+	require.NoError(t, m.AddL2Block(a))
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.Equal(t, uint(derive.BatchV1Type), m.currentChannel.cfg.BatchType)
+
+	m.Clear()
+	b := types.NewBlock(&types.Header{
+		Number: big.NewInt(1),
+		Time:   deltaTime,
+	}, nil, nil, nil, nil)
+	require.NoError(t, m.AddL2Block(b))
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	require.Equal(t, uint(derive.SpanBatchType), m.currentChannel.cfg.BatchType)
+}
+
+// TestChannelManager_PendingDABytes ensures that the pending DA bytes count the blocks
+// that are not added to a channel yet, and the channel data that is not confirmed yet.
+func TestChannelManager_PendingDABytes(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, defaultTestChannelConfig)
+	require.Zero(t, m.PendingDABytes())
+
+	rng := rand.New(rand.NewSource(123))
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(t, m.AddL2Block(a))
+	require.Equal(t, metrics.EstimateBatchSize(a), m.PendingDABytes())
+
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(t, err, io.EOF, "channel not ready yet")
+	require.NotZero(t, m.currentChannel.InputBytes())
+	require.Equal(t, uint64(m.currentChannel.InputBytes()), m.PendingDABytes(),
+		"input of the open channel is pending")
+}
+
+// TestChannelManager_PendingDABytesFrames ensures that the frames of a full channel are pending
+// until the transactions are confirmed.
+func TestChannelManager_PendingDABytesFrames(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			ChannelTimeout: 40,
+			MaxFrameSize:   120_000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+		})
+
+	rng := rand.New(rand.NewSource(123))
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(t, m.AddL2Block(a))
+
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	require.True(t, m.currentChannel.IsFull())
+	require.Equal(t, uint64(txdata.Len()), m.PendingDABytes(), "unconfirmed frame is pending")
+
+	m.TxFailed(txdata.ID())
+	require.Equal(t, uint64(txdata.Len()-1), m.PendingDABytes(), "requeued frame is pending")
+
+	txdata, err = m.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 1})
+	require.Zero(t, m.PendingDABytes(), "confirmed channel is not pending")
+}
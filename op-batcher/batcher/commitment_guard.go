@@ -0,0 +1,125 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrCommitmentViolation is returned when a loaded L2 block violates the sequencer commitments.
+// Channel building halts until the violation is acknowledged.
+var ErrCommitmentViolation = errors.New("L2 block violates sequencer commitments")
+
+// commitmentGuard screens every L2 block before it is loaded into the channel manager,
+// so the batcher does not cement a commitment violation on L1.
+// On a violation the guard halts, until the violating block is acknowledged with AcknowledgeViolation.
+// Blocks are screened against the sequencer and L1 state at their L1 origin.
+// In observe mode violations are reported, but the guard does not halt.
+type commitmentGuard struct {
+	log      log.Logger
+	metr     metrics.Metricer
+	screener commitments.Screener
+	genesis  *rollup.Genesis
+	observe  bool
+
+	mu sync.Mutex
+	// violation is the block that halted the guard, if any
+	violation *eth.BlockID
+	// acknowledged is the block that may be loaded without screening
+	acknowledged common.Hash
+}
+
+func newCommitmentGuard(l log.Logger, m metrics.Metricer, screener commitments.Screener, genesis *rollup.Genesis, mode commitments.Mode) *commitmentGuard {
+	return &commitmentGuard{
+		log:      l,
+		metr:     m,
+		screener: screener,
+		genesis:  genesis,
+		observe:  mode == commitments.ModeObserve,
+	}
+}
+
+// Check screens the block. It returns ErrCommitmentViolation if the block violates the commitments,
+// or if the guard is halted on a previous violation.
+func (g *commitmentGuard) Check(ctx context.Context, block *types.Block) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id := eth.ToBlockID(block)
+	if g.violation != nil {
+		if g.violation.Hash == id.Hash {
+			return fmt.Errorf("%w: halted at block %s", ErrCommitmentViolation, g.violation)
+		}
+		// The violating block is no longer loaded next, the L2 chain was reorged.
+		g.log.Warn("Commitment violation was reorged out, resuming channel building", "violation", g.violation, "block", id)
+		g.violation = nil
+		g.metr.RecordCommitmentGuardResumed()
+	}
+	if g.acknowledged == id.Hash {
+		g.log.Warn("Loading acknowledged commitment violation", "block", id)
+		return nil
+	}
+
+	ref, err := derive.L2BlockToBlockRef(block, g.genesis)
+	if err != nil {
+		return fmt.Errorf("determining L1 origin of L2 block: %w", err)
+	}
+	payload, err := eth.BlockAsPayload(block)
+	if err != nil {
+		return fmt.Errorf("converting L2 block to payload: %w", err)
+	}
+	sequencer, satisfied, err := commitments.ScreenAtOrigin(ctx, g.screener, ref.L1Origin, payload)
+	if err != nil {
+		return fmt.Errorf("screening L2 block: %w", err)
+	}
+	if !satisfied && g.observe {
+		g.log.Warn("L2 block violates sequencer commitments, ignoring in observe mode", "block", id, "l1_origin", ref.L1Origin, "sequencer", sequencer)
+		return nil
+	}
+	if !satisfied {
+		g.violation = &id
+		g.log.Error("L2 block violates sequencer commitments, halting channel building", "block", id, "l1_origin", ref.L1Origin, "sequencer", sequencer)
+		g.metr.RecordCommitmentViolation(id)
+		return fmt.Errorf("%w: block %s", ErrCommitmentViolation, id)
+	}
+	return nil
+}
+
+// Violation returns the block that halted the guard, or nil if the guard is not halted.
+func (g *commitmentGuard) Violation() *eth.BlockID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.violation == nil {
+		return nil
+	}
+	id := *g.violation
+	return &id
+}
+
+// AcknowledgeViolation resumes channel building, loading the violating block with the given hash as-is.
+func (g *commitmentGuard) AcknowledgeViolation(hash common.Hash) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.violation == nil {
+		return errors.New("no commitment violation to acknowledge")
+	}
+	if g.violation.Hash != hash {
+		return fmt.Errorf("block %s does not match commitment violation %s", hash, g.violation)
+	}
+	g.log.Warn("Commitment violation acknowledged, resuming channel building", "block", g.violation)
+	g.acknowledged = hash
+	g.violation = nil
+	g.metr.RecordCommitmentGuardResumed()
+	return nil
+}
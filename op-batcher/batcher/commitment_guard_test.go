@@ -0,0 +1,97 @@
+package batcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var guardTestGenesis = &rollup.Genesis{L2: eth.BlockID{Number: 0}}
+
+func TestCommitmentGuardHaltsOnViolation(t *testing.T) {
+	a := test.L2BlockWithOrigin(1, common.Hash{}, 1, 0)
+	b := test.L2BlockWithOrigin(2, a.Hash(), 1, 0)
+	c := test.L2BlockWithOrigin(3, b.Hash(), 1, 0)
+
+	screener := &testutils.FakeScreener{Rejected: map[common.Hash]bool{b.Hash(): true}}
+	g := newCommitmentGuard(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, guardTestGenesis, commitments.ModeEnforce)
+	ctx := context.Background()
+
+	require.NoError(t, g.Check(ctx, a))
+	require.Nil(t, g.Violation())
+
+	require.ErrorIs(t, g.Check(ctx, b), ErrCommitmentViolation)
+	require.Equal(t, eth.ToBlockID(b), *g.Violation())
+
+	// stays halted on the violating block, without screening it again
+	require.ErrorIs(t, g.Check(ctx, b), ErrCommitmentViolation)
+	require.Equal(t, 2, screener.Screened)
+
+	require.Error(t, g.AcknowledgeViolation(c.Hash()), "must acknowledge the violating block")
+	require.NoError(t, g.AcknowledgeViolation(b.Hash()))
+	require.Nil(t, g.Violation())
+	require.Error(t, g.AcknowledgeViolation(b.Hash()), "nothing left to acknowledge")
+
+	// the acknowledged block is loaded as-is, and screening continues after it
+	require.NoError(t, g.Check(ctx, b))
+	require.NoError(t, g.Check(ctx, c))
+	require.Equal(t, 3, screener.Screened)
+}
+
+func TestCommitmentGuardResumesOnReorg(t *testing.T) {
+	a := test.L2BlockWithOrigin(1, common.Hash{}, 1, 0)
+	b := test.L2BlockWithOrigin(2, a.Hash(), 1, 0)
+	bAlt := test.L2BlockWithOrigin(2, a.Hash(), 1, 1)
+
+	screener := &testutils.FakeScreener{Rejected: map[common.Hash]bool{b.Hash(): true}}
+	g := newCommitmentGuard(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, guardTestGenesis, commitments.ModeEnforce)
+	ctx := context.Background()
+
+	require.NoError(t, g.Check(ctx, a))
+	require.ErrorIs(t, g.Check(ctx, b), ErrCommitmentViolation)
+
+	// the violating block was reorged out, the replacement is screened
+	require.NoError(t, g.Check(ctx, bAlt))
+	require.Nil(t, g.Violation())
+}
+
+func TestCommitmentGuardObserveMode(t *testing.T) {
+	a := test.L2BlockWithOrigin(1, common.Hash{}, 1, 0)
+	b := test.L2BlockWithOrigin(2, a.Hash(), 1, 0)
+
+	screener := &testutils.FakeScreener{Rejected: map[common.Hash]bool{a.Hash(): true}}
+	g := newCommitmentGuard(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, guardTestGenesis, commitments.ModeObserve)
+	ctx := context.Background()
+
+	// the violation is reported, but does not halt channel building
+	require.NoError(t, g.Check(ctx, a))
+	require.Nil(t, g.Violation())
+	require.NoError(t, g.Check(ctx, b))
+	require.Equal(t, 2, screener.Screened)
+}
+
+func TestCommitmentGuardScreensAtL1Origin(t *testing.T) {
+	a := test.L2BlockWithOrigin(1, common.Hash{}, 5, 0)
+	b := test.L2BlockWithOrigin(2, a.Hash(), 6, 0)
+
+	screener := &testutils.FakeScreener{}
+	g := newCommitmentGuard(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, guardTestGenesis, commitments.ModeEnforce)
+	ctx := context.Background()
+
+	// each block is screened against the signer and L1 state of its own L1 origin
+	require.NoError(t, g.Check(ctx, a))
+	require.NoError(t, g.Check(ctx, b))
+	require.Equal(t, testutils.FakeScreening{L1BlockNum: 5, Sequencer: testutils.FakeScreenerSigner(5)}, screener.ScreenedAt[a.Hash()])
+	require.Equal(t, testutils.FakeScreening{L1BlockNum: 6, Sequencer: testutils.FakeScreenerSigner(6)}, screener.ScreenedAt[b.Hash()])
+}
@@ -1,6 +1,8 @@
 package batcher
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -12,7 +14,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -36,6 +40,16 @@ type Config struct {
 
 	// Channel builder parameters
 	Channel ChannelConfig
+
+	// Throttle parameters of the sequencer block building
+	Throttle ThrottleConfig
+
+	// Screener is optional, if set every L2 block is screened against the sequencer
+	// commitments before it is loaded, and channel building halts on a violation.
+	Screener commitments.Screener
+	// ScreeningMode determines how violations are handled: in observe mode they are only reported.
+	// Defaults to enforce if not set.
+	ScreeningMode commitments.Mode
 }
 
 // Check ensures that the [Config] is valid.
@@ -46,9 +60,24 @@ func (c *Config) Check() error {
 	if err := c.Channel.Check(); err != nil {
 		return err
 	}
+	if c.Channel.BatchType == derive.SpanBatchType && c.Rollup.ActivationTime(rollup.Delta) == nil {
+		return errors.New("span batches cannot be used without the Delta upgrade")
+	}
 	return nil
 }
 
+// ThrottleConfig configures the throttling of the sequencer while the batcher is behind.
+// The DA size limits are set on the rollup node, but have no effect until the execution engine enforces them.
+type ThrottleConfig struct {
+	// Threshold is the pending batch data size, in bytes, above which the sequencer is throttled.
+	// If 0, throttling is disabled.
+	Threshold uint64
+	// TxSize is the max DA size of a single transaction while throttling.
+	TxSize uint64
+	// BlockSize is the max DA size of the transactions of a block while throttling.
+	BlockSize uint64
+}
+
 type CLIConfig struct {
 	// L1EthRpc is the HTTP provider URL for L1.
 	L1EthRpc string
@@ -87,15 +116,36 @@ type CLIConfig struct {
 
 	Stopped bool
 
+	// CommitmentGuard enables the screening of L2 blocks against the sequencer commitments,
+	// halting channel building on a violation.
+	CommitmentGuard bool
+
+	// BatchType is the type of the batches that are submitted: 0 for singular batches, 1 for span batches.
+	BatchType uint
+
+	// ThrottleThreshold is the pending batch data size above which the sequencer is throttled, 0 to disable.
+	ThrottleThreshold uint64
+	// ThrottleTxSize is the max DA size of a transaction while throttling.
+	ThrottleTxSize uint64
+	// ThrottleBlockSize is the max DA size of the transactions of a block while throttling.
+	ThrottleBlockSize uint64
+
 	TxMgrConfig      txmgr.CLIConfig
 	RPCConfig        rpc.CLIConfig
 	LogConfig        oplog.CLIConfig
 	MetricsConfig    opmetrics.CLIConfig
 	PprofConfig      oppprof.CLIConfig
 	CompressorConfig compressor.CLIConfig
+	ScreeningConfig  commitments.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
+	if c.BatchType != derive.BatchV1Type && c.BatchType != derive.SpanBatchType {
+		return fmt.Errorf("unknown batch type: %d", c.BatchType)
+	}
+	if c.ThrottleThreshold != 0 && c.ThrottleBlockSize != 0 && c.ThrottleBlockSize < c.ThrottleTxSize {
+		return fmt.Errorf("throttle block size %d is smaller than the throttle tx size %d", c.ThrottleBlockSize, c.ThrottleTxSize)
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -111,6 +161,9 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.ScreeningConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -129,11 +182,17 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
 		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
 		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
+		CommitmentGuard:        ctx.Bool(flags.CommitmentGuardFlag.Name),
+		BatchType:              ctx.Uint(flags.BatchTypeFlag.Name),
+		ThrottleThreshold:      ctx.Uint64(flags.ThrottleThresholdFlag.Name),
+		ThrottleTxSize:         ctx.Uint64(flags.ThrottleTxSizeFlag.Name),
+		ThrottleBlockSize:      ctx.Uint64(flags.ThrottleBlockSizeFlag.Name),
 		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
 		RPCConfig:              rpc.ReadCLIConfig(ctx),
 		LogConfig:              oplog.ReadCLIConfig(ctx),
 		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:            oppprof.ReadCLIConfig(ctx),
 		CompressorConfig:       compressor.ReadCLIConfig(ctx),
+		ScreeningConfig:        commitments.ReadCLIConfig(ctx),
 	}
 }
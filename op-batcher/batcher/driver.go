@@ -13,8 +13,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -41,6 +43,10 @@ type BatchSubmitter struct {
 	lastL1Tip       eth.L1BlockRef
 
 	state *channelManager
+	// throttled is true if the sequencer is currently throttled because of the pending block data
+	throttled bool
+	// guard is optional, and screens L2 blocks before they are loaded into the state
+	guard *commitmentGuard
 }
 
 // NewBatchSubmitterFromCLIConfig initializes the BatchSubmitter, gathering any resources
@@ -91,9 +97,38 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 			SubSafetyMargin:    cfg.SubSafetyMargin,
 			MaxFrameSize:       cfg.MaxL1TxSize - 1, // subtract 1 byte for version
 			CompressorConfig:   cfg.CompressorConfig.Config(),
+			BatchType:          cfg.BatchType,
+			L2GenesisTime:      rcfg.Genesis.L2Time,
+			L2ChainID:          rcfg.L2ChainID,
+			Forks:              rcfg.ForkSchedule(),
+		},
+		Throttle: ThrottleConfig{
+			Threshold: cfg.ThrottleThreshold,
+			TxSize:    cfg.ThrottleTxSize,
+			BlockSize: cfg.ThrottleBlockSize,
 		},
 	}
 
+	if cfg.CommitmentGuard {
+		screeningCfg, err := commitments.NewConfig(cfg.ScreeningConfig)
+		if err != nil {
+			return nil, fmt.Errorf("loading screening config: %w", err)
+		}
+		if screeningCfg.Mode == commitments.ModeDisabled {
+			l.Warn("commitment guard is enabled, but screening is disabled")
+		} else {
+			screener, err := commitments.NewSystemConfigScreener(rcfg.L1SystemConfigAddress, l1Client)
+			if err != nil {
+				return nil, fmt.Errorf("creating commitment screener: %w", err)
+			}
+			if screeningCfg.StateManifest != nil {
+				screener = screener.WithStateProofs(commitments.NewEthStateProofSource(l2Client), screeningCfg.StateManifest)
+			}
+			batcherCfg.Screener = screener
+			batcherCfg.ScreeningMode = screeningCfg.Mode
+		}
+	}
+
 	// Validate the batcher config
 	if err := batcherCfg.Check(); err != nil {
 		return nil, err
@@ -115,10 +150,17 @@ func NewBatchSubmitter(ctx context.Context, cfg Config, l log.Logger, m metrics.
 
 	cfg.metr = m
 
+	var guard *commitmentGuard
+	if cfg.Screener != nil {
+		cfg.log.Info("commitment guard enabled", "mode", cfg.ScreeningMode)
+		guard = newCommitmentGuard(l, m, commitments.WithMetrics(cfg.Screener, m), &cfg.Rollup.Genesis, cfg.ScreeningMode)
+	}
+
 	return &BatchSubmitter{
 		Config: cfg,
 		txMgr:  cfg.TxManager,
 		state:  NewChannelManager(l, m, cfg.Channel),
+		guard:  guard,
 	}, nil
 
 }
@@ -180,6 +222,24 @@ func (l *BatchSubmitter) Stop(ctx context.Context) error {
 	return nil
 }
 
+// CommitmentViolation returns the L2 block that halted channel building,
+// or nil if channel building is not halted or the commitment guard is disabled.
+func (l *BatchSubmitter) CommitmentViolation() *eth.BlockID {
+	if l.guard == nil {
+		return nil
+	}
+	return l.guard.Violation()
+}
+
+// AcknowledgeCommitmentViolation resumes channel building after a commitment violation,
+// batching the violating L2 block with the given hash as-is.
+func (l *BatchSubmitter) AcknowledgeCommitmentViolation(hash common.Hash) error {
+	if l.guard == nil {
+		return errors.New("commitment guard is disabled")
+	}
+	return l.guard.AcknowledgeViolation(hash)
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -232,6 +292,12 @@ func (l *BatchSubmitter) loadBlockIntoState(ctx context.Context, blockNumber uin
 		return nil, fmt.Errorf("getting L2 block: %w", err)
 	}
 
+	if l.guard != nil {
+		if err := l.guard.Check(ctx, block); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := l.state.AddL2Block(block); err != nil {
 		return nil, fmt.Errorf("adding L2 block to state: %w", err)
 	}
@@ -305,9 +371,14 @@ func (l *BatchSubmitter) loop() {
 				continue
 			}
 			l.publishStateToL1(queue, receiptsCh, false)
+			l.updateThrottling(l.shutdownCtx)
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
 		case <-l.shutdownCtx.Done():
+			if l.throttled {
+				// don't leave the sequencer throttled while the batcher is stopped
+				l.setThrottling(l.killCtx, false, 0)
+			}
 			err := l.state.Close()
 			if err != nil {
 				l.log.Error("error closing the channel manager", "err", err)
@@ -318,6 +389,43 @@ func (l *BatchSubmitter) loop() {
 	}
 }
 
+// updateThrottling limits the DA size of the blocks built by the sequencer while the pending L2 block data
+// exceeds the throttle threshold, and lifts the limits once the backlog is below the threshold again.
+// The limits are set on every poll while throttling, so they are restored if the rollup node restarts.
+func (l *BatchSubmitter) updateThrottling(ctx context.Context) {
+	if l.Throttle.Threshold == 0 {
+		return
+	}
+	pending := l.state.PendingDABytes()
+	throttle := pending > l.Throttle.Threshold
+	l.metr.RecordThrottling(pending, throttle)
+	if !throttle && !l.throttled {
+		return
+	}
+	l.setThrottling(ctx, throttle, pending)
+}
+
+// setThrottling sets the DA size limits of the sequencer, or lifts them if throttle is false.
+func (l *BatchSubmitter) setThrottling(ctx context.Context, throttle bool, pending uint64) {
+	var maxTxSize, maxBlockSize uint64
+	if throttle {
+		maxTxSize, maxBlockSize = l.Throttle.TxSize, l.Throttle.BlockSize
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	if err := l.RollupNode.SetMaxDASize(cCtx, maxTxSize, maxBlockSize); err != nil {
+		l.log.Warn("Failed to update the sequencer DA size limits", "throttle", throttle, "pending_bytes", pending, "err", err)
+		return
+	}
+	if throttle != l.throttled {
+		// the rollup node accepts the limits, but the execution engine does not enforce them yet
+		l.log.Info("Requested sequencer DA size limits, not enforced by the execution engine yet",
+			"throttle", throttle, "pending_bytes", pending,
+			"max_tx_size", maxTxSize, "max_block_size", maxBlockSize)
+	}
+	l.throttled = throttle
+}
+
 // publishStateToL1 loops through the block data loaded into `state` and
 // submits the associated data to the L1 in the form of channel frames.
 func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData], drain bool) {
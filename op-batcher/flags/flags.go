@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -77,6 +78,39 @@ var (
 		Usage:   "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
 		EnvVars: prefixEnvVars("STOPPED"),
 	}
+	CommitmentGuardFlag = &cli.BoolFlag{
+		Name: "commitment-guard",
+		Usage: "Screen every L2 block against the sequencer commitments before batching it, and halt channel building on a violation. " +
+			"Violations are only reported if the screening mode is observe. " +
+			"A violation can be acknowledged using the admin_acknowledgeCommitmentViolation RPC",
+		EnvVars: prefixEnvVars("COMMITMENT_GUARD"),
+	}
+	BatchTypeFlag = &cli.UintFlag{
+		Name:    "batch-type",
+		Usage:   "The batch type. 0 for singular batches and 1 for span batches, which require the Delta upgrade.",
+		Value:   0,
+		EnvVars: prefixEnvVars("BATCH_TYPE"),
+	}
+	ThrottleThresholdFlag = &cli.Uint64Flag{
+		Name: "throttle-threshold",
+		Usage: "The estimated size in bytes of the pending L2 block data above which the sequencer is throttled " +
+			"using the admin_setMaxDASize RPC of the rollup node. 0 to disable throttling. " +
+			"Note: the limits have no effect until the execution engine enforces them.",
+		Value:   0,
+		EnvVars: prefixEnvVars("THROTTLE_THRESHOLD"),
+	}
+	ThrottleTxSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-tx-size",
+		Usage:   "The max DA size of a single transaction included by the sequencer while throttling.",
+		Value:   300,
+		EnvVars: prefixEnvVars("THROTTLE_TX_SIZE"),
+	}
+	ThrottleBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-block-size",
+		Usage:   "The max DA size of the transactions of a block built by the sequencer while throttling.",
+		Value:   21_000,
+		EnvVars: prefixEnvVars("THROTTLE_BLOCK_SIZE"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -94,6 +128,11 @@ var optionalFlags = []cli.Flag{
 	MaxChannelDurationFlag,
 	MaxL1TxSizeBytesFlag,
 	StoppedFlag,
+	CommitmentGuardFlag,
+	BatchTypeFlag,
+	ThrottleThresholdFlag,
+	ThrottleTxSizeFlag,
+	ThrottleBlockSizeFlag,
 	SequencerHDPathFlag,
 }
 
@@ -105,6 +144,7 @@ func init() {
 	optionalFlags = append(optionalFlags, rpc.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, compressor.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, commitments.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
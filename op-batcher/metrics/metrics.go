@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -10,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
@@ -41,6 +43,12 @@ type Metricer interface {
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordCommitmentViolation(block eth.BlockID)
+	RecordCommitmentGuardResumed()
+	commitments.Metricer
+
+	RecordThrottling(pendingBytes uint64, active bool)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -73,6 +81,15 @@ type Metrics struct {
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
+
+	commitmentViolations      opmetrics.Event
+	commitmentGuardHalted     prometheus.Gauge
+	commitmentViolationNumber prometheus.Gauge
+	screeningResults          *prometheus.CounterVec
+	screeningDuration         prometheus.Histogram
+
+	pendingDABytes prometheus.Gauge
+	throttling     prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -173,6 +190,40 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		commitmentViolations: opmetrics.NewEvent(factory, ns, "", "commitment_violation", "Commitment violation"),
+		commitmentGuardHalted: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "commitment_guard_halted",
+			Help:      "1 if channel building is halted on an L2 block that violates the sequencer commitments.",
+		}),
+		commitmentViolationNumber: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "commitment_violation_block_number",
+			Help:      "Number of the last L2 block that violated the sequencer commitments.",
+		}),
+		screeningResults: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "screening_results_total",
+			Help:      "Count of commitment screening results.",
+		}, []string{"result"}),
+		screeningDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "screening_duration_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of commitment screening durations.",
+		}),
+
+		pendingDABytes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "pending_da_bytes",
+			Help:      "Estimated batch data size that is not confirmed on L1 yet, as used for throttling.",
+		}),
+		throttling: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttling",
+			Help:      "1 if the batcher requested the sequencer DA size limits because of its backlog. The limits are not enforced by the execution engine yet.",
+		}),
 	}
 }
 
@@ -259,13 +310,13 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 }
 
 func (m *Metrics) RecordL2BlockInPendingQueue(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesTotal.Add(size)
 	m.pendingBlocksBytesCurrent.Add(size)
 }
 
 func (m *Metrics) RecordL2BlockInChannel(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesCurrent.Add(-1 * size)
 	// Refer to RecordL2BlocksAdded to see the current + count of bytes added to a channel
 }
@@ -295,8 +346,34 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
-// estimateBatchSize estimates the size of the batch
-func estimateBatchSize(block *types.Block) uint64 {
+// RecordCommitmentViolation should be called when the commitment guard halts
+// on an L2 block that violates the sequencer commitments.
+func (m *Metrics) RecordCommitmentViolation(block eth.BlockID) {
+	m.commitmentViolations.Record()
+	m.commitmentGuardHalted.Set(1)
+	m.commitmentViolationNumber.Set(float64(block.Number))
+}
+
+func (m *Metrics) RecordCommitmentGuardResumed() {
+	m.commitmentGuardHalted.Set(0)
+}
+
+func (m *Metrics) RecordScreeningResult(result string, duration time.Duration) {
+	m.screeningResults.WithLabelValues(result).Inc()
+	m.screeningDuration.Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordThrottling(pendingBytes uint64, active bool) {
+	m.pendingDABytes.Set(float64(pendingBytes))
+	if active {
+		m.throttling.Set(1)
+	} else {
+		m.throttling.Set(0)
+	}
+}
+
+// EstimateBatchSize estimates the size of the batch data of the block
+func EstimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
 	for _, tx := range block.Transactions() {
 		// Don't include deposit transactions in the batch.
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -35,3 +37,9 @@ func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
+
+func (*noopMetrics) RecordCommitmentViolation(eth.BlockID)       {}
+func (*noopMetrics) RecordCommitmentGuardResumed()               {}
+func (*noopMetrics) RecordScreeningResult(string, time.Duration) {}
+
+func (*noopMetrics) RecordThrottling(uint64, bool) {}
@@ -2,11 +2,17 @@ package rpc
 
 import (
 	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type batcherClient interface {
 	Start() error
 	Stop(ctx context.Context) error
+	CommitmentViolation() *eth.BlockID
+	AcknowledgeCommitmentViolation(hash common.Hash) error
 }
 
 type adminAPI struct {
@@ -26,3 +32,14 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.Stop(ctx)
 }
+
+// CommitmentViolation returns the L2 block that violated the sequencer commitments
+// and halted channel building, or nil if channel building is not halted.
+func (a *adminAPI) CommitmentViolation(_ context.Context) (*eth.BlockID, error) {
+	return a.b.CommitmentViolation(), nil
+}
+
+// AcknowledgeCommitmentViolation resumes channel building, batching the violating L2 block as-is.
+func (a *adminAPI) AcknowledgeCommitmentViolation(_ context.Context, hash common.Hash) error {
+	return a.b.AcknowledgeCommitmentViolation(hash)
+}
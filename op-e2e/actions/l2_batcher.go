@@ -44,6 +44,9 @@ type BatcherCfg struct {
 	BatcherKey *ecdsa.PrivateKey
 
 	GarbageCfg *GarbageChannelCfg
+
+	// BatchType is the type of the batches in the channels: derive.BatchV1Type or derive.SpanBatchType
+	BatchType uint
 }
 
 // L2Batcher buffers and submits L2 batches to L1.
@@ -140,7 +143,11 @@ func (s *L2Batcher) Buffer(t Testing) error {
 				ApproxComprRatio: 1,
 			})
 			require.NoError(t, e, "failed to create compressor")
-			ch, err = derive.NewChannelOut(c)
+			if s.l2BatcherCfg.BatchType == derive.SpanBatchType {
+				ch, err = derive.NewSpanChannelOut(c, derive.NewSpanBatchBuilder(s.rollupCfg.Genesis.L2Time, s.rollupCfg.L2ChainID))
+			} else {
+				ch, err = derive.NewChannelOut(c)
+			}
 		}
 		require.NoError(t, err, "failed to create channel")
 		s.l2ChannelOut = ch
@@ -158,8 +158,9 @@ func (s *L2Sequencer) ActBuildToL1HeadExclUnsafe(t Testing) {
 }
 
 func (s *L2Sequencer) ActBuildL2ToRegolith(t Testing) {
-	require.NotNil(t, s.rollupCfg.RegolithTime, "cannot activate Regolith when it is not scheduled")
-	for s.L2Unsafe().Time < *s.rollupCfg.RegolithTime {
+	regolithTime := s.rollupCfg.ActivationTime(rollup.Regolith)
+	require.NotNil(t, regolithTime, "cannot activate Regolith when it is not scheduled")
+	for s.L2Unsafe().Time < *regolithTime {
 		s.ActL2StartBlock(t)
 		s.ActL2EndBlock(t)
 	}
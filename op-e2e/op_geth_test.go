@@ -198,7 +198,7 @@ func TestGethOnlyPendingBlockIsLatest(t *testing.T) {
 	time.Sleep(time.Second * 4) // conservatively wait 4 seconds, CI might lag during block building.
 
 	// retrieve the block
-	payload, err := opGeth.l2Engine.GetPayload(ctx, *res.PayloadID)
+	payload, err := opGeth.l2Engine.GetPayload(ctx, eth.PayloadInfo{ID: *res.PayloadID, Timestamp: uint64(attrs.Timestamp)})
 	require.NoError(t, err)
 	checkPending("retrieved", 0)
 	require.Len(t, payload.Transactions, 2, "must include L1 info tx and tx from alice")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
 	"os"
 	"time"
 
@@ -109,12 +110,30 @@ func main() {
 					Value: "/tmp/batch_decoder/channel_cache",
 					Usage: "Cache directory for the found channels",
 				},
+				&cli.Uint64Flag{
+					Name:  "l2-chain-id",
+					Value: 10,
+					Usage: "L2 chain id, to derive the transactions of span batches",
+				},
+				&cli.Uint64Flag{
+					Name:  "l2-genesis-timestamp",
+					Value: 1686068903,
+					Usage: "L2 genesis time, to derive the block timestamps of span batches",
+				},
+				&cli.Uint64Flag{
+					Name:  "l2-block-time",
+					Value: 2,
+					Usage: "L2 block time, to derive the block timestamps of span batches",
+				},
 			},
 			Action: func(cliCtx *cli.Context) error {
 				config := reassemble.Config{
-					BatchInbox:   common.HexToAddress(cliCtx.String("inbox")),
-					InDirectory:  cliCtx.String("in"),
-					OutDirectory: cliCtx.String("out"),
+					BatchInbox:    common.HexToAddress(cliCtx.String("inbox")),
+					InDirectory:   cliCtx.String("in"),
+					OutDirectory:  cliCtx.String("out"),
+					L2ChainID:     new(big.Int).SetUint64(cliCtx.Uint64("l2-chain-id")),
+					L2GenesisTime: cliCtx.Uint64("l2-genesis-timestamp"),
+					L2BlockTime:   cliCtx.Uint64("l2-block-time"),
 				}
 				reassemble.Channels(config)
 				return nil
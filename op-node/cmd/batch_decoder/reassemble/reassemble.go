@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math/big"
 	"os"
 	"path"
 	"sort"
@@ -22,6 +23,9 @@ type ChannelWithMetadata struct {
 	InvalidBatches bool                `json:"invalid_batches"`
 	Frames         []FrameWithMetadata `json:"frames"`
 	Batches        []derive.BatchV1    `json:"batches"`
+	// BatchTypes holds the type of every batch in the channel. The blocks of a span batch are
+	// listed in Batches one by one, without parent and L1 origin hashes, which span batches omit.
+	BatchTypes []int `json:"batch_types"`
 }
 
 type FrameWithMetadata struct {
@@ -36,6 +40,10 @@ type Config struct {
 	BatchInbox   common.Address
 	InDirectory  string
 	OutDirectory string
+	// L2ChainID, L2GenesisTime and L2BlockTime are needed to derive the blocks of span batches
+	L2ChainID     *big.Int
+	L2GenesisTime uint64
+	L2BlockTime   uint64
 }
 
 func LoadFrames(directory string, inbox common.Address) []FrameWithMetadata {
@@ -66,7 +74,7 @@ func Channels(config Config) {
 		framesByChannel[frame.Frame.ID] = append(framesByChannel[frame.Frame.ID], frame)
 	}
 	for id, frames := range framesByChannel {
-		ch := processFrames(id, frames)
+		ch := processFrames(config, id, frames)
 		filename := path.Join(config.OutDirectory, fmt.Sprintf("%s.json", id.String()))
 		if err := writeChannel(ch, filename); err != nil {
 			log.Fatal(err)
@@ -84,7 +92,7 @@ func writeChannel(ch ChannelWithMetadata, filename string) error {
 	return enc.Encode(ch)
 }
 
-func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithMetadata {
+func processFrames(cfg Config, id derive.ChannelID, frames []FrameWithMetadata) ChannelWithMetadata {
 	ch := derive.NewChannel(id, eth.L1BlockRef{Number: frames[0].InclusionBlock})
 	invalidFrame := false
 
@@ -101,6 +109,7 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 	}
 
 	var batches []derive.BatchV1
+	var batchTypes []int
 	invalidBatches := false
 	if ch.IsReady() {
 		br, err := derive.BatchReader(ch.Reader(), eth.L1BlockRef{})
@@ -109,8 +118,24 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 				if err != nil {
 					fmt.Printf("Error reading batch for channel %v. Err: %v\n", id.String(), err)
 					invalidBatches = true
+				} else if batch.Batch.IsSpanBatch() {
+					spanBatch, err := batch.Batch.RawSpanBatch.Derive(cfg.L2BlockTime, cfg.L2GenesisTime, cfg.L2ChainID)
+					if err != nil {
+						fmt.Printf("Error deriving span batch for channel %v. Err: %v\n", id.String(), err)
+						invalidBatches = true
+						continue
+					}
+					for _, block := range spanBatch.Batches {
+						batches = append(batches, derive.BatchV1{
+							EpochNum:     block.EpochNum,
+							Timestamp:    block.Timestamp,
+							Transactions: block.Transactions,
+						})
+					}
+					batchTypes = append(batchTypes, derive.SpanBatchType)
 				} else {
 					batches = append(batches, batch.Batch.BatchV1)
+					batchTypes = append(batchTypes, derive.BatchV1Type)
 				}
 			}
 		} else {
@@ -127,6 +152,7 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 		InvalidFrames:  invalidFrame,
 		InvalidBatches: invalidBatches,
 		Batches:        batches,
+		BatchTypes:     batchTypes,
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/doc"
@@ -124,6 +125,12 @@ func RollupNodeMain(ctx *cli.Context) error {
 
 	m.RecordInfo(VersionWithMeta)
 	m.RecordUp()
+	for _, fork := range cfg.Rollup.ForkSchedule() {
+		if fork.Time != nil {
+			m.RecordForkActivation(string(fork.Name), *fork.Time)
+		}
+	}
+	cfg.Rollup.ForkSchedule().LogUpcoming(log, uint64(time.Now().Unix()))
 	log.Info("Rollup node started")
 
 	if cfg.Heartbeat.Enabled {
@@ -36,6 +36,29 @@ function tooltipFormat(v) {
   return out
 }
 
+function screeningFormat(s) {
+  var out = ""
+  out += `<div>`
+  out += `<em>hash</em>: <code>${s["block"]["hash"]}</code><br/>`
+  out += `<em>num</em>: <code>${s["block"]["number"]}</code><br/>`
+  out += `<em>satisfied</em>: <code>${s["satisfied"]}</code><br/>`
+  out += `<em>mode</em>: <code>${s["mode"]}</code><br/>`
+  out += `</div>`
+  return out
+}
+
+function screeningCell(e) {
+    if (!e.hasOwnProperty("screening")) {
+        return `<td></td>`
+    }
+    const s = e.screening
+    const color = s.satisfied ? "#b6e3b6" : "#f2a5a5"
+    const verdict = s.satisfied ? "pass" : "FAIL"
+    return `<td title="${screeningFormat(s)}" data-bs-html="true" data-toggle="tooltip" style="background-color:${color};">
+                ${verdict} ${prettyHex(s.block.hash)}
+            </td>`
+}
+
 async function pageTable() {
     const logs = await fetchLogs();
     if (logs.length === 0) {
@@ -71,6 +94,7 @@ async function pageTable() {
                             <th scope="col">L2Head</th>
                             <th scope="col">L2Safe</th>
                             <th scope="col">L2FinalizedHead</th>
+                            <th scope="col">Screening</th>
                         </tr>
                     </thead>
                         `;
@@ -106,6 +130,7 @@ async function pageTable() {
                         <td title="${tooltipFormat(e.l2FinalizedHead)}" data-bs-html="true" data-toggle="tooltip" style="background-color:${colorCode(e.l2FinalizedHead.hash)};">
                             ${prettyHex(e.l2FinalizedHead.hash)}
                         </td>
+                        ${screeningCell(e)}
                     </tr>`;
                 }
                 html += "</tbody>";
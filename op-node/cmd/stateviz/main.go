@@ -20,6 +20,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -45,6 +46,20 @@ type SnapshotState struct {
 	L2Head          eth.L2BlockRef `json:"l2Head"`          // l2 block that was last optimistically accepted (unsafe head)
 	L2Safe          eth.L2BlockRef `json:"l2Safe"`          // l2 block that was last derived
 	L2FinalizedHead eth.BlockID    `json:"l2FinalizedHead"` // l2 block that is irreversible
+
+	Screening *ScreeningState `json:"screening,omitempty"` // commitment screening of an unsafe l2 block, if this is a screening event
+}
+
+type ScreeningState struct {
+	Block     eth.BlockID `json:"block"`     // l2 block that was screened
+	Satisfied bool        `json:"satisfied"` // whether the block satisfied the sequencer commitments
+	Mode      string      `json:"mode"`      // screening mode of the node
+}
+
+// IsScreening returns whether the snapshot was logged for a commitment screening,
+// in which case it does not carry the rollup state.
+func (e *SnapshotState) IsScreening() bool {
+	return e.Screening != nil
 }
 
 func (e *SnapshotState) UnmarshalJSON(data []byte) error {
@@ -57,6 +72,11 @@ func (e *SnapshotState) UnmarshalJSON(data []byte) error {
 		L2Head          json.RawMessage `json:"l2Head"`
 		L2Safe          json.RawMessage `json:"l2Safe"`
 		L2FinalizedHead json.RawMessage `json:"l2FinalizedHead"`
+
+		L2ScreenedHash   *common.Hash `json:"l2ScreenedHash"`
+		L2ScreenedNumber uint64       `json:"l2ScreenedNumber"`
+		Satisfied        string       `json:"satisfied"` // booleans are logged as strings
+		Mode             string       `json:"mode"`
 	}{}
 	if err := json.Unmarshal(data, &t); err != nil {
 		return err
@@ -65,6 +85,15 @@ func (e *SnapshotState) UnmarshalJSON(data []byte) error {
 	e.EngineAddr = t.EngineAddr
 	e.Event = t.Event
 
+	if t.L2ScreenedHash != nil {
+		e.Screening = &ScreeningState{
+			Block:     eth.BlockID{Hash: *t.L2ScreenedHash, Number: t.L2ScreenedNumber},
+			Satisfied: t.Satisfied == "true",
+			Mode:      t.Mode,
+		}
+		return nil
+	}
+
 	unquote := func(d json.RawMessage) []byte {
 		s, _ := strconv.Unquote(string(d))
 		return []byte(s)
@@ -131,6 +160,8 @@ func loadSnapshot() error {
 	defer file.Close()
 
 	tempEntries := make(map[string][]SnapshotState)
+	// latest rollup state per engine, screening events are displayed along with it
+	lastState := make(map[string]SnapshotState)
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		var entry SnapshotState
@@ -138,6 +169,17 @@ func loadSnapshot() error {
 			return fmt.Errorf("%w: failed to decode snapshot log", err)
 		}
 
+		if entry.IsScreening() {
+			state := lastState[entry.EngineAddr]
+			entry.L1Head = state.L1Head
+			entry.L1Current = state.L1Current
+			entry.L2Head = state.L2Head
+			entry.L2Safe = state.L2Safe
+			entry.L2FinalizedHead = state.L2FinalizedHead
+		} else {
+			lastState[entry.EngineAddr] = entry
+		}
+
 		tempEntries[entry.EngineAddr] = append(tempEntries[entry.EngineAddr], entry)
 	}
 	if err := scanner.Err(); err != nil {
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 
@@ -62,11 +64,38 @@ var (
 		Usage:   "Enable the admin API (experimental)",
 		EnvVars: prefixEnvVars("RPC_ENABLE_ADMIN"),
 	}
+	RPCEnableDebug = &cli.BoolFlag{
+		Name:    "rpc.enable-debug",
+		Usage:   "Enable the debug API, to inspect the internal state of the derivation pipeline",
+		EnvVars: prefixEnvVars("RPC_ENABLE_DEBUG"),
+	}
+	RPCAdminAuthListenAddr = &cli.StringFlag{
+		Name:    "rpc.admin-auth.addr",
+		Usage:   "Listening address of the authenticated admin RPC, which can insert unsafe payloads.",
+		EnvVars: prefixEnvVars("RPC_ADMIN_AUTH_ADDR"),
+		Value:   "127.0.0.1",
+	}
+	RPCAdminAuthListenPort = &cli.IntFlag{
+		Name:    "rpc.admin-auth.port",
+		Usage:   "Listening port of the authenticated admin RPC, which can insert unsafe payloads.",
+		EnvVars: prefixEnvVars("RPC_ADMIN_AUTH_PORT"),
+		Value:   9547,
+	}
+	RPCAdminJWTSecret = &cli.StringFlag{
+		Name:    "rpc.admin-auth.jwt-secret",
+		Usage:   "Path to the JWT secret to authenticate admin RPC requests with. The authenticated admin RPC is disabled if not set. Keys are 32 bytes, hex encoded in a file.",
+		EnvVars: prefixEnvVars("RPC_ADMIN_AUTH_JWT_SECRET"),
+	}
 	RPCAdminPersistence = &cli.StringFlag{
 		Name:    "rpc.admin-state",
 		Usage:   "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
 		EnvVars: prefixEnvVars("RPC_ADMIN_STATE"),
 	}
+	BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use, required to read batches from blobs after the Ecotone upgrade",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -100,6 +129,30 @@ var (
 		EnvVars: prefixEnvVars("L1_HTTP_POLL_INTERVAL"),
 		Value:   time.Second * 12,
 	}
+	L1FallbackAddrs = &cli.StringSliceFlag{
+		Name: "l1.fallback",
+		Usage: "Additional L1 User JSON-RPC endpoints to fail over to when the --l1 endpoint is unhealthy, in order of priority. " +
+			"Comma-separated list of [<rpc-kind>=]<rpc-url> entries, the RPC kind defaults to the --l1.rpckind setting.",
+		EnvVars: prefixEnvVars("L1_FALLBACK_ETH_RPC"),
+	}
+	L1HealthCheckInterval = &cli.DurationFlag{
+		Name:    "l1.health-check-interval",
+		Usage:   "Interval between health checks of the L1 RPC endpoints. Only used if L1 fallback endpoints are configured.",
+		EnvVars: prefixEnvVars("L1_HEALTH_CHECK_INTERVAL"),
+		Value:   time.Second * 6,
+	}
+	L1HealthCheckTimeout = &cli.DurationFlag{
+		Name:    "l1.health-check-timeout",
+		Usage:   "Timeout of the health check requests to an L1 RPC endpoint, must be shorter than the health check interval. Only used if L1 fallback endpoints are configured.",
+		EnvVars: prefixEnvVars("L1_HEALTH_CHECK_TIMEOUT"),
+		Value:   time.Second * 3,
+	}
+	L1MaxHeadLag = &cli.Uint64Flag{
+		Name:    "l1.max-head-lag",
+		Usage:   "Number of blocks the head of an L1 RPC endpoint may lag behind or run ahead of the median head of all endpoints before it is considered unhealthy. Only used if L1 fallback endpoints are configured.",
+		EnvVars: prefixEnvVars("L1_MAX_HEAD_LAG"),
+		Value:   2,
+	}
 	L2EngineJWTSecret = &cli.StringFlag{
 		Name:        "l2.jwt-secret",
 		Usage:       "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if left empty.",
@@ -132,6 +185,22 @@ var (
 		Required: false,
 		Value:    0,
 	}
+	SequencerTxOrderingFlag = &cli.StringFlag{
+		Name: "sequencer.tx-ordering",
+		Usage: "Policy to order the transaction-pool transactions of the sequenced blocks with. " +
+			"'engine' leaves the ordering to the execution engine, the op-node orders the transactions with the other policies. " +
+			"Options: " + openum.EnumString(driver.TxOrderingPolicies),
+		EnvVars:  prefixEnvVars("SEQUENCER_TX_ORDERING"),
+		Required: false,
+		Value:    driver.TxOrderingEngine,
+	}
+	SequencerFairOrderingWindowFlag = &cli.DurationFlag{
+		Name:     "sequencer.fair-ordering-window",
+		Usage:    "Duration of the arrival time batches of the fair transaction ordering policy. Transactions that arrive in the same batch are ordered pseudo-randomly.",
+		EnvVars:  prefixEnvVars("SEQUENCER_FAIR_ORDERING_WINDOW"),
+		Required: false,
+		Value:    500 * time.Millisecond,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -229,6 +298,116 @@ var (
 		Required: false,
 		Value:    false,
 	}
+	L2SnapSyncEnabled = &cli.BoolFlag{
+		Name: "l2.snap-sync",
+		Usage: "Snap sync the execution engine before deriving: the engine snap syncs to the latest L2 output proposed in a finalized L1 block, " +
+			"retrieved from the l2.backup-unsafe-sync-rpc, and the synced state is verified against the proposed output root. " +
+			"Requires the execution engine to be configured for snap sync.",
+		EnvVars:  prefixEnvVars("L2_SNAP_SYNC_ENABLED"),
+		Required: false,
+		Value:    false,
+	}
+	L2SnapSyncL2OutputOracle = &cli.StringFlag{
+		Name:     "l2.snap-sync.l2oo-address",
+		Usage:    "Address of the L2OutputOracle contract on L1, to read the snap sync target and its output root from.",
+		EnvVars:  prefixEnvVars("L2_SNAP_SYNC_L2OO_ADDRESS"),
+		Required: false,
+	}
+	ConductorEnabledFlag = &cli.BoolFlag{
+		Name:    "conductor.enabled",
+		Usage:   "Enable leader election among sequencer replicas: only the elected leader sequences, and replicates its signed blocks to the other replicas before publishing them. Requires a p2p sequencer key.",
+		EnvVars: prefixEnvVars("CONDUCTOR_ENABLED"),
+	}
+	ConductorServerIDFlag = &cli.StringFlag{
+		Name:    "conductor.server-id",
+		Usage:   "Unique ID of this sequencer replica in the sequencer cluster.",
+		EnvVars: prefixEnvVars("CONDUCTOR_SERVER_ID"),
+	}
+	ConductorPeersFlag = &cli.StringSliceFlag{
+		Name:    "conductor.peers",
+		Usage:   "Other replicas of the sequencer cluster, as comma-separated list of <server-id>=<conductor-rpc-url> entries.",
+		EnvVars: prefixEnvVars("CONDUCTOR_PEERS"),
+	}
+	ConductorRPCListenAddr = &cli.StringFlag{
+		Name:    "conductor.rpc.addr",
+		Usage:   "Listening address of the conductor RPC, served to the other replicas of the sequencer cluster.",
+		EnvVars: prefixEnvVars("CONDUCTOR_RPC_ADDR"),
+		Value:   "127.0.0.1",
+	}
+	ConductorRPCListenPort = &cli.IntFlag{
+		Name:    "conductor.rpc.port",
+		Usage:   "Listening port of the conductor RPC, served to the other replicas of the sequencer cluster.",
+		EnvVars: prefixEnvVars("CONDUCTOR_RPC_PORT"),
+		Value:   9546,
+	}
+	ConductorJWTSecret = &cli.StringFlag{
+		Name:    "conductor.jwt-secret",
+		Usage:   "Path to the JWT secret shared by the replicas of the sequencer cluster, to authenticate conductor RPC requests. Keys are 32 bytes, hex encoded in a file.",
+		EnvVars: prefixEnvVars("CONDUCTOR_JWT_SECRET"),
+	}
+	ConductorStateFileFlag = &cli.StringFlag{
+		Name:    "conductor.state-file",
+		Usage:   "File path used to persist the election state of this sequencer replica, so it is retained across restarts.",
+		EnvVars: prefixEnvVars("CONDUCTOR_STATE_FILE"),
+	}
+	ConductorHeartbeatIntervalFlag = &cli.DurationFlag{
+		Name:    "conductor.heartbeat-interval",
+		Usage:   "Interval at which the leader sequencer asserts its leadership and checks its health.",
+		EnvVars: prefixEnvVars("CONDUCTOR_HEARTBEAT_INTERVAL"),
+		Value:   time.Millisecond * 200,
+	}
+	ConductorElectionTimeoutFlag = &cli.DurationFlag{
+		Name:    "conductor.election-timeout",
+		Usage:   "Minimum time without leader heartbeat before a sequencer replica runs for leader.",
+		EnvVars: prefixEnvVars("CONDUCTOR_ELECTION_TIMEOUT"),
+		Value:   time.Second,
+	}
+	ConductorRequestTimeoutFlag = &cli.DurationFlag{
+		Name:    "conductor.request-timeout",
+		Usage:   "Timeout of requests to the other sequencer replicas.",
+		EnvVars: prefixEnvVars("CONDUCTOR_REQUEST_TIMEOUT"),
+		Value:   time.Millisecond * 500,
+	}
+	ConductorMaxUnsafeLagFlag = &cli.DurationFlag{
+		Name:    "conductor.max-unsafe-lag",
+		Usage:   "Maximum lag of the unsafe L2 head behind the wall-clock before the leader sequencer is considered unhealthy and steps down. Disabled if 0.",
+		EnvVars: prefixEnvVars("CONDUCTOR_MAX_UNSAFE_LAG"),
+		Value:   0,
+	}
+	SafeDBPath = &cli.StringFlag{
+		Name:     "safedb.path",
+		Usage:    "File path used to persist safe head update data. Disabled if not set.",
+		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
+		Required: false,
+	}
+	UnsafePayloadsPath = &cli.StringFlag{
+		Name:    "unsafe-payloads.path",
+		Usage:   "File path used to persist queued unsafe payloads, to replay them after a restart instead of syncing them again. Disabled if not set.",
+		EnvVars: prefixEnvVars("UNSAFE_PAYLOADS_PATH"),
+	}
+	UnsafePayloadsMaxSize = &cli.Uint64Flag{
+		Name:    "unsafe-payloads.max-size",
+		Usage:   "Maximum total size in bytes of the persisted unsafe payloads. The payloads with the lowest block numbers are removed first.",
+		EnvVars: prefixEnvVars("UNSAFE_PAYLOADS_MAX_SIZE"),
+		Value:   100 * 1024 * 1024,
+	}
+	UnsafePayloadsMaxAge = &cli.DurationFlag{
+		Name:    "unsafe-payloads.max-age",
+		Usage:   "Maximum duration unsafe payloads are persisted for, after they were received.",
+		EnvVars: prefixEnvVars("UNSAFE_PAYLOADS_MAX_AGE"),
+		Value:   time.Hour,
+	}
+	PipelineCheckpointFile = &cli.StringFlag{
+		Name:    "pipeline.checkpoint-file",
+		Usage:   "File path used to persist derivation pipeline checkpoints, to restart derivation without re-reading the L1 data of a full channel timeout. Disabled if not set.",
+		EnvVars: prefixEnvVars("PIPELINE_CHECKPOINT_FILE"),
+	}
+	PipelineCheckpointInterval = &cli.DurationFlag{
+		Name:    "pipeline.checkpoint-interval",
+		Usage:   "Minimum interval between derivation pipeline checkpoints.",
+		EnvVars: prefixEnvVars("PIPELINE_CHECKPOINT_INTERVAL"),
+		Value:   time.Minute,
+	}
 	BetaExtraNetworks = &cli.BoolFlag{
 		Name: "beta.extra-networks",
 		Usage: fmt.Sprintf("Beta feature: enable selection of a predefined-network from the superchain-registry. "+
@@ -249,19 +428,30 @@ var optionalFlags = []cli.Flag{
 	RPCListenPort,
 	RollupConfig,
 	Network,
+	BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
 	L1RPCMaxBatchSize,
 	L1HTTPPollInterval,
+	L1FallbackAddrs,
+	L1HealthCheckInterval,
+	L1HealthCheckTimeout,
+	L1MaxHeadLag,
 	L2EngineJWTSecret,
 	VerifierL1Confs,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerTxOrderingFlag,
+	SequencerFairOrderingWindowFlag,
 	SequencerL1Confs,
 	L1EpochPollIntervalFlag,
 	RPCEnableAdmin,
+	RPCEnableDebug,
+	RPCAdminAuthListenAddr,
+	RPCAdminAuthListenPort,
+	RPCAdminJWTSecret,
 	RPCAdminPersistence,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
@@ -277,6 +467,25 @@ var optionalFlags = []cli.Flag{
 	BackupL2UnsafeSyncRPCTrustRPC,
 	L2EngineSyncEnabled,
 	SkipSyncStartCheck,
+	L2SnapSyncEnabled,
+	L2SnapSyncL2OutputOracle,
+	SafeDBPath,
+	UnsafePayloadsPath,
+	UnsafePayloadsMaxSize,
+	UnsafePayloadsMaxAge,
+	PipelineCheckpointFile,
+	PipelineCheckpointInterval,
+	ConductorEnabledFlag,
+	ConductorServerIDFlag,
+	ConductorPeersFlag,
+	ConductorRPCListenAddr,
+	ConductorRPCListenPort,
+	ConductorJWTSecret,
+	ConductorStateFileFlag,
+	ConductorHeartbeatIntervalFlag,
+	ConductorElectionTimeoutFlag,
+	ConductorRequestTimeoutFlag,
+	ConductorMaxUnsafeLagFlag,
 	BetaExtraNetworks,
 }
 
@@ -286,6 +495,7 @@ var Flags []cli.Flag
 func init() {
 	optionalFlags = append(optionalFlags, p2pFlags...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, commitments.CLIFlags(EnvVarPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
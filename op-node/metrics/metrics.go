@@ -39,9 +39,13 @@ const (
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
+	RecordForkActivation(fork string, timestamp uint64)
 	RecordRPCServerRequest(method string) func()
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	RecordRPCEndpointHealth(name string, healthy bool, head uint64)
+	RecordRPCEndpointActive(name string, active bool)
+	RecordRPCEndpointFailover(name string)
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
 	RecordSequencingError()
@@ -52,10 +56,15 @@ type Metricer interface {
 	RecordL1Ref(name string, ref eth.L1BlockRef)
 	RecordL2Ref(name string, ref eth.L2BlockRef)
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
+	RecordScreeningQueue(length int)
+	RecordScreeningDropped(count int)
+	RecordScreeningResult(result string, duration time.Duration)
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordTxOrdering(policy string, candidates int, selected int, duration time.Duration)
+	RecordTxOrderingFallback(policy string)
 	RecordGossipEvent(evType int32)
 	IncPeerCount()
 	DecPeerCount()
@@ -85,14 +94,22 @@ type Metrics struct {
 	Info *prometheus.GaugeVec
 	Up   prometheus.Gauge
 
+	ForkActivationTime *prometheus.GaugeVec
+
 	RPCServerRequestsTotal          *prometheus.CounterVec
 	RPCServerRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientRequestsTotal          *prometheus.CounterVec
 	RPCClientRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientResponsesTotal         *prometheus.CounterVec
 
-	L1SourceCache *CacheMetrics
-	L2SourceCache *CacheMetrics
+	RPCEndpointHealthy   *prometheus.GaugeVec
+	RPCEndpointHead      *prometheus.GaugeVec
+	RPCEndpointActive    *prometheus.GaugeVec
+	RPCEndpointFailovers *prometheus.CounterVec
+
+	L1SourceCache  *CacheMetrics
+	L2SourceCache  *CacheMetrics
+	ScreeningCache *CacheMetrics
 
 	DerivationIdle prometheus.Gauge
 
@@ -119,9 +136,19 @@ type Metrics struct {
 	SequencerSealingDurationSeconds prometheus.Histogram
 	SequencerSealingTotal           prometheus.Counter
 
+	TxOrderingCandidates      *prometheus.GaugeVec
+	TxOrderingSelectedTotal   *prometheus.CounterVec
+	TxOrderingDurationSeconds *prometheus.HistogramVec
+	TxOrderingFallbacksTotal  *prometheus.CounterVec
+
 	UnsafePayloadsBufferLen     prometheus.Gauge
 	UnsafePayloadsBufferMemSize prometheus.Gauge
 
+	ScreeningQueueLen        prometheus.Gauge
+	ScreeningDropped         *EventMetrics
+	ScreeningResults         *prometheus.CounterVec
+	ScreeningDurationSeconds prometheus.Histogram
+
 	RefsNumber  *prometheus.GaugeVec
 	RefsTime    *prometheus.GaugeVec
 	RefsHash    *prometheus.GaugeVec
@@ -184,6 +211,13 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op node has finished starting up",
 		}),
+		ForkActivationTime: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "fork_activation_time",
+			Help:      "Activation timestamp of each scheduled network upgrade",
+		}, []string{
+			"fork",
+		}),
 
 		RPCServerRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
@@ -228,9 +262,42 @@ func NewMetrics(procName string) *Metrics {
 			"method",
 			"error",
 		}),
+		RPCEndpointHealthy: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_healthy",
+			Help:      "1 if the RPC endpoint passed the last health check, 0 otherwise",
+		}, []string{
+			"endpoint",
+		}),
+		RPCEndpointHead: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_head",
+			Help:      "Latest block number reported by the RPC endpoint during the last health check",
+		}, []string{
+			"endpoint",
+		}),
+		RPCEndpointActive: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_active",
+			Help:      "1 if the RPC endpoint currently serves the requests, 0 otherwise",
+		}, []string{
+			"endpoint",
+		}),
+		RPCEndpointFailovers: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_failovers_total",
+			Help:      "Count of fail-overs to the RPC endpoint",
+		}, []string{
+			"endpoint",
+		}),
 
-		L1SourceCache: NewCacheMetrics(factory, ns, "l1_source_cache", "L1 Source cache"),
-		L2SourceCache: NewCacheMetrics(factory, ns, "l2_source_cache", "L2 Source cache"),
+		L1SourceCache:  NewCacheMetrics(factory, ns, "l1_source_cache", "L1 Source cache"),
+		L2SourceCache:  NewCacheMetrics(factory, ns, "l2_source_cache", "L2 Source cache"),
+		ScreeningCache: NewCacheMetrics(factory, ns, "screening_cache", "Commitment screening cache"),
 
 		DerivationIdle: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -258,6 +325,26 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Total estimated memory size of buffered L2 unsafe payloads",
 		}),
 
+		ScreeningQueueLen: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "screening_queue_len",
+			Help:      "Number of unsafe payloads queued for commitment screening",
+		}),
+		ScreeningDropped: NewEventMetrics(factory, ns, "screening_dropped", "unsafe payloads dropped because the screening queue is full or the node is stopped"),
+		ScreeningResults: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "screening_results_total",
+			Help:      "Count of commitment screening results",
+		}, []string{
+			"result",
+		}),
+		ScreeningDurationSeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "screening_duration_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of commitment screening durations",
+		}),
+
 		RefsNumber: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "refs_number",
@@ -452,6 +539,36 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Number of sequencer block sealing jobs",
 		}),
 
+		TxOrderingCandidates: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_tx_ordering_candidates",
+			Help:      "Number of transaction-pool transactions considered by the ordering policy for the last block",
+		}, []string{
+			"policy",
+		}),
+		TxOrderingSelectedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sequencer_tx_ordering_selected_total",
+			Help:      "Number of transaction-pool transactions included in blocks by the ordering policy",
+		}, []string{
+			"policy",
+		}),
+		TxOrderingDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "sequencer_tx_ordering_duration_seconds",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+			Help:      "Histogram of the time to select and order the transaction-pool transactions of a block",
+		}, []string{
+			"policy",
+		}),
+		TxOrderingFallbacksTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sequencer_tx_ordering_fallbacks_total",
+			Help:      "Number of blocks for which the transaction ordering was left to the engine after a failure",
+		}, []string{
+			"policy",
+		}),
+
 		registry: registry,
 		factory:  factory,
 	}
@@ -487,6 +604,11 @@ func (m *Metrics) RecordUp() {
 	m.Up.Set(1)
 }
 
+// RecordForkActivation records the activation timestamp of a scheduled network upgrade.
+func (m *Metrics) RecordForkActivation(fork string, timestamp uint64) {
+	m.ForkActivationTime.WithLabelValues(fork).Set(float64(timestamp))
+}
+
 // RecordRPCServerRequest is a helper method to record an incoming RPC
 // call to the opnode's RPC server. It bumps the requests metric,
 // and tracks how long it takes to serve a response.
@@ -534,6 +656,27 @@ func (m *Metrics) RecordRPCClientResponse(method string, err error) {
 	m.RPCClientResponsesTotal.WithLabelValues(method, errStr).Inc()
 }
 
+func (m *Metrics) RecordRPCEndpointHealth(name string, healthy bool, head uint64) {
+	var val float64
+	if healthy {
+		val = 1
+	}
+	m.RPCEndpointHealthy.WithLabelValues(name).Set(val)
+	m.RPCEndpointHead.WithLabelValues(name).Set(float64(head))
+}
+
+func (m *Metrics) RecordRPCEndpointActive(name string, active bool) {
+	var val float64
+	if active {
+		val = 1
+	}
+	m.RPCEndpointActive.WithLabelValues(name).Set(val)
+}
+
+func (m *Metrics) RecordRPCEndpointFailover(name string) {
+	m.RPCEndpointFailovers.WithLabelValues(name).Inc()
+}
+
 func (m *Metrics) SetDerivationIdle(status bool) {
 	var val float64
 	if status {
@@ -594,6 +737,20 @@ func (m *Metrics) RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next
 	m.UnsafePayloadsBufferMemSize.Set(float64(memSize))
 }
 
+func (m *Metrics) RecordScreeningQueue(length int) {
+	m.ScreeningQueueLen.Set(float64(length))
+}
+
+func (m *Metrics) RecordScreeningDropped(count int) {
+	m.ScreeningDropped.Total.Add(float64(count))
+	m.ScreeningDropped.LastTime.Set(float64(time.Now().Unix()))
+}
+
+func (m *Metrics) RecordScreeningResult(result string, duration time.Duration) {
+	m.ScreeningResults.WithLabelValues(result).Inc()
+	m.ScreeningDurationSeconds.Observe(duration.Seconds())
+}
+
 func (m *Metrics) CountSequencedTxs(count int) {
 	m.TransactionsSequencedTotal.Add(float64(count))
 }
@@ -661,6 +818,20 @@ func (m *Metrics) RecordSequencerBuildingDiffTime(duration time.Duration) {
 	m.SequencerBuildingDiffDurationSeconds.Observe(float64(duration) / float64(time.Second))
 }
 
+// RecordTxOrdering tracks the transaction-pool transactions that the ordering policy of the sequencer
+// considered and selected for a block, and how long the selection took.
+func (m *Metrics) RecordTxOrdering(policy string, candidates int, selected int, duration time.Duration) {
+	m.TxOrderingCandidates.WithLabelValues(policy).Set(float64(candidates))
+	m.TxOrderingSelectedTotal.WithLabelValues(policy).Add(float64(selected))
+	m.TxOrderingDurationSeconds.WithLabelValues(policy).Observe(float64(duration) / float64(time.Second))
+}
+
+// RecordTxOrderingFallback counts the blocks for which the sequencer left the ordering to the engine,
+// because the transactions could not be ordered or the engine rejected the ordered transactions.
+func (m *Metrics) RecordTxOrderingFallback(policy string) {
+	m.TxOrderingFallbacksTotal.WithLabelValues(policy).Inc()
+}
+
 // RecordSequencerSealingTime tracks the amount of time the sequencer took to finish sealing the block.
 // Ideally this is 0, realistically it may take some time.
 func (m *Metrics) RecordSequencerSealingTime(duration time.Duration) {
@@ -758,6 +929,9 @@ func (n *noopMetricer) RecordInfo(version string) {
 func (n *noopMetricer) RecordUp() {
 }
 
+func (n *noopMetricer) RecordForkActivation(fork string, timestamp uint64) {
+}
+
 func (n *noopMetricer) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
@@ -769,6 +943,15 @@ func (n *noopMetricer) RecordRPCClientRequest(method string) func(err error) {
 func (n *noopMetricer) RecordRPCClientResponse(method string, err error) {
 }
 
+func (n *noopMetricer) RecordRPCEndpointHealth(name string, healthy bool, head uint64) {
+}
+
+func (n *noopMetricer) RecordRPCEndpointActive(name string, active bool) {
+}
+
+func (n *noopMetricer) RecordRPCEndpointFailover(name string) {
+}
+
 func (n *noopMetricer) SetDerivationIdle(status bool) {
 }
 
@@ -799,6 +982,15 @@ func (n *noopMetricer) RecordL2Ref(name string, ref eth.L2BlockRef) {
 func (n *noopMetricer) RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID) {
 }
 
+func (n *noopMetricer) RecordScreeningQueue(length int) {
+}
+
+func (n *noopMetricer) RecordScreeningDropped(count int) {
+}
+
+func (n *noopMetricer) RecordScreeningResult(result string, duration time.Duration) {
+}
+
 func (n *noopMetricer) CountSequencedTxs(count int) {
 }
 
@@ -811,6 +1003,12 @@ func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to
 func (n *noopMetricer) RecordSequencerReset() {
 }
 
+func (n *noopMetricer) RecordTxOrdering(policy string, candidates int, selected int, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordTxOrderingFallback(policy string) {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
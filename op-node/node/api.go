@@ -8,7 +8,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -28,6 +30,26 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	SetMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) error
+}
+
+type unsafePayloadPoster interface {
+	// PostUnsafePayload validates and screens the payload, signed by the sequencer, like gossip,
+	// queues it for insertion, and returns the resulting unsafe head.
+	PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) (eth.L2BlockRef, error)
+}
+
+type derivationDebugger interface {
+	DerivationDebugInfo(ctx context.Context) (*derive.PipelineDebugInfo, error)
+	DerivationChannelFrames(ctx context.Context, id derive.ChannelID) (*derive.ChannelFrames, error)
+}
+
+type screeningStatusReader interface {
+	FillSyncStatus(status *eth.SyncStatus)
+}
+
+type safeDBReader interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error)
 }
 
 type rpcMetrics interface {
@@ -71,12 +93,77 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// SetMaxDASize limits the data-availability size of each transaction, and of all transactions, that the
+// sequencer includes from the transaction-pool. The batcher uses this to throttle block production when
+// its backlog of unsubmitted data grows. A zero size removes the limit.
+// Note that the limits have no effect yet: the execution engine ignores them in the payload attributes.
+func (n *adminAPI) SetMaxDASize(ctx context.Context, maxTxSize hexutil.Uint64, maxBlockSize hexutil.Uint64) error {
+	recordDur := n.m.RecordRPCServerRequest("admin_setMaxDASize")
+	defer recordDur()
+	return n.dr.SetMaxDASize(ctx, uint64(maxTxSize), uint64(maxBlockSize))
+}
+
+// authAdminAPI is the part of the admin API that is only served to authenticated requests,
+// since it changes the chain the node follows.
+type authAdminAPI struct {
+	payloads unsafePayloadPoster
+	m        rpcMetrics
+}
+
+func NewAuthAdminAPI(payloads unsafePayloadPoster, m rpcMetrics) *authAdminAPI {
+	return &authAdminAPI{
+		payloads: payloads,
+		m:        m,
+	}
+}
+
+// PostUnsafePayload injects a known-good unsafe payload, e.g. one the node missed during an incident.
+// The payload is validated and screened like gossip, including the signature of the sequencer,
+// and the unsafe head after processing it is returned.
+func (n *authAdminAPI) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, signature hexutil.Bytes) (eth.L2BlockRef, error) {
+	recordDur := n.m.RecordRPCServerRequest("admin_postUnsafePayload")
+	defer recordDur()
+	return n.payloads.PostUnsafePayload(ctx, payload, signature)
+}
+
+type debugAPI struct {
+	dr derivationDebugger
+	m  rpcMetrics
+}
+
+func NewDebugAPI(dr derivationDebugger, m rpcMetrics) *debugAPI {
+	return &debugAPI{
+		dr: dr,
+		m:  m,
+	}
+}
+
+// Derivation returns the origin and last error of each derivation pipeline stage,
+// the channels pending in the channel bank, and the batches buffered in the batch queue.
+func (n *debugAPI) Derivation(ctx context.Context) (*derive.PipelineDebugInfo, error) {
+	recordDur := n.m.RecordRPCServerRequest("debug_derivation")
+	defer recordDur()
+	return n.dr.DerivationDebugInfo(ctx)
+}
+
+// DerivationChannel returns the raw frames received so far of a channel pending in the channel bank.
+func (n *debugAPI) DerivationChannel(ctx context.Context, id derive.ChannelID) (*derive.ChannelFrames, error) {
+	recordDur := n.m.RecordRPCServerRequest("debug_derivationChannel")
+	defer recordDur()
+	return n.dr.DerivationChannelFrames(ctx, id)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
 	log    log.Logger
 	m      rpcMetrics
+
+	// screening is optional, and adds the commitment screening status to the sync status if set
+	screening screeningStatusReader
+	// safeDB is optional, and serves the safe head at a given L1 block if set
+	safeDB safeDBReader
 }
 
 func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m rpcMetrics) *nodeAPI {
@@ -112,10 +199,36 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 	}, nil
 }
 
+func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
+	defer recordDur()
+	if n.safeDB == nil {
+		return nil, safedb.ErrNotEnabled
+	}
+	l1Block, safeHead, err := n.safeDB.SafeHeadAtL1(ctx, uint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at l1 block %s: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
-	return n.dr.SyncStatus(ctx)
+	status, err := n.dr.SyncStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n.screening != nil {
+		// fill in a copy, the driver may share the status it returns
+		filled := *status
+		n.screening.FillSyncStatus(&filled)
+		status = &filled
+	}
+	return status, nil
 }
 
 func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
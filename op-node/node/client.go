@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
@@ -32,7 +33,14 @@ type L1EndpointSetup interface {
 	// Setup a RPC client to a L1 node to pull rollup input-data from.
 	// The results of the RPC client may be trusted for faster processing, or strictly validated.
 	// The kind of the RPC may be non-basic, to optimize RPC usage.
-	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
+	// The metrics track the health of the RPC endpoints, if the RPC fails over between multiple endpoints.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m sources.FailoverMetrics) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
+	Check() error
+}
+
+type L1BeaconEndpointSetup interface {
+	// Setup a client to the beacon API of a L1 consensus node, to fetch the blobs of L1 blocks with.
+	Setup(ctx context.Context, log log.Logger) (cl *sources.L1BeaconClient, err error)
 	Check() error
 }
 
@@ -156,6 +164,30 @@ type L1EndpointConfig struct {
 	// It is recommended to use websockets or IPC for efficient following of the changing block.
 	// Setting this to 0 disables polling.
 	HttpPollInterval time.Duration
+
+	// L1FallbackNodes are additional L1 endpoints, in order of priority after L1NodeAddr,
+	// that are failed over to when the preferred endpoints are unhealthy.
+	L1FallbackNodes []L1FallbackNode
+
+	// L1HealthCheckInterval is the interval between health checks of the L1 endpoints,
+	// if there are fallback endpoints.
+	L1HealthCheckInterval time.Duration
+
+	// L1HealthCheckTimeout limits the duration of the health check requests to an L1 endpoint.
+	// It must be shorter than L1HealthCheckInterval, so a slow endpoint cannot delay the next health check.
+	L1HealthCheckTimeout time.Duration
+
+	// L1MaxHeadLag is the number of blocks the head of an L1 endpoint may differ from the median head
+	// of all endpoints, before it is considered unhealthy.
+	L1MaxHeadLag uint64
+}
+
+// L1FallbackNode is an L1 endpoint to fail over to.
+type L1FallbackNode struct {
+	Addr string
+	// RPCKind identifies the RPC provider kind of the endpoint.
+	// If empty, the L1RPCKind of the L1EndpointConfig is used.
+	RPCKind sources.RPCProviderKind
 }
 
 var _ L1EndpointSetup = (*L1EndpointConfig)(nil)
@@ -167,10 +199,27 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.RateLimit < 0 {
 		return fmt.Errorf("rate limit cannot be negative")
 	}
+	for i, fallback := range cfg.L1FallbackNodes {
+		if fallback.Addr == "" {
+			return fmt.Errorf("L1 fallback endpoint %d has no address", i)
+		}
+		if fallback.RPCKind != "" && !sources.ValidRPCProviderKind(fallback.RPCKind) {
+			return fmt.Errorf("L1 fallback endpoint %d has unknown rpc kind: %s", i, fallback.RPCKind)
+		}
+	}
+	if len(cfg.L1FallbackNodes) > 0 {
+		if cfg.L1HealthCheckInterval <= 0 {
+			return fmt.Errorf("invalid L1 health check interval: %s", cfg.L1HealthCheckInterval)
+		}
+		if cfg.L1HealthCheckTimeout <= 0 || cfg.L1HealthCheckTimeout >= cfg.L1HealthCheckInterval {
+			return fmt.Errorf("L1 health check timeout %s must be positive and shorter than the health check interval %s",
+				cfg.L1HealthCheckTimeout, cfg.L1HealthCheckInterval)
+		}
+	}
 	return nil
 }
 
-func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m sources.FailoverMetrics) (client.RPC, *sources.L1ClientConfig, error) {
 	opts := []client.RPCOption{
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
 		client.WithDialBackoff(10),
@@ -185,7 +234,39 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	}
 	rpcCfg := sources.L1ClientDefaultConfig(rollupCfg, cfg.L1TrustRPC, cfg.L1RPCKind)
 	rpcCfg.MaxRequestsPerBatch = cfg.BatchSize
-	return l1Node, rpcCfg, nil
+	if len(cfg.L1FallbackNodes) == 0 {
+		return l1Node, rpcCfg, nil
+	}
+
+	// The endpoints are named by index, since the addresses of RPC providers commonly contain API keys.
+	endpoints := []sources.FailoverEndpoint{{Name: "l1_0", RPC: l1Node, Kind: cfg.L1RPCKind}}
+	for i, fallback := range cfg.L1FallbackNodes {
+		kind := fallback.RPCKind
+		if kind == "" {
+			kind = cfg.L1RPCKind
+		}
+		fallbackNode, err := client.NewRPC(ctx, log, fallback.Addr, opts...)
+		if err != nil {
+			for _, e := range endpoints {
+				e.RPC.Close()
+			}
+			return nil, nil, fmt.Errorf("failed to dial L1 fallback endpoint %d: %w", i, err)
+		}
+		endpoints = append(endpoints, sources.FailoverEndpoint{Name: fmt.Sprintf("l1_%d", i+1), RPC: fallbackNode, Kind: kind})
+	}
+	failover, err := sources.NewFailoverRPC(log, m, &sources.FailoverConfig{
+		HealthCheckInterval: cfg.L1HealthCheckInterval,
+		HealthCheckTimeout:  cfg.L1HealthCheckTimeout,
+		MaxHeadLag:          cfg.L1MaxHeadLag,
+	}, endpoints...)
+	if err != nil {
+		for _, e := range endpoints {
+			e.RPC.Close()
+		}
+		return nil, nil, fmt.Errorf("failed to create L1 failover RPC: %w", err)
+	}
+	rpcCfg.RPCProviders = failover
+	return failover, rpcCfg, nil
 }
 
 // PreparedL1Endpoint enables testing with an in-process pre-setup RPC connection to L1
@@ -197,7 +278,7 @@ type PreparedL1Endpoint struct {
 
 var _ L1EndpointSetup = (*PreparedL1Endpoint)(nil)
 
-func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m sources.FailoverMetrics) (client.RPC, *sources.L1ClientConfig, error) {
 	return p.Client, sources.L1ClientDefaultConfig(rollupCfg, p.TrustRPC, p.RPCProviderKind), nil
 }
 
@@ -208,3 +289,20 @@ func (cfg *PreparedL1Endpoint) Check() error {
 
 	return nil
 }
+
+type L1BeaconEndpointConfig struct {
+	BeaconAddr string // Address of L1 beacon API endpoint to use
+}
+
+var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
+
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (*sources.L1BeaconClient, error) {
+	return sources.NewL1BeaconClient(&http.Client{Timeout: time.Second * 30}, cfg.BeaconAddr), nil
+}
+
+func (cfg *L1BeaconEndpointConfig) Check() error {
+	if cfg.BeaconAddr == "" {
+		return errors.New("expected beacon address, but got none")
+	}
+	return nil
+}
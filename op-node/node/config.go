@@ -9,8 +9,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -20,6 +22,9 @@ type Config struct {
 	L2     L2EndpointSetup
 	L2Sync L2SyncEndpointSetup
 
+	// Beacon is optional, but required to read batches from blobs after the Ecotone upgrade.
+	Beacon L1BeaconEndpointSetup
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -46,12 +51,64 @@ type Config struct {
 	Heartbeat HeartbeatConfig
 
 	Sync sync.Config
+
+	// Conductor configures the optional leader election among sequencer replicas.
+	Conductor ConductorConfig
+
+	// SafeDBPath is the path of the database that tracks the safe head per L1 block.
+	// The safe head database is disabled if empty.
+	SafeDBPath string
+
+	// PipelineCheckpoints persists derivation pipeline checkpoints, to restart derivation faster.
+	PipelineCheckpoints derive.CheckpointStore
+
+	// UnsafePayloads configures the persistence of queued unsafe payloads across restarts.
+	UnsafePayloads UnsafePayloadsConfig
+
+	// Screening determines how unsafe L2 blocks are screened against the sequencer commitments.
+	Screening commitments.Config
+}
+
+type UnsafePayloadsConfig struct {
+	// Path of the database that persists the queued unsafe payloads.
+	// The unsafe payloads are not persisted if empty.
+	Path string
+	// MaxSize is the maximum total size in bytes of the persisted payloads.
+	MaxSize uint64
+	// MaxAge is the maximum duration payloads are persisted for, after they were received.
+	MaxAge time.Duration
+}
+
+func (cfg *UnsafePayloadsConfig) Check() error {
+	if cfg.Path == "" {
+		return nil
+	}
+	if cfg.MaxSize == 0 {
+		return errors.New("max size of persisted unsafe payloads must be set")
+	}
+	if cfg.MaxAge <= 0 {
+		return errors.New("max age of persisted unsafe payloads must be positive")
+	}
+	return nil
 }
 
 type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// EnableDebug enables the debug API, to inspect the derivation pipeline
+	EnableDebug bool
+
+	// AdminAuthListenAddr and AdminAuthListenPort are the address of the authenticated admin API,
+	// which is served if AdminJWTSecret is set.
+	AdminAuthListenAddr string
+	AdminAuthListenPort int
+	AdminJWTSecret      [32]byte
+}
+
+// AdminAuthEnabled returns true if the authenticated admin API is served.
+func (cfg *RPCConfig) AdminAuthEnabled() bool {
+	return cfg.AdminJWTSecret != [32]byte{}
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -76,6 +133,61 @@ func (m MetricsConfig) Check() error {
 	return nil
 }
 
+// ConductorConfig configures the membership of the sequencer in a cluster of sequencer replicas,
+// of which only the elected leader sequences.
+type ConductorConfig struct {
+	Enabled bool
+	// ServerID identifies this sequencer replica in the cluster.
+	ServerID string
+	// Peers are the conductor RPC endpoints of the other replicas, by server ID.
+	Peers map[string]string
+
+	// ListenAddr and ListenPort are where the conductor RPC is served to the other replicas,
+	// separately from the public rollup-node RPC.
+	ListenAddr string
+	ListenPort int
+	// JWTSecret is shared by the replicas of the cluster, to authenticate conductor RPC requests.
+	JWTSecret [32]byte
+	// StateFile persists the election state of the replica across restarts.
+	StateFile string
+
+	HeartbeatInterval time.Duration
+	ElectionTimeout   time.Duration
+	RequestTimeout    time.Duration
+
+	// MaxUnsafeLag is the maximum time the unsafe L2 head may lag behind the wall-clock,
+	// before the replica is considered unhealthy, and steps down as leader. Disabled if 0.
+	MaxUnsafeLag time.Duration
+}
+
+func (c *ConductorConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ServerID == "" {
+		return errors.New("missing conductor server ID")
+	}
+	if _, ok := c.Peers[c.ServerID]; ok {
+		return fmt.Errorf("conductor server %q cannot be its own peer", c.ServerID)
+	}
+	if c.JWTSecret == ([32]byte{}) {
+		return errors.New("missing conductor JWT secret")
+	}
+	if c.StateFile == "" {
+		return errors.New("missing conductor state file")
+	}
+	if c.HeartbeatInterval <= 0 {
+		return errors.New("conductor heartbeat interval must be positive")
+	}
+	if c.ElectionTimeout <= c.HeartbeatInterval {
+		return fmt.Errorf("conductor election timeout %s must be larger than the heartbeat interval %s", c.ElectionTimeout, c.HeartbeatInterval)
+	}
+	if c.RequestTimeout <= 0 {
+		return errors.New("conductor request timeout must be positive")
+	}
+	return nil
+}
+
 type HeartbeatConfig struct {
 	Enabled bool
 	Moniker string
@@ -111,12 +223,40 @@ func (cfg *Config) Check() error {
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if cfg.Beacon != nil {
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("beacon endpoint config error: %w", err)
+		}
+	} else if cfg.Rollup.ActivationTime(rollup.Ecotone) != nil {
+		return errors.New("the Ecotone upgrade is scheduled, but no L1 beacon API endpoint is configured to read blobs from")
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
 	if err := cfg.Pprof.Check(); err != nil {
 		return fmt.Errorf("pprof config error: %w", err)
 	}
+	if err := cfg.Screening.Check(); err != nil {
+		return fmt.Errorf("screening config error: %w", err)
+	}
+	if err := cfg.UnsafePayloads.Check(); err != nil {
+		return fmt.Errorf("unsafe payloads config error: %w", err)
+	}
+	if err := cfg.Conductor.Check(); err != nil {
+		return fmt.Errorf("conductor config error: %w", err)
+	}
+	if err := cfg.Driver.Check(); err != nil {
+		return fmt.Errorf("driver config error: %w", err)
+	}
+	if err := cfg.Sync.Check(); err != nil {
+		return fmt.Errorf("sync config error: %w", err)
+	}
+	if cfg.Conductor.Enabled && !cfg.Driver.SequencerEnabled {
+		return errors.New("the sequencer conductor requires the sequencer to be enabled")
+	}
+	if cfg.Conductor.Enabled && cfg.P2PSigner == nil {
+		return errors.New("the sequencer conductor requires a p2p signer, to sign the replicated payloads")
+	}
 	if cfg.P2P != nil {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
@@ -4,20 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/payloaddb"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -25,20 +36,39 @@ type OpNode struct {
 	log        log.Logger
 	appVersion string
 	metrics    *metrics.Metrics
+	rollupCfg  *rollup.Config
 
 	l1HeadsSub     ethereum.Subscription // Subscription to get L1 heads (automatically re-subscribes on error)
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source        *sources.L1Client       // L1 Client to fetch data from
+	beacon          *sources.L1BeaconClient // L1 beacon API client to fetch blobs from, optional (may be nil)
+	l2Driver        *driver.Driver          // L2 Engine to Sync
+	l2Source        *sources.EngineClient   // L2 Execution Engine RPC bindings
+	rpcSync         *sources.SyncClient     // Alt-sync RPC client, optional (may be nil)
+	server          *rpcServer              // RPC server hosting the rollup-node API
+	conductorServer *rpcServer              // RPC server hosting the conductor API for the other sequencer replicas, optional (may be nil)
+	adminAuthServer *rpcServer              // RPC server hosting the authenticated admin API, optional (may be nil)
+	p2pNode         *p2p.NodeP2P            // P2P node functionality
+	p2pSigner       p2p.Signer              // p2p gogssip application messages will be signed with this signer
+	tracer          Tracer                  // tracer to get events for testing/debugging
+	runCfg          *RuntimeConfig          // runtime configurables
+	runCfgReload    chan eth.L1BlockRef     // latest L1 head to reload the runtime config at
+
+	screeningCache *screeningCache            // cached commitment screening outcomes
+	screening      *screeningService          // asynchronous screening of unsafe payloads, before passing them on to the L2 driver
+	screeningStat  *screeningStatus           // outcome of the latest screenings, reported in the sync status
+	stateManifest  *commitments.StateManifest // optional L2 state that screened payloads carry proofs of
+
+	safeDB closableSafeDB // optional record of the safe head per L1 block
+
+	payloadDB closablePayloadDB // optional persistence of queued unsafe payloads across restarts
+
+	sequencerConductor conductor.SequencerConductor // determines if this node may sequence
+	cluster            *conductor.ClusterConductor  // leader election among sequencer replicas, optional (may be nil)
+
+	snapshotLog log.Logger // rollup state snapshots, for visualization with stateviz
 
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
@@ -46,6 +76,20 @@ type OpNode struct {
 	resourcesClose context.CancelFunc
 }
 
+// closableSafeDB is the safe head database, it records the safe head per L1 block and serves it over RPC.
+type closableSafeDB interface {
+	derive.SafeHeadListener
+	safeDBReader
+	io.Closer
+}
+
+// closablePayloadDB persists the screened unsafe payloads, to replay them into the L2 driver after a restart.
+type closablePayloadDB interface {
+	Add(payload *eth.ExecutionPayload) error
+	LoadPayloads(safeHead uint64) ([]*eth.ExecutionPayload, error)
+	io.Closer
+}
+
 // The OpNode handles incoming gossip
 var _ p2p.GossipIn = (*OpNode)(nil)
 
@@ -55,9 +99,14 @@ func New(ctx context.Context, cfg *Config, log log.Logger, snapshotLog log.Logge
 	}
 
 	n := &OpNode{
-		log:        log,
-		appVersion: appVersion,
-		metrics:    m,
+		log:           log,
+		appVersion:    appVersion,
+		metrics:       m,
+		rollupCfg:     &cfg.Rollup,
+		runCfgReload:  make(chan eth.L1BlockRef, 1),
+		screeningStat: newScreeningStatus(cfg.Screening.Mode),
+		stateManifest: cfg.Screening.StateManifest,
+		snapshotLog:   snapshotLog,
 	}
 	// not a context leak, gossipsub is closed with a context.
 	n.resourcesCtx, n.resourcesClose = context.WithCancel(context.Background())
@@ -81,15 +130,22 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
+	if err := n.initL1BeaconAPI(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init L1 beacon API: %w", err)
+	}
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
-	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
-		return fmt.Errorf("failed to init L2: %w", err)
+	if err := n.initConductor(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init the sequencer conductor: %w", err)
 	}
+	// The RPC sync client is initialized before L2, since it is the source of the snap sync target.
 	if err := n.initRPCSync(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init RPC sync: %w", err)
 	}
+	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
+		return fmt.Errorf("failed to init L2: %w", err)
+	}
 	if err := n.initP2PSigner(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P signer: %w", err)
 	}
@@ -116,7 +172,7 @@ func (n *OpNode) initTracer(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
-	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup)
+	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup, n.metrics)
 	if err != nil {
 		return fmt.Errorf("failed to get L1 RPC client: %w", err)
 	}
@@ -131,6 +187,9 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("failed to validate the L1 config: %w", err)
 	}
 
+	// The screening cache tracks the L1 heads, so it must exist before subscribing to them
+	n.screeningCache = newScreeningCache(n.metrics.ScreeningCache, screeningCacheSize)
+
 	// Keep subscribed to the L1 heads, which keeps the L1 maintainer pointing to the best headers to sync
 	n.l1HeadsSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
 		if err != nil {
@@ -155,6 +214,27 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
+	if cfg.Beacon == nil {
+		n.log.Info("no L1 beacon API endpoint configured, blobs cannot be read")
+		return nil
+	}
+	beacon, err := cfg.Beacon.Setup(ctx, n.log)
+	if err != nil {
+		return fmt.Errorf("failed to setup L1 beacon API client: %w", err)
+	}
+	n.beacon = beacon
+
+	// Verify the beacon API is reachable, but tolerate it being down at startup:
+	// the blobs are only fetched once the Ecotone upgrade is active.
+	fetchCtx, fetchCancel := context.WithTimeout(ctx, time.Second*10)
+	defer fetchCancel()
+	if _, err := n.beacon.GetTimeToSlotFn(fetchCtx); err != nil {
+		n.log.Warn("failed to reach L1 beacon API, will retry when blobs are needed", "err", err)
+	}
+	return nil
+}
+
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
 	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
@@ -176,6 +256,7 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 			continue
 		}
 
+		go n.reloadRuntimeConfig(n.resourcesCtx)
 		return nil
 	}
 
@@ -199,7 +280,47 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	if cfg.SafeDBPath != "" {
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath)
+		safeDB, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
+		}
+		n.safeDB = safeDB
+	} else {
+		n.safeDB = safedb.Disabled
+	}
+
+	if cfg.UnsafePayloads.Path != "" {
+		n.log.Info("Unsafe payload persistence enabled", "path", cfg.UnsafePayloads.Path)
+		payloadDB, err := payloaddb.NewPayloadDB(n.log, cfg.UnsafePayloads.Path, cfg.UnsafePayloads.MaxSize, cfg.UnsafePayloads.MaxAge)
+		if err != nil {
+			return fmt.Errorf("failed to create unsafe payload database at %v: %w", cfg.UnsafePayloads.Path, err)
+		}
+		n.payloadDB = payloadDB
+	} else {
+		n.payloadDB = payloaddb.Disabled
+	}
+
+	var l1Blobs derive.L1BlobsFetcher
+	if n.beacon != nil {
+		l1Blobs = n.beacon
+	}
+	var snapSync driver.SnapSync
+	if cfg.Sync.SnapSync {
+		if n.rpcSync == nil {
+			return errors.New("snap sync requires a backup L2 sync RPC to retrieve the sync target from")
+		}
+		outputs, err := sync.NewL2OutputOracle(cfg.Sync.L2OutputOracleAddr, n.l1Source.EthClient)
+		if err != nil {
+			return fmt.Errorf("failed to bind to L2OutputOracle %s: %w", cfg.Sync.L2OutputOracleAddr, err)
+		}
+		n.log.Info("Snap sync enabled", "l2_output_oracle", cfg.Sync.L2OutputOracleAddr)
+		snapSync = sync.NewSnapSync(n.log, n.l1Source, outputs, n.rpcSync, n.l2Source)
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, l1Blobs, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync, n.safeDB, cfg.PipelineCheckpoints, n.sequencerConductor, snapSync)
+	n.screening = newScreeningService(n.log, n.metrics, n.screenPayload, n.deliverUnsafeL2Payload,
+		screeningWorkers, screeningQueueSize, screeningTimeout, screeningRetryDelay)
 
 	return nil
 }
@@ -220,8 +341,89 @@ func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initConductor(ctx context.Context, cfg *Config) error {
+	if !cfg.Conductor.Enabled {
+		n.sequencerConductor = conductor.NoOpConductor{}
+		return nil
+	}
+	peers := make(map[string]conductor.Peer, len(cfg.Conductor.Peers))
+	for id, addr := range cfg.Conductor.Peers {
+		auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.Conductor.JWTSecret))
+		peerRPC, err := client.NewRPC(ctx, n.log, addr, client.WithGethRPCOptions(auth), client.WithDialBackoff(10))
+		if err != nil {
+			return fmt.Errorf("failed to dial sequencer replica %q at %s: %w", id, addr, err)
+		}
+		peers[id] = sources.NewConductorClient(peerRPC)
+	}
+	cluster, err := conductor.NewClusterConductor(n.log.New("conductor", cfg.Conductor.ServerID), conductor.Config{
+		ServerID:          cfg.Conductor.ServerID,
+		Peers:             peers,
+		HeartbeatInterval: cfg.Conductor.HeartbeatInterval,
+		ElectionTimeout:   cfg.Conductor.ElectionTimeout,
+		RequestTimeout:    cfg.Conductor.RequestTimeout,
+		Store:             conductor.NewFileStateStore(cfg.Conductor.StateFile),
+		HealthCheck: func(ctx context.Context) error {
+			return n.sequencerHealthCheck(ctx, cfg.Conductor.MaxUnsafeLag)
+		},
+		SignPayload: func(ctx context.Context, payload *eth.ExecutionPayload) ([]byte, error) {
+			if n.p2pSigner == nil {
+				return nil, fmt.Errorf("node has no p2p signer, payload %s cannot be replicated", payload.ID())
+			}
+			sig, err := p2p.SignBlockPayload(ctx, &cfg.Rollup, n.p2pSigner, payload)
+			if err != nil {
+				return nil, err
+			}
+			return sig[:], nil
+		},
+		OnPayload: func(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) error {
+			return n.onReplicatedPayload(&cfg.Rollup, payload, signature)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	n.cluster = cluster
+	n.sequencerConductor = cluster
+	n.log.Info("Sequencer conductor enabled", "server_id", cfg.Conductor.ServerID, "peers", len(peers))
+	return nil
+}
+
+// onReplicatedPayload processes an unsafe payload replicated by the leader of the sequencer cluster.
+// Replicated payloads are checked like gossiped payloads: the payload must be signed by the sequencer,
+// and is screened before it is passed on to the L2 driver.
+func (n *OpNode) onReplicatedPayload(rollupCfg *rollup.Config, payload *eth.ExecutionPayload, signature []byte) error {
+	if err := p2p.VerifyBlockPayloadSignature(rollupCfg, n.runCfg.P2PSequencerAddress(), payload, signature); err != nil {
+		return fmt.Errorf("replicated payload %s has an invalid signature: %w", payload.ID(), err)
+	}
+	n.log.Info("Received signed execution payload from sequencer leader", "id", payload.ID())
+
+	// Validate commitments asynchronously, the payload is passed on to the L2 Engine once screened
+	if err := n.screening.Submit(payload); err != nil {
+		n.log.Warn("failed to queue replicated L2 payload for commitment screening", "err", err, "id", payload.ID())
+		return err
+	}
+	return nil
+}
+
+// sequencerHealthCheck returns an error if the sequencer is not fit to lead the sequencer cluster.
+func (n *OpNode) sequencerHealthCheck(ctx context.Context, maxUnsafeLag time.Duration) error {
+	status, err := n.l2Driver.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+	if status.HeadL1 == (eth.L1BlockRef{}) {
+		return errors.New("no L1 head")
+	}
+	if maxUnsafeLag > 0 {
+		if lag := time.Since(time.Unix(int64(status.UnsafeL2.Time), 0)); lag > maxUnsafeLag {
+			return fmt.Errorf("unsafe head %s lags %s behind, more than %s", status.UnsafeL2, lag, maxUnsafeLag)
+		}
+	}
+	return nil
+}
+
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.screeningStat, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
@@ -232,11 +434,31 @@ func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics))
 		n.log.Info("Admin RPC enabled")
 	}
+	if cfg.RPC.EnableDebug {
+		server.EnableDebugAPI(NewDebugAPI(n.l2Driver, n.metrics))
+		n.log.Info("Debug RPC enabled")
+	}
 	n.log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
 		return fmt.Errorf("unable to start RPC server: %w", err)
 	}
 	n.server = server
+	if n.cluster != nil {
+		conductorServer := newConductorRPCServer(&cfg.Conductor, conductor.NewAPI(n.cluster), n.log.New("rpc", "conductor"), n.appVersion)
+		if err := conductorServer.Start(); err != nil {
+			return fmt.Errorf("unable to start conductor RPC server: %w", err)
+		}
+		n.conductorServer = conductorServer
+		n.log.Info("Started conductor RPC server", "addr", conductorServer.Addr())
+	}
+	if cfg.RPC.AdminAuthEnabled() {
+		adminAuthServer := newAuthAdminRPCServer(&cfg.RPC, NewAuthAdminAPI(n, n.metrics), n.log.New("rpc", "admin-auth"), n.appVersion)
+		if err := adminAuthServer.Start(); err != nil {
+			return fmt.Errorf("unable to start authenticated admin RPC server: %w", err)
+		}
+		n.adminAuthServer = adminAuthServer
+		n.log.Info("Started authenticated admin RPC server", "addr", adminAuthServer.Addr())
+	}
 	return nil
 }
 
@@ -288,6 +510,9 @@ func (n *OpNode) Start(ctx context.Context) error {
 		return err
 	}
 
+	// replay the unsafe payloads that were queued before the restart, now that the driver can process them
+	n.replayUnsafePayloads(ctx)
+
 	// If the backup unsafe sync client is enabled, start its event loop
 	if n.rpcSync != nil {
 		if err := n.rpcSync.Start(); err != nil {
@@ -297,12 +522,31 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Info("Started L2-RPC sync service")
 	}
 
+	// only participate in leader elections once the driver runs, and is able to sequence
+	if n.cluster != nil {
+		n.cluster.Start()
+		n.log.Info("Started sequencer conductor")
+	}
+
 	return nil
 }
 
 func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	n.tracer.OnNewL1Head(ctx, sig)
 
+	if n.screeningCache.OnNewL1Head(sig) {
+		n.log.Info("L1 reorg detected, invalidated cached commitment screenings", "l1_head", sig)
+	}
+
+	// Keep the runtime config up to date, to track unsafe block signer changes.
+	// It is reloaded in the background, so the driver learns of the L1 head without delay.
+	// Only the latest L1 head is retained, if the previous reload is still in progress.
+	select {
+	case <-n.runCfgReload:
+	default:
+	}
+	n.runCfgReload <- sig
+
 	if n.l2Driver == nil {
 		return
 	}
@@ -314,6 +558,22 @@ func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	}
 }
 
+// reloadRuntimeConfig reloads the runtime config at every new L1 head, until the context is canceled.
+func (n *OpNode) reloadRuntimeConfig(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case l1Head := <-n.runCfgReload:
+			loadCtx, loadCancel := context.WithTimeout(ctx, time.Second*10)
+			if err := n.runCfg.Load(loadCtx, l1Head); err != nil {
+				n.log.Warn("failed to reload runtime config", "l1_head", l1Head, "err", err)
+			}
+			loadCancel()
+		}
+	}
+}
+
 func (n *OpNode) OnNewL1Safe(ctx context.Context, sig eth.L1BlockRef) {
 	if n.l2Driver == nil {
 		return
@@ -343,7 +603,7 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPay
 
 	// Validate commitments
 	n.log.Info("🤖 Validating sequencer's commitments for L2 block", "id", payload.ID())
-	if err := n.validateCommitments(ctx, payload); err != nil {
+	if err := n.screenPayload(ctx, payload); err != nil {
 		n.log.Error("⛔️ Failed to validate commitments", "err", err)
 		return err
 	}
@@ -370,21 +630,134 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *e
 
 	n.log.Info("Received signed execution payload from p2p", "id", payload.ID(), "peer", from)
 
-	// Validate commitments
+	// Validate commitments asynchronously, the payload is passed on to the L2 Engine once screened
 	n.log.Info("🤖 Validating sequencer's commitments for L2 block", "id", payload.ID())
-	if err := n.validateCommitments(ctx, payload); err != nil {
-		n.log.Error("⛔️ Failed to validate commitments", "err", err)
+	if err := n.screening.Submit(payload); err != nil {
+		n.log.Warn("failed to queue L2 payload for commitment screening", "err", err, "id", payload.ID())
 		return err
 	}
 
-	// Pass on the event to the L2 Engine
+	return nil
+}
+
+const (
+	// postedPayloadTimeout bounds the wait for a payload posted through the admin RPC to be processed.
+	postedPayloadTimeout = 10 * time.Second
+	// postedPayloadPollInterval is the interval at which the unsafe head is checked for the posted payload.
+	postedPayloadPollInterval = 100 * time.Millisecond
+)
+
+// PostUnsafePayload injects an unsafe payload through the admin RPC. The payload and the signature of the
+// sequencer are checked like gossip, except for the payload age, since missed blocks may be older than gossip accepts. The payload is then screened synchronously, so the caller learns of screening failures,
+// and passed on to the L2 driver. The unsafe head is returned once the payload is part of the unsafe chain.
+// An error is returned if the payload is not part of the unsafe chain in time, e.g. if its parent is missing,
+// or if the unsafe chain has another block at the height of the payload.
+func (n *OpNode) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) (eth.L2BlockRef, error) {
+	n.log.Info("Received execution payload from admin RPC", "id", payload.ID())
+	if err := checkPostedPayload(n.rollupCfg, n.runCfg.P2PSequencerAddress(), payload, signature, time.Now()); err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("invalid payload %s: %w", payload.ID(), err)
+	}
+
+	screenCtx, cancel := context.WithTimeout(ctx, screeningTimeout)
+	err := n.screenPayload(screenCtx, payload)
+	cancel()
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("payload %s failed commitment screening: %w", payload.ID(), err)
+	}
+
+	if err := n.l2Driver.OnUnsafeL2Payload(ctx, payload); err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to queue payload %s: %w", payload.ID(), err)
+	}
+	n.persistUnsafePayload(payload)
+
+	ctx, cancel = context.WithTimeout(ctx, postedPayloadTimeout)
+	defer cancel()
+	ticker := time.NewTicker(postedPayloadPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := n.l2Driver.SyncStatus(ctx)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to get unsafe head: %w", err)
+		}
+		if status.UnsafeL2.Number >= uint64(payload.BlockNumber) {
+			// the unsafe chain may have been extended past the payload already
+			block := status.UnsafeL2
+			if block.Number > uint64(payload.BlockNumber) {
+				if block, err = n.l2Source.L2BlockRefByNumber(ctx, uint64(payload.BlockNumber)); err != nil {
+					return eth.L2BlockRef{}, fmt.Errorf("failed to get unsafe block at height of payload %s: %w", payload.ID(), err)
+				}
+			}
+			if block.Hash != payload.BlockHash {
+				return eth.L2BlockRef{}, fmt.Errorf("unsafe chain has block %s instead of payload %s", block, payload.ID())
+			}
+			return status.UnsafeL2, nil
+		}
+		select {
+		case <-ctx.Done():
+			return eth.L2BlockRef{}, fmt.Errorf("payload %s was not processed in time, unsafe head is %s: %w", payload.ID(), status.UnsafeL2, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkPostedPayload runs the gossip validation rules that apply to payloads posted through the admin RPC.
+func checkPostedPayload(rollupCfg *rollup.Config, sequencer common.Address, payload *eth.ExecutionPayload, signature []byte, now time.Time) error {
+	if uint64(payload.Timestamp) > uint64(now.Unix())+5 {
+		return fmt.Errorf("timestamp %d is too far in the future", uint64(payload.Timestamp))
+	}
+	if actual, ok := payload.CheckBlockHash(); !ok {
+		return fmt.Errorf("bad block hash %s, expected %s", payload.BlockHash, actual)
+	}
+	if err := p2p.VerifyBlockPayloadSignature(rollupCfg, sequencer, payload, signature); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	return nil
+}
+
+// deliverUnsafeL2Payload passes on a screened unsafe payload to the L2 Engine
+func (n *OpNode) deliverUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	if err := n.l2Driver.OnUnsafeL2Payload(ctx, payload); err != nil {
 		n.log.Warn("failed to notify engine driver of new L2 payload", "err", err, "id", payload.ID())
+		return
 	}
+	n.persistUnsafePayload(payload)
+}
 
-	return nil
+// persistUnsafePayload persists a screened unsafe payload, such that it can be replayed after a restart.
+// Failing to persist the payload does not affect processing it, and is only logged.
+func (n *OpNode) persistUnsafePayload(payload *eth.ExecutionPayload) {
+	if err := n.payloadDB.Add(payload); err != nil {
+		n.log.Warn("failed to persist unsafe payload", "err", err, "id", payload.ID())
+	}
+}
+
+// replayUnsafePayloads passes the persisted unsafe payloads after the safe head of the engine on to the L2 driver.
+// The payloads were screened before they were persisted. Failing to replay them is only logged,
+// the missing payloads are synced again like any other gap in the unsafe chain.
+func (n *OpNode) replayUnsafePayloads(ctx context.Context) {
+	safeHead, err := n.l2Source.L2BlockRefByLabel(ctx, eth.Safe)
+	if err != nil {
+		n.log.Warn("failed to get safe head to replay unsafe payloads from", "err", err)
+		return
+	}
+	payloads, err := n.payloadDB.LoadPayloads(safeHead.Number)
+	if err != nil {
+		n.log.Warn("failed to load persisted unsafe payloads", "err", err)
+		return
+	}
+	if len(payloads) == 0 {
+		return
+	}
+	n.log.Info("Replaying persisted unsafe payloads", "count", len(payloads), "safe_head", safeHead.ID(),
+		"first", payloads[0].ID(), "last", payloads[len(payloads)-1].ID())
+	for _, payload := range payloads {
+		if err := n.l2Driver.OnUnsafeL2Payload(ctx, payload); err != nil {
+			n.log.Warn("failed to replay unsafe payload", "err", err, "id", payload.ID())
+			return
+		}
+	}
 }
 
 func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
@@ -418,6 +791,12 @@ func (n *OpNode) Close() error {
 	if n.server != nil {
 		n.server.Stop()
 	}
+	if n.conductorServer != nil {
+		n.conductorServer.Stop()
+	}
+	if n.adminAuthServer != nil {
+		n.adminAuthServer.Stop()
+	}
 	if n.p2pNode != nil {
 		if err := n.p2pNode.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p node: %w", err))
@@ -438,6 +817,18 @@ func (n *OpNode) Close() error {
 		n.l1HeadsSub.Unsubscribe()
 	}
 
+	// stop the conductor before the L2 driver: leadership is given up, and no more payloads are replicated to the driver
+	if n.cluster != nil {
+		if err := n.cluster.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close sequencer conductor: %w", err))
+		}
+	}
+
+	// stop screening before the L2 driver, no more payloads are handed off after this
+	if n.screening != nil {
+		n.screening.Close()
+	}
+
 	// close L2 driver
 	if n.l2Driver != nil {
 		if err := n.l2Driver.Close(); err != nil {
@@ -452,6 +843,19 @@ func (n *OpNode) Close() error {
 		}
 	}
 
+	// close the safe head database after the L2 driver, it is not updated anymore
+	if n.safeDB != nil {
+		if err := n.safeDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
+		}
+	}
+
+	if n.payloadDB != nil {
+		if err := n.payloadDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close unsafe payload db: %w", err))
+		}
+	}
+
 	// close L2 engine RPC client
 	if n.l2Source != nil {
 		n.l2Source.Close()
@@ -1,13 +1,53 @@
 package node
 
 import (
+	"context"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestUnixTimeStale(t *testing.T) {
 	require.True(t, unixTimeStale(1_600_000_000, 1*time.Hour))
 	require.False(t, unixTimeStale(uint64(time.Now().Unix()), 1*time.Hour))
 }
+
+func TestCheckPostedPayload(t *testing.T) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sequencer := crypto.PubkeyToAddress(key.PublicKey)
+	signer := p2p.NewLocalSigner(key)
+	sign := func(payload *eth.ExecutionPayload) []byte {
+		sig, err := p2p.SignBlockPayload(context.Background(), cfg, signer, payload)
+		require.NoError(t, err)
+		return sig[:]
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	payload := &eth.ExecutionPayload{
+		ParentHash:  common.Hash{1},
+		BlockNumber: 100,
+		GasLimit:    30_000_000,
+		Timestamp:   eth.Uint64Quantity(now.Unix() - 3600),
+	}
+	actual, _ := payload.CheckBlockHash()
+	payload.BlockHash = actual
+	require.NoError(t, checkPostedPayload(cfg, sequencer, payload, sign(payload), now), "old payloads are accepted, unlike gossip")
+
+	require.ErrorContains(t, checkPostedPayload(cfg, common.Address{0xaa}, payload, sign(payload), now), "invalid signature")
+
+	payload.Timestamp = eth.Uint64Quantity(now.Unix() + 6)
+	require.ErrorContains(t, checkPostedPayload(cfg, sequencer, payload, sign(payload), now), "future")
+
+	payload.Timestamp = eth.Uint64Quantity(now.Unix())
+	require.ErrorContains(t, checkPostedPayload(cfg, sequencer, payload, sign(payload), now), "bad block hash")
+}
@@ -0,0 +1,26 @@
+package payloaddb
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DisabledDB is the unsafe payload database of nodes that do not persist unsafe payloads.
+type DisabledDB struct{}
+
+var Disabled = &DisabledDB{}
+
+func (d *DisabledDB) Enabled() bool {
+	return false
+}
+
+func (d *DisabledDB) Add(_ *eth.ExecutionPayload) error {
+	return nil
+}
+
+func (d *DisabledDB) LoadPayloads(_ uint64) ([]*eth.ExecutionPayload, error) {
+	return nil, nil
+}
+
+func (d *DisabledDB) Close() error {
+	return nil
+}
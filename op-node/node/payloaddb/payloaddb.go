@@ -0,0 +1,265 @@
+// Package payloaddb persists the unsafe payloads that are queued for processing by the rollup node,
+// such that they can be replayed after a restart, instead of fetching every gap block again.
+package payloaddb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrInvalidEntry  = errors.New("invalid db entry")
+	ErrAlreadyClosed = errors.New("unsafe payload database already closed")
+)
+
+const (
+	// keyPrefixPayload is the prefix of the payload entries, keyed by block number and hash
+	keyPrefixPayload byte = 0
+	// keyLength is the length of the payload keys: prefix, block number and block hash
+	keyLength = 1 + 8 + 32
+	// valuePrefixLength is the length of the receive time that prefixes the encoded payload
+	valuePrefixLength = 8
+)
+
+// payloadKey returns the key of the payload with the given block number and hash.
+// The number is encoded big-endian so the keys sort by block number.
+func payloadKey(id eth.BlockID) []byte {
+	key := make([]byte, 0, keyLength)
+	key = append(key, keyPrefixPayload)
+	key = binary.BigEndian.AppendUint64(key, id.Number)
+	return append(key, id.Hash[:]...)
+}
+
+func decodePayloadKey(key []byte) (eth.BlockID, error) {
+	if len(key) != keyLength || key[0] != keyPrefixPayload {
+		return eth.BlockID{}, ErrInvalidEntry
+	}
+	return eth.BlockID{Number: binary.BigEndian.Uint64(key[1:9]), Hash: common.BytesToHash(key[9:])}, nil
+}
+
+// payloadValue encodes the time the payload was received, followed by the SSZ encoding of the payload.
+func payloadValue(payload *eth.ExecutionPayload, received time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(valuePrefixLength + int(payload.SizeSSZ()))
+	_ = binary.Write(&buf, binary.BigEndian, uint64(received.Unix()))
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return nil, fmt.Errorf("failed to encode payload %s: %w", payload.ID(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodePayloadValue(val []byte) (*eth.ExecutionPayload, error) {
+	if len(val) < valuePrefixLength {
+		return nil, ErrInvalidEntry
+	}
+	var payload eth.ExecutionPayload
+	if err := payload.UnmarshalSSZ(uint32(len(val)-valuePrefixLength), bytes.NewReader(val[valuePrefixLength:])); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEntry, err)
+	}
+	return &payload, nil
+}
+
+// entry tracks a persisted payload, to enforce the size and age bounds without reading the payloads.
+type entry struct {
+	id       eth.BlockID
+	size     uint64
+	received time.Time
+}
+
+// PayloadDB is a persistent database of unsafe payloads, bounded by the total size and the age of the payloads.
+// When the size bound is exceeded, the payloads with the lowest block numbers are removed first,
+// like the in-memory queue of unsafe payloads does.
+type PayloadDB struct {
+	m       sync.Mutex
+	log     log.Logger
+	db      *pebble.DB
+	maxSize uint64
+	maxAge  time.Duration
+
+	// entries of the persisted payloads, sorted by key
+	entries []entry
+	size    uint64
+
+	closed bool
+}
+
+// NewPayloadDB opens, or creates, the unsafe payload database at the given path.
+func NewPayloadDB(logger log.Logger, path string, maxSize uint64, maxAge time.Duration) (*PayloadDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open unsafe payload db at %s: %w", path, err)
+	}
+	d := &PayloadDB{
+		log:     logger,
+		db:      db,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+	}
+	if err := d.loadEntries(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *PayloadDB) loadEntries() error {
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: payloadKey(eth.BlockID{Number: 0}),
+		UpperBound: payloadKey(eth.BlockID{Number: math.MaxUint64}),
+	})
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		id, err := decodePayloadKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read unsafe payload entry: %w", err)
+		}
+		if len(val) < valuePrefixLength {
+			return ErrInvalidEntry
+		}
+		e := entry{
+			id:       id,
+			size:     uint64(len(val)),
+			received: time.Unix(int64(binary.BigEndian.Uint64(val[:valuePrefixLength])), 0),
+		}
+		d.entries = append(d.entries, e)
+		d.size += e.size
+	}
+	return iter.Error()
+}
+
+func (d *PayloadDB) Enabled() bool {
+	return true
+}
+
+// Add persists the unsafe payload, and removes the payloads that exceed the size or age bound.
+func (d *PayloadDB) Add(payload *eth.ExecutionPayload) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrAlreadyClosed
+	}
+	id := payload.ID()
+	key := payloadKey(id)
+	i := sort.Search(len(d.entries), func(i int) bool {
+		return bytes.Compare(payloadKey(d.entries[i].id), key) >= 0
+	})
+	if i < len(d.entries) && d.entries[i].id == id {
+		return nil // already persisted
+	}
+	now := time.Now()
+	val, err := payloadValue(payload, now)
+	if err != nil {
+		return err
+	}
+	if uint64(len(val)) > d.maxSize {
+		return fmt.Errorf("cannot persist payload %s, size %d is larger than max db size %d", id, len(val), d.maxSize)
+	}
+	if err := d.db.Set(key, val, pebble.Sync); err != nil {
+		return fmt.Errorf("failed to persist unsafe payload %s: %w", id, err)
+	}
+	d.entries = append(d.entries, entry{})
+	copy(d.entries[i+1:], d.entries[i:])
+	d.entries[i] = entry{id: id, size: uint64(len(val)), received: now}
+	d.size += uint64(len(val))
+	return d.prune(0, now)
+}
+
+// LoadPayloads returns the persisted payloads after the given safe head number, ordered by block number.
+// Payloads at or before the safe head, and payloads that exceed the age bound, are removed.
+func (d *PayloadDB) LoadPayloads(safeHead uint64) ([]*eth.ExecutionPayload, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return nil, ErrAlreadyClosed
+	}
+	if err := d.prune(safeHead, time.Now()); err != nil {
+		return nil, err
+	}
+	payloads := make([]*eth.ExecutionPayload, 0, len(d.entries))
+	for _, e := range d.entries {
+		val, closer, err := d.db.Get(payloadKey(e.id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read unsafe payload %s: %w", e.id, err)
+		}
+		payload, err := decodePayloadValue(val)
+		_ = closer.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode unsafe payload %s: %w", e.id, err)
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+// prune removes the payloads at or before the given safe head number, the payloads that were received
+// longer than the max age ago, and the payloads with the lowest block numbers that exceed the max size.
+func (d *PayloadDB) prune(safeHead uint64, now time.Time) error {
+	size := d.size
+	removed := 0
+	remove := make([]bool, len(d.entries))
+	for i, e := range d.entries {
+		if e.id.Number <= safeHead || now.Sub(e.received) > d.maxAge {
+			remove[i] = true
+			removed += 1
+			size -= e.size
+		}
+	}
+	for i, e := range d.entries {
+		if size <= d.maxSize {
+			break
+		}
+		if !remove[i] {
+			remove[i] = true
+			removed += 1
+			size -= e.size
+		}
+	}
+	if removed == 0 {
+		return nil
+	}
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	kept := d.entries[:0]
+	for i, e := range d.entries {
+		if remove[i] {
+			if err := batch.Delete(payloadKey(e.id), nil); err != nil {
+				return fmt.Errorf("failed to remove unsafe payload %s: %w", e.id, err)
+			}
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("failed to prune unsafe payload db: %w", err)
+	}
+	d.log.Debug("Pruned unsafe payload db", "removed", removed, "remaining", len(kept), "size", size)
+	d.entries = kept
+	d.size = size
+	return nil
+}
+
+func (d *PayloadDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
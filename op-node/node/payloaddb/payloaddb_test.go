@@ -0,0 +1,112 @@
+package payloaddb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func payload(num uint64) *eth.ExecutionPayload {
+	return &eth.ExecutionPayload{
+		ParentHash:   common.Hash{0x02, byte(num - 1)},
+		BlockNumber:  eth.Uint64Quantity(num),
+		BlockHash:    common.Hash{0x02, byte(num)},
+		Timestamp:    eth.Uint64Quantity(1000 + 2*num),
+		ExtraData:    eth.BytesMax32{},
+		Transactions: []eth.Data{{0x01, byte(num)}},
+	}
+}
+
+func blockNumbers(payloads []*eth.ExecutionPayload) []uint64 {
+	out := make([]uint64, 0, len(payloads))
+	for _, p := range payloads {
+		out = append(out, uint64(p.BlockNumber))
+	}
+	return out
+}
+
+func TestLoadPayloads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewPayloadDB(logger, dir, 1_000_000, time.Hour)
+	require.NoError(t, err)
+
+	for _, num := range []uint64{12, 10, 11, 15, 11} {
+		require.NoError(t, db.Add(payload(num)))
+	}
+	payloads, err := db.LoadPayloads(9)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 11, 12, 15}, blockNumbers(payloads), "ordered by block number, without duplicates")
+	require.Equal(t, payload(10), payloads[0])
+	require.NoError(t, db.Close())
+
+	// Reopen the db, the payloads persist across restarts
+	db, err = NewPayloadDB(logger, dir, 1_000_000, time.Hour)
+	require.NoError(t, err)
+	defer db.Close()
+	payloads, err = db.LoadPayloads(11)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{12, 15}, blockNumbers(payloads), "payloads at or before the safe head are discarded")
+	payloads, err = db.LoadPayloads(0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{12, 15}, blockNumbers(payloads), "discarded payloads are removed")
+}
+
+func TestMaxSize(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	val, err := payloadValue(payload(1), time.Now())
+	require.NoError(t, err)
+	size := uint64(len(val))
+
+	db, err := NewPayloadDB(logger, t.TempDir(), 3*size, time.Hour)
+	require.NoError(t, err)
+	defer db.Close()
+	for _, num := range []uint64{11, 13, 10, 12} {
+		require.NoError(t, db.Add(payload(num)))
+	}
+	payloads, err := db.LoadPayloads(0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11, 12, 13}, blockNumbers(payloads), "lowest block numbers are removed first")
+	require.Equal(t, 3*size, db.size)
+
+	large := payload(14)
+	large.Transactions = []eth.Data{make([]byte, 3*size)}
+	require.ErrorContains(t, db.Add(large), "larger than max db size")
+}
+
+func TestMaxAge(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewPayloadDB(logger, dir, 1_000_000, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, db.Add(payload(10)))
+	require.NoError(t, db.Add(payload(11)))
+	// backdate the first payload
+	val, err := payloadValue(payload(10), time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, db.db.Set(payloadKey(payload(10).ID()), val, nil))
+	require.NoError(t, db.Close())
+
+	db, err = NewPayloadDB(logger, dir, 1_000_000, time.Hour)
+	require.NoError(t, err)
+	defer db.Close()
+	payloads, err := db.LoadPayloads(0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11}, blockNumbers(payloads), "payloads older than the max age are discarded")
+}
+
+func TestClosed(t *testing.T) {
+	db, err := NewPayloadDB(testlog.Logger(t, log.LvlInfo), t.TempDir(), 1_000_000, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	require.NoError(t, db.Close())
+	require.ErrorIs(t, db.Add(payload(1)), ErrAlreadyClosed)
+	_, err = db.LoadPayloads(0)
+	require.ErrorIs(t, err, ErrAlreadyClosed)
+}
@@ -1,119 +1,103 @@
 package node
 
 import (
-	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
 
-	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+// screenPayload screens the payload according to the screening mode, and records the outcome.
+// It returns an error if the payload may not be processed further.
+func (n *OpNode) screenPayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	mode := n.screeningStat.Mode()
+	if mode == commitments.ModeDisabled {
+		return nil
+	}
+
+	err := n.validateCommitments(ctx, payload)
+	if err == nil || errors.Is(err, commitments.ErrFailedScreening) {
+		satisfied := err == nil
+		n.screeningStat.OnScreened(payload.ID(), satisfied)
+		event := "Screening passed"
+		if !satisfied {
+			event = "Screening failed"
+		}
+		n.snapshotLog.Info("Rollup State Snapshot",
+			"event", event,
+			"l2ScreenedHash", payload.BlockHash,
+			"l2ScreenedNumber", uint64(payload.BlockNumber),
+			"satisfied", satisfied,
+			"mode", mode)
+	}
+	if err != nil && mode == commitments.ModeObserve {
+		n.log.Warn("Ignoring failed commitments validation in observe mode", "id", payload.ID(), "err", err)
+		return nil
+	}
+	return err
+}
+
 // validateCommitments validates that the proposer's commitments are satisfied for the given payload.
 // It does this by passing the payload to the L1 SystemConfig contracts, which checks the commitments.
+// The payload is screened against the commitments of the sequencer that was in effect at its L1 origin.
+// The screening is evaluated against the latest observed L1 head, and its outcome is cached
+// so the same payload is not screened again against the same L1 state.
 // It returns an error if the commitments are not satisfied.
 func (n *OpNode) validateCommitments(ctx context.Context, payload *eth.ExecutionPayload) error {
-	instance, err := bindings.NewSystemConfig(n.runCfg.rollupCfg.L1SystemConfigAddress, n.l1Source.EthClient)
+	ref, err := derive.PayloadToBlockRef(payload, &n.runCfg.rollupCfg.Genesis)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to determine L1 origin of payload: %w", err)
 	}
-
-	// Encoding payload
-	payloadBytes, err := n.encodePayload(payload)
-	if err != nil {
-		return err
+	sequencer := n.runCfg.P2PSequencerAddressAt(ref.L1Origin)
+	l1Head := n.screeningCache.L1Head()
+	key := screeningKey{
+		BlockHash:   payload.BlockHash,
+		L1BlockHash: l1Head.Hash,
+		Sequencer:   sequencer,
+		Target:      commitments.BlockTarget,
 	}
 
-	// Calling Screen function
-	satisfied, err := instance.Screen(nil, n.runCfg.P2PSequencerAddress(), *n.target(), payloadBytes)
-	if err != nil {
-		return err
+	// Without an L1 head the screening runs against the latest L1 state, which cannot be cached.
+	cacheable := l1Head != (eth.L1BlockRef{})
+	satisfied, ok := false, false
+	if cacheable {
+		satisfied, ok = n.screeningCache.Get(key)
+	}
+	if !ok {
+		satisfied, err = n.screen(ctx, l1Head, key, payload)
+		if err != nil {
+			return err
+		}
+		if cacheable {
+			n.screeningCache.Add(key, satisfied)
+		}
 	}
 	if !satisfied {
-		return errors.New("Failed_Screening")
+		return commitments.ErrFailedScreening
 	}
 
-	n.log.Info("Commitments satisfied", "sequencer", n.runCfg.P2PSequencerAddress())
+	n.log.Info("Commitments satisfied", "sequencer", sequencer, "l1_origin", ref.L1Origin, "l1_head", l1Head.ID(), "cached", ok)
 	return nil
 }
 
-func (n *OpNode) target() *[32]byte {
-	var target [32]byte
-	copy(target[:], common.Hex2BytesFixed("0x0000000000000000000000000000000000000000000000000000000000000001", 32))
-	return &target
-}
-
-func (n *OpNode) encodePayload(payload *eth.ExecutionPayload) ([]byte, error) {
-	var (
-		structExecutionPayload, _ = abi.NewType("tuple", "struct ExecutionPayload", []abi.ArgumentMarshaling{
-			{Name: "ParentHash", Type: "bytes32"},
-			{Name: "FeeRecipient", Type: "address"},
-			{Name: "StateRoot", Type: "bytes32"},
-			{Name: "ReceiptsRoot", Type: "bytes32"},
-			{Name: "LogsBloom", Type: "bytes"},
-			{Name: "PrevRandao", Type: "bytes32"},
-			{Name: "BlockNumber", Type: "uint64"},
-			{Name: "GasLimit", Type: "uint64"},
-			{Name: "GasUsed", Type: "uint64"},
-			{Name: "Timestamp", Type: "uint64"},
-			{Name: "ExtraData", Type: "bytes"},
-			{Name: "BaseFeePerGas", Type: "bytes32"},
-			{Name: "BlockHash", Type: "bytes32"},
-			{Name: "Transactions", Type: "bytes"},
-		})
-
-		args = abi.Arguments{
-			{Type: structExecutionPayload, Name: "param_one"},
-		}
-	)
-
-	_payload := struct {
-		ParentHash    common.Hash
-		FeeRecipient  common.Address
-		StateRoot     [32]byte
-		ReceiptsRoot  [32]byte
-		LogsBloom     []byte
-		PrevRandao    [32]byte
-		BlockNumber   hexutil.Uint64
-		GasLimit      hexutil.Uint64
-		GasUsed       hexutil.Uint64
-		Timestamp     hexutil.Uint64
-		ExtraData     []byte
-		BaseFeePerGas [32]byte
-		BlockHash     common.Hash
-		Transactions  []byte
-	}{
-		payload.ParentHash,
-		payload.FeeRecipient,
-		payload.StateRoot,
-		payload.ReceiptsRoot,
-		[]byte(payload.LogsBloom.String()),
-		payload.PrevRandao,
-		payload.BlockNumber,
-		payload.GasLimit,
-		payload.GasUsed,
-		payload.Timestamp,
-		payload.ExtraData,
-		payload.BaseFeePerGas.Bytes32(),
-		payload.BlockHash,
-		encodeTransactions(payload.Transactions),
-	}
-
-	packed, err := args.Pack(&_payload)
+// screen calls the L1 SystemConfig to screen the payload against the commitments of the sequencer.
+func (n *OpNode) screen(ctx context.Context, l1Head eth.L1BlockRef, key screeningKey, payload *eth.ExecutionPayload) (bool, error) {
+	screener, err := commitments.NewSystemConfigScreener(n.runCfg.rollupCfg.L1SystemConfigAddress, n.l1Source.EthClient)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	return packed, nil
-}
-
-func encodeTransactions(txs []eth.Data) []byte {
-	var res [][]byte
-	for _, v := range txs {
-		res = append(res, v)
+	if n.stateManifest != nil {
+		screener = screener.WithStateProofs(n.l2Source, n.stateManifest)
 	}
+	metered := commitments.WithMetrics(screener, n.metrics)
 
-	return bytes.Join(res, nil)
+	var l1BlockNum *big.Int
+	if l1Head != (eth.L1BlockRef{}) {
+		l1BlockNum = new(big.Int).SetUint64(l1Head.Number)
+	}
+	return metered.Screen(ctx, l1BlockNum, key.Sequencer, payload)
 }
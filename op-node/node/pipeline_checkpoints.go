@@ -0,0 +1,79 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+var _ derive.CheckpointStore = (*ActivePipelineCheckpoints)(nil)
+var _ derive.CheckpointStore = DisabledPipelineCheckpoints{}
+
+// ActivePipelineCheckpoints persists the derivation pipeline checkpoints to a file.
+type ActivePipelineCheckpoints struct {
+	lock     sync.Mutex
+	file     string
+	interval time.Duration
+}
+
+func NewPipelineCheckpoints(file string, interval time.Duration) *ActivePipelineCheckpoints {
+	return &ActivePipelineCheckpoints{file: file, interval: interval}
+}
+
+func (p *ActivePipelineCheckpoints) Enabled() bool {
+	return true
+}
+
+func (p *ActivePipelineCheckpoints) CheckpointInterval() time.Duration {
+	return p.interval
+}
+
+func (p *ActivePipelineCheckpoints) LoadCheckpoint() (*derive.PipelineCheckpoint, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	data, err := os.ReadFile(p.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read checkpoint file (%v): %w", p.file, err)
+	}
+	var cp derive.PipelineCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file (%v): %w", p.file, err)
+	}
+	return &cp, nil
+}
+
+func (p *ActivePipelineCheckpoints) SaveCheckpoint(cp *derive.PipelineCheckpoint) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	return writeFileAtomic(p.file, data)
+}
+
+// DisabledPipelineCheckpoints does not take or restore any derivation pipeline checkpoints.
+type DisabledPipelineCheckpoints struct{}
+
+func (DisabledPipelineCheckpoints) Enabled() bool {
+	return false
+}
+
+func (DisabledPipelineCheckpoints) CheckpointInterval() time.Duration {
+	return 0
+}
+
+func (DisabledPipelineCheckpoints) LoadCheckpoint() (*derive.PipelineCheckpoint, error) {
+	return nil, nil
+}
+
+func (DisabledPipelineCheckpoints) SaveCheckpoint(cp *derive.PipelineCheckpoint) error {
+	return nil
+}
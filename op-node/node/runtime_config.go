@@ -19,6 +19,10 @@ var (
 	UnsafeBlockSignerAddressSystemConfigStorageSlot = common.HexToHash("0x65a7ed542fb37fe237fdfbdd70b31598523fe5b32879e307bae27a0bd9581c08")
 )
 
+// maxSignerHistory is the number of unsafe block signer changes that are retained,
+// to be able to attribute in-flight payloads to the signer of their L1 origin.
+const maxSignerHistory = 16
+
 type RuntimeCfgL1Source interface {
 	ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error)
 }
@@ -27,6 +31,8 @@ type RuntimeCfgL1Source interface {
 // These options are loaded based on initial loading + updates for every subsequent L1 block.
 // Only the *latest* values are maintained however, the runtime config has no concept of chain history,
 // does not require any archive data, and may be out of sync with the rollup derivation process.
+// The exception is a short history of unsafe block signer changes, tracked as they are loaded,
+// so payloads can be attributed to the signer that was in effect at their L1 origin.
 type RuntimeConfig struct {
 	mu sync.RWMutex
 
@@ -40,6 +46,16 @@ type RuntimeConfig struct {
 	l1Ref eth.L1BlockRef
 
 	runtimeConfigData
+
+	// signerHistory holds the loaded unsafe block signer changes, ordered by L1 block number.
+	// Each entry is in effect from its L1 block, until the L1 block of the next entry.
+	signerHistory []signerAtL1
+}
+
+// signerAtL1 is an unsafe block signer address, as loaded at the given L1 block.
+type signerAtL1 struct {
+	l1Ref eth.L1BlockRef
+	addr  common.Address
 }
 
 // runtimeConfigData is a flat bundle of configurable data, easy and light to copy around.
@@ -63,6 +79,23 @@ func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	return r.p2pBlockSignerAddr
 }
 
+// P2PSequencerAddressAt returns the unsafe block signer address that was in effect at the given L1 block.
+// If the L1 block predates the retained history, the oldest known signer is returned.
+// If the L1 block is past the latest loaded L1 block, the latest signer is returned.
+func (r *RuntimeConfig) P2PSequencerAddressAt(l1 eth.BlockID) common.Address {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.signerHistory) - 1; i >= 0; i-- {
+		if r.signerHistory[i].l1Ref.Number <= l1.Number {
+			return r.signerHistory[i].addr
+		}
+	}
+	if len(r.signerHistory) > 0 {
+		return r.signerHistory[0].addr
+	}
+	return r.p2pBlockSignerAddr
+}
+
 // Load resets the runtime configuration by fetching the latest config data from L1 at the given L1 block.
 // Load is safe to call concurrently, but will lock the runtime configuration modifications only,
 // and will thus not block other Load calls with possibly alternative L1 block views.
@@ -75,6 +108,28 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
 	r.p2pBlockSignerAddr = common.BytesToAddress(val[:])
+	r.recordSigner(l1Ref, r.p2pBlockSignerAddr)
 	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
 	return nil
 }
+
+// recordSigner tracks the signer loaded at the given L1 block in the signer history.
+// Entries at or after the L1 block are dropped first: they were either reorged out,
+// or are superseded by this view of L1. The caller must hold the write lock.
+func (r *RuntimeConfig) recordSigner(l1Ref eth.L1BlockRef, addr common.Address) {
+	i := len(r.signerHistory)
+	for i > 0 && r.signerHistory[i-1].l1Ref.Number >= l1Ref.Number {
+		i--
+	}
+	r.signerHistory = r.signerHistory[:i]
+	if i > 0 && r.signerHistory[i-1].addr == addr {
+		return
+	}
+	if i > 0 {
+		r.log.Info("unsafe block signer changed", "l1_block", l1Ref.ID(), "prev", r.signerHistory[i-1].addr, "next", addr)
+	}
+	r.signerHistory = append(r.signerHistory, signerAtL1{l1Ref: l1Ref, addr: addr})
+	if len(r.signerHistory) > maxSignerHistory {
+		r.signerHistory = r.signerHistory[len(r.signerHistory)-maxSignerHistory:]
+	}
+}
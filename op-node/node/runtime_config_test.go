@@ -0,0 +1,76 @@
+package node
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type stubRuntimeCfgL1Source map[common.Hash]common.Address
+
+func (s stubRuntimeCfgL1Source) ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error) {
+	return common.BytesToHash(s[blockHash].Bytes()), nil
+}
+
+func TestRuntimeConfigSignerHistory(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	signerA, signerB, signerC := testutils.RandomAddress(rng), testutils.RandomAddress(rng), testutils.RandomAddress(rng)
+
+	l1 := func(num uint64) eth.L1BlockRef {
+		return eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: num}
+	}
+	a10, a11, a20, b20 := l1(10), l1(11), l1(20), l1(20)
+	src := stubRuntimeCfgL1Source{
+		a10.Hash: signerA,
+		a11.Hash: signerA,
+		a20.Hash: signerB,
+		b20.Hash: signerC,
+	}
+
+	runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlInfo), src, &rollup.Config{})
+	require.Equal(t, common.Address{}, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 10}))
+
+	ctx := context.Background()
+	require.NoError(t, runCfg.Load(ctx, a10))
+	require.NoError(t, runCfg.Load(ctx, a11))
+	require.NoError(t, runCfg.Load(ctx, a20))
+	require.Len(t, runCfg.signerHistory, 2, "unchanged signers are not recorded")
+	require.Equal(t, signerB, runCfg.P2PSequencerAddress())
+	require.Equal(t, signerA, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 5}), "fall back to oldest known signer")
+	require.Equal(t, signerA, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 19}))
+	require.Equal(t, signerB, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 20}))
+	require.Equal(t, signerB, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 100}))
+
+	// an L1 reorg replaces the history at and after the reorged block
+	require.NoError(t, runCfg.Load(ctx, b20))
+	require.Len(t, runCfg.signerHistory, 2)
+	require.Equal(t, signerA, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 19}))
+	require.Equal(t, signerC, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 20}))
+
+	// reorg back to before the signer change
+	require.NoError(t, runCfg.Load(ctx, a11))
+	require.Len(t, runCfg.signerHistory, 1)
+	require.Equal(t, signerA, runCfg.P2PSequencerAddressAt(eth.BlockID{Number: 20}))
+}
+
+func TestRuntimeConfigSignerHistoryBounded(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	src := stubRuntimeCfgL1Source{}
+	runCfg := NewRuntimeConfig(testlog.Logger(t, log.LvlInfo), src, &rollup.Config{})
+	for i := uint64(0); i < maxSignerHistory*2; i++ {
+		ref := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: i}
+		src[ref.Hash] = testutils.RandomAddress(rng)
+		require.NoError(t, runCfg.Load(context.Background(), ref))
+	}
+	require.Len(t, runCfg.signerHistory, maxSignerHistory)
+	require.Equal(t, uint64(maxSignerHistory), runCfg.signerHistory[0].l1Ref.Number)
+}
@@ -0,0 +1,179 @@
+// Package safedb records which L2 block was the safe head as of each L1 block,
+// as derived by the rollup node, in a persistent database.
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrInvalidEntry  = errors.New("invalid db entry")
+	ErrNotEnabled    = errors.New("safe head database not enabled")
+	ErrAlreadyClosed = errors.New("safe head database already closed")
+)
+
+const (
+	// keyPrefixSafeByL1BlockNum is the prefix of the entries keyed by L1 block number
+	keyPrefixSafeByL1BlockNum byte = 0
+)
+
+// safeByL1BlockNumKey returns the key of the entry of the given L1 block number.
+// The number is encoded big-endian so the keys sort by L1 block number.
+func safeByL1BlockNumKey(l1BlockNum uint64) []byte {
+	var key [9]byte
+	key[0] = keyPrefixSafeByL1BlockNum
+	binary.BigEndian.PutUint64(key[1:], l1BlockNum)
+	return key[:]
+}
+
+// safeByL1BlockNumValue encodes the L1 block hash, the L2 safe head hash and the L2 safe head number.
+func safeByL1BlockNumValue(l1 eth.BlockID, l2 eth.BlockID) []byte {
+	val := make([]byte, 0, 72)
+	val = append(val, l1.Hash[:]...)
+	val = append(val, l2.Hash[:]...)
+	return binary.BigEndian.AppendUint64(val, l2.Number)
+}
+
+func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.BlockID, err error) {
+	if len(key) != 9 || len(val) != 72 || key[0] != keyPrefixSafeByL1BlockNum {
+		return eth.BlockID{}, eth.BlockID{}, ErrInvalidEntry
+	}
+	l1 = eth.BlockID{Hash: common.BytesToHash(val[:32]), Number: binary.BigEndian.Uint64(key[1:])}
+	l2 = eth.BlockID{Hash: common.BytesToHash(val[32:64]), Number: binary.BigEndian.Uint64(val[64:])}
+	return
+}
+
+// SafeDB is a persistent database of the L2 safe head as of each L1 block.
+// Entries are only recorded for L1 blocks at which the safe head changed, or the safe head
+// was updated as part of deriving from that L1 block. The safe head at any other L1 block is
+// that of the last recorded L1 block before it.
+type SafeDB struct {
+	// m guards access to the db: writes take the write lock, reads the read lock,
+	// so that reads never observe a partially applied reset.
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	closed bool
+}
+
+// NewSafeDB opens, or creates, the safe head database at the given path.
+func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head db at %s: %w", path, err)
+	}
+	return &SafeDB{
+		log: logger,
+		db:  db,
+	}, nil
+}
+
+func (d *SafeDB) Enabled() bool {
+	return true
+}
+
+// SafeHeadUpdated records that the given safe head is the safe head as of the given L1 block.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrAlreadyClosed
+	}
+	d.log.Debug("Record safe head", "l2", safeHead.ID(), "l1", l1Head)
+	if err := d.db.Set(safeByL1BlockNumKey(l1Head.Number), safeByL1BlockNumValue(l1Head, safeHead.ID()), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to record safe head update: %w", err)
+	}
+	return nil
+}
+
+// SafeHeadReset removes all entries with a safe head after the given safe head, which the
+// derivation pipeline was reset to. Those entries are recorded again as the pipeline derives
+// the blocks again, unless they were reorged out on L1 or L2.
+func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return ErrAlreadyClosed
+	}
+	// Blocks cannot be derived from L1 blocks before their L1 origin,
+	// so entries before the L1 origin of the safe head cannot have a later safe head.
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(safeHead.L1Origin.Number),
+		UpperBound: safeByL1BlockNumKey(math.MaxUint64),
+	})
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read safe head entry: %w", err)
+		}
+		l1Block, l2Block, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return err
+		}
+		if l2Block.Number > safeHead.Number {
+			d.log.Info("Truncating safe head db", "reset_safe_head", safeHead.ID(), "from_l1", l1Block, "l2", l2Block)
+			if err := d.db.DeleteRange(safeByL1BlockNumKey(l1Block.Number), safeByL1BlockNumKey(math.MaxUint64), pebble.Sync); err != nil {
+				return fmt.Errorf("failed to truncate safe head db: %w", err)
+			}
+			return nil
+		}
+	}
+	return iter.Error()
+}
+
+// SafeHeadAtL1 returns the L2 safe head as of the given L1 block number, together with the L1 block
+// the safe head was recorded at, which is the given L1 block or the last recorded L1 block before it.
+// ErrNotFound is returned if no safe head was recorded at or before the L1 block.
+func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return eth.BlockID{}, eth.BlockID{}, ErrAlreadyClosed
+	}
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: safeByL1BlockNumKey(math.MaxUint64),
+	})
+	defer iter.Close()
+	var valid bool
+	if l1BlockNum == math.MaxUint64 {
+		valid = iter.Last()
+	} else {
+		valid = iter.SeekLT(safeByL1BlockNumKey(l1BlockNum + 1))
+	}
+	if !valid {
+		if err := iter.Error(); err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return eth.BlockID{}, eth.BlockID{}, err
+	}
+	return decodeSafeByL1BlockNum(iter.Key(), val)
+}
+
+func (d *SafeDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
@@ -0,0 +1,106 @@
+package safedb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func l2Ref(num uint64, l1OriginNum uint64) eth.L2BlockRef {
+	return eth.L2BlockRef{
+		Hash:     common.Hash{0x02, byte(num)},
+		Number:   num,
+		L1Origin: eth.BlockID{Hash: common.Hash{0x01, byte(l1OriginNum)}, Number: l1OriginNum},
+	}
+}
+
+func l1ID(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0x01, byte(num)}, Number: num}
+}
+
+func TestStoreSafeHeads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, _, err = db.SafeHeadAtL1(context.Background(), 100)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(10, 98), l1ID(100)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(12, 99), l1ID(102)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(15, 101), l1ID(103)))
+
+	verify := func(db *SafeDB, l1BlockNum uint64, expectedL1 uint64, expectedL2 uint64) {
+		l1, l2, err := db.SafeHeadAtL1(context.Background(), l1BlockNum)
+		require.NoError(t, err)
+		require.Equal(t, l1ID(expectedL1), l1, "l1 block for %d", l1BlockNum)
+		require.Equal(t, l2Ref(expectedL2, 0).ID(), l2, "safe head for %d", l1BlockNum)
+	}
+	_, _, err = db.SafeHeadAtL1(context.Background(), 99)
+	require.ErrorIs(t, err, ErrNotFound, "no safe head before first entry")
+	verify(db, 100, 100, 10)
+	verify(db, 101, 100, 10)
+	verify(db, 102, 102, 12)
+	verify(db, 103, 103, 15)
+	verify(db, 2000, 103, 15)
+	verify(db, math.MaxUint64, 103, 15)
+
+	// data is persisted across restarts
+	require.NoError(t, db.Close())
+	db, err = NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	verify(db, 101, 100, 10)
+	verify(db, 103, 103, 15)
+}
+
+func TestSafeHeadReset(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(10, 98), l1ID(100)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(12, 99), l1ID(102)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(15, 101), l1ID(103)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(18, 102), l1ID(104)))
+
+	// reset to a safe head that was recorded at L1 block 102, later entries are removed
+	require.NoError(t, db.SafeHeadReset(l2Ref(13, 99)))
+	l1, l2, err := db.SafeHeadAtL1(context.Background(), 104)
+	require.NoError(t, err)
+	require.Equal(t, l1ID(102), l1)
+	require.Equal(t, l2Ref(12, 0).ID(), l2)
+
+	// resetting to a safe head before all entries clears the db
+	require.NoError(t, db.SafeHeadReset(l2Ref(5, 90)))
+	_, _, err = db.SafeHeadAtL1(context.Background(), 104)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClosed(t *testing.T) {
+	db, err := NewSafeDB(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	require.NoError(t, db.Close(), "closing twice is fine")
+	require.ErrorIs(t, db.SafeHeadUpdated(l2Ref(10, 98), l1ID(100)), ErrAlreadyClosed)
+	require.ErrorIs(t, db.SafeHeadReset(l2Ref(10, 98)), ErrAlreadyClosed)
+	_, _, err = db.SafeHeadAtL1(context.Background(), 100)
+	require.ErrorIs(t, err, ErrAlreadyClosed)
+}
+
+func TestDisabled(t *testing.T) {
+	require.False(t, Disabled.Enabled())
+	require.NoError(t, Disabled.SafeHeadUpdated(l2Ref(10, 98), l1ID(100)))
+	require.NoError(t, Disabled.SafeHeadReset(l2Ref(10, 98)))
+	_, _, err := Disabled.SafeHeadAtL1(context.Background(), 100)
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
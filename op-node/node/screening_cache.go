@@ -0,0 +1,71 @@
+package node
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/sources/caching"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// screeningCacheSize is the number of screening outcomes to keep around.
+// A payload is typically screened a few times within seconds (publish, gossip, alt-sync),
+// so this only needs to cover the recent unsafe blocks.
+const screeningCacheSize = 1000
+
+type screeningKey struct {
+	BlockHash   common.Hash
+	L1BlockHash common.Hash
+	Sequencer   common.Address
+	Target      [32]byte
+}
+
+// screeningCache caches the outcome of commitment screenings, keyed by the payload and the L1 block
+// the screening was evaluated against. The L1 view is tracked through new L1 heads,
+// and all cached outcomes are dropped when the L1 chain reorgs.
+type screeningCache struct {
+	mu     sync.RWMutex
+	l1Head eth.L1BlockRef
+
+	cache *caching.LRUCache[screeningKey, bool]
+}
+
+func newScreeningCache(m caching.Metrics, size int) *screeningCache {
+	return &screeningCache{
+		cache: caching.NewLRUCache[screeningKey, bool](m, "screening", size),
+	}
+}
+
+// L1Head returns the L1 block that screenings are evaluated against.
+// It is zeroed if no L1 head has been observed yet.
+func (c *screeningCache) L1Head() eth.L1BlockRef {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.l1Head
+}
+
+// OnNewL1Head updates the L1 view, and returns true if the cached outcomes were invalidated.
+// Any head that does not directly extend the previous one is treated as a reorg.
+func (c *screeningCache) OnNewL1Head(head eth.L1BlockRef) (invalidated bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	prev := c.l1Head
+	if prev.Hash == head.Hash {
+		return false
+	}
+	c.l1Head = head
+	if prev == (eth.L1BlockRef{}) || (head.ParentHash == prev.Hash && head.Number == prev.Number+1) {
+		return false
+	}
+	c.cache.Purge()
+	return true
+}
+
+func (c *screeningCache) Get(key screeningKey) (satisfied bool, ok bool) {
+	return c.cache.Get(key)
+}
+
+func (c *screeningCache) Add(key screeningKey, satisfied bool) {
+	c.cache.Add(key, satisfied)
+}
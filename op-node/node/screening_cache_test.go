@@ -0,0 +1,54 @@
+package node
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestScreeningCache(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+	b := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: a.Number + 1, ParentHash: a.Hash}
+	bAlt := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: a.Number + 1, ParentHash: a.Hash}
+
+	cache := newScreeningCache(nil, 10)
+	require.Equal(t, eth.L1BlockRef{}, cache.L1Head())
+
+	key := screeningKey{BlockHash: testutils.RandomHash(rng), Sequencer: testutils.RandomAddress(rng)}
+	_, ok := cache.Get(key)
+	require.False(t, ok)
+	cache.Add(key, true)
+
+	t.Run("first head", func(t *testing.T) {
+		require.False(t, cache.OnNewL1Head(a))
+		require.Equal(t, a, cache.L1Head())
+		satisfied, ok := cache.Get(key)
+		require.True(t, ok)
+		require.True(t, satisfied)
+	})
+
+	t.Run("same head", func(t *testing.T) {
+		require.False(t, cache.OnNewL1Head(a))
+		_, ok := cache.Get(key)
+		require.True(t, ok)
+	})
+
+	t.Run("extending head", func(t *testing.T) {
+		require.False(t, cache.OnNewL1Head(b))
+		require.Equal(t, b, cache.L1Head())
+		_, ok := cache.Get(key)
+		require.True(t, ok)
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		require.True(t, cache.OnNewL1Head(bAlt))
+		require.Equal(t, bAlt, cache.L1Head())
+		_, ok := cache.Get(key)
+		require.False(t, ok)
+	})
+}
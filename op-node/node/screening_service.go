@@ -0,0 +1,237 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// screeningWorkers is the number of payloads that are screened concurrently.
+	screeningWorkers = 4
+	// screeningQueueSize is the number of payloads that may be pending screening or hand-off,
+	// before newly received payloads are dropped.
+	screeningQueueSize = 64
+	// screeningTimeout bounds a single screening call to L1.
+	screeningTimeout = 10 * time.Second
+	// screeningRetryDelay is the delay before a payload is screened again,
+	// when the L2 state it is screened against is not available yet.
+	screeningRetryDelay = 2 * time.Second
+	// screeningMaxAttempts bounds how many times a payload is screened,
+	// while the L2 state it is screened against is not available.
+	screeningMaxAttempts = 10
+)
+
+var (
+	ErrScreeningQueueFull = errors.New("screening queue is full")
+	ErrScreeningClosed    = errors.New("screening service is closed")
+)
+
+type ScreeningMetrics interface {
+	RecordScreeningQueue(length int)
+	RecordScreeningDropped(count int)
+}
+
+// screenFn screens the payload, and returns an error if it is not acceptable.
+type screenFn func(ctx context.Context, payload *eth.ExecutionPayload) error
+
+// deliverFn hands off a screened payload.
+type deliverFn func(ctx context.Context, payload *eth.ExecutionPayload)
+
+type screeningJob struct {
+	payload  *eth.ExecutionPayload
+	attempts int
+	done     chan struct{}
+	err      error
+	// parked is set before done is closed, if the job left the ordered queue to wait for its parent state.
+	parked bool
+}
+
+// screeningService screens payloads asynchronously, with a bounded pool of workers.
+// Payloads are screened concurrently, but handed off in the order they were submitted,
+// so the receiver observes the same ordering as without the screening.
+// Payloads that fail screening are not handed off.
+// Payloads whose parent state is not available yet are parked: they leave the ordered queue,
+// so they do not hold up later payloads, and are screened again after a delay,
+// as their parent is typically still being processed by the engine, or has yet to arrive.
+// Parked payloads are handed off as soon as they pass screening.
+type screeningService struct {
+	log     log.Logger
+	metrics ScreeningMetrics
+
+	screen  screenFn
+	deliver deliverFn
+	timeout time.Duration
+
+	retryDelay  time.Duration
+	maxAttempts int
+
+	// slots bounds the number of payloads in flight: pending screening or hand-off.
+	slots chan struct{}
+	// work is consumed by the workers, in any order.
+	work chan *screeningJob
+	// ordered is consumed by the hand-off loop, in submission order.
+	ordered chan *screeningJob
+	// resumed is consumed by the hand-off loop, in any order, and holds parked jobs that completed screening.
+	resumed chan *screeningJob
+
+	// submitLock keeps the work and ordered queues consistent across concurrent submissions.
+	submitLock sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newScreeningService(log log.Logger, m ScreeningMetrics, screen screenFn, deliver deliverFn, workers int, queueSize int, timeout time.Duration, retryDelay time.Duration) *screeningService {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &screeningService{
+		log:     log,
+		metrics: m,
+		screen:  screen,
+		deliver: deliver,
+		timeout: timeout,
+
+		retryDelay:  retryDelay,
+		maxAttempts: screeningMaxAttempts,
+
+		slots:   make(chan struct{}, queueSize),
+		work:    make(chan *screeningJob, queueSize),
+		ordered: make(chan *screeningJob, queueSize),
+		resumed: make(chan *screeningJob, queueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	s.wg.Add(workers + 1)
+	for i := 0; i < workers; i++ {
+		go s.worker()
+	}
+	go s.handOff()
+	return s
+}
+
+// Submit queues the payload for screening and hand-off.
+// It does not block: if the queue is full the payload is dropped and ErrScreeningQueueFull is returned.
+func (s *screeningService) Submit(payload *eth.ExecutionPayload) error {
+	if s.ctx.Err() != nil {
+		return ErrScreeningClosed
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.metrics.RecordScreeningDropped(1)
+		return ErrScreeningQueueFull
+	}
+	s.metrics.RecordScreeningQueue(len(s.slots))
+
+	job := &screeningJob{payload: payload, done: make(chan struct{})}
+	// Both queues are as large as the number of slots, so these never block.
+	s.submitLock.Lock()
+	s.ordered <- job
+	s.work <- job
+	s.submitLock.Unlock()
+	return nil
+}
+
+func (s *screeningService) worker() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case job := <-s.work:
+			ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+			job.err = s.screen(ctx, job.payload)
+			cancel()
+			job.attempts++
+
+			if errors.Is(job.err, commitments.ErrParentStateUnavailable) && job.attempts < s.maxAttempts {
+				s.log.Debug("Parent state of payload is not available yet, retrying screening", "id", job.payload.ID(), "attempts", job.attempts)
+				if !job.parked {
+					// let the hand-off loop move on to later payloads, which may include the parent
+					job.parked = true
+					close(job.done)
+				}
+				time.AfterFunc(s.retryDelay, func() { s.retry(job) })
+				continue
+			}
+			if job.parked {
+				// The job holds its slot, so the resumed queue has room for it.
+				s.resumed <- job
+			} else {
+				close(job.done)
+			}
+		}
+	}
+}
+
+// retry queues the job for screening again.
+// The job still holds its slot, so the work queue has room for it.
+func (s *screeningService) retry(job *screeningJob) {
+	select {
+	case <-s.ctx.Done():
+	case s.work <- job:
+	}
+}
+
+func (s *screeningService) handOff() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case job := <-s.resumed:
+			s.finish(job)
+		case job := <-s.ordered:
+			// hand off resumed jobs while waiting, as the job may be waiting for one of them
+		wait:
+			for {
+				select {
+				case <-s.ctx.Done():
+					return
+				case resumed := <-s.resumed:
+					s.finish(resumed)
+				case <-job.done:
+					break wait
+				}
+			}
+			// parked jobs are finished once they are resumed
+			if !job.parked {
+				s.finish(job)
+			}
+		}
+	}
+}
+
+// finish hands off the job if it passed screening, and releases its slot.
+// Once the service is closed, the job keeps its slot, to be reported as dropped by Close.
+func (s *screeningService) finish(job *screeningJob) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	if job.err != nil {
+		s.log.Error("⛔️ Failed to validate commitments", "id", job.payload.ID(), "err", job.err)
+	} else {
+		s.deliver(s.ctx, job.payload)
+	}
+	<-s.slots
+	s.metrics.RecordScreeningQueue(len(s.slots))
+}
+
+// Close stops the screening service, dropping any payloads that are still in flight,
+// and waits for the workers to exit. The dropped payloads are logged and recorded in the metrics.
+func (s *screeningService) Close() {
+	s.cancel()
+	s.wg.Wait()
+	// every payload in flight holds a slot until it is handed off
+	if dropped := len(s.slots); dropped > 0 {
+		s.log.Warn("Dropped unsafe payloads that were still being screened", "count", dropped)
+		s.metrics.RecordScreeningDropped(dropped)
+	}
+}
@@ -0,0 +1,185 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestScreeningServiceOrderedHandOff(t *testing.T) {
+	var mu sync.Mutex
+	var delivered []uint64
+	done := make(chan struct{})
+
+	screen := func(ctx context.Context, payload *eth.ExecutionPayload) error {
+		// screen earlier payloads slower, to complete out of order
+		time.Sleep(time.Duration(10-payload.BlockNumber) * time.Millisecond)
+		if payload.BlockNumber == 3 {
+			return errors.New("Failed_Screening")
+		}
+		return nil
+	}
+	deliver := func(ctx context.Context, payload *eth.ExecutionPayload) {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, uint64(payload.BlockNumber))
+		if payload.BlockNumber == 9 {
+			close(done)
+		}
+	}
+
+	s := newScreeningService(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, screen, deliver, 4, 10, time.Second, time.Millisecond)
+	defer s.Close()
+	for i := uint64(0); i < 10; i++ {
+		require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: eth.Uint64Quantity(i)}))
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for hand-off")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []uint64{0, 1, 2, 4, 5, 6, 7, 8, 9}, delivered)
+}
+
+func TestScreeningServiceBackPressure(t *testing.T) {
+	release := make(chan struct{})
+	screen := func(ctx context.Context, payload *eth.ExecutionPayload) error {
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	deliver := func(ctx context.Context, payload *eth.ExecutionPayload) {}
+
+	m := &testScreeningMetrics{}
+	s := newScreeningService(testlog.Logger(t, log.LvlInfo), m, screen, deliver, 1, 2, time.Minute, time.Millisecond)
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 1}))
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 2}))
+	require.ErrorIs(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 3}), ErrScreeningQueueFull)
+	require.Equal(t, int64(1), m.dropped.Load())
+
+	// Close does not wait for the blocked screenings, it cancels them, and records the payloads as dropped
+	s.Close()
+	require.Equal(t, int64(3), m.dropped.Load())
+	require.ErrorIs(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 4}), ErrScreeningClosed)
+}
+
+type testScreeningMetrics struct {
+	dropped atomic.Int64
+}
+
+func (m *testScreeningMetrics) RecordScreeningQueue(length int) {}
+
+func (m *testScreeningMetrics) RecordScreeningDropped(count int) {
+	m.dropped.Add(int64(count))
+}
+
+func TestScreeningServiceTimeout(t *testing.T) {
+	screen := func(ctx context.Context, payload *eth.ExecutionPayload) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	delivered := make(chan struct{}, 1)
+	deliver := func(ctx context.Context, payload *eth.ExecutionPayload) {
+		delivered <- struct{}{}
+	}
+
+	s := newScreeningService(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, screen, deliver, 1, 2, 10*time.Millisecond, time.Millisecond)
+	defer s.Close()
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 1}))
+	// the slot is released once the timed-out payload is discarded
+	require.Eventually(t, func() bool { return len(s.slots) == 0 }, 5*time.Second, 5*time.Millisecond)
+	require.Empty(t, delivered)
+}
+
+func TestScreeningServiceRetryParentStateUnavailable(t *testing.T) {
+	var attempts atomic.Int32
+	screen := func(ctx context.Context, payload *eth.ExecutionPayload) error {
+		// the parent state becomes available on the third attempt
+		if attempts.Add(1) < 3 || payload.BlockNumber == 2 {
+			return fmt.Errorf("failed to fetch state proofs: %w", commitments.ErrParentStateUnavailable)
+		}
+		return nil
+	}
+	delivered := make(chan uint64, 2)
+	deliver := func(ctx context.Context, payload *eth.ExecutionPayload) {
+		delivered <- uint64(payload.BlockNumber)
+	}
+
+	s := newScreeningService(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, screen, deliver, 1, 2, time.Second, time.Millisecond)
+	defer s.Close()
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 1}))
+	select {
+	case num := <-delivered:
+		require.Equal(t, uint64(1), num)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for hand-off")
+	}
+	require.Equal(t, int32(3), attempts.Load())
+
+	// the payload is dropped once the attempts are exhausted
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 2}))
+	require.Eventually(t, func() bool { return len(s.slots) == 0 }, 5*time.Second, 5*time.Millisecond)
+	require.Equal(t, int32(3+screeningMaxAttempts), attempts.Load())
+	require.Empty(t, delivered)
+}
+
+func TestScreeningServiceChildBeforeParent(t *testing.T) {
+	var parentDelivered atomic.Bool
+	var childAttempts atomic.Int32
+	screen := func(ctx context.Context, payload *eth.ExecutionPayload) error {
+		// the child can only be screened once its parent was handed off
+		if payload.BlockNumber == 2 {
+			childAttempts.Add(1)
+			if !parentDelivered.Load() {
+				return fmt.Errorf("failed to fetch state proofs: %w", commitments.ErrParentStateUnavailable)
+			}
+		}
+		return nil
+	}
+	delivered := make(chan uint64, 3)
+	deliver := func(ctx context.Context, payload *eth.ExecutionPayload) {
+		if payload.BlockNumber == 1 {
+			parentDelivered.Store(true)
+		}
+		delivered <- uint64(payload.BlockNumber)
+	}
+
+	s := newScreeningService(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, screen, deliver, 1, 3, time.Second, 200*time.Millisecond)
+	defer s.Close()
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 2}))
+	require.Eventually(t, func() bool { return childAttempts.Load() == 1 }, 5*time.Second, time.Millisecond)
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 1}))
+	require.NoError(t, s.Submit(&eth.ExecutionPayload{BlockNumber: 3}))
+
+	// the parked child does not hold up the parent, nor later payloads
+	for _, expected := range []uint64{1, 3, 2} {
+		select {
+		case num := <-delivered:
+			require.Equal(t, expected, num)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for hand-off")
+		}
+		if expected == 3 {
+			require.Equal(t, int32(1), childAttempts.Load(), "payloads were handed off before the child was screened again")
+		}
+	}
+	require.Eventually(t, func() bool { return len(s.slots) == 0 }, 5*time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,47 @@
+package node
+
+import (
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// screeningStatus tracks the outcome of the latest commitment screenings, for the sync status.
+type screeningStatus struct {
+	mu sync.RWMutex
+
+	mode          commitments.Mode
+	lastScreened  eth.BlockID
+	lastViolation eth.BlockID
+}
+
+func newScreeningStatus(mode commitments.Mode) *screeningStatus {
+	if mode == "" {
+		mode = commitments.ModeEnforce
+	}
+	return &screeningStatus{mode: mode}
+}
+
+func (s *screeningStatus) Mode() commitments.Mode {
+	return s.mode
+}
+
+// OnScreened records the screening outcome of the given block.
+func (s *screeningStatus) OnScreened(id eth.BlockID, satisfied bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastScreened = id
+	if !satisfied {
+		s.lastViolation = id
+	}
+}
+
+// FillSyncStatus adds the screening status to the given sync status.
+func (s *screeningStatus) FillSyncStatus(status *eth.SyncStatus) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status.ScreenedL2 = s.lastScreened
+	status.LastViolationL2 = s.lastViolation
+	status.ScreeningMode = s.mode.String()
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 )
 
@@ -25,11 +26,15 @@ type rpcServer struct {
 	appVersion string
 	listenAddr net.Addr
 	log        log.Logger
+	// jwtSecret authenticates requests to the server, if set
+	jwtSecret []byte
 	sources.L2Client
 }
 
-func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
+func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, screening screeningStatusReader, safeDB safeDBReader, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
 	api := NewNodeAPI(rollupCfg, l2Client, dr, log.New("rpc", "node"), m)
+	api.screening = screening
+	api.safeDB = safeDB
 	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
 	r := &rpcServer{
@@ -54,6 +59,14 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+func (s *rpcServer) EnableDebugAPI(api *debugAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "debug",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -73,7 +86,7 @@ func (s *rpcServer) Start() error {
 	// other services to connect to the opnode. VHosts in particular
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, s.jwtSecret)
 
 	mux := http.NewServeMux()
 	mux.Handle("/", nodeHandler)
@@ -94,6 +107,39 @@ func (s *rpcServer) Start() error {
 	return nil
 }
 
+// newConductorRPCServer creates the server of the conductor API, for the other replicas of the sequencer cluster.
+// The conductor API can change the leader of the cluster, and is thus served separately from the public rollup-node RPC,
+// and only to requests authenticated with the JWT secret shared by the cluster.
+func newConductorRPCServer(cfg *ConductorConfig, api *conductor.API, log log.Logger, appVersion string) *rpcServer {
+	return &rpcServer{
+		endpoint: net.JoinHostPort(cfg.ListenAddr, strconv.Itoa(cfg.ListenPort)),
+		apis: []rpc.API{{
+			Namespace:     conductor.NamespaceRPC,
+			Service:       api,
+			Authenticated: true,
+		}},
+		appVersion: appVersion,
+		log:        log,
+		jwtSecret:  cfg.JWTSecret[:],
+	}
+}
+
+// newAuthAdminRPCServer creates the server of the authenticated admin API. The authenticated admin API
+// can insert unsafe payloads, and is thus only served to requests authenticated with the admin JWT secret.
+func newAuthAdminRPCServer(cfg *RPCConfig, api *authAdminAPI, log log.Logger, appVersion string) *rpcServer {
+	return &rpcServer{
+		endpoint: net.JoinHostPort(cfg.AdminAuthListenAddr, strconv.Itoa(cfg.AdminAuthListenPort)),
+		apis: []rpc.API{{
+			Namespace:     "admin",
+			Service:       api,
+			Authenticated: true,
+		}},
+		appVersion: appVersion,
+		log:        log,
+		jwtSecret:  cfg.AdminJWTSecret[:],
+	}
+}
+
 func (r *rpcServer) Stop() {
 	_ = r.httpServer.Shutdown(context.Background())
 }
@@ -3,10 +3,13 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,12 +18,18 @@ import (
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-node/version"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -102,7 +111,7 @@ func TestOutputAtBlock(t *testing.T) {
 	status := randomSyncStatus(rand.New(rand.NewSource(123)))
 	drClient.ExpectBlockRefWithStatus(0xdcdc89, ref, status, nil)
 
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, nil, log, "0.0", metrics.NoopMetrics)
 	require.NoError(t, err)
 	require.NoError(t, server.Start())
 	defer server.Stop()
@@ -134,7 +143,7 @@ func TestVersion(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, nil, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer server.Stop()
@@ -148,6 +157,191 @@ func TestVersion(t *testing.T) {
 	assert.Equal(t, version.Version+"-"+version.Meta, out)
 }
 
+func TestSafeHeadAtL1Block(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	db, err := safedb.NewSafeDB(log, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	rng := rand.New(rand.NewSource(123))
+	l1Block := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 100}
+	safeHead := testutils.RandomL2BlockRef(rng)
+	require.NoError(t, db.SafeHeadUpdated(safeHead, l1Block))
+
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, db, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SafeHeadResponse
+	err = client.CallContext(context.Background(), &out, "optimism_safeHeadAtL1Block", hexutil.Uint64(105))
+	require.NoError(t, err)
+	require.Equal(t, l1Block, out.L1Block)
+	require.Equal(t, safeHead.ID(), out.SafeHead)
+
+	err = client.CallContext(context.Background(), &out, "optimism_safeHeadAtL1Block", hexutil.Uint64(99))
+	require.ErrorContains(t, err, safedb.ErrNotFound.Error())
+}
+
+type mockPayloadPoster struct {
+	mock.Mock
+}
+
+func (m *mockPayloadPoster) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) (eth.L2BlockRef, error) {
+	out := m.Mock.MethodCalled("PostUnsafePayload", payload, signature)
+	return out[0].(eth.L2BlockRef), *out[1].(*error)
+}
+
+func TestSetMaxDASize(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, nil, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var noErr error
+	drClient.Mock.On("SetMaxDASize", uint64(300), uint64(21_000)).Once().Return(&noErr)
+	err = client.CallContext(context.Background(), nil, "admin_setMaxDASize", hexutil.Uint64(300), hexutil.Uint64(21_000))
+	require.NoError(t, err)
+
+	seqErr := errors.New("sequencer is not enabled")
+	drClient.Mock.On("SetMaxDASize", uint64(0), uint64(0)).Once().Return(&seqErr)
+	err = client.CallContext(context.Background(), nil, "admin_setMaxDASize", hexutil.Uint64(0), hexutil.Uint64(0))
+	require.ErrorContains(t, err, seqErr.Error())
+	drClient.Mock.AssertExpectations(t)
+}
+
+func TestPostUnsafePayload(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr:          "localhost",
+		ListenPort:          0,
+		AdminAuthListenAddr: "localhost",
+		AdminAuthListenPort: 0,
+		AdminJWTSecret:      [32]byte{0x01},
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, nil, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+	poster := &mockPayloadPoster{}
+	authServer := newAuthAdminRPCServer(rpcCfg, NewAuthAdminAPI(poster, metrics.NoopMetrics), log, "0.0")
+	require.NoError(t, authServer.Start())
+	defer authServer.Stop()
+
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(rpcCfg.AdminJWTSecret))
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+authServer.Addr().String(), rpcclient.WithGethRPCOptions(auth), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	rng := rand.New(rand.NewSource(123))
+	payload := &eth.ExecutionPayload{
+		ParentHash:   testutils.RandomHash(rng),
+		BlockNumber:  42,
+		BlockHash:    testutils.RandomHash(rng),
+		Timestamp:    1234,
+		Transactions: []eth.Data{{0x01, 0x02}},
+	}
+	signature := hexutil.Bytes(testutils.RandomData(rng, 65))
+	unsafeHead := testutils.RandomL2BlockRef(rng)
+	var noErr error
+	poster.Mock.On("PostUnsafePayload", mock.MatchedBy(func(p *eth.ExecutionPayload) bool {
+		return p.BlockHash == payload.BlockHash
+	}), []byte(signature)).Once().Return(unsafeHead, &noErr)
+
+	var out eth.L2BlockRef
+	err = client.CallContext(context.Background(), &out, "admin_postUnsafePayload", payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, unsafeHead, out)
+	poster.Mock.AssertExpectations(t)
+
+	// unauthenticated requests, and requests to the public admin API, are rejected
+	unauthenticated, err := rpcclient.NewRPC(context.Background(), log, "http://"+authServer.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	require.Error(t, unauthenticated.CallContext(context.Background(), &out, "admin_postUnsafePayload", payload, signature))
+	public, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	require.Error(t, public.CallContext(context.Background(), &out, "admin_postUnsafePayload", payload, signature))
+	poster.Mock.AssertExpectations(t)
+}
+
+func TestDerivationDebug(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, nil, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableDebugAPI(NewDebugAPI(drClient, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	rng := rand.New(rand.NewSource(123))
+	info := &derive.PipelineDebugInfo{
+		Stages: []derive.StageDebugInfo{
+			{Name: derive.StageChannelBank, Origin: testutils.RandomBlockRef(rng), LastError: "test error", LastErrorTime: 1234},
+		},
+		Channels: []derive.ChannelInfo{
+			{ID: derive.ChannelID{1}, OpenBlock: testutils.RandomBlockRef(rng), FramesReceived: 2, Size: 400, TimeoutBlock: 300},
+		},
+		Batches: []derive.BatchInfo{
+			{Timestamp: 42, Parent: testutils.RandomHash(rng).Bytes(), EpochNum: 7, L1InclusionBlock: testutils.RandomBlockRef(rng), Blocks: 1, Validity: "future"},
+		},
+	}
+	drClient.Mock.On("DerivationDebugInfo").Return(info)
+	var out *derive.PipelineDebugInfo
+	require.NoError(t, client.CallContext(context.Background(), &out, "debug_derivation"))
+	require.Equal(t, info, out)
+
+	frames := &derive.ChannelFrames{
+		ID:     derive.ChannelID{1},
+		Frames: []derive.FrameInfo{{FrameNumber: 0, Raw: []byte{0x01, 0x02}}},
+	}
+	drClient.Mock.On("DerivationChannelFrames", derive.ChannelID{1}).Return(frames)
+	var outFrames *derive.ChannelFrames
+	require.NoError(t, client.CallContext(context.Background(), &outFrames, "debug_derivationChannel", derive.ChannelID{1}))
+	require.Equal(t, frames, outFrames)
+	drClient.Mock.AssertExpectations(t)
+}
+
 func randomSyncStatus(rng *rand.Rand) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		CurrentL1:          testutils.RandomBlockRef(rng),
@@ -171,6 +365,11 @@ func TestSyncStatus(t *testing.T) {
 	status := randomSyncStatus(rng)
 	drClient.On("SyncStatus").Return(status)
 
+	screened, violation := testutils.RandomBlockID(rng), testutils.RandomBlockID(rng)
+	screening := newScreeningStatus(commitments.ModeObserve)
+	screening.OnScreened(violation, false)
+	screening.OnScreened(screened, true)
+
 	rpcCfg := &RPCConfig{
 		ListenAddr: "localhost",
 		ListenPort: 0,
@@ -178,7 +377,7 @@ func TestSyncStatus(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, screening, nil, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer server.Stop()
@@ -189,7 +388,13 @@ func TestSyncStatus(t *testing.T) {
 	var out *eth.SyncStatus
 	err = client.CallContext(context.Background(), &out, "optimism_syncStatus")
 	assert.NoError(t, err)
-	assert.Equal(t, status, out)
+	expected := *status
+	expected.ScreenedL2 = screened
+	expected.LastViolationL2 = violation
+	expected.ScreeningMode = "observe"
+	assert.Equal(t, &expected, out)
+	assert.Equal(t, eth.BlockID{}, status.ScreenedL2, "status of the driver is not modified")
+	assert.Empty(t, status.ScreeningMode, "status of the driver is not modified")
 }
 
 type mockDriverClient struct {
@@ -224,3 +429,47 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
+
+func (c *mockDriverClient) SetMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) error {
+	return *c.Mock.MethodCalled("SetMaxDASize", maxTxSize, maxBlockSize).Get(0).(*error)
+}
+
+func (c *mockDriverClient) DerivationDebugInfo(ctx context.Context) (*derive.PipelineDebugInfo, error) {
+	return c.Mock.MethodCalled("DerivationDebugInfo").Get(0).(*derive.PipelineDebugInfo), nil
+}
+
+func (c *mockDriverClient) DerivationChannelFrames(ctx context.Context, id derive.ChannelID) (*derive.ChannelFrames, error) {
+	return c.Mock.MethodCalled("DerivationChannelFrames", id).Get(0).(*derive.ChannelFrames), nil
+}
+
+func TestConductorRPCServerAuth(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	cluster, err := conductor.NewClusterConductor(log, conductor.Config{
+		ServerID:          "seq-0",
+		HeartbeatInterval: time.Second,
+		ElectionTimeout:   2 * time.Second,
+		RequestTimeout:    time.Second,
+	})
+	require.NoError(t, err)
+	cfg := &ConductorConfig{ListenAddr: "localhost", ListenPort: 0, JWTSecret: [32]byte{0x01}}
+	server := newConductorRPCServer(cfg, conductor.NewAPI(cluster), log, "0.0")
+	require.NoError(t, server.Start())
+	defer server.Stop()
+	addr := "http://" + server.Addr().String()
+
+	unauthenticated, err := rpcclient.NewRPC(context.Background(), log, addr, rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	var leaderID string
+	require.Error(t, unauthenticated.CallContext(context.Background(), &leaderID, "conductor_leaderID"))
+
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.JWTSecret))
+	authenticated, err := rpcclient.NewRPC(context.Background(), log, addr, rpcclient.WithGethRPCOptions(auth), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	var leader bool
+	require.NoError(t, authenticated.CallContext(context.Background(), &leader, "conductor_leader"))
+	require.False(t, leader)
+
+	var version string
+	require.Error(t, authenticated.CallContext(context.Background(), &version, "optimism_version"),
+		"only the conductor API is served to the replicas")
+}
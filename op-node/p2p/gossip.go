@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/golang/snappy"
+	"github.com/hashicorp/go-multierror"
 	lru "github.com/hashicorp/golang-lru/v2"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
@@ -69,10 +70,37 @@ func blocksTopicV1(cfg *rollup.Config) string {
 	return fmt.Sprintf("/optimism/%s/0/blocks", cfg.L2ChainID.String())
 }
 
+func blocksTopicV2(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/1/blocks", cfg.L2ChainID.String())
+}
+
+// blocksTopicVersion returns the version of the blocks topic that a payload with the given timestamp is gossiped on.
+// The v1 topic carries the pre-Canyon V1 payloads.
+// The v2 topic carries all payloads from Canyon onwards: the SSZ encoding of V2 and later payloads is self-describing,
+// so later payload versions do not need another topic.
+func blocksTopicVersion(cfg *rollup.Config, timestamp uint64) eth.BlockVersion {
+	if cfg.IsActive(rollup.Canyon, timestamp) {
+		return eth.BlockV2
+	}
+	return eth.BlockV1
+}
+
+// blocksTopics returns the names of the blocks topics, by topic version.
+// Nodes participate in the v2 topic only when Canyon is scheduled,
+// and then stay subscribed to both topics, to not miss any blocks around the transition.
+func blocksTopics(cfg *rollup.Config) map[eth.BlockVersion]string {
+	topics := map[eth.BlockVersion]string{eth.BlockV1: blocksTopicV1(cfg)}
+	if cfg.ActivationTime(rollup.Canyon) != nil {
+		topics[eth.BlockV2] = blocksTopicV2(cfg)
+	}
+	return topics
+}
+
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg)) // add more topics here in the future, if any.
+	// add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg))
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -238,7 +266,8 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
-func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig) pubsub.ValidatorEx {
+// BuildBlocksValidator builds the validator of the blocks topic of the given topic version.
+func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, topicVersion eth.BlockVersion) pubsub.ValidatorEx {
 
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
@@ -303,6 +332,18 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			return pubsub.ValidationReject
 		}
 
+		// [REJECT] if the payload is not gossiped on the topic of the network upgrades active at the `payload.timestamp`
+		if expected := blocksTopicVersion(cfg, uint64(payload.Timestamp)); expected != topicVersion {
+			log.Warn("payload gossiped on wrong topic", "timestamp", uint64(payload.Timestamp), "topic", topicVersion, "expected", expected, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the payload format does not match the network upgrades active at the `payload.timestamp`
+		if err := payload.CheckVersion(cfg.PayloadVersion(uint64(payload.Timestamp))); err != nil {
+			log.Warn("payload has invalid version", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
 		// [REJECT] if the `block_hash` in the `payload` is not valid
 		if actual, ok := payload.CheckBlockHash(); !ok {
 			log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
@@ -379,19 +420,37 @@ type GossipOut interface {
 }
 
 type publisher struct {
-	log         log.Logger
-	cfg         *rollup.Config
-	blocksTopic *pubsub.Topic
-	runCfg      GossipRuntimeConfig
+	log          log.Logger
+	cfg          *rollup.Config
+	blocksTopics map[eth.BlockVersion]*pubsub.Topic
+	runCfg       GossipRuntimeConfig
 }
 
 var _ GossipOut = (*publisher)(nil)
 
+// BlocksTopicPeers returns the peers of all the blocks topics, without duplicates.
 func (p *publisher) BlocksTopicPeers() []peer.ID {
-	return p.blocksTopic.ListPeers()
+	seen := make(map[peer.ID]struct{})
+	var out []peer.ID
+	for _, topic := range p.blocksTopics {
+		for _, id := range topic.ListPeers() {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			out = append(out, id)
+		}
+	}
+	return out
 }
 
 func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload, signer Signer) error {
+	topicVersion := blocksTopicVersion(p.cfg, uint64(payload.Timestamp))
+	topic, ok := p.blocksTopics[topicVersion]
+	if !ok {
+		return fmt.Errorf("not joined to the blocks topic %s of payload with timestamp %d", topicVersion, uint64(payload.Timestamp))
+	}
+
 	res := msgBufPool.Get().(*[]byte)
 	buf := bytes.NewBuffer((*res)[:0])
 	defer func() {
@@ -415,42 +474,61 @@ func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.Execution
 	// This also copies the data, freeing up the original buffer to go back into the pool
 	out := snappy.Encode(nil, data)
 
-	return p.blocksTopic.Publish(ctx, out)
+	return topic.Publish(ctx, out)
 }
 
 func (p *publisher) Close() error {
-	return p.blocksTopic.Close()
+	var result *multierror.Error
+	for version, topic := range p.blocksTopics {
+		if err := topic.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close blocks topic %s: %w", version, err))
+		}
+	}
+	return result.ErrorOrNil()
 }
 
 func JoinGossip(p2pCtx context.Context, self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
-	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg)))
-	blocksTopicName := blocksTopicV1(cfg)
+	p := &publisher{log: log, cfg: cfg, blocksTopics: make(map[eth.BlockVersion]*pubsub.Topic), runCfg: runCfg}
+	for version, name := range blocksTopics(cfg) {
+		topic, err := joinBlocksTopic(p2pCtx, self, ps, log, cfg, runCfg, gossipIn, version, name)
+		if err != nil {
+			return nil, err
+		}
+		p.blocksTopics[version] = topic
+	}
+	return p, nil
+}
+
+func joinBlocksTopic(p2pCtx context.Context, self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config,
+	runCfg GossipRuntimeConfig, gossipIn GossipIn, version eth.BlockVersion, blocksTopicName string) (*pubsub.Topic, error) {
+	log = log.New("topic_version", version)
+	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg, version)))
 	err := ps.RegisterTopicValidator(blocksTopicName,
 		val,
 		pubsub.WithValidatorTimeout(3*time.Second),
 		pubsub.WithValidatorConcurrency(4))
 	if err != nil {
-		return nil, fmt.Errorf("failed to register blocks gossip topic: %w", err)
+		return nil, fmt.Errorf("failed to register blocks gossip topic %s: %w", version, err)
 	}
 	blocksTopic, err := ps.Join(blocksTopicName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to join blocks gossip topic: %w", err)
+		return nil, fmt.Errorf("failed to join blocks gossip topic %s: %w", version, err)
 	}
 	blocksTopicEvents, err := blocksTopic.EventHandler()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create blocks gossip topic handler: %w", err)
+		return nil, fmt.Errorf("failed to create blocks gossip topic %s handler: %w", version, err)
 	}
 	go LogTopicEvents(p2pCtx, log.New("topic", "blocks"), blocksTopicEvents)
 
 	subscription, err := blocksTopic.Subscribe()
 	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
+		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic %s: %w", version, err)
 	}
 
 	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
 	go subscriber(p2pCtx, subscription)
 
-	return &publisher{log: log, cfg: cfg, blocksTopic: blocksTopic, runCfg: runCfg}, nil
+	return blocksTopic, nil
 }
 
 type TopicSubscriber func(ctx context.Context, sub *pubsub.Subscription)
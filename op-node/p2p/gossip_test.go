@@ -1,18 +1,24 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/snappy"
 
 	"github.com/ethereum/go-ethereum/log"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
@@ -84,3 +90,69 @@ func TestVerifyBlockSignature(t *testing.T) {
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
 }
+
+func TestBlocksTopicVersion(t *testing.T) {
+	canyonTime := uint64(1000)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100), CanyonTime: &canyonTime}
+	require.Equal(t, eth.BlockV1, blocksTopicVersion(cfg, canyonTime-1))
+	require.Equal(t, eth.BlockV2, blocksTopicVersion(cfg, canyonTime))
+	require.Equal(t, map[eth.BlockVersion]string{
+		eth.BlockV1: "/optimism/100/0/blocks",
+		eth.BlockV2: "/optimism/100/1/blocks",
+	}, blocksTopics(cfg))
+
+	cfg.CanyonTime = nil
+	require.Equal(t, eth.BlockV1, blocksTopicVersion(cfg, canyonTime))
+	require.Equal(t, map[eth.BlockVersion]string{eth.BlockV1: "/optimism/100/0/blocks"}, blocksTopics(cfg))
+}
+
+func TestBlocksValidatorTopicVersions(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	now := uint64(time.Now().Unix())
+	canyonTime := now - 10
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100), CanyonTime: &canyonTime}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
+	signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+
+	newMessage := func(t *testing.T, timestamp uint64, withdrawals *types.Withdrawals) *pubsub.Message {
+		payload := &eth.ExecutionPayload{
+			BlockNumber:  eth.Uint64Quantity(timestamp),
+			Timestamp:    eth.Uint64Quantity(timestamp),
+			Transactions: []eth.Data{},
+			Withdrawals:  withdrawals,
+		}
+		payload.BlockHash, _ = payload.CheckBlockHash()
+		var buf bytes.Buffer
+		_, err := payload.MarshalSSZ(&buf)
+		require.NoError(t, err)
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, buf.Bytes())
+		require.NoError(t, err)
+		data := snappy.Encode(nil, append(sig[:], buf.Bytes()...))
+		return &pubsub.Message{Message: &pb.Message{Data: data}}
+	}
+
+	testCases := []struct {
+		name        string
+		topic       eth.BlockVersion
+		timestamp   uint64
+		withdrawals *types.Withdrawals
+		expected    pubsub.ValidationResult
+	}{
+		{"v1 payload on v1 topic", eth.BlockV1, canyonTime - 2, nil, pubsub.ValidationAccept},
+		{"v1 payload on v2 topic", eth.BlockV2, canyonTime - 2, nil, pubsub.ValidationReject},
+		{"v2 payload on v2 topic", eth.BlockV2, canyonTime, &types.Withdrawals{}, pubsub.ValidationAccept},
+		{"v2 payload on v1 topic", eth.BlockV1, canyonTime, &types.Withdrawals{}, pubsub.ValidationReject},
+		{"v1 payload after canyon", eth.BlockV2, canyonTime, nil, pubsub.ValidationReject},
+		{"v2 payload before canyon", eth.BlockV1, canyonTime - 2, &types.Withdrawals{}, pubsub.ValidationReject},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			val := BuildBlocksValidator(logger, cfg, runCfg, tc.topic)
+			msg := newMessage(t, tc.timestamp, tc.withdrawals)
+			require.Equal(t, tc.expected, val(context.Background(), "alice", msg))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"math"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -61,7 +62,7 @@ func NewScorer(cfg *rollup.Config, peerStore Peerstore, metricer ScoreMetrics, a
 // The returned [pubsub.ExtendedPeerScoreInspectFn] is called with a mapping of peer IDs to peer score snapshots.
 // The incoming peer score snapshots only contain gossip-score components.
 func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
-	blocksTopicName := blocksTopicV1(s.cfg)
+	blocksTopicNames := blocksTopics(s.cfg)
 	return func(m map[peer.ID]*pubsub.PeerScoreSnapshot) {
 		allScores := make([]store.PeerScores, 0, len(m))
 		// Now set the new scores.
@@ -72,11 +73,15 @@ func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
 				IPColocationFactor: snap.IPColocationFactor,
 				BehavioralPenalty:  snap.BehaviourPenalty,
 			}
-			if topSnap, ok := snap.Topics[blocksTopicName]; ok {
-				diff.Blocks.TimeInMesh = float64(topSnap.TimeInMesh) / float64(time.Second)
-				diff.Blocks.MeshMessageDeliveries = topSnap.MeshMessageDeliveries
-				diff.Blocks.FirstMessageDeliveries = topSnap.FirstMessageDeliveries
-				diff.Blocks.InvalidMessageDeliveries = topSnap.InvalidMessageDeliveries
+			// The blocks scores are aggregated over the versions of the blocks topic:
+			// the deliveries add up, and the time in mesh is that of the longest membership.
+			for _, blocksTopicName := range blocksTopicNames {
+				if topSnap, ok := snap.Topics[blocksTopicName]; ok {
+					diff.Blocks.TimeInMesh = math.Max(diff.Blocks.TimeInMesh, float64(topSnap.TimeInMesh)/float64(time.Second))
+					diff.Blocks.MeshMessageDeliveries += topSnap.MeshMessageDeliveries
+					diff.Blocks.FirstMessageDeliveries += topSnap.FirstMessageDeliveries
+					diff.Blocks.InvalidMessageDeliveries += topSnap.InvalidMessageDeliveries
+				}
 			}
 			if peerScores, err := s.peerStore.SetScore(id, &diff); err != nil {
 				s.log.Warn("Unable to update peer gossip score", "err", err)
@@ -1,9 +1,11 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 var SigningDomainBlocksV1 = [32]byte{}
@@ -39,6 +42,40 @@ func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, err
 	return SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
 }
 
+// SignBlockPayload signs the payload like it is signed when published on p2p,
+// for payloads that are shared outside of gossip.
+func SignBlockPayload(ctx context.Context, cfg *rollup.Config, signer Signer, payload *eth.ExecutionPayload) (*[65]byte, error) {
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return nil, fmt.Errorf("failed to encode execution payload to sign: %w", err)
+	}
+	return signer.Sign(ctx, SigningDomainBlocksV1, cfg.L2ChainID, buf.Bytes())
+}
+
+// VerifyBlockPayloadSignature returns an error if the payload was not signed by the expected signer,
+// like SignBlockPayload signs it.
+func VerifyBlockPayloadSignature(cfg *rollup.Config, expected common.Address, payload *eth.ExecutionPayload, signature []byte) error {
+	if expected == (common.Address{}) {
+		return errors.New("no configured p2p sequencer address")
+	}
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return fmt.Errorf("failed to encode execution payload to verify: %w", err)
+	}
+	signingHash, err := BlockSigningHash(cfg, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to compute block signing hash: %w", err)
+	}
+	pub, err := crypto.SigToPub(signingHash[:], signature)
+	if err != nil {
+		return fmt.Errorf("invalid block signature: %w", err)
+	}
+	if addr := crypto.PubkeyToAddress(*pub); addr != expected {
+		return fmt.Errorf("unexpected block author %s, expected %s", addr, expected)
+	}
+	return nil
+}
+
 // LocalSigner is suitable for testing
 type LocalSigner struct {
 	priv   *ecdsa.PrivateKey
@@ -1,11 +1,16 @@
 package p2p
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
-	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 func TestSigningHash_DifferentDomain(t *testing.T) {
@@ -65,3 +70,24 @@ func TestSigningHash_LimitChainID(t *testing.T) {
 	_, err := SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, []byte("arbitraryData"))
 	require.ErrorContains(t, err, "chain_id is too large")
 }
+
+func TestSignBlockPayload(t *testing.T) {
+	cfg := &rollup.Config{
+		L2ChainID: big.NewInt(100),
+	}
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signerAddr := crypto.PubkeyToAddress(priv.PublicKey)
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0x0a}, Transactions: []eth.Data{}}
+
+	sig, err := SignBlockPayload(context.Background(), cfg, NewLocalSigner(priv), payload)
+	require.NoError(t, err)
+	require.NoError(t, VerifyBlockPayloadSignature(cfg, signerAddr, payload, sig[:]))
+
+	require.ErrorContains(t, VerifyBlockPayloadSignature(cfg, common.Address{0x01}, payload, sig[:]), "unexpected block author")
+	require.Error(t, VerifyBlockPayloadSignature(cfg, common.Address{}, payload, sig[:]), "no sequencer address")
+	require.Error(t, VerifyBlockPayloadSignature(cfg, signerAddr, payload, nil), "missing signature")
+	other := *payload
+	other.BlockNumber = 11
+	require.Error(t, VerifyBlockPayloadSignature(cfg, signerAddr, &other, sig[:]), "signature of another payload")
+}
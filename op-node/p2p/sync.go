@@ -608,7 +608,7 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 		return fmt.Errorf("failed to read version part of response: %w", err)
 	}
 	version := binary.LittleEndian.Uint32(versionData[:])
-	if version != 0 {
+	if version > uint32(eth.BlockV3) {
 		return fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
 	}
 	// payload is SSZ encoded with Snappy framed compression
@@ -624,10 +624,13 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 	if err := res.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
+	if actual := res.Version(); actual != eth.BlockVersion(version) {
+		return fmt.Errorf("decoded ExecutionPayload version %s does not match response version %d", actual, version)
+	}
 	if err := str.CloseRead(); err != nil {
 		return fmt.Errorf("failed to close reading side")
 	}
-	if err := verifyBlock(&res, n); err != nil {
+	if err := verifyBlock(s.cfg, &res, n); err != nil {
 		return fmt.Errorf("received execution payload is invalid: %w", err)
 	}
 	select {
@@ -638,11 +641,14 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 	return nil
 }
 
-func verifyBlock(payload *eth.ExecutionPayload, expectedNum uint64) error {
+func verifyBlock(cfg *rollup.Config, payload *eth.ExecutionPayload, expectedNum uint64) error {
 	// verify L2 block
 	if expectedNum != uint64(payload.BlockNumber) {
 		return fmt.Errorf("received execution payload for block %d, but expected block %d", payload.BlockNumber, expectedNum)
 	}
+	if err := payload.CheckVersion(cfg.PayloadVersion(uint64(payload.Timestamp))); err != nil {
+		return fmt.Errorf("received execution payload for block %d with invalid version: %w", expectedNum, err)
+	}
 	actual, ok := payload.CheckBlockHash()
 	if !ok { // payload itself contains bad block hash
 		return fmt.Errorf("received execution payload for block %d with bad block hash %s, expected %s", expectedNum, payload.BlockHash, actual)
@@ -796,8 +802,9 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
 
 	// 0 - resultCode: success = 0
-	// 1:5 - version: 0
+	// 1:5 - version: the payload version, 0 for V1, 1 for V2, 2 for V3
 	var tmp [5]byte
+	binary.LittleEndian.PutUint32(tmp[1:], uint32(payload.Version()))
 	if _, err := stream.Write(tmp[:]); err != nil {
 		return req, fmt.Errorf("failed to write response header data: %w", err)
 	}
@@ -0,0 +1,37 @@
+package conductor
+
+import (
+	"context"
+)
+
+// NamespaceRPC is the RPC namespace the conductor API is served on.
+const NamespaceRPC = "conductor"
+
+// API serves the requests of the other replicas in the cluster, and the leadership status of the replica.
+type API struct {
+	c *ClusterConductor
+}
+
+func NewAPI(c *ClusterConductor) *API {
+	return &API{c: c}
+}
+
+func (api *API) RequestVote(ctx context.Context, req *VoteRequest) (*VoteResponse, error) {
+	return api.c.RequestVote(ctx, req)
+}
+
+func (api *API) AppendPayload(ctx context.Context, req *AppendRequest) (*AppendResponse, error) {
+	return api.c.AppendPayload(ctx, req)
+}
+
+func (api *API) Leader(ctx context.Context) (bool, error) {
+	return api.c.Leader(ctx), nil
+}
+
+func (api *API) LeaderID(_ context.Context) (string, error) {
+	return api.c.LeaderID(), nil
+}
+
+func (api *API) TransferLeadership(ctx context.Context) error {
+	return api.c.TransferLeadership(ctx)
+}
@@ -0,0 +1,678 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotLeader = errors.New("not the leader sequencer")
+	ErrNoQuorum  = errors.New("not enough sequencer replicas reached")
+	// ErrUnknownPeer is returned for requests on behalf of a server that is not a replica of the cluster.
+	ErrUnknownPeer = errors.New("unknown sequencer replica")
+)
+
+// VoteRequest is sent by a candidate to ask for the vote of the other replicas.
+type VoteRequest struct {
+	Term      uint64 `json:"term"`
+	Candidate string `json:"candidate"`
+	// LastPayload is the latest unsafe payload the candidate committed or received.
+	// Replicas only vote for candidates that are ahead of them, or at the same payload,
+	// so the new leader has all committed payloads.
+	LastPayload eth.BlockID `json:"lastPayload"`
+}
+
+type VoteResponse struct {
+	Term    uint64 `json:"term"`
+	Granted bool   `json:"granted"`
+}
+
+// AppendRequest is sent by the leader to replicate an unsafe payload.
+// Requests without payload are heartbeats, which maintain the leadership.
+type AppendRequest struct {
+	Term    uint64                `json:"term"`
+	Leader  string                `json:"leader"`
+	Payload *eth.ExecutionPayload `json:"payload,omitempty"`
+	// Signature is the signature of the payload by the sequencer, like payloads are signed for gossip.
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+	// Committed is the latest payload the leader replicated to a majority of the replicas.
+	// Replicas only process replicated payloads once they are committed.
+	Committed eth.BlockID `json:"committed"`
+}
+
+type AppendResponse struct {
+	Term    uint64 `json:"term"`
+	Success bool   `json:"success"`
+}
+
+// Peer is another sequencer replica of the cluster.
+type Peer interface {
+	RequestVote(ctx context.Context, req *VoteRequest) (*VoteResponse, error)
+	AppendPayload(ctx context.Context, req *AppendRequest) (*AppendResponse, error)
+}
+
+// HealthCheck returns an error if the local sequencer is not healthy enough to lead the cluster.
+type HealthCheck func(ctx context.Context) error
+
+// PayloadSigner signs an unsafe payload before the leader replicates it.
+type PayloadSigner func(ctx context.Context, payload *eth.ExecutionPayload) ([]byte, error)
+
+// PayloadHandler processes an unsafe payload that was replicated and committed by the leader, along with its signature.
+type PayloadHandler func(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) error
+
+type Config struct {
+	// ServerID uniquely identifies the replica within the cluster.
+	ServerID string
+	// Peers are the other replicas of the cluster, by server ID.
+	Peers map[string]Peer
+
+	// HeartbeatInterval is the interval at which the leader asserts its leadership, and checks its health.
+	HeartbeatInterval time.Duration
+	// ElectionTimeout is the minimum time without leader heartbeat before a replica runs for leader.
+	// The actual timeout is randomized between the ElectionTimeout and twice the ElectionTimeout.
+	ElectionTimeout time.Duration
+	// RequestTimeout is the timeout of the requests to the peers.
+	RequestTimeout time.Duration
+
+	// HealthCheck is optional. Unhealthy leaders step down, and unhealthy replicas do not run for leader.
+	HealthCheck HealthCheck
+	// SignPayload is optional, and signs the unsafe payloads replicated by this replica when it leads the cluster.
+	SignPayload PayloadSigner
+	// OnPayload is optional, and receives the unsafe payloads replicated by the leader, in order,
+	// once the leader committed them to a majority of the replicas.
+	OnPayload PayloadHandler
+	// Store is optional, and persists the term, vote and last payload of the replica before it answers
+	// any request. Without it the state is kept in memory only, and a restarted replica may vote twice in a term.
+	Store StateStore
+}
+
+func (c *Config) Check() error {
+	if c.ServerID == "" {
+		return errors.New("missing server ID")
+	}
+	if _, ok := c.Peers[c.ServerID]; ok {
+		return fmt.Errorf("server %q cannot be its own peer", c.ServerID)
+	}
+	if c.HeartbeatInterval <= 0 {
+		return errors.New("heartbeat interval must be positive")
+	}
+	if c.ElectionTimeout <= c.HeartbeatInterval {
+		return fmt.Errorf("election timeout %s must be larger than the heartbeat interval %s", c.ElectionTimeout, c.HeartbeatInterval)
+	}
+	if c.RequestTimeout <= 0 {
+		return errors.New("request timeout must be positive")
+	}
+	return nil
+}
+
+type role uint8
+
+// maxPendingPayloads bounds the number of replicated payloads that are retained until they are committed.
+const maxPendingPayloads = 64
+
+const (
+	follower role = iota
+	candidate
+	leader
+)
+
+func (r role) String() string {
+	switch r {
+	case follower:
+		return "follower"
+	case candidate:
+		return "candidate"
+	case leader:
+		return "leader"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(r))
+	}
+}
+
+// ClusterConductor elects a leader among a cluster of sequencer replicas, in terms, like Raft:
+// a replica that misses the heartbeats of the leader runs for leader in a new term, and becomes
+// leader when a majority of the replicas votes for it. The leader replicates every unsafe payload
+// to a majority of the replicas before it is published, and steps down when it is unhealthy,
+// or when it cannot reach a majority of the replicas anymore.
+// Replicated payloads are only processed by the followers once the leader announces them as committed,
+// so payloads that did not reach a majority of the replicas are never processed.
+type ClusterConductor struct {
+	log log.Logger
+	cfg Config
+
+	mu sync.Mutex
+
+	role     role
+	term     uint64
+	votedFor string // the candidate voted for in the current term, if any
+	leaderID string // the leader of the current term, if known
+
+	lastPayload eth.BlockID // latest committed or replicated unsafe payload
+	committed   eth.BlockID // latest payload known to be committed to a majority of the replicas
+
+	pending map[uint64]replicatedPayload // replicated payloads that are not committed yet, by block number
+	ready   []replicatedPayload          // committed payloads that are yet to be processed, in order
+	applied chan struct{}                // signalled when payloads are ready to be processed
+	commits chan struct{}                // signalled when the leader committed a payload
+
+	store     StateStore
+	persisted PersistentState // the state as last stored
+
+	lastContact     time.Time     // last contact with the leader, or vote granted
+	electionTimeout time.Duration // randomized election timeout
+	rng             *rand.Rand
+
+	leaderUpdates chan struct{}
+
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+var _ SequencerConductor = (*ClusterConductor)(nil)
+
+func NewClusterConductor(log log.Logger, cfg Config) (*ClusterConductor, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid conductor config: %w", err)
+	}
+	store := cfg.Store
+	if store == nil {
+		store = new(memoryStateStore)
+	}
+	state, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conductor state: %w", err)
+	}
+	c := &ClusterConductor{
+		log:           log,
+		cfg:           cfg,
+		term:          state.Term,
+		votedFor:      state.VotedFor,
+		lastPayload:   state.LastPayload,
+		store:         store,
+		persisted:     state,
+		pending:       make(map[uint64]replicatedPayload),
+		applied:       make(chan struct{}, 1),
+		commits:       make(chan struct{}, 1),
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		leaderUpdates: make(chan struct{}, 1),
+		closing:       make(chan struct{}),
+	}
+	if state != (PersistentState{}) {
+		log.Info("Loaded conductor state", "term", state.Term, "voted_for", state.VotedFor, "last_payload", state.LastPayload)
+	}
+	c.resetElectionTimerLocked()
+	return c, nil
+}
+
+// replicatedPayload is an unsafe payload replicated by the leader, along with its signature.
+type replicatedPayload struct {
+	payload   *eth.ExecutionPayload
+	signature []byte
+}
+
+// Start starts participating in the leader elections of the cluster.
+func (c *ClusterConductor) Start() {
+	c.wg.Add(2)
+	go c.loop()
+	go c.applyLoop()
+}
+
+// Close stops participating in the cluster, and steps down if this replica is the leader.
+func (c *ClusterConductor) Close() error {
+	close(c.closing)
+	c.wg.Wait()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.role == leader {
+		c.becomeFollowerLocked(c.term)
+	}
+	return nil
+}
+
+func (c *ClusterConductor) Leader(ctx context.Context) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.role == leader
+}
+
+// LeaderID returns the server ID of the current leader, or an empty string if the leader is not known.
+func (c *ClusterConductor) LeaderID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.leaderID
+}
+
+// LeaderUpdates is signalled whenever this replica gains or loses the leadership.
+func (c *ClusterConductor) LeaderUpdates() <-chan struct{} {
+	return c.leaderUpdates
+}
+
+// TransferLeadership makes the leader step down, and sit out the next election,
+// so another replica takes over the leadership.
+func (c *ClusterConductor) TransferLeadership(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.role != leader {
+		return ErrNotLeader
+	}
+	c.log.Info("Transferring sequencer leadership", "term", c.term)
+	c.becomeFollowerLocked(c.term)
+	c.electionTimeout *= 2
+	return nil
+}
+
+// CommitUnsafePayload replicates the payload to the other replicas,
+// and fails if the payload did not reach a majority of the cluster.
+func (c *ClusterConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	c.mu.Lock()
+	if c.role != leader {
+		c.mu.Unlock()
+		return ErrNotLeader
+	}
+	req := &AppendRequest{Term: c.term, Leader: c.cfg.ServerID, Payload: payload, Committed: c.committed}
+	if err := c.persistLocked(); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	c.mu.Unlock()
+
+	if c.cfg.SignPayload != nil {
+		sig, err := c.cfg.SignPayload(ctx, payload)
+		if err != nil {
+			return fmt.Errorf("failed to sign payload %s: %w", payload.ID(), err)
+		}
+		req.Signature = sig
+	}
+
+	acks := 1 + c.broadcast(ctx, func(ctx context.Context, peer Peer) (uint64, bool, error) {
+		resp, err := peer.AppendPayload(ctx, req)
+		if err != nil {
+			return 0, false, err
+		}
+		return resp.Term, resp.Success, nil
+	})
+	if acks < c.quorum() {
+		return fmt.Errorf("%w: payload %s replicated to %d of %d replicas", ErrNoQuorum, payload.ID(), acks, len(c.cfg.Peers)+1)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.term != req.Term {
+		return fmt.Errorf("%w: lost leadership of term %d while committing payload %s", ErrNotLeader, req.Term, payload.ID())
+	}
+	c.lastPayload = payload.ID()
+	c.committed = payload.ID()
+	// announce the commit to the followers right away, rather than with the next heartbeat
+	select {
+	case c.commits <- struct{}{}:
+	default:
+	}
+	return c.persistLocked()
+}
+
+// RequestVote handles the vote request of a candidate.
+func (c *ClusterConductor) RequestVote(ctx context.Context, req *VoteRequest) (*VoteResponse, error) {
+	if _, ok := c.cfg.Peers[req.Candidate]; !ok {
+		return nil, fmt.Errorf("%w: candidate %q", ErrUnknownPeer, req.Candidate)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if req.Term > c.term {
+		c.becomeFollowerLocked(req.Term)
+	}
+	granted := false
+	if req.Term == c.term && (c.votedFor == "" || c.votedFor == req.Candidate) && c.upToDateLocked(req.LastPayload) {
+		c.votedFor = req.Candidate
+		c.lastContact = time.Now()
+		granted = true
+	}
+	if err := c.persistLocked(); err != nil {
+		return nil, err
+	}
+	c.log.Debug("Handled vote request", "term", req.Term, "candidate", req.Candidate, "granted", granted)
+	return &VoteResponse{Term: c.term, Granted: granted}, nil
+}
+
+// upToDateLocked returns true if the last payload of a candidate is ahead of the last payload of this replica,
+// or is the same payload. A candidate with a different payload at the same height is not up to date.
+func (c *ClusterConductor) upToDateLocked(last eth.BlockID) bool {
+	if last.Number != c.lastPayload.Number {
+		return last.Number > c.lastPayload.Number
+	}
+	return last.Hash == c.lastPayload.Hash
+}
+
+// AppendPayload handles the heartbeat, or replicated payload, of the leader.
+// Replicated payloads are acknowledged once they are retained, and are processed once they are committed.
+func (c *ClusterConductor) AppendPayload(ctx context.Context, req *AppendRequest) (*AppendResponse, error) {
+	// Only replicas of the cluster can lead it: the term of an unknown leader is not adopted.
+	if _, ok := c.cfg.Peers[req.Leader]; !ok {
+		return nil, fmt.Errorf("%w: leader %q", ErrUnknownPeer, req.Leader)
+	}
+	c.mu.Lock()
+	if req.Term < c.term {
+		defer c.mu.Unlock()
+		if err := c.persistLocked(); err != nil {
+			return nil, err
+		}
+		return &AppendResponse{Term: c.term, Success: false}, nil
+	}
+	if req.Term > c.term || c.role != follower {
+		c.becomeFollowerLocked(req.Term)
+	}
+	if c.leaderID != req.Leader {
+		c.log.Info("Following new sequencer leader", "leader", req.Leader, "term", req.Term)
+		c.leaderID = req.Leader
+	}
+	c.lastContact = time.Now()
+	defer c.mu.Unlock()
+
+	if req.Payload != nil {
+		c.retainLocked(replicatedPayload{payload: req.Payload, signature: req.Signature})
+		if req.Payload.ID().Number >= c.lastPayload.Number {
+			c.lastPayload = req.Payload.ID()
+		}
+	}
+	if err := c.persistLocked(); err != nil {
+		return nil, err
+	}
+	c.commitLocked(req.Committed)
+	return &AppendResponse{Term: c.term, Success: true}, nil
+}
+
+// retainLocked retains a replicated payload until it is committed.
+// A payload replaces any earlier payload at the same height, which a previous leader failed to commit.
+func (c *ClusterConductor) retainLocked(p replicatedPayload) {
+	num := uint64(p.payload.BlockNumber)
+	if num <= c.committed.Number {
+		return // already committed, or conflicting with a committed payload
+	}
+	c.pending[num] = p
+	for len(c.pending) > maxPendingPayloads {
+		oldest := num
+		for n := range c.pending {
+			if n < oldest {
+				oldest = n
+			}
+		}
+		c.log.Warn("Dropping uncommitted replicated payload", "id", c.pending[oldest].payload.ID())
+		delete(c.pending, oldest)
+	}
+}
+
+// commitLocked marks the retained payloads up to and including the committed payload as ready to be processed.
+// Only the retained payloads that the committed payload builds on are processed, other retained payloads
+// at or below the committed height were not committed, and are dropped.
+func (c *ClusterConductor) commitLocked(committed eth.BlockID) {
+	if committed.Number <= c.committed.Number {
+		return
+	}
+	var chain []replicatedPayload
+	for next := committed; ; {
+		p, ok := c.pending[next.Number]
+		if !ok || p.payload.BlockHash != next.Hash {
+			break
+		}
+		chain = append(chain, p)
+		next = eth.BlockID{Hash: p.payload.ParentHash, Number: next.Number - 1}
+	}
+	if len(chain) == 0 {
+		c.log.Debug("Committed payload was not replicated to this replica", "committed", committed)
+	}
+	for n := range c.pending {
+		if n <= committed.Number {
+			delete(c.pending, n)
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		c.ready = append(c.ready, chain[i])
+	}
+	c.committed = committed
+	select {
+	case c.applied <- struct{}{}:
+	default:
+	}
+}
+
+// applyLoop passes the committed payloads on to OnPayload, in order.
+func (c *ClusterConductor) applyLoop() {
+	defer c.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		select {
+		case <-c.applied:
+		case <-c.closing:
+			return
+		}
+		c.mu.Lock()
+		ready := c.ready
+		c.ready = nil
+		c.mu.Unlock()
+		for _, p := range ready {
+			if c.cfg.OnPayload == nil {
+				continue
+			}
+			if err := c.cfg.OnPayload(ctx, p.payload, p.signature); err != nil {
+				c.log.Warn("Failed to process committed payload", "id", p.payload.ID(), "err", err)
+			}
+		}
+	}
+}
+
+func (c *ClusterConductor) loop() {
+	defer c.wg.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticker := time.NewTicker(c.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.tick(ctx)
+		case <-c.commits:
+			c.mu.Lock()
+			r, term, committed := c.role, c.term, c.committed
+			c.mu.Unlock()
+			if r == leader {
+				c.heartbeat(ctx, term, committed)
+			}
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+func (c *ClusterConductor) tick(ctx context.Context) {
+	c.mu.Lock()
+	r, term, committed := c.role, c.term, c.committed
+	electionDue := time.Since(c.lastContact) > c.electionTimeout
+	c.mu.Unlock()
+
+	if r == leader {
+		if err := c.checkHealth(ctx); err != nil {
+			c.log.Warn("Sequencer leader is unhealthy, stepping down", "term", term, "err", err)
+			c.stepDown(term)
+			return
+		}
+		if !c.heartbeat(ctx, term, committed) {
+			c.log.Warn("Sequencer leader lost contact with the majority of the cluster, stepping down", "term", term)
+			c.stepDown(term)
+		}
+		return
+	}
+	if !electionDue {
+		return
+	}
+	if err := c.checkHealth(ctx); err != nil {
+		c.log.Warn("Sequencer replica is unhealthy, not running for leader", "err", err)
+		c.mu.Lock()
+		c.resetElectionTimerLocked()
+		c.mu.Unlock()
+		return
+	}
+	c.campaign(ctx)
+}
+
+func (c *ClusterConductor) checkHealth(ctx context.Context) error {
+	if c.cfg.HealthCheck == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+	return c.cfg.HealthCheck(ctx)
+}
+
+// campaign runs for leader in a new term.
+func (c *ClusterConductor) campaign(ctx context.Context) {
+	c.mu.Lock()
+	c.role = candidate
+	c.term += 1
+	c.votedFor = c.cfg.ServerID
+	c.leaderID = ""
+	c.resetElectionTimerLocked()
+	req := &VoteRequest{Term: c.term, Candidate: c.cfg.ServerID, LastPayload: c.lastPayload}
+	if err := c.persistLocked(); err != nil {
+		c.mu.Unlock()
+		c.log.Error("Not running for sequencer leader", "term", req.Term, "err", err)
+		return
+	}
+	c.mu.Unlock()
+	c.log.Debug("Running for sequencer leader", "term", req.Term)
+
+	votes := 1 + c.broadcast(ctx, func(ctx context.Context, peer Peer) (uint64, bool, error) {
+		resp, err := peer.RequestVote(ctx, req)
+		if err != nil {
+			return 0, false, err
+		}
+		return resp.Term, resp.Granted, nil
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.term != req.Term || c.role != candidate {
+		return // a newer term started while campaigning
+	}
+	if votes < c.quorum() {
+		c.log.Debug("Lost sequencer leader election", "term", req.Term, "votes", votes)
+		return
+	}
+	c.log.Info("Elected sequencer leader", "term", req.Term, "votes", votes)
+	// Payloads of earlier terms that this replica retained may have been committed by a majority,
+	// as only replicas that are up to date with a majority are elected. Process them,
+	// and commit them on the followers with the next heartbeat.
+	if c.lastPayload.Number > c.committed.Number {
+		c.commitLocked(c.lastPayload)
+	}
+	c.role = leader
+	c.leaderID = c.cfg.ServerID
+	c.notifyLeaderUpdate()
+}
+
+// heartbeat asserts the leadership, and announces the latest committed payload.
+// It returns false if the majority of the cluster did not acknowledge it.
+func (c *ClusterConductor) heartbeat(ctx context.Context, term uint64, committed eth.BlockID) bool {
+	req := &AppendRequest{Term: term, Leader: c.cfg.ServerID, Committed: committed}
+	acks := 1 + c.broadcast(ctx, func(ctx context.Context, peer Peer) (uint64, bool, error) {
+		resp, err := peer.AppendPayload(ctx, req)
+		if err != nil {
+			return 0, false, err
+		}
+		return resp.Term, resp.Success, nil
+	})
+	return acks >= c.quorum()
+}
+
+func (c *ClusterConductor) stepDown(term uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.term == term && c.role == leader {
+		c.becomeFollowerLocked(term)
+	}
+}
+
+// broadcast sends a request to all peers concurrently, and returns the number of successful responses.
+// Responses from newer terms make this replica follow the newer term.
+func (c *ClusterConductor) broadcast(ctx context.Context, fn func(ctx context.Context, peer Peer) (term uint64, ok bool, err error)) int {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+	results := make(chan bool, len(c.cfg.Peers))
+	for id, peer := range c.cfg.Peers {
+		go func(id string, peer Peer) {
+			term, ok, err := fn(ctx, peer)
+			if err != nil {
+				c.log.Debug("Failed to reach sequencer replica", "peer", id, "err", err)
+				results <- false
+				return
+			}
+			c.mu.Lock()
+			if term > c.term {
+				c.becomeFollowerLocked(term)
+			}
+			c.mu.Unlock()
+			results <- ok
+		}(id, peer)
+	}
+	count := 0
+	for range c.cfg.Peers {
+		if <-results {
+			count += 1
+		}
+	}
+	return count
+}
+
+// persistLocked stores the term, vote and last payload, if they changed since they were last stored.
+// It is called before any request or response leaves the replica, so the other replicas never observe
+// a state that a restart of the replica would lose.
+func (c *ClusterConductor) persistLocked() error {
+	state := PersistentState{Term: c.term, VotedFor: c.votedFor, LastPayload: c.lastPayload}
+	if state == c.persisted {
+		return nil
+	}
+	if err := c.store.Store(state); err != nil {
+		return fmt.Errorf("failed to persist conductor state: %w", err)
+	}
+	c.persisted = state
+	return nil
+}
+
+func (c *ClusterConductor) quorum() int {
+	return (len(c.cfg.Peers)+1)/2 + 1
+}
+
+func (c *ClusterConductor) becomeFollowerLocked(term uint64) {
+	wasLeader := c.role == leader
+	if term > c.term {
+		c.term = term
+		c.votedFor = ""
+		c.leaderID = ""
+	}
+	c.role = follower
+	if wasLeader {
+		c.leaderID = ""
+		c.log.Info("Sequencer leadership lost", "term", c.term)
+		c.notifyLeaderUpdate()
+	}
+	c.resetElectionTimerLocked()
+}
+
+func (c *ClusterConductor) resetElectionTimerLocked() {
+	c.lastContact = time.Now()
+	c.electionTimeout = c.cfg.ElectionTimeout + time.Duration(c.rng.Int63n(int64(c.cfg.ElectionTimeout)))
+}
+
+func (c *ClusterConductor) notifyLeaderUpdate() {
+	select {
+	case c.leaderUpdates <- struct{}{}:
+	default: // already signalled, the leadership is read again by the receiver
+	}
+}
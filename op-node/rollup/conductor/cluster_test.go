@@ -0,0 +1,408 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var errDisconnected = errors.New("disconnected")
+
+// testPeer connects an in-process replica to another, and can simulate a network partition.
+type testPeer struct {
+	from, to *testReplica
+}
+
+func (p *testPeer) connected() bool {
+	return !p.from.disconnected.Load() && !p.to.disconnected.Load()
+}
+
+func (p *testPeer) RequestVote(ctx context.Context, req *VoteRequest) (*VoteResponse, error) {
+	if !p.connected() {
+		return nil, errDisconnected
+	}
+	return p.to.RequestVote(ctx, req)
+}
+
+func (p *testPeer) AppendPayload(ctx context.Context, req *AppendRequest) (*AppendResponse, error) {
+	if !p.connected() {
+		return nil, errDisconnected
+	}
+	return p.to.AppendPayload(ctx, req)
+}
+
+type testReplica struct {
+	*ClusterConductor
+	healthy      atomic.Bool
+	disconnected atomic.Bool
+
+	mu         sync.Mutex
+	payloads   []*eth.ExecutionPayload
+	signatures [][]byte
+}
+
+func (r *testReplica) Payloads() []*eth.ExecutionPayload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*eth.ExecutionPayload(nil), r.payloads...)
+}
+
+func (r *testReplica) Signatures() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]byte(nil), r.signatures...)
+}
+
+// setupCluster creates a cluster of in-process replicas.
+func setupCluster(t *testing.T, size int) []*testReplica {
+	replicas := make([]*testReplica, size)
+	for i := range replicas {
+		replicas[i] = new(testReplica)
+		replicas[i].healthy.Store(true)
+	}
+	for i, r := range replicas {
+		r := r
+		peers := make(map[string]Peer)
+		for j, other := range replicas {
+			if i != j {
+				peers[fmt.Sprintf("seq-%d", j)] = &testPeer{from: r, to: other}
+			}
+		}
+		id := fmt.Sprintf("seq-%d", i)
+		c, err := NewClusterConductor(testlog.Logger(t, log.LvlInfo).New("replica", i), Config{
+			ServerID:          id,
+			Peers:             peers,
+			HeartbeatInterval: 10 * time.Millisecond,
+			ElectionTimeout:   50 * time.Millisecond,
+			RequestTimeout:    20 * time.Millisecond,
+			HealthCheck: func(ctx context.Context) error {
+				if !r.healthy.Load() {
+					return errors.New("unhealthy")
+				}
+				return nil
+			},
+			SignPayload: func(ctx context.Context, payload *eth.ExecutionPayload) ([]byte, error) {
+				return []byte(id), nil
+			},
+			OnPayload: func(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) error {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.payloads = append(r.payloads, payload)
+				r.signatures = append(r.signatures, signature)
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		r.ClusterConductor = c
+	}
+	for _, r := range replicas {
+		r := r
+		r.Start()
+		t.Cleanup(func() { _ = r.Close() })
+	}
+	return replicas
+}
+
+// waitForLeader waits for a single leader among the given replicas, and returns it.
+func waitForLeader(t *testing.T, replicas []*testReplica) *testReplica {
+	var leader *testReplica
+	require.Eventually(t, func() bool {
+		leader = nil
+		for _, r := range replicas {
+			if r.Leader(context.Background()) {
+				if leader != nil {
+					return false
+				}
+				leader = r
+			}
+		}
+		return leader != nil
+	}, 5*time.Second, 10*time.Millisecond, "expected a single leader")
+	return leader
+}
+
+func followers(replicas []*testReplica, leader *testReplica) []*testReplica {
+	var out []*testReplica
+	for _, r := range replicas {
+		if r != leader {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestClusterElectsLeader(t *testing.T) {
+	replicas := setupCluster(t, 3)
+	leader := waitForLeader(t, replicas)
+	for _, r := range followers(replicas, leader) {
+		require.Eventually(t, func() bool {
+			return r.LeaderID() == leader.cfg.ServerID
+		}, 5*time.Second, 10*time.Millisecond, "followers learn about the leader")
+	}
+}
+
+func TestSingleReplicaCluster(t *testing.T) {
+	replicas := setupCluster(t, 1)
+	leader := waitForLeader(t, replicas)
+	require.NoError(t, leader.CommitUnsafePayload(context.Background(), &eth.ExecutionPayload{BlockNumber: 1}))
+}
+
+func TestCommitUnsafePayload(t *testing.T) {
+	replicas := setupCluster(t, 3)
+	leader := waitForLeader(t, replicas)
+
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: [32]byte{10}}
+	for _, r := range followers(replicas, leader) {
+		require.ErrorIs(t, r.CommitUnsafePayload(context.Background(), payload), ErrNotLeader)
+	}
+	require.NoError(t, leader.CommitUnsafePayload(context.Background(), payload))
+	for _, r := range followers(replicas, leader) {
+		require.Eventually(t, func() bool {
+			return len(r.Payloads()) == 1
+		}, 5*time.Second, 10*time.Millisecond, "committed payload is processed by the followers")
+		require.Equal(t, []*eth.ExecutionPayload{payload}, r.Payloads())
+		require.Equal(t, [][]byte{[]byte(leader.LeaderID())}, r.Signatures(), "payload is signed by the leader")
+	}
+	require.Empty(t, leader.Payloads(), "leader does not receive its own payload")
+}
+
+func TestUncommittedPayloadIsNotProcessed(t *testing.T) {
+	replicas := setupCluster(t, 5)
+	leader := waitForLeader(t, replicas)
+	others := followers(replicas, leader)
+
+	// only one follower receives the payload, which thus does not reach a majority
+	for _, r := range others[1:] {
+		r.disconnected.Store(true)
+	}
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: [32]byte{10}}
+	err := leader.CommitUnsafePayload(context.Background(), payload)
+	require.True(t, errors.Is(err, ErrNoQuorum) || errors.Is(err, ErrNotLeader), "unexpected error: %v", err)
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, others[0].Payloads(), "uncommitted payload is not processed")
+}
+
+func TestFollowerProcessesCommittedPayloads(t *testing.T) {
+	c := newTestConductor(t, "seq-1", "seq-2")
+	a := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0x0a}}
+	b := &eth.ExecutionPayload{BlockNumber: 11, BlockHash: common.Hash{0x0b}, ParentHash: a.BlockHash}
+	bAlt := &eth.ExecutionPayload{BlockNumber: 11, BlockHash: common.Hash{0xbb}, ParentHash: a.BlockHash}
+	readyIDs := func() (out []eth.BlockID) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, p := range c.ready {
+			out = append(out, p.payload.ID())
+		}
+		return out
+	}
+
+	for _, p := range []*eth.ExecutionPayload{a, b} {
+		resp, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 1, Leader: "seq-1", Payload: p})
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+	}
+	require.Empty(t, readyIDs(), "replicated payloads are retained until committed")
+
+	_, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 1, Leader: "seq-1", Committed: a.ID()})
+	require.NoError(t, err)
+	require.Equal(t, []eth.BlockID{a.ID()}, readyIDs())
+
+	// a new leader replaces the uncommitted payload of the previous leader
+	_, err = c.AppendPayload(context.Background(), &AppendRequest{Term: 2, Leader: "seq-2", Payload: bAlt, Committed: a.ID()})
+	require.NoError(t, err)
+	_, err = c.AppendPayload(context.Background(), &AppendRequest{Term: 2, Leader: "seq-2", Committed: bAlt.ID()})
+	require.NoError(t, err)
+	require.Equal(t, []eth.BlockID{a.ID(), bAlt.ID()}, readyIDs())
+}
+
+func TestLeadershipTransfersWhenUnhealthy(t *testing.T) {
+	replicas := setupCluster(t, 3)
+	leader := waitForLeader(t, replicas)
+	require.NoError(t, leader.CommitUnsafePayload(context.Background(), &eth.ExecutionPayload{BlockNumber: 10}))
+
+	leader.healthy.Store(false)
+	newLeader := waitForLeader(t, followers(replicas, leader))
+	require.False(t, leader.Leader(context.Background()), "unhealthy leader stepped down")
+	select {
+	case <-leader.LeaderUpdates():
+	default:
+		t.Fatal("expected leadership update signal")
+	}
+
+	// the old leader follows the new leader
+	require.NoError(t, newLeader.CommitUnsafePayload(context.Background(), &eth.ExecutionPayload{BlockNumber: 11}))
+	require.Eventually(t, func() bool {
+		payloads := leader.Payloads()
+		return len(payloads) > 0 && uint64(payloads[len(payloads)-1].BlockNumber) == 11
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLeaderStepsDownWithoutQuorum(t *testing.T) {
+	replicas := setupCluster(t, 3)
+	leader := waitForLeader(t, replicas)
+
+	// partition the leader from the rest of the cluster
+	leader.disconnected.Store(true)
+	err := leader.CommitUnsafePayload(context.Background(), &eth.ExecutionPayload{BlockNumber: 10})
+	if err != nil {
+		// the leader may already have stepped down after missing heartbeats
+		require.True(t, errors.Is(err, ErrNoQuorum) || errors.Is(err, ErrNotLeader), "unexpected error: %v", err)
+	}
+	require.Eventually(t, func() bool {
+		return !leader.Leader(context.Background())
+	}, 5*time.Second, 10*time.Millisecond, "partitioned leader steps down")
+
+	// the majority elects a new leader
+	newLeader := waitForLeader(t, followers(replicas, leader))
+	require.NoError(t, newLeader.CommitUnsafePayload(context.Background(), &eth.ExecutionPayload{BlockNumber: 10}))
+}
+
+func TestTransferLeadership(t *testing.T) {
+	replicas := setupCluster(t, 3)
+	leader := waitForLeader(t, replicas)
+	require.NoError(t, leader.TransferLeadership(context.Background()))
+	require.ErrorIs(t, leader.TransferLeadership(context.Background()), ErrNotLeader)
+	waitForLeader(t, replicas)
+}
+
+// newTestConductor creates an unstarted conductor, with peers that are never contacted.
+func newTestConductor(t *testing.T, peers ...string) *ClusterConductor {
+	return newTestConductorWithStore(t, nil, peers...)
+}
+
+func newTestConductorWithStore(t *testing.T, store StateStore, peers ...string) *ClusterConductor {
+	cfg := Config{
+		ServerID:          "seq-0",
+		Peers:             make(map[string]Peer),
+		HeartbeatInterval: time.Second,
+		ElectionTimeout:   2 * time.Second,
+		RequestTimeout:    time.Second,
+		Store:             store,
+	}
+	for _, id := range peers {
+		cfg.Peers[id] = nil
+	}
+	c, err := NewClusterConductor(testlog.Logger(t, log.LvlInfo), cfg)
+	require.NoError(t, err)
+	return c
+}
+
+func TestVoteRequiresUpToDateCandidate(t *testing.T) {
+	c := newTestConductor(t, "seq-1", "seq-2", "seq-3", "seq-4", "seq-5")
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0x0a}}
+	_, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 1, Leader: "seq-1", Payload: payload})
+	require.NoError(t, err)
+
+	resp, err := c.RequestVote(context.Background(), &VoteRequest{Term: 2, Candidate: "seq-2", LastPayload: eth.BlockID{Number: 9}})
+	require.NoError(t, err)
+	require.False(t, resp.Granted, "candidate is behind")
+	resp, err = c.RequestVote(context.Background(), &VoteRequest{Term: 2, Candidate: "seq-5", LastPayload: eth.BlockID{Number: 10, Hash: common.Hash{0x0b}}})
+	require.NoError(t, err)
+	require.False(t, resp.Granted, "candidate has a conflicting payload")
+	resp, err = c.RequestVote(context.Background(), &VoteRequest{Term: 2, Candidate: "seq-3", LastPayload: payload.ID()})
+	require.NoError(t, err)
+	require.True(t, resp.Granted)
+	resp, err = c.RequestVote(context.Background(), &VoteRequest{Term: 2, Candidate: "seq-4", LastPayload: eth.BlockID{Number: 11}})
+	require.NoError(t, err)
+	require.False(t, resp.Granted, "already voted in this term")
+	require.Equal(t, uint64(2), resp.Term)
+
+	appendResp, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 1, Leader: "seq-1"})
+	require.NoError(t, err)
+	require.False(t, appendResp.Success, "stale leader is rejected")
+}
+
+func TestStateSurvivesRestart(t *testing.T) {
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "conductor", "state.json"))
+	c := newTestConductorWithStore(t, store, "seq-1", "seq-2", "seq-3")
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0x0a}}
+	_, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 1, Leader: "seq-1", Payload: payload})
+	require.NoError(t, err)
+	resp, err := c.RequestVote(context.Background(), &VoteRequest{Term: 2, Candidate: "seq-2", LastPayload: payload.ID()})
+	require.NoError(t, err)
+	require.True(t, resp.Granted)
+
+	// the restarted replica does not vote twice in the same term, nor for a candidate that is behind
+	restarted := newTestConductorWithStore(t, store, "seq-1", "seq-2", "seq-3")
+	resp, err = restarted.RequestVote(context.Background(), &VoteRequest{Term: 2, Candidate: "seq-3", LastPayload: payload.ID()})
+	require.NoError(t, err)
+	require.False(t, resp.Granted, "already voted in this term")
+	require.Equal(t, uint64(2), resp.Term)
+	resp, err = restarted.RequestVote(context.Background(), &VoteRequest{Term: 3, Candidate: "seq-3", LastPayload: eth.BlockID{Number: 9}})
+	require.NoError(t, err)
+	require.False(t, resp.Granted, "candidate is behind the acknowledged payload")
+
+	state, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, PersistentState{Term: 3, LastPayload: payload.ID()}, state)
+}
+
+// failingStore fails to persist the state.
+type failingStore struct{}
+
+func (failingStore) Load() (PersistentState, error) { return PersistentState{}, nil }
+
+func (failingStore) Store(state PersistentState) error { return errors.New("disk failure") }
+
+func TestNoResponseWithoutPersistedState(t *testing.T) {
+	c := newTestConductorWithStore(t, failingStore{}, "seq-1", "seq-2")
+	_, err := c.RequestVote(context.Background(), &VoteRequest{Term: 1, Candidate: "seq-2"})
+	require.ErrorContains(t, err, "disk failure", "the vote is not granted before it is persisted")
+	_, err = c.AppendPayload(context.Background(), &AppendRequest{Term: 2, Leader: "seq-1"})
+	require.ErrorContains(t, err, "disk failure", "the term is not acknowledged before it is persisted")
+}
+
+func TestRejectUnknownPeer(t *testing.T) {
+	c := newTestConductor(t, "seq-1")
+	var received []*eth.ExecutionPayload
+	c.cfg.OnPayload = func(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) error {
+		received = append(received, payload)
+		return nil
+	}
+
+	_, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 5, Leader: "intruder", Payload: &eth.ExecutionPayload{BlockNumber: 10}})
+	require.ErrorIs(t, err, ErrUnknownPeer)
+	_, err = c.RequestVote(context.Background(), &VoteRequest{Term: 5, Candidate: "intruder", LastPayload: eth.BlockID{Number: 10}})
+	require.ErrorIs(t, err, ErrUnknownPeer)
+	require.Empty(t, received)
+	require.Empty(t, c.LeaderID())
+
+	// the term of the unknown leader was not adopted, so the leader of an earlier term is still followed
+	resp, err := c.AppendPayload(context.Background(), &AppendRequest{Term: 1, Leader: "seq-1"})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Equal(t, uint64(1), resp.Term)
+}
+
+func TestConfigCheck(t *testing.T) {
+	valid := Config{ServerID: "a", HeartbeatInterval: time.Second, ElectionTimeout: 2 * time.Second, RequestTimeout: time.Second}
+	require.NoError(t, valid.Check())
+
+	cfg := valid
+	cfg.ServerID = ""
+	require.Error(t, cfg.Check())
+
+	cfg = valid
+	cfg.Peers = map[string]Peer{"a": nil}
+	require.Error(t, cfg.Check(), "own peer")
+
+	cfg = valid
+	cfg.ElectionTimeout = cfg.HeartbeatInterval
+	require.Error(t, cfg.Check())
+
+	cfg = valid
+	cfg.RequestTimeout = 0
+	require.Error(t, cfg.Check())
+}
@@ -0,0 +1,39 @@
+// Package conductor coordinates a set of sequencer replicas, such that only a single replica,
+// the elected leader, sequences new blocks, and such that every unsafe payload is replicated
+// to a majority of the replicas before it is gossiped.
+package conductor
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerConductor is consulted by the driver before sequencing, and before publishing the sequenced payloads.
+type SequencerConductor interface {
+	// Leader returns true if this node is the leader sequencer, and thus allowed to sequence blocks.
+	Leader(ctx context.Context) bool
+	// LeaderUpdates is signalled when the leadership changes, so the leadership can be read again.
+	LeaderUpdates() <-chan struct{}
+	// CommitUnsafePayload replicates the new unsafe payload to the other sequencer replicas,
+	// and returns an error if the payload was not accepted by the cluster.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+}
+
+// NoOpConductor is the conductor of a single sequencer, which is always the leader.
+type NoOpConductor struct{}
+
+var _ SequencerConductor = NoOpConductor{}
+
+func (NoOpConductor) Leader(ctx context.Context) bool {
+	return true
+}
+
+// LeaderUpdates returns a nil channel, the leadership never changes.
+func (NoOpConductor) LeaderUpdates() <-chan struct{} {
+	return nil
+}
+
+func (NoOpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return nil
+}
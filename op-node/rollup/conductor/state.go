@@ -0,0 +1,108 @@
+package conductor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PersistentState is the state of a replica that must survive restarts for the elections to be safe:
+// a restarted replica must not vote twice in the same term, and must not vote for a candidate
+// that misses a payload it acknowledged before the restart.
+type PersistentState struct {
+	Term        uint64      `json:"term"`
+	VotedFor    string      `json:"votedFor"`
+	LastPayload eth.BlockID `json:"lastPayload"`
+}
+
+// StateStore persists the state of a replica.
+type StateStore interface {
+	// Load returns the persisted state, or the zero state if no state was persisted yet.
+	Load() (PersistentState, error)
+	// Store durably persists the state, before it returns.
+	Store(state PersistentState) error
+}
+
+// FileStateStore persists the state of a replica to a JSON file.
+type FileStateStore struct {
+	path string
+}
+
+var _ StateStore = (*FileStateStore)(nil)
+
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+func (s *FileStateStore) Load() (PersistentState, error) {
+	var state PersistentState
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return state, fmt.Errorf("read conductor state file (%v): %w", s.path, err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("invalid conductor state file (%v): %w", s.path, err)
+	}
+	return state, nil
+}
+
+// Store writes the state to a temp file, which is synced to disk and then renamed into place,
+// so the state file is never left partially written. The directory is synced as well,
+// so the rename is durable before Store returns.
+func (s *FileStateStore) Store(state PersistentState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal conductor state: %w", err)
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create dir (%v): %w", dir, err)
+	}
+	tmpFile := s.path + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open file (%v) for writing: %w", tmpFile, err)
+	}
+	defer file.Close() // Ensure file is closed even if write or sync fails
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("write to temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close temp file (%v): %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, s.path); err != nil {
+		return fmt.Errorf("rename temp file to final destination: %w", err)
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("open dir (%v): %w", dir, err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("sync dir (%v): %w", dir, err)
+	}
+	return nil
+}
+
+// memoryStateStore keeps the state in memory only, it does not survive restarts.
+type memoryStateStore struct {
+	state PersistentState
+}
+
+func (s *memoryStateStore) Load() (PersistentState, error) {
+	return s.state, nil
+}
+
+func (s *memoryStateStore) Store(state PersistentState) error {
+	s.state = state
+	return nil
+}
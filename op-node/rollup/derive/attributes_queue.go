@@ -27,15 +27,21 @@ type AttributesBuilder interface {
 	PreparePayloadAttributes(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (attrs *eth.PayloadAttributes, err error)
 }
 
+// SafeBatchProvider provides the next batch to build on top of the given L2 safe head.
+type SafeBatchProvider interface {
+	Origin() eth.L1BlockRef
+	NextBatch(ctx context.Context, l2SafeHead eth.L2BlockRef) (*BatchData, error)
+}
+
 type AttributesQueue struct {
 	log     log.Logger
 	config  *rollup.Config
 	builder AttributesBuilder
-	prev    *BatchQueue
+	prev    SafeBatchProvider
 	batch   *BatchData
 }
 
-func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
+func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev SafeBatchProvider) *AttributesQueue {
 	return &AttributesQueue{
 		log:     log,
 		config:  cfg,
@@ -86,6 +92,7 @@ func (aq *AttributesQueue) createNextAttributes(ctx context.Context, batch *Batc
 	if err != nil {
 		return nil, err
 	}
+	LogForkActivations(aq.log, aq.config, uint64(attrs.Timestamp))
 
 	// we are verifying, not sequencing, we've got all transactions and do not pull from the tx-pool
 	// (that would make the block derivation non-deterministic)
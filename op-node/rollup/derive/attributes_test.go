@@ -242,6 +242,59 @@ func TestPreparePayloadAttributes(t *testing.T) {
 			})
 		}
 	})
+	// Test that the payload attributes builder adds the Shanghai and Cancun fields based on L2-time-based activation
+	t.Run("canyon and ecotone", func(t *testing.T) {
+		canyonTime, ecotoneTime := uint64(1000), uint64(2000)
+		beaconRoot := common.Hash{0xbe}
+		testCases := []struct {
+			name             string
+			l2ParentTime     uint64
+			l1BeaconRoot     *common.Hash
+			withdrawals      bool
+			parentBeaconRoot *common.Hash
+		}{
+			{"before canyon", canyonTime - cfg.BlockTime - 1, &beaconRoot, false, nil},
+			{"canyon", canyonTime - cfg.BlockTime, &beaconRoot, true, nil},
+			{"ecotone", ecotoneTime - cfg.BlockTime, &beaconRoot, true, &beaconRoot},
+			{"ecotone without l1 beacon root", ecotoneTime, nil, true, &common.Hash{}},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				cfgCopy := *cfg
+				cfg := &cfgCopy
+				cfg.CanyonTime = &canyonTime
+				cfg.EcotoneTime = &ecotoneTime
+				rng := rand.New(rand.NewSource(1234))
+				l1Fetcher := &testutils.MockL1Source{}
+				defer l1Fetcher.AssertExpectations(t)
+				l2Parent := testutils.RandomL2BlockRef(rng)
+				l2Parent.Time = tc.l2ParentTime
+
+				l1CfgFetcher := &testutils.MockL2Client{}
+				l1CfgFetcher.ExpectSystemConfigByL2Hash(l2Parent.Hash, testSysCfg, nil)
+				defer l1CfgFetcher.AssertExpectations(t)
+
+				l1Info := testutils.RandomBlockInfo(rng)
+				l1Info.InfoHash = l2Parent.L1Origin.Hash
+				l1Info.InfoNum = l2Parent.L1Origin.Number
+				l1Info.InfoTime = 500
+				l1Info.InfoParentBeaconRoot = tc.l1BeaconRoot
+
+				epoch := l1Info.ID()
+				l1Fetcher.ExpectInfoByHash(epoch.Hash, l1Info, nil)
+				attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, l1CfgFetcher)
+				attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch)
+				require.NoError(t, err)
+				if tc.withdrawals {
+					require.Equal(t, &types.Withdrawals{}, attrs.Withdrawals, "empty withdrawals list")
+				} else {
+					require.Nil(t, attrs.Withdrawals)
+				}
+				require.Equal(t, tc.parentBeaconRoot, attrs.ParentBeaconBlockRoot)
+			})
+		}
+	})
 }
 
 func encodeDeposits(deposits []*types.DepositTx) (out []eth.Data, err error) {
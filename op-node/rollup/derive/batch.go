@@ -20,6 +20,10 @@ import (
 // BatchV1Type := 0
 // batchV1 := BatchV1Type ++ RLP([epoch, timestamp, transaction_list]
 //
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload
+// See span_batch.go for the span batch encoding.
+//
 // An empty input is not a valid batch.
 //
 // Note: the type system is based on L1 typed transactions.
@@ -31,6 +35,7 @@ var encodeBufferPool = sync.Pool{
 
 const (
 	BatchV1Type = iota
+	SpanBatchType
 )
 
 type BatchV1 struct {
@@ -44,9 +49,22 @@ type BatchV1 struct {
 
 type BatchData struct {
 	BatchV1
+	// RawSpanBatch is set instead of BatchV1 if the batch is a span batch,
+	// which needs to be derived with the rollup config before use.
+	RawSpanBatch *RawSpanBatch
 	// batches may contain additional data with new upgrades
 }
 
+// NewSpanBatchData creates the batch data of a span batch.
+func NewSpanBatchData(spanBatch *RawSpanBatch) *BatchData {
+	return &BatchData{RawSpanBatch: spanBatch}
+}
+
+// IsSpanBatch returns true if the batch is a span batch.
+func (b *BatchData) IsSpanBatch() bool {
+	return b.RawSpanBatch != nil
+}
+
 func (b *BatchV1) Epoch() eth.BlockID {
 	return eth.BlockID{Hash: b.EpochHash, Number: uint64(b.EpochNum)}
 }
@@ -70,6 +88,10 @@ func (b *BatchData) MarshalBinary() ([]byte, error) {
 }
 
 func (b *BatchData) encodeTyped(buf *bytes.Buffer) error {
+	if b.RawSpanBatch != nil {
+		buf.WriteByte(SpanBatchType)
+		return b.RawSpanBatch.encode(buf)
+	}
 	buf.WriteByte(BatchV1Type)
 	return rlp.Encode(buf, &b.BatchV1)
 }
@@ -101,6 +123,9 @@ func (b *BatchData) decodeTyped(data []byte) error {
 	switch data[0] {
 	case BatchV1Type:
 		return rlp.DecodeBytes(data[1:], &b.BatchV1)
+	case SpanBatchType:
+		b.RawSpanBatch = new(RawSpanBatch)
+		return b.RawSpanBatch.decode(bytes.NewReader(data[1:]))
 	default:
 		return fmt.Errorf("unrecognized batch type: %d", data[0])
 	}
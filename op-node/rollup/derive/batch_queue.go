@@ -44,6 +44,9 @@ type BatchQueue struct {
 
 	// batches in order of when we've first seen them, grouped by L2 timestamp
 	batches map[uint64][]*BatchWithL1InclusionBlock
+
+	// nextSpan holds the remaining blocks of the last accepted span batch, to be returned one by one
+	nextSpan []*SpanBatchElement
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
@@ -82,6 +85,15 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef)
 		bq.log.Info("Advancing bq origin", "origin", bq.origin, "originBehind", originBehind)
 	}
 
+	// Return the remaining blocks of the last accepted span batch first
+	if len(bq.nextSpan) > 0 {
+		if batch, err := bq.popNextBatch(safeL2Head); err != nil {
+			return nil, err
+		} else if batch != nil {
+			return batch, nil
+		}
+	}
+
 	// Load more data into the batch queue
 	outOfData := false
 	if batch, err := bq.prev.NextBatch(ctx); err == io.EOF {
@@ -124,6 +136,7 @@ func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Syst
 	// throw out this block.
 	bq.l1Blocks = bq.l1Blocks[:0]
 	bq.l1Blocks = append(bq.l1Blocks, base)
+	bq.nextSpan = bq.nextSpan[:0]
 	return io.EOF
 }
 
@@ -135,12 +148,61 @@ func (bq *BatchQueue) AddBatch(batch *BatchData, l2SafeHead eth.L2BlockRef) {
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
+	if batch.IsSpanBatch() {
+		spanBatch, err := batch.RawSpanBatch.Derive(bq.config.BlockTime, bq.config.Genesis.L2Time, bq.config.L2ChainID)
+		if err != nil {
+			bq.log.Warn("failed to derive span batch, dropping it", "err", err)
+			return
+		}
+		data.SpanBatch = spanBatch
+	}
 	validity := CheckBatch(bq.config, bq.log, bq.l1Blocks, l2SafeHead, &data)
 	if validity == BatchDrop {
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
-	bq.log.Debug("Adding batch", "batch_timestamp", batch.Timestamp, "parent_hash", batch.ParentHash, "batch_epoch", batch.Epoch(), "txs", len(batch.Transactions))
-	bq.batches[batch.Timestamp] = append(bq.batches[batch.Timestamp], &data)
+	if data.SpanBatch != nil {
+		bq.log.Debug("Adding span batch", "batch_timestamp", data.Timestamp(), "block_count", data.SpanBatch.GetBlockCount())
+	} else {
+		bq.log.Debug("Adding batch", "batch_timestamp", batch.Timestamp, "parent_hash", batch.ParentHash, "batch_epoch", batch.Epoch(), "txs", len(batch.Transactions))
+	}
+	bq.batches[data.Timestamp()] = append(bq.batches[data.Timestamp()], &data)
+}
+
+// popNextBatch returns the next block of the last accepted span batch, completed with the
+// parent hash of the safe head and the hash of its L1 origin. If the block does not build on
+// the safe head, which happens if a block of the span was not applied, the remaining blocks
+// of the span are discarded and nil is returned.
+func (bq *BatchQueue) popNextBatch(l2SafeHead eth.L2BlockRef) (*BatchData, error) {
+	next := bq.nextSpan[0]
+	if next.Timestamp != l2SafeHead.Time+bq.config.BlockTime {
+		bq.log.Warn("span batch block does not build on the safe head, discarding the rest of the span batch",
+			"batch_timestamp", next.Timestamp, "l2_safe_head", l2SafeHead.ID(), "l2_safe_head_time", l2SafeHead.Time)
+		bq.nextSpan = bq.nextSpan[:0]
+		return nil, nil
+	}
+	if len(bq.l1Blocks) == 0 || uint64(next.EpochNum) < bq.l1Blocks[0].Number || uint64(next.EpochNum) > bq.l1Blocks[0].Number+1 {
+		bq.log.Warn("span batch block L1 origin is not buffered, discarding the rest of the span batch",
+			"batch_timestamp", next.Timestamp, "batch_epoch", next.EpochNum)
+		bq.nextSpan = bq.nextSpan[:0]
+		return nil, nil
+	}
+	// advance epoch if necessary
+	if uint64(next.EpochNum) == bq.l1Blocks[0].Number+1 {
+		if len(bq.l1Blocks) < 2 {
+			return nil, NewCriticalError(fmt.Errorf("span batch block L1 origin %d is not buffered", next.EpochNum))
+		}
+		bq.l1Blocks = bq.l1Blocks[1:]
+	}
+	bq.nextSpan = bq.nextSpan[1:]
+	return &BatchData{
+		BatchV1: BatchV1{
+			ParentHash:   l2SafeHead.Hash,
+			EpochNum:     next.EpochNum,
+			EpochHash:    bq.l1Blocks[0].Hash,
+			Timestamp:    next.Timestamp,
+			Transactions: next.Transactions,
+		},
+	}, nil
 }
 
 // deriveNextBatch derives the next batch to apply on top of the current L2 safe head,
@@ -176,8 +238,17 @@ batchLoop:
 		validity := CheckBatch(bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch)
 		switch validity {
 		case BatchFuture:
-			return nil, NewCriticalError(fmt.Errorf("found batch with timestamp %d marked as future batch, but expected timestamp %d", batch.Batch.Timestamp, nextTimestamp))
+			return nil, NewCriticalError(fmt.Errorf("found batch with timestamp %d marked as future batch, but expected timestamp %d", batch.Timestamp(), nextTimestamp))
 		case BatchDrop:
+			if batch.SpanBatch != nil {
+				bq.log.Warn("dropping span batch",
+					"batch_timestamp", batch.Timestamp(),
+					"block_count", batch.SpanBatch.GetBlockCount(),
+					"l2_safe_head", l2SafeHead.ID(),
+					"l2_safe_head_time", l2SafeHead.Time,
+				)
+				continue
+			}
 			bq.log.Warn("dropping batch",
 				"batch_timestamp", batch.Batch.Timestamp,
 				"parent_hash", batch.Batch.ParentHash,
@@ -208,6 +279,16 @@ batchLoop:
 		bq.batches[nextTimestamp] = remaining
 	}
 
+	if nextBatch != nil && nextBatch.SpanBatch != nil {
+		bq.log.Info("Found next span batch", "epoch", epoch, "batch_timestamp", nextBatch.Timestamp(), "block_count", nextBatch.SpanBatch.GetBlockCount())
+		bq.nextSpan = nextBatch.SpanBatch.Batches
+		if batch, err := bq.popNextBatch(l2SafeHead); err != nil {
+			return nil, err
+		} else if batch != nil {
+			return batch, nil
+		}
+		return nil, NewCriticalError(errors.New("accepted span batch does not build on the safe head"))
+	}
 	if nextBatch != nil {
 		// advance epoch if necessary
 		if nextBatch.Batch.EpochNum == rollup.Epoch(epoch.Number)+1 {
@@ -244,7 +325,7 @@ batchLoop:
 	if nextTimestamp < nextEpoch.Time || firstOfEpoch {
 		bq.log.Info("Generating next batch", "epoch", epoch, "timestamp", nextTimestamp)
 		return &BatchData{
-			BatchV1{
+			BatchV1: BatchV1{
 				ParentHash:   l2SafeHead.Hash,
 				EpochNum:     rollup.Epoch(epoch.Number),
 				EpochHash:    epoch.Hash,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"io"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -48,7 +49,7 @@ func mockHash(time uint64, layer uint8) common.Hash {
 func b(timestamp uint64, epoch eth.L1BlockRef) *BatchData {
 	rng := rand.New(rand.NewSource(int64(timestamp)))
 	data := testutils.RandomData(rng, 20)
-	return &BatchData{BatchV1{
+	return &BatchData{BatchV1: BatchV1{
 		ParentHash:   mockHash(timestamp-2, 2),
 		Timestamp:    timestamp,
 		EpochNum:     rollup.Epoch(epoch.Number),
@@ -370,3 +371,94 @@ func TestBatchQueueMissing(t *testing.T) {
 	require.Empty(t, b.BatchV1.Transactions)
 	require.Equal(t, rollup.Epoch(1), b.EpochNum)
 }
+
+// TestBatchQueueSpanBatch adds a span batch and asserts that the batch queue
+// returns a batch for each of its blocks, built on top of the safe head.
+func TestBatchQueueSpanBatch(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	safeHead := eth.L2BlockRef{
+		Hash:           mockHash(10, 2),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           10,
+		L1Origin:       l1[0].ID(),
+		SequenceNumber: 0,
+	}
+	deltaTime := uint64(0)
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     30,
+		L2ChainID:         big.NewInt(901),
+		DeltaTime:         &deltaTime,
+	}
+
+	var expected []*BatchData
+	builder := NewSpanBatchBuilder(cfg.Genesis.L2Time, cfg.L2ChainID)
+	for i, ts := range []uint64{12, 14, 16, 18, 20, 22} {
+		epoch := l1[0]
+		if ts >= 22 {
+			epoch = l1[1]
+		}
+		batch := &BatchData{BatchV1: BatchV1{
+			ParentHash: mockHash(ts-2, 2),
+			Timestamp:  ts,
+			EpochNum:   rollup.Epoch(epoch.Number),
+			EpochHash:  epoch.Hash,
+		}}
+		builder.AppendSingularBatch(&batch.BatchV1, uint64(i))
+		expected = append(expected, batch)
+	}
+	raw, err := builder.GetRawSpanBatch()
+	require.NoError(t, err)
+
+	input := &fakeBatchQueueInput{
+		batches: []*BatchData{NewSpanBatchData(raw), nil},
+		errors:  []error{nil, io.EOF},
+		origin:  l1[0],
+	}
+
+	bq := NewBatchQueue(log, cfg, input)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	// Advance the origin
+	input.origin = l1[1]
+
+	for i := 0; i < len(expected); i++ {
+		b, e := bq.NextBatch(context.Background(), safeHead)
+		require.NoError(t, e)
+		require.Equal(t, expected[i], b)
+
+		safeHead.Number += 1
+		safeHead.Time += 2
+		safeHead.Hash = mockHash(b.Timestamp, 2)
+		safeHead.L1Origin = b.Epoch()
+	}
+	require.Empty(t, bq.nextSpan)
+	_, e := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, e, io.EOF)
+
+	// span batches are dropped before the Delta upgrade
+	cfg.DeltaTime = nil
+	spanBatch, err := raw.Derive(cfg.BlockTime, cfg.Genesis.L2Time, cfg.L2ChainID)
+	require.NoError(t, err)
+	validity := CheckBatch(cfg, log, l1, eth.L2BlockRef{Hash: mockHash(10, 2), Time: 10, L1Origin: l1[0].ID()},
+		&BatchWithL1InclusionBlock{L1InclusionBlock: l1[1], Batch: NewSpanBatchData(raw), SpanBatch: spanBatch})
+	require.Equal(t, BatchValidity(BatchDrop), validity)
+}
+
+func TestBatchQueueSpanBatchOriginNotBuffered(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 20})
+	cfg := &rollup.Config{BlockTime: 2}
+	bq := NewBatchQueue(log, cfg, &fakeBatchQueueInput{origin: l1[0]})
+	bq.l1Blocks = l1[:1]
+	// the next block of the span batch advances to an L1 origin that is not buffered
+	bq.nextSpan = []*SpanBatchElement{{EpochNum: rollup.Epoch(l1[1].Number), Timestamp: 12}}
+
+	_, err := bq.popNextBatch(eth.L2BlockRef{Time: 10, L1Origin: l1[0].ID()})
+	require.ErrorIs(t, err, ErrCritical)
+}
@@ -1,8 +1,11 @@
 package derive
 
 import (
+	"fmt"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -10,6 +13,16 @@ import (
 type BatchWithL1InclusionBlock struct {
 	L1InclusionBlock eth.L1BlockRef
 	Batch            *BatchData
+	// SpanBatch is the derived span batch, if Batch is a span batch.
+	SpanBatch *SpanBatch
+}
+
+// Timestamp returns the timestamp of the batch, or of the first block of the span batch.
+func (b *BatchWithL1InclusionBlock) Timestamp() uint64 {
+	if b.SpanBatch != nil {
+		return b.SpanBatch.GetTimestamp()
+	}
+	return b.Batch.Timestamp
 }
 
 type BatchValidity uint8
@@ -25,10 +38,33 @@ const (
 	BatchFuture
 )
 
+func (v BatchValidity) String() string {
+	switch v {
+	case BatchDrop:
+		return "drop"
+	case BatchAccept:
+		return "accept"
+	case BatchUndecided:
+		return "undecided"
+	case BatchFuture:
+		return "future"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(v))
+	}
+}
+
 // CheckBatch checks if the given batch can be applied on top of the given l2SafeHead, given the contextual L1 blocks the batch was included in.
 // The first entry of the l1Blocks should match the origin of the l2SafeHead. One or more consecutive l1Blocks should be provided.
 // In case of only a single L1 block, the decision whether a batch is valid may have to stay undecided.
 func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
+	if batch.SpanBatch != nil {
+		return checkSpanBatch(cfg, log, l1Blocks, l2SafeHead, batch)
+	}
+	return checkSingularBatch(cfg, log, l1Blocks, l2SafeHead, batch)
+}
+
+// checkSingularBatch implements CheckBatch for batches of a single L2 block.
+func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
 	// add details to the log
 	log = log.New(
 		"batch_timestamp", batch.Batch.Timestamp,
@@ -128,15 +164,156 @@ func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l
 	}
 
 	// We can do this check earlier, but it's a more intensive one, so we do this last.
-	for i, txBytes := range batch.Batch.Transactions {
+	if !checkBatchTransactions(log, batch.Batch.Transactions) {
+		return BatchDrop
+	}
+
+	return BatchAccept
+}
+
+// checkBatchTransactions checks that the transactions are not empty, and not deposits.
+func checkBatchTransactions(log log.Logger, txs []hexutil.Bytes) bool {
+	for i, txBytes := range txs {
 		if len(txBytes) == 0 {
 			log.Warn("transaction data must not be empty, but found empty tx", "tx_index", i)
-			return BatchDrop
+			return false
 		}
 		if txBytes[0] == types.DepositTxType {
 			log.Warn("sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", i)
+			return false
+		}
+	}
+	return true
+}
+
+// checkSpanBatch implements CheckBatch for span batches. The span batch is accepted as a whole,
+// if all of its blocks are valid when applied in order on top of the given l2SafeHead.
+// Span batches that overlap with the safe chain are not supported, and dropped.
+func checkSpanBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
+	spanBatch := batch.SpanBatch
+	if len(spanBatch.Batches) == 0 {
+		log.Warn("empty span batch, cannot proceed")
+		return BatchDrop
+	}
+	startEpochNum := uint64(spanBatch.GetStartEpochNum())
+	endEpochNum := uint64(spanBatch.Batches[len(spanBatch.Batches)-1].EpochNum)
+	// add details to the log
+	log = log.New(
+		"batch_timestamp", spanBatch.GetTimestamp(),
+		"parent_check", hexutil.Bytes(spanBatch.ParentCheck[:]),
+		"origin_check", hexutil.Bytes(spanBatch.L1OriginCheck[:]),
+		"start_epoch_number", startEpochNum,
+		"end_epoch_number", endEpochNum,
+		"block_count", len(spanBatch.Batches),
+	)
+
+	// sanity check we have consistent inputs
+	if len(l1Blocks) == 0 {
+		log.Warn("missing L1 block input, cannot proceed with batch checking")
+		return BatchUndecided
+	}
+	epoch := l1Blocks[0]
+
+	if !cfg.IsActive(rollup.Delta, batch.L1InclusionBlock.Time) {
+		log.Warn("received span batch before Delta upgrade", "l1_inclusion_time", batch.L1InclusionBlock.Time)
+		return BatchDrop
+	}
+	if !cfg.IsActive(rollup.Delta, spanBatch.GetTimestamp()) {
+		log.Warn("received span batch with L2 blocks before Delta upgrade")
+		return BatchDrop
+	}
+
+	nextTimestamp := l2SafeHead.Time + cfg.BlockTime
+	if spanBatch.GetTimestamp() > nextTimestamp {
+		log.Trace("received out-of-order batch for future processing after next batch", "next_timestamp", nextTimestamp)
+		return BatchFuture
+	}
+	if spanBatch.GetTimestamp() < nextTimestamp {
+		log.Warn("dropping span batch with old timestamp, overlapping span batches are not supported", "min_timestamp", nextTimestamp)
+		return BatchDrop
+	}
+
+	// dependent on above timestamp check. If the timestamp is correct, then it must build on top of the safe head.
+	if !spanBatch.CheckParentHash(l2SafeHead.Hash) {
+		log.Warn("ignoring batch with mismatching parent hash", "current_safe_head", l2SafeHead.Hash)
+		return BatchDrop
+	}
+
+	// Filter out batches that were included too late.
+	if startEpochNum+cfg.SeqWindowSize < batch.L1InclusionBlock.Number {
+		log.Warn("batch was included too late, sequence window expired")
+		return BatchDrop
+	}
+
+	// Check the L1 origin of the first block of the batch
+	if startEpochNum < epoch.Number {
+		log.Warn("dropped batch, epoch is too old", "minimum", epoch.ID())
+		return BatchDrop
+	} else if startEpochNum > epoch.Number+1 {
+		log.Warn("batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
+		return BatchDrop
+	}
+
+	if endEpochNum > batch.L1InclusionBlock.Number {
+		log.Warn("batch has L1 origin after its inclusion block", "l1_inclusion_block", batch.L1InclusionBlock.ID())
+		return BatchDrop
+	}
+	// With the L1 origin of the last block outside of the known L1 blocks, we cannot check the batch yet.
+	// Note: this means that we are unable to determine validity of a batch without more information,
+	// to prevent the eager algorithm from diverging from a non-eager algorithm.
+	if endEpochNum-epoch.Number >= uint64(len(l1Blocks)) {
+		log.Info("eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
+		return BatchUndecided
+	}
+	if !spanBatch.CheckOriginHash(l1Blocks[endEpochNum-epoch.Number].Hash) {
+		log.Warn("batch is for different L1 chain, epoch hash does not match", "expected", l1Blocks[endEpochNum-epoch.Number].ID())
+		return BatchDrop
+	}
+
+	prevEpochNum := epoch.Number
+	for i, block := range spanBatch.Batches {
+		blockEpochNum := uint64(block.EpochNum)
+		if blockEpochNum < prevEpochNum || blockEpochNum > prevEpochNum+1 {
+			log.Warn("block L1 origin does not follow the previous L1 origin", "block_index", i, "block_epoch", blockEpochNum, "prev_epoch", prevEpochNum)
+			return BatchDrop
+		}
+		blockOrigin := l1Blocks[blockEpochNum-epoch.Number]
+		if block.Timestamp < blockOrigin.Time {
+			log.Warn("block timestamp is less than L1 origin timestamp", "block_index", i, "l2_timestamp", block.Timestamp, "l1_timestamp", blockOrigin.Time, "origin", blockOrigin.ID())
+			return BatchDrop
+		}
+
+		// Check if we ran out of sequencer time drift
+		if max := blockOrigin.Time + cfg.MaxSequencerDrift; block.Timestamp > max {
+			if len(block.Transactions) == 0 {
+				// If the sequencer is co-operating by producing an empty block,
+				// then allow the block if it was the right thing to do to maintain the L2 time >= L1 time invariant.
+				// We only check blocks that do not advance the epoch, to ensure epoch advancement regardless of time drift is allowed.
+				if blockEpochNum == prevEpochNum {
+					nextIndex := blockEpochNum - epoch.Number + 1
+					if nextIndex >= uint64(len(l1Blocks)) {
+						log.Info("without the next L1 origin we cannot determine yet if this empty block that exceeds the time drift is still valid", "block_index", i)
+						return BatchUndecided
+					}
+					if block.Timestamp >= l1Blocks[nextIndex].Time { // check if the next L1 origin could have been adopted
+						log.Info("block exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid", "block_index", i)
+						return BatchDrop
+					} else {
+						log.Info("continuing with empty block before late L1 block to preserve L2 time invariant", "block_index", i)
+					}
+				}
+			} else {
+				// If the sequencer is ignoring the time drift rule, then drop the batch and force an empty batch instead,
+				// as the sequencer is not allowed to include anything past this point without moving to the next epoch.
+				log.Warn("block exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "block_index", i, "max_time", max)
+				return BatchDrop
+			}
+		}
+
+		if !checkBatchTransactions(log.New("block_index", i), block.Transactions) {
 			return BatchDrop
 		}
+		prevEpochNum = blockEpochNum
 	}
 
 	return BatchAccept
@@ -0,0 +1,152 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// blobOrCalldata is the data of a single batch transaction: either calldata,
+// or a blob that is yet to be filled in by the index of the blob in the L1 block.
+type blobOrCalldata struct {
+	calldata *eth.Data
+	blob     *eth.Blob
+}
+
+// BlobDataSource fetches both calldata and blobs from batch transactions,
+// in the order of the transactions in the L1 block. It is used after the Ecotone upgrade.
+// Like DataSource, it is fault tolerant: fetching is re-attempted on the next call to Next.
+type BlobDataSource struct {
+	data         []blobOrCalldata
+	ref          eth.L1BlockRef
+	batcherAddr  common.Address
+	cfg          *rollup.Config
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	log          log.Logger
+}
+
+// NewBlobDataSource creates a new blob data source. The data is fetched on the first call to Next.
+func NewBlobDataSource(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	return &BlobDataSource{
+		ref:          ref,
+		cfg:          cfg,
+		fetcher:      fetcher,
+		blobsFetcher: blobsFetcher,
+		log:          log.New("origin", ref),
+		batcherAddr:  batcherAddr,
+	}
+}
+
+// Next returns the next piece of data if it has it. If the block or its blobs cannot be fetched,
+// a ResetError is returned if the block cannot be found, and a temporary error otherwise.
+// Blobs that cannot be decoded are skipped.
+func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
+	if ds.data == nil {
+		data, err := ds.open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ds.data = data
+	}
+
+	for len(ds.data) > 0 {
+		next := ds.data[0]
+		ds.data = ds.data[1:]
+		if next.calldata != nil {
+			return *next.calldata, nil
+		}
+		data, err := next.blob.ToData()
+		if err != nil {
+			ds.log.Warn("ignoring blob due to parse failure", "err", err)
+			continue
+		}
+		return data, nil
+	}
+	return nil, io.EOF
+}
+
+// open fetches the batch transactions of the L1 block, and the blobs they commit to.
+// The returned slice is never nil, to mark the source as opened.
+func (ds *BlobDataSource) open(ctx context.Context) ([]blobOrCalldata, error) {
+	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, NewResetError(fmt.Errorf("failed to open blob data source: %w", err))
+		}
+		return nil, NewTemporaryError(fmt.Errorf("failed to open blob data source: %w", err))
+	}
+
+	data, hashes := dataAndHashesFromTxs(txs, ds.cfg, ds.batcherAddr, ds.log)
+	if len(hashes) == 0 {
+		return data, nil
+	}
+	if ds.blobsFetcher == nil {
+		return nil, NewCriticalError(fmt.Errorf("cannot read blobs of block %s without a blobs fetcher", ds.ref))
+	}
+
+	blobs, err := ds.blobsFetcher.GetBlobs(ctx, ds.ref, hashes)
+	if errors.Is(err, ethereum.NotFound) {
+		// If the L1 block was available, then the blobs should be available too. The only
+		// exception is if the blob retention window has expired, which we will ultimately handle
+		// by failing over to a blob archival service.
+		return nil, NewResetError(fmt.Errorf("failed to fetch blobs: %w", err))
+	} else if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch blobs: %w", err))
+	} else if len(blobs) != len(hashes) {
+		return nil, NewTemporaryError(fmt.Errorf("expected %d blobs, but got %d", len(hashes), len(blobs)))
+	}
+
+	// fill in the blobs, in the order of the batch transactions
+	blobIndex := 0
+	for i := range data {
+		if data[i].calldata == nil {
+			data[i].blob = blobs[blobIndex]
+			blobIndex++
+		}
+	}
+	return data, nil
+}
+
+// dataAndHashesFromTxs extracts the calldata of the non-blob batch transactions, and placeholders for
+// the blobs of blob batch transactions, together with the hashes of those blobs, indexed within the L1 block.
+// The calldata of blob batch transactions is ignored.
+func dataAndHashesFromTxs(txs types.Transactions, config *rollup.Config, batcherAddr common.Address, log log.Logger) ([]blobOrCalldata, []eth.IndexedBlobHash) {
+	data := []blobOrCalldata{}
+	var hashes []eth.IndexedBlobHash
+	// blob transactions are only supported by the Cancun signer, which is not used before the Ecotone upgrade
+	l1Signer := types.NewCancunSigner(config.L1ChainID)
+	blobIndex := 0 // index of each blob in the block's blob sidecar
+	for i, tx := range txs {
+		// skip any non-batcher transactions
+		if !isValidBatchTx(config, l1Signer, batcherAddr, tx, i, log) {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		// handle non-blob batcher transactions by extracting their calldata
+		if tx.Type() != types.BlobTxType {
+			calldata := eth.Data(tx.Data())
+			data = append(data, blobOrCalldata{calldata: &calldata})
+			continue
+		}
+		// handle blob batcher transactions by extracting their blob hashes, ignoring any calldata.
+		if len(tx.Data()) > 0 {
+			log.Warn("blob tx has calldata, which will be ignored", "txhash", tx.Hash())
+		}
+		for _, h := range tx.BlobHashes() {
+			hashes = append(hashes, eth.IndexedBlobHash{Index: uint64(blobIndex), Hash: h})
+			data = append(data, blobOrCalldata{})
+			blobIndex++
+		}
+	}
+	return data, hashes
+}
@@ -0,0 +1,185 @@
+package derive
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type testBlobsFetcher struct {
+	blobs map[common.Hash]*eth.Blob
+	err   error
+	calls int
+}
+
+func (f *testBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]*eth.Blob, 0, len(hashes))
+	for _, h := range hashes {
+		out = append(out, f.blobs[h.Hash])
+	}
+	return out, nil
+}
+
+func (f *testBlobsFetcher) addBlob(t *testing.T, data eth.Data) common.Hash {
+	var b eth.Blob
+	require.NoError(t, b.FromData(data))
+	h := crypto.Keccak256Hash(b[:]) // the fetcher is trusted to verify the blobs, any unique hash will do
+	f.blobs[h] = &b
+	return h
+}
+
+func createBlobTx(t *testing.T, signer types.Signer, author *ecdsa.PrivateKey, to common.Address, data []byte, hashes ...common.Hash) *types.Transaction {
+	t.Helper()
+	out, err := types.SignNewTx(author, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(signer.ChainID()),
+		GasTipCap:  uint256.NewInt(2 * params.GWei),
+		GasFeeCap:  uint256.NewInt(30 * params.GWei),
+		Gas:        100_000,
+		To:         &to,
+		Value:      uint256.NewInt(0),
+		Data:       data,
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		BlobHashes: hashes,
+	})
+	require.NoError(t, err)
+	return out
+}
+
+func TestBlobDataSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+	}
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	signer := types.NewCancunSigner(cfg.L1ChainID)
+	logger := testlog.Logger(t, log.LvlCrit)
+	ref := testutils.RandomBlockRef(rng)
+
+	fetcher := &testBlobsFetcher{blobs: make(map[common.Hash]*eth.Blob)}
+	hashA := fetcher.addBlob(t, eth.Data("frames A"))
+	hashB := fetcher.addBlob(t, eth.Data("frames B"))
+	hashOther := fetcher.addBlob(t, eth.Data("not ours"))
+	var invalid eth.Blob
+	invalid[0] = 1 // invalid field element
+	hashInvalid := crypto.Keccak256Hash(invalid[:])
+	fetcher.blobs[hashInvalid] = &invalid
+
+	calldataA := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 100, author: batcherPriv}).Create(t, signer, rng)
+	calldataB := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 100, author: batcherPriv}).Create(t, signer, rng)
+	txs := types.Transactions{
+		calldataA,
+		// blob of another sender, takes blob index 0
+		createBlobTx(t, signer, testutils.RandomKey(), cfg.BatchInboxAddress, nil, hashOther),
+		// blobs at index 1, 2, 3. The calldata of blob transactions is ignored.
+		createBlobTx(t, signer, batcherPriv, cfg.BatchInboxAddress, []byte{0xaa}, hashA, hashInvalid, hashB),
+		calldataB,
+	}
+
+	l1F := &testutils.MockL1Source{}
+	l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+	defer l1F.AssertExpectations(t)
+
+	src := NewBlobDataSource(logger, cfg, l1F, fetcher, ref, batcherAddr)
+	var out []eth.Data
+	for {
+		data, err := src.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		out = append(out, data)
+	}
+	require.Equal(t, []eth.Data{calldataA.Data(), eth.Data("frames A"), eth.Data("frames B"), calldataB.Data()}, out)
+	require.Equal(t, 1, fetcher.calls)
+
+	hashes := []eth.IndexedBlobHash{{Index: 1, Hash: hashA}, {Index: 2, Hash: hashInvalid}, {Index: 3, Hash: hashB}}
+	_, gotHashes := dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+	require.Equal(t, hashes, gotHashes)
+}
+
+func TestBlobDataSourceErrors(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+	}
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	signer := types.NewCancunSigner(cfg.L1ChainID)
+	logger := testlog.Logger(t, log.LvlCrit)
+	ref := testutils.RandomBlockRef(rng)
+	txs := types.Transactions{createBlobTx(t, signer, batcherPriv, cfg.BatchInboxAddress, nil, common.Hash{0x01})}
+
+	t.Run("blobs not found", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		fetcher := &testBlobsFetcher{err: errors.New("unavailable")}
+		_, err := NewBlobDataSource(logger, cfg, l1F, fetcher, ref, batcherAddr).Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+	})
+	t.Run("blobs pruned", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		fetcher := &testBlobsFetcher{err: fmt.Errorf("%w: block not found", ethereum.NotFound)}
+		_, err := NewBlobDataSource(logger, cfg, l1F, fetcher, ref, batcherAddr).Next(context.Background())
+		require.ErrorIs(t, err, ErrReset)
+	})
+	t.Run("no blobs fetcher", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+		_, err := NewBlobDataSource(logger, cfg, l1F, nil, ref, batcherAddr).Next(context.Background())
+		require.ErrorIs(t, err, ErrCritical)
+	})
+	t.Run("retry", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), nil, errors.New("down"))
+		fetcher := &testBlobsFetcher{blobs: make(map[common.Hash]*eth.Blob)}
+		src := NewBlobDataSource(logger, cfg, l1F, fetcher, ref, batcherAddr)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), types.Transactions{}, nil)
+		_, err = src.Next(context.Background())
+		require.Equal(t, io.EOF, err)
+		require.Zero(t, fetcher.calls)
+	})
+}
+
+func TestDataSourceFactoryEcotone(t *testing.T) {
+	ecotoneTime := uint64(1000)
+	cfg := &rollup.Config{EcotoneTime: &ecotoneTime, L1ChainID: big.NewInt(100)}
+	l1F := &testutils.MockL1Source{}
+	// the calldata source is opened eagerly, the blob data source lazily
+	l1F.ExpectInfoAndTxsByHash(common.Hash{0x01}, &testutils.MockBlockInfo{}, nil, errors.New("down"))
+	defer l1F.AssertExpectations(t)
+	factory := NewDataSourceFactory(testlog.Logger(t, log.LvlCrit), cfg, l1F, nil)
+
+	require.IsType(t, &DataSource{}, factory.OpenData(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x01}, Time: ecotoneTime - 1}, common.Address{}))
+	require.IsType(t, &BlobDataSource{}, factory.OpenData(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x02}, Time: ecotoneTime}, common.Address{}))
+}
@@ -23,22 +23,35 @@ type L1TransactionFetcher interface {
 	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
 }
 
+// L1BlobsFetcher fetches the blobs that were committed to in an L1 block.
+type L1BlobsFetcher interface {
+	// GetBlobs fetches the blobs with the given hashes, in the same order, verified against the hashes.
+	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
+}
+
 // DataSourceFactory readers raw transactions from a given block & then filters for
 // batch submitter transactions.
 // This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
 type DataSourceFactory struct {
-	log     log.Logger
-	cfg     *rollup.Config
-	fetcher L1TransactionFetcher
+	log          log.Logger
+	cfg          *rollup.Config
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
 }
 
-func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher) *DataSourceFactory {
-	return &DataSourceFactory{log: log, cfg: cfg, fetcher: fetcher}
+// NewDataSourceFactory creates a factory of data sources. The blobs fetcher is optional,
+// but required to read the data of L1 blocks after the Ecotone upgrade.
+func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher) *DataSourceFactory {
+	return &DataSourceFactory{log: log, cfg: cfg, fetcher: fetcher, blobsFetcher: blobsFetcher}
 }
 
 // OpenData returns a DataIter. This struct implements the `Next` function.
-func (ds *DataSourceFactory) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
-	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, id, batcherAddr)
+// After the Ecotone upgrade, the data is read from both calldata and blobs.
+func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	if ds.cfg.IsActive(rollup.Ecotone, ref.Time) {
+		return NewBlobDataSource(ds.log, ds.cfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr)
+	}
+	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, ref.ID(), batcherAddr)
 }
 
 // DataSource is a fault tolerant approach to fetching data.
@@ -108,19 +121,28 @@ func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address,
 	var out []eth.Data
 	l1Signer := config.L1Signer()
 	for j, tx := range txs {
-		if to := tx.To(); to != nil && *to == config.BatchInboxAddress {
-			seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
-			if err != nil {
-				log.Warn("tx in inbox with invalid signature", "index", j, "err", err)
-				continue // bad signature, ignore
-			}
-			// some random L1 user might have sent a transaction to our batch inbox, ignore them
-			if seqDataSubmitter != batcherAddr {
-				log.Warn("tx in inbox with unauthorized submitter", "index", j, "err", err)
-				continue // not an authorized batch submitter, ignore
-			}
+		if isValidBatchTx(config, l1Signer, batcherAddr, tx, j, log) {
 			out = append(out, tx.Data())
 		}
 	}
 	return out
 }
+
+// isValidBatchTx returns whether the transaction is sent to the batch inbox address by the batch sender address.
+func isValidBatchTx(config *rollup.Config, l1Signer types.Signer, batcherAddr common.Address, tx *types.Transaction, index int, log log.Logger) bool {
+	to := tx.To()
+	if to == nil || *to != config.BatchInboxAddress {
+		return false
+	}
+	seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
+	if err != nil {
+		log.Warn("tx in inbox with invalid signature", "index", index, "err", err)
+		return false // bad signature, ignore
+	}
+	// some random L1 user might have sent a transaction to our batch inbox, ignore them
+	if seqDataSubmitter != batcherAddr {
+		log.Warn("tx in inbox with unauthorized submitter", "index", index, "err", err)
+		return false // not an authorized batch submitter, ignore
+	}
+	return true
+}
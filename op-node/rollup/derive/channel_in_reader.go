@@ -19,7 +19,7 @@ type ChannelInReader struct {
 
 	nextBatchFn func() (BatchWithL1InclusionBlock, error)
 
-	prev *ChannelBank
+	prev NextDataProvider
 
 	metrics Metrics
 }
@@ -27,7 +27,7 @@ type ChannelInReader struct {
 var _ ResettableStage = (*ChannelInReader)(nil)
 
 // NewChannelInReader creates a ChannelInReader, which should be Reset(origin) before use.
-func NewChannelInReader(log log.Logger, prev *ChannelBank, metrics Metrics) *ChannelInReader {
+func NewChannelInReader(log log.Logger, prev NextDataProvider, metrics Metrics) *ChannelInReader {
 	return &ChannelInReader{
 		log:     log,
 		prev:    prev,
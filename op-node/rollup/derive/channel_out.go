@@ -58,6 +58,11 @@ type ChannelOut struct {
 	// Compressor stage. Write input data to it
 	compress Compressor
 
+	// batchType is the type of the batches that are written to the channel: BatchV1Type or SpanBatchType
+	batchType uint
+	// spanBatchBuilder accumulates the blocks of the channel into a single span batch, if batchType is SpanBatchType
+	spanBatchBuilder *SpanBatchBuilder
+
 	closed bool
 }
 
@@ -65,12 +70,27 @@ func (co *ChannelOut) ID() ChannelID {
 	return co.id
 }
 
+// NewChannelOut creates a channel out that writes a singular batch per block.
 func NewChannelOut(compress Compressor) (*ChannelOut, error) {
+	return newChannelOut(BatchV1Type, compress, nil)
+}
+
+// NewSpanChannelOut creates a channel out that writes all blocks of the channel as a single span batch.
+func NewSpanChannelOut(compress Compressor, spanBatchBuilder *SpanBatchBuilder) (*ChannelOut, error) {
+	if spanBatchBuilder == nil {
+		return nil, errors.New("span channel out requires a span batch builder")
+	}
+	return newChannelOut(SpanBatchType, compress, spanBatchBuilder)
+}
+
+func newChannelOut(batchType uint, compress Compressor, spanBatchBuilder *SpanBatchBuilder) (*ChannelOut, error) {
 	c := &ChannelOut{
-		id:        ChannelID{}, // TODO: use GUID here instead of fully random data
-		frame:     0,
-		rlpLength: 0,
-		compress:  compress,
+		id:               ChannelID{}, // TODO: use GUID here instead of fully random data
+		frame:            0,
+		rlpLength:        0,
+		compress:         compress,
+		batchType:        batchType,
+		spanBatchBuilder: spanBatchBuilder,
 	}
 	_, err := rand.Read(c.id[:])
 	if err != nil {
@@ -85,6 +105,9 @@ func (co *ChannelOut) Reset() error {
 	co.frame = 0
 	co.rlpLength = 0
 	co.compress.Reset()
+	if co.spanBatchBuilder != nil {
+		co.spanBatchBuilder.Reset()
+	}
 	co.closed = false
 	_, err := rand.Read(co.id[:])
 	return err
@@ -99,11 +122,82 @@ func (co *ChannelOut) AddBlock(block *types.Block) (uint64, error) {
 		return 0, errors.New("already closed")
 	}
 
-	batch, _, err := BlockToBatch(block)
+	batch, l1Info, err := BlockToBatch(block)
 	if err != nil {
 		return 0, err
 	}
-	return co.AddBatch(batch)
+	return co.AddSingularBatch(batch, l1Info.SequenceNumber)
+}
+
+// AddSingularBatch adds the batch of a block to the channel, with the sequence number of the block
+// within its epoch. Depending on the batch type of the channel, the batch is either added as is,
+// or appended to the span batch of the channel. It returns the RLP encoded byte size of the batch,
+// or of the span batch, and the same errors as AddBatch. If an error is returned,
+// the batch is not part of the channel.
+func (co *ChannelOut) AddSingularBatch(batch *BatchData, seqNum uint64) (uint64, error) {
+	if co.batchType != SpanBatchType {
+		return co.AddBatch(batch)
+	}
+	if co.closed {
+		return 0, errors.New("already closed")
+	}
+
+	prevOriginCheck := co.spanBatchBuilder.spanBatch.L1OriginCheck
+	co.spanBatchBuilder.AppendSingularBatch(&batch.BatchV1, seqNum)
+	// The span batch is re-encoded as a whole, and replaces the previous contents of the channel.
+	// This is why no frames are output before the channel is closed, see ReadyBytes.
+	// The cost of re-encoding is bounded by the target size of the compressor.
+	buf, err := co.encodeSpanBatch()
+	if err == nil && buf.Len() > MaxRLPBytesPerChannel {
+		err = fmt.Errorf("could not add %d bytes to channel, max is %d. err: %w",
+			buf.Len(), MaxRLPBytesPerChannel, ErrTooManyRLPBytes)
+	}
+	var written int
+	if err == nil {
+		co.compress.Reset()
+		written, err = co.compress.Write(buf.Bytes())
+	}
+	if err != nil {
+		// Restore the channel to the span batch without the new batch.
+		co.spanBatchBuilder.removeLastBatch(prevOriginCheck)
+		if restoreErr := co.rewriteSpanBatch(); restoreErr != nil {
+			return 0, fmt.Errorf("failed to restore span batch after error %v: %w", err, restoreErr)
+		}
+		return 0, err
+	}
+	co.rlpLength = buf.Len()
+	return uint64(written), nil
+}
+
+// encodeSpanBatch returns the RLP encoding of the span batch of the channel.
+func (co *ChannelOut) encodeSpanBatch() (*bytes.Buffer, error) {
+	rawSpanBatch, err := co.spanBatchBuilder.GetRawSpanBatch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build span batch: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := rlp.Encode(&buf, NewSpanBatchData(rawSpanBatch)); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// rewriteSpanBatch replaces the contents of the compressor with the current span batch.
+func (co *ChannelOut) rewriteSpanBatch() error {
+	co.compress.Reset()
+	co.rlpLength = 0
+	if co.spanBatchBuilder.GetBlockCount() == 0 {
+		return nil
+	}
+	buf, err := co.encodeSpanBatch()
+	if err != nil {
+		return err
+	}
+	if _, err := co.compress.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	co.rlpLength = buf.Len()
+	return nil
 }
 
 // AddBatch adds a batch to the channel. It returns the RLP encoded byte size
@@ -144,7 +238,12 @@ func (co *ChannelOut) InputBytes() int {
 // ReadyBytes returns the number of bytes that the channel out can immediately output into a frame.
 // Use `Flush` or `Close` to move data from the compression buffer into the ready buffer if more bytes
 // are needed. Add blocks may add to the ready buffer, but it is not guaranteed due to the compression stage.
+// The span batch of a span batch channel is rewritten whenever a batch is added,
+// so no bytes are ready before the channel is closed.
 func (co *ChannelOut) ReadyBytes() int {
+	if co.batchType == SpanBatchType && !co.closed {
+		return 0
+	}
 	return co.compress.Len()
 }
 
@@ -183,6 +282,10 @@ func (co *ChannelOut) OutputFrame(w *bytes.Buffer, maxSize uint64) (uint16, erro
 	if maxSize < FrameV0OverHeadSize {
 		return 0, ErrMaxFrameSizeTooSmall
 	}
+	// The compressed data of an open span batch channel is still rewritten when batches are added.
+	if co.batchType == SpanBatchType && !co.closed {
+		return 0, errors.New("cannot output frames of a span batch channel before it is closed")
+	}
 
 	// Copy data from the local buffer into the frame data buffer
 	maxDataSize := maxSize - FrameV0OverHeadSize
@@ -239,7 +342,7 @@ func BlockToBatch(block *types.Block) (*BatchData, L1BlockInfo, error) {
 	}
 
 	return &BatchData{
-		BatchV1{
+		BatchV1: BatchV1{
 			ParentHash:   block.ParentHash(),
 			EpochNum:     rollup.Epoch(l1Info.Number),
 			EpochHash:    l1Info.BlockHash,
@@ -2,7 +2,9 @@ package derive
 
 import (
 	"bytes"
+	"math"
 	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -155,3 +157,59 @@ func TestBlockToBatchValidity(t *testing.T) {
 	_, _, err := BlockToBatch(block)
 	require.ErrorContains(t, err, "has no transactions")
 }
+
+// fullCompressor is a nonCompressor that is full once it holds more than limit bytes.
+type fullCompressor struct {
+	nonCompressor
+	limit int
+}
+
+func (s *fullCompressor) Write(p []byte) (int, error) {
+	if s.Len()+len(p) > s.limit {
+		return 0, CompressorFullErr
+	}
+	return s.nonCompressor.Write(p)
+}
+
+func TestSpanChannelOutAddSingularBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chainID := big.NewInt(901)
+	batches := randomSingularBatches(t, rng, chainID, 4, 20, 2, 5)
+
+	compress := &fullCompressor{}
+	compress.limit = math.MaxInt
+	cout, err := NewSpanChannelOut(compress, NewSpanBatchBuilder(10, chainID))
+	require.NoError(t, err)
+
+	decode := func() *RawSpanBatch {
+		var batch BatchData
+		require.NoError(t, rlp.DecodeBytes(compress.Bytes(), &batch))
+		require.True(t, batch.IsSpanBatch())
+		return batch.RawSpanBatch
+	}
+	for i, batch := range batches[:3] {
+		_, err := cout.AddSingularBatch(&BatchData{BatchV1: *batch}, uint64(i))
+		require.NoError(t, err)
+		// the channel holds a single span batch with all blocks so far
+		require.Equal(t, uint64(i+1), decode().blockCount)
+		require.Equal(t, compress.Len(), cout.InputBytes())
+		// the span batch is still rewritten, no frames can be output before closing the channel
+		require.Zero(t, cout.ReadyBytes())
+		var buf bytes.Buffer
+		_, err = cout.OutputFrame(&buf, 1000)
+		require.Error(t, err)
+	}
+
+	// if the compressor is full, the channel keeps the previous span batch
+	compress.limit = compress.Len()
+	_, err = cout.AddSingularBatch(&BatchData{BatchV1: *batches[3]}, 3)
+	require.ErrorIs(t, err, CompressorFullErr)
+	raw := decode()
+	require.Equal(t, uint64(3), raw.blockCount)
+	spanBatch, err := raw.Derive(2, 10, chainID)
+	require.NoError(t, err)
+	require.True(t, spanBatch.CheckOriginHash(batches[2].EpochHash))
+
+	require.NoError(t, cout.Reset())
+	require.Zero(t, compress.Len())
+}
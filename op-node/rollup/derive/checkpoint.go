@@ -0,0 +1,229 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// CheckpointStore persists checkpoints of the derivation pipeline,
+// such that derivation can continue from the checkpoint after a restart,
+// instead of re-reading the L1 data of a full channel-timeout window.
+type CheckpointStore interface {
+	// Enabled returns true if checkpoints should be taken and restored.
+	Enabled() bool
+	// CheckpointInterval is the minimum duration between checkpoints.
+	CheckpointInterval() time.Duration
+	// LoadCheckpoint returns the last persisted checkpoint, or nil if there is none.
+	LoadCheckpoint() (*PipelineCheckpoint, error)
+	// SaveCheckpoint persists the checkpoint, replacing any previous checkpoint.
+	SaveCheckpoint(cp *PipelineCheckpoint) error
+}
+
+// PipelineCheckpoint is a snapshot of the state of the derivation pipeline stages.
+// Checkpoints are only taken directly after advancing to a new L1 origin,
+// when the data of all previous L1 blocks has been read into the channel bank and batch queue.
+type PipelineCheckpoint struct {
+	// L2Genesis identifies the chain of the checkpoint.
+	L2Genesis eth.BlockID `json:"l2Genesis"`
+	// SafeHead is the L2 safe head that the buffered pipeline data builds on.
+	SafeHead eth.L2BlockRef `json:"safeHead"`
+	// Origin is the next L1 block to read data from.
+	Origin eth.L1BlockRef `json:"origin"`
+	// SystemConfig is the system config as of the origin.
+	SystemConfig eth.SystemConfig `json:"systemConfig"`
+
+	Frames     []Frame              `json:"frames"`
+	Channels   []channelCheckpoint  `json:"channels"`
+	BatchQueue batchQueueCheckpoint `json:"batchQueue"`
+}
+
+type channelCheckpoint struct {
+	ID                      ChannelID      `json:"id"`
+	OpenBlock               eth.L1BlockRef `json:"openBlock"`
+	Size                    uint64         `json:"size"`
+	Closed                  bool           `json:"closed"`
+	HighestFrameNumber      uint16         `json:"highestFrameNumber"`
+	EndFrameNumber          uint16         `json:"endFrameNumber"`
+	Frames                  []Frame        `json:"frames"`
+	HighestL1InclusionBlock eth.L1BlockRef `json:"highestL1InclusionBlock"`
+}
+
+type batchCheckpoint struct {
+	L1InclusionBlock eth.L1BlockRef `json:"l1InclusionBlock"`
+	// Batch is the binary encoding of the batch data.
+	Batch hexutil.Bytes `json:"batch"`
+}
+
+type batchQueueCheckpoint struct {
+	Origin   eth.L1BlockRef      `json:"origin"`
+	L1Blocks []eth.L1BlockRef    `json:"l1Blocks"`
+	Batches  [][]batchCheckpoint `json:"batches"`
+	NextSpan []*SpanBatchElement `json:"nextSpan"`
+}
+
+// checkpoint takes a checkpoint of the pipeline stages. It returns nil if the stages are not in a state
+// that can be checkpointed, i.e. if any stage still holds partially processed data of a previous L1 block.
+func (dp *DerivationPipeline) checkpoint() (*PipelineCheckpoint, error) {
+	if dp.l1Src.datas != nil || dp.chInReader.nextBatchFn != nil || dp.attributesQueue.batch != nil {
+		return nil, nil
+	}
+	cp := &PipelineCheckpoint{
+		L2Genesis:    dp.cfg.Genesis.L2,
+		SafeHead:     dp.eng.SafeL2Head(),
+		Origin:       dp.traversal.block,
+		SystemConfig: dp.traversal.sysCfg,
+		Frames:       append([]Frame(nil), dp.frameQueue.frames...),
+	}
+	for _, id := range dp.bank.channelQueue {
+		ch := dp.bank.channels[id]
+		chCp := channelCheckpoint{
+			ID:                      ch.id,
+			OpenBlock:               ch.openBlock,
+			Size:                    ch.size,
+			Closed:                  ch.closed,
+			HighestFrameNumber:      ch.highestFrameNumber,
+			EndFrameNumber:          ch.endFrameNumber,
+			HighestL1InclusionBlock: ch.highestL1InclusionBlock,
+		}
+		for _, f := range ch.inputs {
+			chCp.Frames = append(chCp.Frames, f)
+		}
+		sort.Slice(chCp.Frames, func(i, j int) bool {
+			return chCp.Frames[i].FrameNumber < chCp.Frames[j].FrameNumber
+		})
+		cp.Channels = append(cp.Channels, chCp)
+	}
+	bq := dp.batchQueue
+	cp.BatchQueue = batchQueueCheckpoint{
+		Origin:   bq.origin,
+		L1Blocks: append([]eth.L1BlockRef(nil), bq.l1Blocks...),
+		NextSpan: append([]*SpanBatchElement(nil), bq.nextSpan...),
+	}
+	timestamps := make([]uint64, 0, len(bq.batches))
+	for ts := range bq.batches {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	for _, ts := range timestamps {
+		var batches []batchCheckpoint
+		for _, b := range bq.batches[ts] {
+			data, err := b.Batch.MarshalBinary()
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode batch with timestamp %d: %w", ts, err)
+			}
+			batches = append(batches, batchCheckpoint{L1InclusionBlock: b.L1InclusionBlock, Batch: data})
+		}
+		cp.BatchQueue.Batches = append(cp.BatchQueue.Batches, batches)
+	}
+	return cp, nil
+}
+
+// maybeCheckpoint persists a checkpoint of the pipeline, if the checkpoint interval passed since the last checkpoint.
+// Failing to take a checkpoint does not affect derivation, and is only logged.
+func (dp *DerivationPipeline) maybeCheckpoint() {
+	if dp.checkpoints == nil || !dp.checkpoints.Enabled() {
+		return
+	}
+	if time.Since(dp.lastCheckpoint) < dp.checkpoints.CheckpointInterval() {
+		return
+	}
+	cp, err := dp.checkpoint()
+	if err != nil {
+		dp.log.Warn("failed to take derivation pipeline checkpoint", "err", err)
+		return
+	} else if cp == nil {
+		return
+	}
+	if err := dp.checkpoints.SaveCheckpoint(cp); err != nil {
+		dp.log.Warn("failed to persist derivation pipeline checkpoint", "err", err)
+		return
+	}
+	dp.lastCheckpoint = time.Now()
+	dp.log.Info("persisted derivation pipeline checkpoint", "origin", cp.Origin, "safe_head", cp.SafeHead, "channels", len(cp.Channels))
+}
+
+// restoreCheckpoint restores the pipeline stages from the persisted checkpoint, after the engine queue was reset.
+// It returns an error if there is no checkpoint, or if the checkpoint is not consistent with the current L1 and L2 chains,
+// in which case the remaining stages should be reset as usual.
+func (dp *DerivationPipeline) restoreCheckpoint(ctx context.Context) error {
+	cp, err := dp.checkpoints.LoadCheckpoint()
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if cp == nil {
+		return errors.New("no checkpoint available")
+	}
+	if cp.L2Genesis != dp.cfg.Genesis.L2 {
+		return fmt.Errorf("checkpoint of different chain with L2 genesis %s", cp.L2Genesis)
+	}
+	// The checkpoint origin must still be canonical. The L1 traversal continues from here,
+	// and will detect any reorg of the next L1 block as usual.
+	l1Ref, err := dp.l1Fetcher.L1BlockRefByNumber(ctx, cp.Origin.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %d: %w", cp.Origin.Number, err)
+	}
+	if l1Ref.Hash != cp.Origin.Hash {
+		return fmt.Errorf("checkpoint origin %s is not canonical, found %s", cp.Origin, l1Ref)
+	}
+
+	batches := make(map[uint64][]*BatchWithL1InclusionBlock)
+	for _, group := range cp.BatchQueue.Batches {
+		for _, b := range group {
+			var batch BatchData
+			if err := batch.UnmarshalBinary(b.Batch); err != nil {
+				return fmt.Errorf("failed to decode checkpoint batch: %w", err)
+			}
+			data := &BatchWithL1InclusionBlock{L1InclusionBlock: b.L1InclusionBlock, Batch: &batch}
+			if batch.IsSpanBatch() {
+				spanBatch, err := batch.RawSpanBatch.Derive(dp.cfg.BlockTime, dp.cfg.Genesis.L2Time, dp.cfg.L2ChainID)
+				if err != nil {
+					return fmt.Errorf("failed to derive checkpoint span batch: %w", err)
+				}
+				data.SpanBatch = spanBatch
+			}
+			batches[data.Timestamp()] = append(batches[data.Timestamp()], data)
+		}
+	}
+	// The engine queue verifies the checkpoint safe head against the engine, and rewinds the safe head to it.
+	// Any blocks after it are consolidated again, with the data of the restored stages.
+	if err := dp.eng.RestoreSafeHead(ctx, cp.SafeHead, cp.Origin, cp.SystemConfig); err != nil {
+		return err
+	}
+
+	dp.traversal.block = cp.Origin
+	dp.traversal.done = false
+	dp.traversal.sysCfg = cp.SystemConfig
+	dp.l1Src.datas = nil
+	dp.frameQueue.frames = append(dp.frameQueue.frames[:0], cp.Frames...)
+	dp.bank.channels = make(map[ChannelID]*Channel)
+	dp.bank.channelQueue = make([]ChannelID, 0, len(cp.Channels))
+	for _, chCp := range cp.Channels {
+		ch := NewChannel(chCp.ID, chCp.OpenBlock)
+		ch.size = chCp.Size
+		ch.closed = chCp.Closed
+		ch.highestFrameNumber = chCp.HighestFrameNumber
+		ch.endFrameNumber = chCp.EndFrameNumber
+		ch.highestL1InclusionBlock = chCp.HighestL1InclusionBlock
+		for _, f := range chCp.Frames {
+			ch.inputs[uint64(f.FrameNumber)] = f
+		}
+		dp.bank.channels[ch.id] = ch
+		dp.bank.channelQueue = append(dp.bank.channelQueue, ch.id)
+	}
+	dp.chInReader.nextBatchFn = nil
+	dp.batchQueue.origin = cp.BatchQueue.Origin
+	dp.batchQueue.l1Blocks = append(dp.batchQueue.l1Blocks[:0], cp.BatchQueue.L1Blocks...)
+	dp.batchQueue.batches = batches
+	dp.batchQueue.nextSpan = append(dp.batchQueue.nextSpan[:0], cp.BatchQueue.NextSpan...)
+	dp.attributesQueue.batch = nil
+
+	dp.log.Info("restored derivation pipeline from checkpoint", "origin", cp.Origin, "safe_head", cp.SafeHead, "channels", len(cp.Channels))
+	return nil
+}
@@ -0,0 +1,186 @@
+package derive
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type fakeCheckpointStore struct {
+	cp *PipelineCheckpoint
+}
+
+func (f *fakeCheckpointStore) Enabled() bool {
+	return true
+}
+
+func (f *fakeCheckpointStore) CheckpointInterval() time.Duration {
+	return 0
+}
+
+func (f *fakeCheckpointStore) LoadCheckpoint() (*PipelineCheckpoint, error) {
+	if f.cp == nil {
+		return nil, nil
+	}
+	// round-trip through JSON, like a persisted checkpoint
+	data, err := json.Marshal(f.cp)
+	if err != nil {
+		return nil, err
+	}
+	var out PipelineCheckpoint
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (f *fakeCheckpointStore) SaveCheckpoint(cp *PipelineCheckpoint) error {
+	f.cp = cp
+	return nil
+}
+
+type checkpointTestSetup struct {
+	cfg      *rollup.Config
+	l1       *testutils.MockL1Source
+	engine   *testutils.MockEngine
+	pipeline *DerivationPipeline
+	eq       *EngineQueue
+}
+
+func setupCheckpointTest(t *testing.T, store CheckpointStore) *checkpointTestSetup {
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2:     eth.BlockID{Hash: common.Hash{0xaa}, Number: 0},
+			L2Time: 10,
+		},
+		BlockTime:      2,
+		SeqWindowSize:  10,
+		ChannelTimeout: 10,
+		L2ChainID:      big.NewInt(901),
+	}
+	l1 := &testutils.MockL1Source{}
+	engine := &testutils.MockEngine{}
+	dp := NewDerivationPipeline(testlog.Logger(t, log.LvlInfo), cfg, l1, nil, engine, &testutils.TestDerivationMetrics{}, &sync.Config{}, nil, store)
+	return &checkpointTestSetup{cfg: cfg, l1: l1, engine: engine, pipeline: dp, eq: dp.eng.(*EngineQueue)}
+}
+
+func TestPipelineCheckpoint(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1A := testutils.RandomBlockRef(rng)
+	l1B := testutils.NextRandomRef(rng, l1A)
+	l1C := testutils.NextRandomRef(rng, l1B)
+	safe := testutils.RandomL2BlockRef(rng)
+	safe.L1Origin = l1A.ID()
+	unsafe := testutils.NextRandomL2Ref(rng, 2, safe, l1B.ID())
+
+	store := &fakeCheckpointStore{}
+	a := setupCheckpointTest(t, store)
+	dp := a.pipeline
+	dp.traversal.block = l1C
+	dp.traversal.sysCfg = eth.SystemConfig{BatcherAddr: common.Address{0x42}, GasLimit: 30_000_000}
+	a.eq.safeHead = safe
+	// a closed channel, and an open channel with a missing frame
+	dp.bank.IngestFrame(Frame{ID: ChannelID{1}, FrameNumber: 0, Data: []byte("a")})
+	dp.bank.IngestFrame(Frame{ID: ChannelID{1}, FrameNumber: 1, Data: []byte("b"), IsLast: true})
+	dp.bank.IngestFrame(Frame{ID: ChannelID{2}, FrameNumber: 1, Data: []byte("c")})
+	dp.frameQueue.frames = []Frame{{ID: ChannelID{3}, FrameNumber: 0, Data: []byte("d")}}
+	batch := &BatchData{BatchV1: BatchV1{
+		ParentHash:   safe.Hash,
+		EpochNum:     rollup.Epoch(l1A.Number),
+		EpochHash:    l1A.Hash,
+		Timestamp:    safe.Time + 2,
+		Transactions: []hexutil.Bytes{{0x01, 0x02}},
+	}}
+	dp.batchQueue.origin = l1B
+	dp.batchQueue.l1Blocks = []eth.L1BlockRef{l1A, l1B}
+	dp.batchQueue.batches = map[uint64][]*BatchWithL1InclusionBlock{
+		batch.Timestamp: {{L1InclusionBlock: l1B, Batch: batch}},
+	}
+
+	// no checkpoint is taken when data is still being processed
+	dp.chInReader.nextBatchFn = func() (BatchWithL1InclusionBlock, error) { return BatchWithL1InclusionBlock{}, nil }
+	dp.maybeCheckpoint()
+	require.Nil(t, store.cp)
+	dp.chInReader.nextBatchFn = nil
+	dp.maybeCheckpoint()
+	require.NotNil(t, store.cp)
+
+	// restore into a new pipeline, after its engine queue was reset to a later safe head
+	b := setupCheckpointTest(t, store)
+	b.eq.safeHead = unsafe
+	b.eq.unsafeHead = unsafe
+	b.eq.finalized = eth.L2BlockRef{}
+	b.l1.ExpectL1BlockRefByNumber(l1C.Number, l1C, nil)
+	b.engine.ExpectPayloadByNumber(safe.Number, &eth.ExecutionPayload{BlockHash: safe.Hash, BlockNumber: eth.Uint64Quantity(safe.Number)}, nil)
+	require.NoError(t, b.pipeline.restoreCheckpoint(context.Background()))
+
+	require.Equal(t, safe, b.eq.safeHead, "safe head is rewound to the checkpoint")
+	require.Equal(t, unsafe, b.eq.unsafeHead)
+	require.Equal(t, l1C, b.eq.origin)
+	require.Equal(t, l1C, b.pipeline.traversal.block)
+	require.Equal(t, dp.traversal.sysCfg, b.pipeline.traversal.sysCfg)
+	require.Equal(t, dp.frameQueue.frames, b.pipeline.frameQueue.frames)
+	require.Equal(t, dp.bank.channelQueue, b.pipeline.bank.channelQueue)
+	require.Equal(t, dp.bank.channels, b.pipeline.bank.channels)
+	require.Equal(t, dp.batchQueue.origin, b.pipeline.batchQueue.origin)
+	require.Equal(t, dp.batchQueue.l1Blocks, b.pipeline.batchQueue.l1Blocks)
+	require.Equal(t, dp.batchQueue.batches, b.pipeline.batchQueue.batches)
+	b.l1.AssertExpectations(t)
+	b.engine.AssertExpectations(t)
+}
+
+func TestPipelineCheckpointMismatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1A := testutils.RandomBlockRef(rng)
+	safe := testutils.RandomL2BlockRef(rng)
+	safe.L1Origin = l1A.ID()
+	cp := &PipelineCheckpoint{
+		L2Genesis: eth.BlockID{Hash: common.Hash{0xaa}, Number: 0},
+		SafeHead:  safe,
+		Origin:    l1A,
+	}
+
+	t.Run("no checkpoint", func(t *testing.T) {
+		s := setupCheckpointTest(t, &fakeCheckpointStore{})
+		require.ErrorContains(t, s.pipeline.restoreCheckpoint(context.Background()), "no checkpoint")
+	})
+	t.Run("different chain", func(t *testing.T) {
+		other := *cp
+		other.L2Genesis = eth.BlockID{Hash: common.Hash{0xbb}}
+		s := setupCheckpointTest(t, &fakeCheckpointStore{cp: &other})
+		require.ErrorContains(t, s.pipeline.restoreCheckpoint(context.Background()), "different chain")
+	})
+	t.Run("L1 reorg", func(t *testing.T) {
+		s := setupCheckpointTest(t, &fakeCheckpointStore{cp: cp})
+		s.l1.ExpectL1BlockRefByNumber(l1A.Number, testutils.RandomBlockRef(rng), nil)
+		require.ErrorContains(t, s.pipeline.restoreCheckpoint(context.Background()), "not canonical")
+	})
+	t.Run("L2 reorg", func(t *testing.T) {
+		s := setupCheckpointTest(t, &fakeCheckpointStore{cp: cp})
+		s.eq.unsafeHead = safe
+		s.l1.ExpectL1BlockRefByNumber(l1A.Number, l1A, nil)
+		s.engine.ExpectPayloadByNumber(safe.Number, &eth.ExecutionPayload{BlockHash: common.Hash{0xcc}}, nil)
+		require.ErrorContains(t, s.pipeline.restoreCheckpoint(context.Background()), "not canonical")
+	})
+	t.Run("finalized ahead", func(t *testing.T) {
+		s := setupCheckpointTest(t, &fakeCheckpointStore{cp: cp})
+		s.eq.unsafeHead = eth.L2BlockRef{Number: safe.Number + 10}
+		s.eq.finalized = eth.L2BlockRef{Number: safe.Number + 1}
+		s.l1.ExpectL1BlockRefByNumber(l1A.Number, l1A, nil)
+		require.ErrorContains(t, s.pipeline.restoreCheckpoint(context.Background()), "older than finalized")
+	})
+}
@@ -0,0 +1,277 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Names of the derivation pipeline stages, in the order in which data flows through the pipeline.
+const (
+	StageL1Traversal     = "l1_traversal"
+	StageL1Retrieval     = "l1_retrieval"
+	StageFrameQueue      = "frame_queue"
+	StageChannelBank     = "channel_bank"
+	StageChannelInReader = "channel_in_reader"
+	StageBatchQueue      = "batch_queue"
+	StageAttributesQueue = "attributes_queue"
+	StageEngineQueue     = "engine_queue"
+)
+
+var stageOrder = []string{
+	StageL1Traversal,
+	StageL1Retrieval,
+	StageFrameQueue,
+	StageChannelBank,
+	StageChannelInReader,
+	StageBatchQueue,
+	StageAttributesQueue,
+	StageEngineQueue,
+}
+
+// PipelineDebugInfo describes the internal state of the derivation pipeline, to debug stalled derivation.
+type PipelineDebugInfo struct {
+	Stages   []StageDebugInfo `json:"stages"`
+	Channels []ChannelInfo    `json:"channels"`
+	Batches  []BatchInfo      `json:"batches"`
+}
+
+// StageDebugInfo describes a single stage of the derivation pipeline.
+type StageDebugInfo struct {
+	Name   string         `json:"name"`
+	Origin eth.L1BlockRef `json:"origin"`
+	// LastError is the last error returned by the stage, including errors of previous stages that passed through it.
+	// Errors that only signal the stage is waiting for more data are not included.
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorTime is the unix timestamp of the last error, or 0 if the stage did not return an error.
+	LastErrorTime uint64 `json:"lastErrorTime,omitempty"`
+}
+
+// ChannelInfo describes a channel pending in the channel bank.
+type ChannelInfo struct {
+	ID             ChannelID      `json:"id"`
+	OpenBlock      eth.L1BlockRef `json:"openBlock"`
+	FramesReceived int            `json:"framesReceived"`
+	Size           uint64         `json:"size"`
+	Closed         bool           `json:"closed"`
+	Ready          bool           `json:"ready"`
+	// TimeoutBlock is the last L1 block number the channel can be completed in, before it times out.
+	TimeoutBlock uint64 `json:"timeoutBlock"`
+}
+
+// BatchInfo describes a batch buffered in the batch queue, with its validity verdict against the current safe head.
+type BatchInfo struct {
+	Timestamp uint64 `json:"timestamp"`
+	// Parent is the parent hash of the batch, or the first 20 bytes of it for span batches.
+	Parent           hexutil.Bytes  `json:"parent"`
+	EpochNum         uint64         `json:"epochNum"`
+	L1InclusionBlock eth.L1BlockRef `json:"l1InclusionBlock"`
+	// Blocks is the number of L2 blocks of the batch, greater than 1 for span batches.
+	Blocks   int    `json:"blocks"`
+	Validity string `json:"validity"`
+}
+
+// ChannelFrames lists the frames of a channel pending in the channel bank.
+type ChannelFrames struct {
+	ID     ChannelID   `json:"id"`
+	Frames []FrameInfo `json:"frames"`
+}
+
+// FrameInfo is a frame, with its binary encoding as it was submitted to L1.
+type FrameInfo struct {
+	FrameNumber uint16        `json:"frameNumber"`
+	IsLast      bool          `json:"isLast"`
+	Raw         hexutil.Bytes `json:"raw"`
+}
+
+type stageError struct {
+	err  error
+	time time.Time
+}
+
+// stageErrors tracks the last error returned by each stage.
+// Like the stages, it is not safe for concurrent use.
+type stageErrors map[string]stageError
+
+func (s stageErrors) record(stage string, err error) {
+	if err == nil || err == io.EOF || errors.Is(err, NotEnoughData) || errors.Is(err, EngineP2PSyncing) {
+		return
+	}
+	s[stage] = stageError{err: err, time: time.Now()}
+}
+
+// The below wrap the input of each stage, to record the errors returned by the previous stage.
+
+type debugBlockProvider struct {
+	NextBlockProvider
+	name string
+	errs stageErrors
+}
+
+func (p *debugBlockProvider) NextL1Block(ctx context.Context) (eth.L1BlockRef, error) {
+	ref, err := p.NextBlockProvider.NextL1Block(ctx)
+	p.errs.record(p.name, err)
+	return ref, err
+}
+
+type debugDataProvider struct {
+	NextDataProvider
+	name string
+	errs stageErrors
+}
+
+func (p *debugDataProvider) NextData(ctx context.Context) ([]byte, error) {
+	data, err := p.NextDataProvider.NextData(ctx)
+	p.errs.record(p.name, err)
+	return data, err
+}
+
+type debugFrameProvider struct {
+	NextFrameProvider
+	name string
+	errs stageErrors
+}
+
+func (p *debugFrameProvider) NextFrame(ctx context.Context) (Frame, error) {
+	frame, err := p.NextFrameProvider.NextFrame(ctx)
+	p.errs.record(p.name, err)
+	return frame, err
+}
+
+type debugBatchProvider struct {
+	NextBatchProvider
+	name string
+	errs stageErrors
+}
+
+func (p *debugBatchProvider) NextBatch(ctx context.Context) (*BatchData, error) {
+	batch, err := p.NextBatchProvider.NextBatch(ctx)
+	p.errs.record(p.name, err)
+	return batch, err
+}
+
+type debugSafeBatchProvider struct {
+	SafeBatchProvider
+	name string
+	errs stageErrors
+}
+
+func (p *debugSafeBatchProvider) NextBatch(ctx context.Context, l2SafeHead eth.L2BlockRef) (*BatchData, error) {
+	batch, err := p.SafeBatchProvider.NextBatch(ctx, l2SafeHead)
+	p.errs.record(p.name, err)
+	return batch, err
+}
+
+type debugAttributesProvider struct {
+	NextAttributesProvider
+	name string
+	errs stageErrors
+}
+
+func (p *debugAttributesProvider) NextAttributes(ctx context.Context, l2SafeHead eth.L2BlockRef) (*eth.PayloadAttributes, error) {
+	attrs, err := p.NextAttributesProvider.NextAttributes(ctx, l2SafeHead)
+	p.errs.record(p.name, err)
+	return attrs, err
+}
+
+// DebugInfo returns the internal state of the pipeline stages.
+// It must not be called concurrently with the pipeline Step.
+func (dp *DerivationPipeline) DebugInfo() *PipelineDebugInfo {
+	origins := map[string]eth.L1BlockRef{
+		StageL1Traversal:     dp.traversal.Origin(),
+		StageL1Retrieval:     dp.l1Src.Origin(),
+		StageFrameQueue:      dp.frameQueue.Origin(),
+		StageChannelBank:     dp.bank.Origin(),
+		StageChannelInReader: dp.chInReader.Origin(),
+		StageBatchQueue:      dp.batchQueue.Origin(),
+		StageAttributesQueue: dp.attributesQueue.Origin(),
+		StageEngineQueue:     dp.eng.Origin(),
+	}
+	info := &PipelineDebugInfo{
+		Stages:   make([]StageDebugInfo, 0, len(stageOrder)),
+		Channels: make([]ChannelInfo, 0, len(dp.bank.channelQueue)),
+		Batches:  make([]BatchInfo, 0, len(dp.batchQueue.batches)),
+	}
+	for _, name := range stageOrder {
+		stage := StageDebugInfo{Name: name, Origin: origins[name]}
+		if e, ok := dp.stageErrs[name]; ok {
+			stage.LastError = e.err.Error()
+			stage.LastErrorTime = uint64(e.time.Unix())
+		}
+		info.Stages = append(info.Stages, stage)
+	}
+	for _, id := range dp.bank.channelQueue {
+		ch := dp.bank.channels[id]
+		info.Channels = append(info.Channels, ChannelInfo{
+			ID:             ch.id,
+			OpenBlock:      ch.openBlock,
+			FramesReceived: len(ch.inputs),
+			Size:           ch.Size(),
+			Closed:         ch.closed,
+			Ready:          ch.IsReady(),
+			TimeoutBlock:   ch.OpenBlockNumber() + dp.cfg.ChannelTimeout,
+		})
+	}
+
+	// The verdicts are only informational, checking the batches should not add to the logs.
+	quiet := log.New()
+	quiet.SetHandler(log.DiscardHandler())
+	safeHead := dp.eng.SafeL2Head()
+	for _, batches := range dp.batchQueue.batches {
+		for _, b := range batches {
+			batch := BatchInfo{
+				Timestamp:        b.Timestamp(),
+				L1InclusionBlock: b.L1InclusionBlock,
+				Blocks:           1,
+			}
+			if b.SpanBatch != nil {
+				batch.Parent = b.SpanBatch.ParentCheck[:]
+				batch.EpochNum = uint64(b.SpanBatch.GetStartEpochNum())
+				batch.Blocks = b.SpanBatch.GetBlockCount()
+			} else {
+				batch.Parent = b.Batch.ParentHash[:]
+				batch.EpochNum = uint64(b.Batch.EpochNum)
+			}
+			if len(dp.batchQueue.l1Blocks) == 0 {
+				batch.Validity = BatchValidity(BatchUndecided).String()
+			} else {
+				batch.Validity = CheckBatch(dp.cfg, quiet, dp.batchQueue.l1Blocks, safeHead, b).String()
+			}
+			info.Batches = append(info.Batches, batch)
+		}
+	}
+	sort.SliceStable(info.Batches, func(i, j int) bool {
+		return info.Batches[i].Timestamp < info.Batches[j].Timestamp
+	})
+	return info
+}
+
+// ChannelFrames returns the frames received so far of the given channel pending in the channel bank.
+// It must not be called concurrently with the pipeline Step.
+func (dp *DerivationPipeline) ChannelFrames(id ChannelID) (*ChannelFrames, error) {
+	ch, ok := dp.bank.channels[id]
+	if !ok {
+		return nil, fmt.Errorf("channel %s is not pending in the channel bank", id)
+	}
+	out := &ChannelFrames{ID: id, Frames: make([]FrameInfo, 0, len(ch.inputs))}
+	for _, f := range ch.inputs {
+		var buf bytes.Buffer
+		if err := f.MarshalBinary(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode frame %d: %w", f.FrameNumber, err)
+		}
+		out.Frames = append(out.Frames, FrameInfo{FrameNumber: f.FrameNumber, IsLast: f.IsLast, Raw: buf.Bytes()})
+	}
+	sort.Slice(out.Frames, func(i, j int) bool {
+		return out.Frames[i].FrameNumber < out.Frames[j].FrameNumber
+	})
+	return out, nil
+}
@@ -0,0 +1,147 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestPipelineDebugInfo(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1A := testutils.RandomBlockRef(rng)
+	l1B := testutils.NextRandomRef(rng, l1A)
+	safe := testutils.RandomL2BlockRef(rng)
+	safe.L1Origin = l1A.ID()
+	safe.Time = l1A.Time
+
+	s := setupCheckpointTest(t, nil)
+	dp := s.pipeline
+	dp.traversal.block = l1B
+	s.eq.safeHead = safe
+	s.eq.origin = l1A
+
+	dp.bank.IngestFrame(Frame{ID: ChannelID{1}, FrameNumber: 1, Data: []byte("b"), IsLast: true})
+	dp.bank.IngestFrame(Frame{ID: ChannelID{1}, FrameNumber: 0, Data: []byte("a")})
+	dp.bank.IngestFrame(Frame{ID: ChannelID{2}, FrameNumber: 1, Data: []byte("c")})
+
+	valid := &BatchData{BatchV1: BatchV1{
+		ParentHash: safe.Hash,
+		EpochNum:   rollup.Epoch(l1A.Number),
+		EpochHash:  l1A.Hash,
+		Timestamp:  safe.Time + s.cfg.BlockTime,
+	}}
+	invalid := &BatchData{BatchV1: BatchV1{
+		ParentHash: testutils.RandomHash(rng),
+		EpochNum:   rollup.Epoch(l1A.Number),
+		EpochHash:  l1A.Hash,
+		Timestamp:  safe.Time + s.cfg.BlockTime,
+	}}
+	future := &BatchData{BatchV1: BatchV1{
+		ParentHash: testutils.RandomHash(rng),
+		EpochNum:   rollup.Epoch(l1A.Number),
+		EpochHash:  l1A.Hash,
+		Timestamp:  safe.Time + 2*s.cfg.BlockTime,
+	}}
+	dp.batchQueue.origin = l1B
+	dp.batchQueue.l1Blocks = []eth.L1BlockRef{l1A, l1B}
+	dp.batchQueue.batches = map[uint64][]*BatchWithL1InclusionBlock{
+		valid.Timestamp:  {{L1InclusionBlock: l1B, Batch: valid}, {L1InclusionBlock: l1B, Batch: invalid}},
+		future.Timestamp: {{L1InclusionBlock: l1B, Batch: future}},
+	}
+
+	stageErr := errors.New("test stage error")
+	dp.stageErrs.record(StageChannelBank, stageErr)
+	dp.stageErrs.record(StageFrameQueue, NotEnoughData)
+
+	info := dp.DebugInfo()
+	require.Len(t, info.Stages, 8)
+	require.Equal(t, StageL1Traversal, info.Stages[0].Name)
+	require.Equal(t, l1B, info.Stages[0].Origin)
+	require.Equal(t, StageEngineQueue, info.Stages[7].Name)
+	require.Equal(t, l1A, info.Stages[7].Origin)
+	for _, stage := range info.Stages {
+		if stage.Name == StageChannelBank {
+			require.Equal(t, stageErr.Error(), stage.LastError)
+			require.NotZero(t, stage.LastErrorTime)
+		} else {
+			require.Empty(t, stage.LastError, "stage %s has no error", stage.Name)
+		}
+	}
+
+	require.Equal(t, []ChannelInfo{
+		{ID: ChannelID{1}, OpenBlock: dp.bank.Origin(), FramesReceived: 2, Size: 2 + 2*frameOverhead, Closed: true, Ready: true, TimeoutBlock: l1B.Number + s.cfg.ChannelTimeout},
+		{ID: ChannelID{2}, OpenBlock: dp.bank.Origin(), FramesReceived: 1, Size: 1 + frameOverhead, TimeoutBlock: l1B.Number + s.cfg.ChannelTimeout},
+	}, info.Channels)
+
+	require.Len(t, info.Batches, 3)
+	verdicts := make(map[string]string)
+	for _, b := range info.Batches[:2] {
+		require.Equal(t, valid.Timestamp, b.Timestamp)
+		verdicts[b.Parent.String()] = b.Validity
+	}
+	require.Equal(t, "accept", verdicts[hexutil.Encode(valid.ParentHash[:])])
+	require.Equal(t, "drop", verdicts[hexutil.Encode(invalid.ParentHash[:])])
+	require.Equal(t, future.Timestamp, info.Batches[2].Timestamp)
+	require.Equal(t, "future", info.Batches[2].Validity)
+}
+
+func TestPipelineChannelFrames(t *testing.T) {
+	s := setupCheckpointTest(t, nil)
+	dp := s.pipeline
+	frames := []Frame{
+		{ID: ChannelID{1}, FrameNumber: 1, Data: []byte("b")},
+		{ID: ChannelID{1}, FrameNumber: 0, Data: []byte("a")},
+	}
+	for _, f := range frames {
+		dp.bank.IngestFrame(f)
+	}
+
+	out, err := dp.ChannelFrames(ChannelID{1})
+	require.NoError(t, err)
+	require.Equal(t, ChannelID{1}, out.ID)
+	require.Len(t, out.Frames, 2)
+	for i, f := range out.Frames {
+		require.Equal(t, uint16(i), f.FrameNumber)
+		var frame Frame
+		require.NoError(t, frame.UnmarshalBinary(bytes.NewReader(f.Raw)))
+		require.Equal(t, frames[1-i], frame, "raw frame decodes to the ingested frame")
+	}
+
+	_, err = dp.ChannelFrames(ChannelID{2})
+	require.ErrorContains(t, err, "not pending")
+}
+
+func TestStageErrors(t *testing.T) {
+	errs := make(stageErrors)
+	input := &fakeChannelBankInput{}
+	testErr := errors.New("test frame error")
+	input.AddFrame(Frame{}, io.EOF)
+	input.AddFrame(Frame{}, NotEnoughData)
+	input.AddFrame(Frame{}, testErr)
+	input.AddFrame(Frame{}, nil)
+	bank := NewChannelBank(testlog.Logger(t, log.LvlError), &rollup.Config{ChannelTimeout: 10}, &debugFrameProvider{input, StageFrameQueue, errs}, nil, &testutils.TestDerivationMetrics{})
+
+	next := func() error {
+		_, err := bank.NextData(context.Background())
+		return err
+	}
+	require.ErrorIs(t, next(), io.EOF)
+	require.ErrorIs(t, next(), NotEnoughData)
+	require.Empty(t, errs, "errors that signal waiting for data are not recorded")
+	require.ErrorIs(t, next(), testErr)
+	require.ErrorIs(t, errs[StageFrameQueue].err, testErr)
+	require.ErrorIs(t, next(), NotEnoughData)
+	require.ErrorIs(t, errs[StageFrameQueue].err, testErr, "the last error is kept")
+}
@@ -41,9 +41,40 @@ func AttributesMatchBlock(attrs *eth.PayloadAttributes, parentHash common.Hash,
 	if *attrs.GasLimit != block.GasLimit {
 		return fmt.Errorf("gas limit does not match. expected %d. got: %d", *attrs.GasLimit, block.GasLimit)
 	}
+	if withdrawalErr := checkWithdrawalsMatch(attrs.Withdrawals, block.Withdrawals); withdrawalErr != nil {
+		return withdrawalErr
+	}
+	if !equalHashPtr(attrs.ParentBeaconBlockRoot, block.ParentBeaconBlockRoot) {
+		return fmt.Errorf("parent beacon block root does not match. expected %v. got: %v", attrs.ParentBeaconBlockRoot, block.ParentBeaconBlockRoot)
+	}
 	return nil
 }
 
+func checkWithdrawalsMatch(attrWithdrawals *types.Withdrawals, blockWithdrawals *types.Withdrawals) error {
+	if attrWithdrawals == nil && blockWithdrawals == nil {
+		return nil
+	}
+	if attrWithdrawals == nil || blockWithdrawals == nil {
+		return fmt.Errorf("withdrawals presence does not match. expected %t. got: %t", attrWithdrawals != nil, blockWithdrawals != nil)
+	}
+	if len(*attrWithdrawals) != len(*blockWithdrawals) {
+		return fmt.Errorf("withdrawals count does not match. expected %d. got: %d", len(*attrWithdrawals), len(*blockWithdrawals))
+	}
+	for i, w := range *attrWithdrawals {
+		if *w != *(*blockWithdrawals)[i] {
+			return fmt.Errorf("withdrawal %d does not match. expected %v. got: %v", i, *w, *(*blockWithdrawals)[i])
+		}
+	}
+	return nil
+}
+
+func equalHashPtr(a, b *common.Hash) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // logL1InfoTxns reports the values from the L1 info tx when they differ to aid
 // debugging. This check is the one that has been most frequently triggered.
 func logL1InfoTxns(l log.Logger, l2Number, l2Timestamp uint64, safeTx, unsafeTx hexutil.Bytes) {
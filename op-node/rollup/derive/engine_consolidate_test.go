@@ -0,0 +1,54 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestAttributesMatchBlockUpgradeFields(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	parentHash := common.Hash{0x01}
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	setup := func() (*eth.PayloadAttributes, *eth.ExecutionPayload) {
+		attrs := &eth.PayloadAttributes{
+			Timestamp:             1000,
+			GasLimit:              &gasLimit,
+			Withdrawals:           &types.Withdrawals{},
+			ParentBeaconBlockRoot: &common.Hash{0xbe},
+		}
+		block := &eth.ExecutionPayload{
+			ParentHash:            parentHash,
+			Timestamp:             1000,
+			GasLimit:              gasLimit,
+			Withdrawals:           &types.Withdrawals{},
+			ParentBeaconBlockRoot: &common.Hash{0xbe},
+		}
+		return attrs, block
+	}
+
+	attrs, block := setup()
+	require.NoError(t, AttributesMatchBlock(attrs, parentHash, block, logger))
+
+	attrs, block = setup()
+	block.Withdrawals = nil
+	require.ErrorContains(t, AttributesMatchBlock(attrs, parentHash, block, logger), "withdrawals presence does not match")
+
+	attrs, block = setup()
+	block.Withdrawals = &types.Withdrawals{{Index: 1}}
+	require.ErrorContains(t, AttributesMatchBlock(attrs, parentHash, block, logger), "withdrawals count does not match")
+
+	attrs, block = setup()
+	block.ParentBeaconBlockRoot = &common.Hash{0xbf}
+	require.ErrorContains(t, AttributesMatchBlock(attrs, parentHash, block, logger), "parent beacon block root does not match")
+
+	attrs, block = setup()
+	attrs.ParentBeaconBlockRoot = nil
+	require.ErrorContains(t, AttributesMatchBlock(attrs, parentHash, block, logger), "parent beacon block root does not match")
+}
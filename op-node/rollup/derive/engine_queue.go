@@ -28,7 +28,7 @@ type NextAttributesProvider interface {
 }
 
 type Engine interface {
-	GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error)
+	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayload, error)
 	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
 	NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error)
 	PayloadByHash(context.Context, common.Hash) (*eth.ExecutionPayload, error)
@@ -85,6 +85,21 @@ const finalityLookback = 4*32 + 1
 // We do not want to do this too often, since it requires fetching a L1 block by number, so no cache data.
 const finalityDelay = 64
 
+// SafeHeadListener is notified of the L1 block each safe head was fully derived from,
+// and of resets of the safe head, so it can maintain an index of L1 block to safe head.
+type SafeHeadListener interface {
+	// Enabled reports if tracking safe head changes is enabled.
+	Enabled() bool
+
+	// SafeHeadUpdated indicates that the safe head has been updated in response to processing batch data.
+	// The l1Block specified is the block the safe head was fully derived from.
+	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error
+
+	// SafeHeadReset indicates that the derivation pipeline reset back to the specified safe head.
+	// Any data recorded for safe heads after the reset safe head should be discarded.
+	SafeHeadReset(resetSafeHead eth.L2BlockRef) error
+}
+
 type FinalityData struct {
 	// The last L2 block that was fully derived and inserted into the L2 engine while processing this L1 block.
 	L2Block eth.L2BlockRef
@@ -107,7 +122,7 @@ type EngineQueue struct {
 	engineSyncTarget eth.L2BlockRef
 
 	buildingOnto eth.L2BlockRef
-	buildingID   eth.PayloadID
+	buildingInfo eth.PayloadInfo
 	buildingSafe bool
 
 	// Track when the rollup node changes the forkchoice without engine action,
@@ -139,12 +154,19 @@ type EngineQueue struct {
 	l1Fetcher L1Fetcher
 
 	syncCfg *sync.Config
+
+	safeHeadNotifs SafeHeadListener // notified when the safe head is updated or reset
+	// safeHeadDerived indicates if the safe head was derived from L1 data since the last reset,
+	// so that the safe head found by the reset is not attributed to the reset origin.
+	safeHeadDerived bool
+	// lastNotified is the last safe head and L1 block pair the safeHeadNotifs listener was notified of.
+	lastNotified FinalityData
 }
 
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config, safeHeadListener SafeHeadListener) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -155,6 +177,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics M
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
 		syncCfg:        syncCfg,
+		safeHeadNotifs: safeHeadListener,
 	}
 }
 
@@ -268,7 +291,10 @@ func (eq *EngineQueue) Step(ctx context.Context) error {
 		return err
 	}
 	eq.origin = newOrigin
-	eq.postProcessSafeL2() // make sure we track the last L2 safe head for every new L1 block
+	// make sure we track the last L2 safe head for every new L1 block
+	if err := eq.postProcessSafeL2(); err != nil {
+		return err
+	}
 	// try to finalize the L2 blocks we have synced so far (no-op if L1 finality is behind)
 	if err := eq.tryFinalizePastL2Blocks(ctx); err != nil {
 		return err
@@ -378,9 +404,12 @@ func (eq *EngineQueue) tryFinalizeL2() {
 	eq.metrics.RecordL2Ref("l2_finalized", finalizedL2)
 }
 
-// postProcessSafeL2 buffers the L1 block the safe head was fully derived from,
-// to finalize it once the L1 block, or later, finalizes.
-func (eq *EngineQueue) postProcessSafeL2() {
+// postProcessSafeL2 notifies the safe head listener of the L1 block the safe head was fully derived from,
+// and buffers it, to finalize the safe head once the L1 block, or later, finalizes.
+func (eq *EngineQueue) postProcessSafeL2() error {
+	if err := eq.notifySafeHeadUpdated(); err != nil {
+		return err
+	}
 	// prune finality data if necessary
 	if len(eq.finalityData) >= finalityLookback {
 		eq.finalityData = append(eq.finalityData[:0], eq.finalityData[1:finalityLookback]...)
@@ -402,6 +431,7 @@ func (eq *EngineQueue) postProcessSafeL2() {
 			eq.log.Debug("updated finality-data", "last_l1", last.L1Block, "last_l2", last.L2Block)
 		}
 	}
+	return nil
 }
 
 func (eq *EngineQueue) logSyncProgress(reason string) {
@@ -488,6 +518,12 @@ func (eq *EngineQueue) tryNextUnsafePayload(ctx context.Context) error {
 		return io.EOF // time to go to next stage if we cannot process the first unsafe payload
 	}
 
+	if err := CheckPayloadVersion(first, eq.cfg); err != nil {
+		eq.log.Warn("skipping unsafe payload with invalid format", "err", err)
+		eq.unsafePayloads.Pop()
+		return nil
+	}
+
 	ref, err := PayloadToBlockRef(first, &eq.cfg.Genesis)
 	if err != nil {
 		eq.log.Error("failed to decode L2 block ref from payload", "err", err)
@@ -607,8 +643,11 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 	eq.needForkchoiceUpdate = true
 	eq.metrics.RecordL2Ref("l2_safe", ref)
 	// unsafe head stays the same, we did not reorg the chain.
+	eq.safeHeadDerived = true
 	eq.safeAttributes = nil
-	eq.postProcessSafeL2()
+	if err := eq.postProcessSafeL2(); err != nil {
+		return err
+	}
 	eq.logSyncProgress("reconciled with L1")
 
 	return nil
@@ -670,8 +709,8 @@ func (eq *EngineQueue) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 	if eq.isEngineSyncing() {
 		return BlockInsertTemporaryErr, fmt.Errorf("engine is in progess of p2p sync")
 	}
-	if eq.buildingID != (eth.PayloadID{}) {
-		eq.log.Warn("did not finish previous block building, starting new building now", "prev_onto", eq.buildingOnto, "prev_payload_id", eq.buildingID, "new_onto", parent)
+	if eq.buildingInfo.ID != (eth.PayloadID{}) {
+		eq.log.Warn("did not finish previous block building, starting new building now", "prev_onto", eq.buildingOnto, "prev_payload_id", eq.buildingInfo.ID, "new_onto", parent)
 		// TODO: maybe worth it to force-cancel the old payload ID here.
 	}
 	fc := eth.ForkchoiceState{
@@ -683,14 +722,14 @@ func (eq *EngineQueue) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 	if err != nil {
 		return errTyp, err
 	}
-	eq.buildingID = id
+	eq.buildingInfo = eth.PayloadInfo{ID: id, Timestamp: uint64(attrs.Timestamp), ParentBeaconBlockRoot: attrs.ParentBeaconBlockRoot}
 	eq.buildingSafe = updateSafe
 	eq.buildingOnto = parent
 	return BlockInsertOK, nil
 }
 
 func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
-	if eq.buildingID == (eth.PayloadID{}) {
+	if eq.buildingInfo.ID == (eth.PayloadID{}) {
 		return nil, BlockInsertPrestateErr, fmt.Errorf("cannot complete payload building: not currently building a payload")
 	}
 	if eq.buildingOnto.Hash != eq.unsafeHead.Hash { // E.g. when safe-attributes consolidation fails, it will drop the existing work.
@@ -701,9 +740,9 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 		SafeBlockHash:      eq.safeHead.Hash,
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
-	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, eq.buildingSafe)
+	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingInfo, eq.buildingSafe)
 	if err != nil {
-		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingID, errTyp, err)
+		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingInfo.ID, errTyp, err)
 	}
 	ref, err := PayloadToBlockRef(payload, &eq.cfg.Genesis)
 	if err != nil {
@@ -717,22 +756,26 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 
 	if eq.buildingSafe {
 		eq.safeHead = ref
-		eq.postProcessSafeL2()
+		eq.safeHeadDerived = true
 		eq.metrics.RecordL2Ref("l2_safe", ref)
+		if err := eq.postProcessSafeL2(); err != nil {
+			eq.resetBuildingState()
+			return nil, BlockInsertTemporaryErr, err
+		}
 	}
 	eq.resetBuildingState()
 	return payload, BlockInsertOK, nil
 }
 
 func (eq *EngineQueue) CancelPayload(ctx context.Context, force bool) error {
-	if eq.buildingID == (eth.PayloadID{}) { // only cancel if there is something to cancel.
+	if eq.buildingInfo.ID == (eth.PayloadID{}) { // only cancel if there is something to cancel.
 		return nil
 	}
 	// the building job gets wrapped up as soon as the payload is retrieved, there's no explicit cancel in the Engine API
-	eq.log.Error("cancelling old block sealing job", "payload", eq.buildingID)
-	_, err := eq.engine.GetPayload(ctx, eq.buildingID)
+	eq.log.Error("cancelling old block sealing job", "payload", eq.buildingInfo.ID)
+	_, err := eq.engine.GetPayload(ctx, eq.buildingInfo)
 	if err != nil {
-		eq.log.Error("failed to cancel block building job", "payload", eq.buildingID, "err", err)
+		eq.log.Error("failed to cancel block building job", "payload", eq.buildingInfo.ID, "err", err)
 		if !force {
 			return err
 		}
@@ -742,11 +785,11 @@ func (eq *EngineQueue) CancelPayload(ctx context.Context, force bool) error {
 }
 
 func (eq *EngineQueue) BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool) {
-	return eq.buildingOnto, eq.buildingID, eq.buildingSafe
+	return eq.buildingOnto, eq.buildingInfo.ID, eq.buildingSafe
 }
 
 func (eq *EngineQueue) resetBuildingState() {
-	eq.buildingID = eth.PayloadID{}
+	eq.buildingInfo = eth.PayloadInfo{}
 	eq.buildingOnto = eth.L2BlockRef{}
 	eq.buildingSafe = false
 }
@@ -801,6 +844,13 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	eq.resetBuildingState()
 	eq.needForkchoiceUpdate = true
 	eq.finalityData = eq.finalityData[:0]
+	eq.safeHeadDerived = false
+	eq.lastNotified = FinalityData{}
+	if eq.safeHeadNotifs != nil && eq.safeHeadNotifs.Enabled() {
+		if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+			return NewTemporaryError(fmt.Errorf("failed to notify safe head reset to %s: %w", safe, err))
+		}
+	}
 	// note: finalizedL1 and triedFinalizeAt do not reset, since these do not change between reorgs.
 	// note: we do not clear the unsafe payloads queue; if the payloads are not applicable anymore the parent hash checks will clear out the old payloads.
 	eq.origin = pipelineOrigin
@@ -813,6 +863,56 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	return io.EOF
 }
 
+// RestoreSafeHead rewinds the safe head to the given safe head, and continues derivation from the given L1 origin,
+// such that derivation can continue from a pipeline checkpoint instead of the origin determined by Reset.
+// This must only be called directly after Reset. The safe head must be canonical, and not older than the finalized block.
+// Blocks after it are consolidated again, like after a regular reset.
+func (eq *EngineQueue) RestoreSafeHead(ctx context.Context, safe eth.L2BlockRef, origin eth.L1BlockRef, sysCfg eth.SystemConfig) error {
+	if safe.Number > eq.unsafeHead.Number {
+		return fmt.Errorf("checkpoint safe head %s is ahead of unsafe head %s", safe, eq.unsafeHead)
+	}
+	if safe.Number < eq.finalized.Number {
+		return fmt.Errorf("checkpoint safe head %s is older than finalized block %s", safe, eq.finalized)
+	}
+	payload, err := eq.engine.PayloadByNumber(ctx, safe.Number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 block %d: %w", safe.Number, err)
+	}
+	if payload.BlockHash != safe.Hash {
+		return fmt.Errorf("checkpoint safe head %s is not canonical, found %s", safe, payload.ID())
+	}
+	if eq.safeHeadNotifs != nil && eq.safeHeadNotifs.Enabled() {
+		if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+			return fmt.Errorf("failed to notify safe head reset to %s: %w", safe, err)
+		}
+	}
+	eq.log.Info("Restoring safe head from checkpoint", "safe", safe, "prev_safe", eq.safeHead, "origin", origin)
+	eq.safeHead = safe
+	eq.needForkchoiceUpdate = true
+	eq.origin = origin
+	eq.sysCfg = sysCfg
+	eq.metrics.RecordL2Ref("l2_safe", safe)
+	return nil
+}
+
+// notifySafeHeadUpdated notifies the safe head listener, if enabled, of the current safe head
+// and the L1 block it was derived from. Safe heads that were not derived since the last reset are not notified.
+func (eq *EngineQueue) notifySafeHeadUpdated() error {
+	if eq.safeHeadNotifs == nil || !eq.safeHeadNotifs.Enabled() || !eq.safeHeadDerived {
+		return nil
+	}
+	update := FinalityData{L2Block: eq.safeHead, L1Block: eq.origin.ID()}
+	if update == eq.lastNotified {
+		return nil
+	}
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.safeHead, eq.origin.ID()); err != nil {
+		// The engine already applied the safe head, but the record of it is missing: stop instead of leaving a gap.
+		return NewCriticalError(fmt.Errorf("failed to notify safe head update to %s from L1 block %s: %w", eq.safeHead, eq.origin, err))
+	}
+	eq.lastNotified = update
+	return nil
+}
+
 // UnsafeL2SyncTarget retrieves the first queued-up L2 unsafe payload, or a zeroed reference if there is none.
 func (eq *EngineQueue) UnsafeL2SyncTarget() eth.L2BlockRef {
 	if first := eq.unsafePayloads.Peek(); first != nil {
@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -40,6 +41,35 @@ func (f *fakeAttributesQueue) NextAttributes(_ context.Context, _ eth.L2BlockRef
 
 var _ NextAttributesProvider = (*fakeAttributesQueue)(nil)
 
+type safeHeadUpdate struct {
+	safeHead eth.L2BlockRef
+	l1Block  eth.BlockID
+}
+
+type fakeSafeHeadListener struct {
+	enabled bool
+	err     error
+	updates []safeHeadUpdate
+	resets  []eth.L2BlockRef
+}
+
+func (f *fakeSafeHeadListener) Enabled() bool {
+	return f.enabled
+}
+
+func (f *fakeSafeHeadListener) SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.updates = append(f.updates, safeHeadUpdate{safeHead: newSafeHead, l1Block: l1Block})
+	return nil
+}
+
+func (f *fakeSafeHeadListener) SafeHeadReset(resetSafeHead eth.L2BlockRef) error {
+	f.resets = append(f.resets, resetSafeHead)
+	return nil
+}
+
 func TestEngineQueue_Finalize(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 
@@ -247,7 +277,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 
 	prev := &fakeAttributesQueue{}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -481,7 +511,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refE}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -812,7 +842,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 			}, nil)
 
 			prev := &fakeAttributesQueue{origin: refE}
-			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, nil)
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -910,7 +940,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	}
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs}
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, &sync.Config{}, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -937,7 +967,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	eng.ExpectForkchoiceUpdate(preFc, attrs, preFcRes, nil)
 	// Don't let the payload be confirmed straight away
 	mockErr := fmt.Errorf("mock error")
-	eng.ExpectGetPayload(id, nil, mockErr)
+	eng.ExpectGetPayload(eth.PayloadInfo{ID: id, Timestamp: uint64(attrs.Timestamp)}, nil, mockErr)
 	// The job will be not be cancelled, the untyped error is a temporary error
 
 	require.ErrorIs(t, eq.Step(context.Background()), NotEnoughData, "queue up attributes")
@@ -977,7 +1007,7 @@ func TestBlockBuildingRace(t *testing.T) {
 			a1InfoTx,
 		},
 	}
-	eng.ExpectGetPayload(id, payloadA1, nil)
+	eng.ExpectGetPayload(eth.PayloadInfo{ID: id, Timestamp: uint64(attrs.Timestamp)}, payloadA1, nil)
 	eng.ExpectNewPayload(payloadA1, &eth.PayloadStatusV1{
 		Status:          eth.ExecutionValid,
 		LatestValidHash: &refA1.Hash,
@@ -1080,7 +1110,8 @@ func TestResetLoop(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	listener := &fakeSafeHeadListener{enabled: true}
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, listener)
 	eq.unsafeHead = refA2
 	eq.engineSyncTarget = refA2
 	eq.safeHead = refA1
@@ -1093,6 +1124,7 @@ func TestResetLoop(t *testing.T) {
 
 	// Peform the reset
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
+	require.Equal(t, []eth.L2BlockRef{refA0}, listener.resets, "safe head reset is notified")
 
 	// Expect a FCU after the reset
 	preFc := &eth.ForkchoiceState{
@@ -1178,7 +1210,7 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, &sync.Config{}, nil)
 	eq.unsafeHead = refA2
 	eq.safeHead = refA0
 	eq.finalized = refA0
@@ -1194,3 +1226,38 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+func TestEngineQueue_PostProcessSafeL2NotifiesSafeHead(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	listener := &fakeSafeHeadListener{enabled: true}
+	eq := NewEngineQueue(testlog.Logger(t, log.LvlInfo), &rollup.Config{}, nil, metrics.NoopMetrics, nil, nil, &sync.Config{}, listener)
+	eq.safeHead = testutils.RandomL2BlockRef(rng)
+	eq.origin = testutils.RandomBlockRef(rng)
+
+	require.NoError(t, eq.postProcessSafeL2())
+	require.Empty(t, listener.updates, "safe head not derived since reset")
+	require.Len(t, eq.finalityData, 1)
+
+	eq.safeHeadDerived = true
+	require.NoError(t, eq.postProcessSafeL2())
+	require.NoError(t, eq.postProcessSafeL2())
+	require.Equal(t, []safeHeadUpdate{{safeHead: eq.safeHead, l1Block: eq.origin.ID()}}, listener.updates, "notified once per change")
+
+	eq.safeHead = testutils.NextRandomL2Ref(rng, 2, eq.safeHead, eq.safeHead.L1Origin)
+	require.NoError(t, eq.postProcessSafeL2())
+	require.Len(t, listener.updates, 2)
+	require.Equal(t, eq.safeHead, listener.updates[1].safeHead)
+	require.Equal(t, FinalityData{L2Block: eq.safeHead, L1Block: eq.origin.ID()}, eq.finalityData[0])
+
+	// the safe head is not tracked for finality without a record of it
+	listener.err = errors.New("db closed")
+	eq.origin = testutils.NextRandomRef(rng, eq.origin)
+	require.ErrorIs(t, eq.postProcessSafeL2(), ErrCritical)
+	require.Len(t, eq.finalityData, 1)
+
+	listener.err = nil
+	listener.enabled = false
+	require.NoError(t, eq.postProcessSafeL2())
+	require.Len(t, listener.updates, 2, "no notifications when disabled")
+	require.Len(t, eq.finalityData, 2)
+}
@@ -49,6 +49,17 @@ func PayloadToBlockRef(payload *eth.ExecutionPayload, genesis *rollup.Genesis) (
 	}, nil
 }
 
+// CheckPayloadVersion checks that the payload has the format of the network upgrades
+// that are active at the timestamp of the payload: withdrawals since Canyon,
+// and the blob gas fields and parent beacon block root since Ecotone.
+func CheckPayloadVersion(payload *eth.ExecutionPayload, cfg *rollup.Config) error {
+	version := cfg.PayloadVersion(uint64(payload.Timestamp))
+	if err := payload.CheckVersion(version); err != nil {
+		return fmt.Errorf("invalid %s payload %s at timestamp %d: %w", version, payload.ID(), uint64(payload.Timestamp), err)
+	}
+	return nil
+}
+
 func PayloadToSystemConfig(payload *eth.ExecutionPayload, cfg *rollup.Config) (eth.SystemConfig, error) {
 	if uint64(payload.BlockNumber) == cfg.Genesis.L2.Number {
 		if payload.BlockHash != cfg.Genesis.L2.Hash {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -55,6 +56,7 @@ type EngineQueueStage interface {
 	Origin() eth.L1BlockRef
 	SystemConfig() eth.SystemConfig
 	SetUnsafeHead(head eth.L2BlockRef)
+	RestoreSafeHead(ctx context.Context, safe eth.L2BlockRef, origin eth.L1BlockRef, sysCfg eth.SystemConfig) error
 
 	Finalize(l1Origin eth.L1BlockRef)
 	AddUnsafePayload(payload *eth.ExecutionPayload)
@@ -72,45 +74,79 @@ type DerivationPipeline struct {
 	// >= len(stages) if no additional resetting is required
 	resetting int
 	stages    []ResettableStage
+	// Names of the stages, by index
+	stageNames []string
+
+	// Last error returned by each stage
+	stageErrs stageErrors
 
 	// Special stages to keep track of
 	traversal *L1Traversal
 	eng       EngineQueueStage
 
+	// Stages with state that is included in checkpoints
+	l1Src           *L1Retrieval
+	frameQueue      *FrameQueue
+	bank            *ChannelBank
+	chInReader      *ChannelInReader
+	batchQueue      *BatchQueue
+	attributesQueue *AttributesQueue
+
+	checkpoints    CheckpointStore
+	lastCheckpoint time.Time
+	// restoring is true until the first reset of the pipeline, which tries to restore the persisted checkpoint.
+	restoring bool
+
 	metrics Metrics
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, syncCfg *sync.Config) *DerivationPipeline {
+// If checkpoints are enabled, the first reset of the pipeline continues from the persisted checkpoint, if it is still consistent.
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, l1Blobs L1BlobsFetcher, engine Engine, metrics Metrics, syncCfg *sync.Config, safeHeadListener SafeHeadListener, checkpoints CheckpointStore) *DerivationPipeline {
+
+	// The input of every stage is wrapped to track the last error returned by the previous stage, for debugging.
+	stageErrs := make(stageErrors)
 
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
-	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher) // auxiliary stage for L1Retrieval
-	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
-	frameQueue := NewFrameQueue(log, l1Src)
-	bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher, metrics)
-	chInReader := NewChannelInReader(log, bank, metrics)
-	batchQueue := NewBatchQueue(log, cfg, chInReader)
+	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher, l1Blobs) // auxiliary stage for L1Retrieval
+	l1Src := NewL1Retrieval(log, dataSrc, &debugBlockProvider{l1Traversal, StageL1Traversal, stageErrs})
+	frameQueue := NewFrameQueue(log, &debugDataProvider{l1Src, StageL1Retrieval, stageErrs})
+	bank := NewChannelBank(log, cfg, &debugFrameProvider{frameQueue, StageFrameQueue, stageErrs}, l1Fetcher, metrics)
+	chInReader := NewChannelInReader(log, &debugDataProvider{bank, StageChannelBank, stageErrs}, metrics)
+	batchQueue := NewBatchQueue(log, cfg, &debugBatchProvider{chInReader, StageChannelInReader, stageErrs})
 	attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, engine)
-	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
+	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, &debugSafeBatchProvider{batchQueue, StageBatchQueue, stageErrs})
 
 	// Step stages
-	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher, syncCfg)
+	eng := NewEngineQueue(log, cfg, engine, metrics, &debugAttributesProvider{attributesQueue, StageAttributesQueue, stageErrs}, l1Fetcher, syncCfg, safeHeadListener)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
 	// Note: The engine queue stage is the only reset that can fail.
 	stages := []ResettableStage{eng, l1Traversal, l1Src, frameQueue, bank, chInReader, batchQueue, attributesQueue}
+	stageNames := []string{StageEngineQueue, StageL1Traversal, StageL1Retrieval, StageFrameQueue, StageChannelBank, StageChannelInReader, StageBatchQueue, StageAttributesQueue}
 
 	return &DerivationPipeline{
-		log:       log,
-		cfg:       cfg,
-		l1Fetcher: l1Fetcher,
-		resetting: 0,
-		stages:    stages,
-		eng:       eng,
-		metrics:   metrics,
-		traversal: l1Traversal,
+		log:             log,
+		cfg:             cfg,
+		l1Fetcher:       l1Fetcher,
+		resetting:       0,
+		stages:          stages,
+		stageNames:      stageNames,
+		stageErrs:       stageErrs,
+		eng:             eng,
+		metrics:         metrics,
+		traversal:       l1Traversal,
+		l1Src:           l1Src,
+		frameQueue:      frameQueue,
+		bank:            bank,
+		chInReader:      chInReader,
+		batchQueue:      batchQueue,
+		attributesQueue: attributesQueue,
+		checkpoints:     checkpoints,
+		lastCheckpoint:  time.Now(),
+		restoring:       checkpoints != nil && checkpoints.Enabled(),
 	}
 }
 
@@ -197,8 +233,19 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 		if err := dp.stages[dp.resetting].Reset(ctx, dp.eng.Origin(), dp.eng.SystemConfig()); err == io.EOF {
 			dp.log.Debug("reset of stage completed", "stage", dp.resetting, "origin", dp.eng.Origin())
 			dp.resetting += 1
+			// After the engine queue found the L2 heads to start from, try to continue from the checkpoint,
+			// instead of resetting the remaining stages.
+			if dp.resetting == 1 && dp.restoring {
+				dp.restoring = false
+				if err := dp.restoreCheckpoint(ctx); err != nil {
+					dp.log.Warn("cannot restore derivation pipeline checkpoint, resetting the pipeline", "err", err)
+				} else {
+					dp.resetting = len(dp.stages)
+				}
+			}
 			return nil
 		} else if err != nil {
+			dp.stageErrs.record(dp.stageNames[dp.resetting], err)
 			return fmt.Errorf("stage %d failed resetting: %w", dp.resetting, err)
 		} else {
 			return nil
@@ -206,9 +253,17 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	}
 
 	// Now step the engine queue. It will pull earlier data as needed.
-	if err := dp.eng.Step(ctx); err == io.EOF {
+	err := dp.eng.Step(ctx)
+	dp.stageErrs.record(StageEngineQueue, err)
+	if err == io.EOF {
 		// If every stage has returned io.EOF, try to advance the L1 Origin
-		return dp.traversal.AdvanceL1Block(ctx)
+		if err := dp.traversal.AdvanceL1Block(ctx); err != nil {
+			dp.stageErrs.record(StageL1Traversal, err)
+			return err
+		}
+		// All data of the previous L1 blocks has been processed, which makes this a good point to checkpoint.
+		dp.maybeCheckpoint()
+		return nil
 	} else if errors.Is(err, EngineP2PSyncing) {
 		return err
 	} else if err != nil {
@@ -0,0 +1,336 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// Span batch format
+//
+// A span batch covers a contiguous range of L2 blocks, to avoid the overhead of a batch per block.
+//
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload
+// prefix := rel_timestamp ++ l1_origin_num ++ parent_check ++ l1_origin_check
+// payload := block_count ++ origin_bits ++ block_tx_counts ++ txs
+//
+// rel_timestamp is the timestamp of the first block relative to the L2 genesis time,
+// l1_origin_num is the L1 origin number of the last block,
+// parent_check and l1_origin_check are the first 20 bytes of the parent hash of the first block,
+// and of the L1 origin hash of the last block.
+// origin_bits is a bitlist of block_count bits, where the bit of a block is set if it changed the L1 origin.
+// The integers are encoded as unsigned varints. See spanBatchTxs for the encoding of the txs.
+
+// MaxSpanBatchSize is the maximum amount of bytes that will be needed
+// to decode every span batch field. This value cannot be larger than
+// MaxRLPBytesPerChannel because single batch cannot be larger than channel size.
+const MaxSpanBatchSize = MaxRLPBytesPerChannel
+
+var (
+	ErrTooBigSpanBatchSize = errors.New("span batch size limit reached")
+	ErrEmptySpanBatch      = errors.New("span-batch must not be empty")
+)
+
+type spanBatchPrefix struct {
+	relTimestamp  uint64   // Relative timestamp of the first block
+	l1OriginNum   uint64   // L1 origin number of the last block
+	parentCheck   [20]byte // First 20 bytes of the first block's parent hash
+	l1OriginCheck [20]byte // First 20 bytes of the last block's L1 origin hash
+}
+
+type spanBatchPayload struct {
+	blockCount    uint64        // Number of L2 blocks in the span
+	originBits    *big.Int      // Bitlist of blockCount bits. Each bit indicates if the L1 origin is changed at the L2 block.
+	blockTxCounts []uint64      // List of transaction counts for each L2 block
+	txs           *spanBatchTxs // Transactions encoded in SpanBatch specs
+}
+
+// RawSpanBatch is the encoded form of a span batch, which needs to be derived with the
+// L2 genesis time, block time and chain ID into the SpanBatch it represents.
+type RawSpanBatch struct {
+	spanBatchPrefix
+	spanBatchPayload
+}
+
+func readUvarint(r *bytes.Reader, name string) (uint64, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return v, nil
+}
+
+func (b *RawSpanBatch) decode(r *bytes.Reader) error {
+	if r.Len() > MaxSpanBatchSize {
+		return ErrTooBigSpanBatchSize
+	}
+	var err error
+	if b.relTimestamp, err = readUvarint(r, "rel timestamp"); err != nil {
+		return err
+	}
+	if b.l1OriginNum, err = readUvarint(r, "l1 origin num"); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, b.parentCheck[:]); err != nil {
+		return fmt.Errorf("failed to read parent check: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.l1OriginCheck[:]); err != nil {
+		return fmt.Errorf("failed to read l1 origin check: %w", err)
+	}
+	if b.blockCount, err = readUvarint(r, "block count"); err != nil {
+		return err
+	}
+	// Each block needs at least a byte for its tx count, which bounds the block count.
+	if b.blockCount > MaxSpanBatchSize {
+		return ErrTooBigSpanBatchSize
+	}
+	if b.blockCount == 0 {
+		return ErrEmptySpanBatch
+	}
+	if b.originBits, err = decodeSpanBatchBits(r, b.blockCount); err != nil {
+		return fmt.Errorf("failed to read origin bits: %w", err)
+	}
+	b.blockTxCounts = make([]uint64, 0, b.blockCount)
+	var totalBlockTxCount uint64
+	for i := uint64(0); i < b.blockCount; i++ {
+		count, err := readUvarint(r, "block tx count")
+		if err != nil {
+			return err
+		}
+		// Each tx needs at least 64 bytes for its signature, which bounds the tx count.
+		if count > MaxSpanBatchSize || totalBlockTxCount+count > MaxSpanBatchSize {
+			return ErrTooBigSpanBatchSize
+		}
+		totalBlockTxCount += count
+		b.blockTxCounts = append(b.blockTxCounts, count)
+	}
+	b.txs = &spanBatchTxs{totalBlockTxCount: totalBlockTxCount}
+	if err := b.txs.decode(r); err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("span batch has %d trailing bytes", r.Len())
+	}
+	return nil
+}
+
+func (b *RawSpanBatch) encode(w io.Writer) error {
+	var buf [binary.MaxVarintLen64]byte
+	writeUvarint := func(v uint64) error {
+		n := binary.PutUvarint(buf[:], v)
+		_, err := w.Write(buf[:n])
+		return err
+	}
+	if err := writeUvarint(b.relTimestamp); err != nil {
+		return fmt.Errorf("cannot write rel timestamp: %w", err)
+	}
+	if err := writeUvarint(b.l1OriginNum); err != nil {
+		return fmt.Errorf("cannot write l1 origin number: %w", err)
+	}
+	if _, err := w.Write(b.parentCheck[:]); err != nil {
+		return fmt.Errorf("cannot write parent check: %w", err)
+	}
+	if _, err := w.Write(b.l1OriginCheck[:]); err != nil {
+		return fmt.Errorf("cannot write l1 origin check: %w", err)
+	}
+	if err := writeUvarint(b.blockCount); err != nil {
+		return fmt.Errorf("cannot write block count: %w", err)
+	}
+	if err := encodeSpanBatchBits(w, b.blockCount, b.originBits); err != nil {
+		return fmt.Errorf("cannot write origin bits: %w", err)
+	}
+	for _, count := range b.blockTxCounts {
+		if err := writeUvarint(count); err != nil {
+			return fmt.Errorf("cannot write block tx count: %w", err)
+		}
+	}
+	return b.txs.encode(w)
+}
+
+// Derive converts the raw span batch into the span batch it represents,
+// with the timestamps, L1 origin numbers and full transactions of every block.
+func (b *RawSpanBatch) Derive(blockTime, genesisTimestamp uint64, chainID *big.Int) (*SpanBatch, error) {
+	if b.blockCount == 0 {
+		return nil, ErrEmptySpanBatch
+	}
+	blockOriginNums := make([]uint64, b.blockCount)
+	l1OriginBlockNumber := b.l1OriginNum
+	for i := int(b.blockCount) - 1; i >= 0; i-- {
+		blockOriginNums[i] = l1OriginBlockNumber
+		if b.originBits.Bit(i) == 1 && i > 0 {
+			if l1OriginBlockNumber == 0 {
+				return nil, errors.New("span batch L1 origin number underflows")
+			}
+			l1OriginBlockNumber--
+		}
+	}
+
+	fullTxs, err := b.txs.fullTxs(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SpanBatch{
+		ParentCheck:   b.parentCheck,
+		L1OriginCheck: b.l1OriginCheck,
+	}
+	txIdx := 0
+	for i := 0; i < int(b.blockCount); i++ {
+		batch := SpanBatchElement{
+			Timestamp: genesisTimestamp + b.relTimestamp + blockTime*uint64(i),
+			EpochNum:  rollup.Epoch(blockOriginNums[i]),
+		}
+		for j := 0; j < int(b.blockTxCounts[i]); j++ {
+			batch.Transactions = append(batch.Transactions, fullTxs[txIdx])
+			txIdx++
+		}
+		out.Batches = append(out.Batches, &batch)
+	}
+	return out, nil
+}
+
+// SpanBatchElement is the derived form of an L2 block in a span batch.
+type SpanBatchElement struct {
+	EpochNum     rollup.Epoch // aka l1 num
+	Timestamp    uint64
+	Transactions []hexutil.Bytes
+}
+
+// SpanBatch is the derived form of a span batch: a contiguous range of L2 blocks,
+// of which only the parent of the first block, and the L1 origin of the last block are committed to.
+type SpanBatch struct {
+	ParentCheck   [20]byte // First 20 bytes of the first block's parent hash
+	L1OriginCheck [20]byte // First 20 bytes of the last block's L1 origin hash
+	Batches       []*SpanBatchElement
+}
+
+// GetTimestamp returns the timestamp of the first block in the span.
+func (b *SpanBatch) GetTimestamp() uint64 {
+	return b.Batches[0].Timestamp
+}
+
+// GetStartEpochNum returns the L1 origin number of the first block in the span.
+func (b *SpanBatch) GetStartEpochNum() rollup.Epoch {
+	return b.Batches[0].EpochNum
+}
+
+// GetBlockCount returns the number of blocks in the span.
+func (b *SpanBatch) GetBlockCount() int {
+	return len(b.Batches)
+}
+
+// CheckParentHash checks if the parent hash of the first block matches the parent check of the span batch.
+func (b *SpanBatch) CheckParentHash(hash common.Hash) bool {
+	return bytes.Equal(b.ParentCheck[:], hash[:20])
+}
+
+// CheckOriginHash checks if the L1 origin hash of the last block matches the L1 origin check of the span batch.
+func (b *SpanBatch) CheckOriginHash(hash common.Hash) bool {
+	return bytes.Equal(b.L1OriginCheck[:], hash[:20])
+}
+
+// SpanBatchBuilder builds a span batch from consecutive singular batches.
+type SpanBatchBuilder struct {
+	genesisTimestamp uint64
+	chainID          *big.Int
+	spanBatch        *SpanBatch
+	// originChangedBit is the origin bit of the first block, which cannot be derived
+	// from the batches of the span, and is set from the L1 info sequence number instead.
+	originChangedBit uint
+}
+
+func NewSpanBatchBuilder(genesisTimestamp uint64, chainID *big.Int) *SpanBatchBuilder {
+	return &SpanBatchBuilder{
+		genesisTimestamp: genesisTimestamp,
+		chainID:          chainID,
+		spanBatch:        &SpanBatch{},
+	}
+}
+
+// AppendSingularBatch appends the batch of the next L2 block to the span batch,
+// with the sequence number of the block within its epoch.
+func (b *SpanBatchBuilder) AppendSingularBatch(batch *BatchV1, seqNum uint64) {
+	if len(b.spanBatch.Batches) == 0 {
+		b.originChangedBit = 0
+		if seqNum == 0 {
+			b.originChangedBit = 1
+		}
+		copy(b.spanBatch.ParentCheck[:], batch.ParentHash[:20])
+	}
+	copy(b.spanBatch.L1OriginCheck[:], batch.EpochHash[:20])
+	b.spanBatch.Batches = append(b.spanBatch.Batches, &SpanBatchElement{
+		EpochNum:     batch.EpochNum,
+		Timestamp:    batch.Timestamp,
+		Transactions: batch.Transactions,
+	})
+}
+
+// removeLastBatch undoes the last AppendSingularBatch, restoring the L1 origin check from before that call.
+func (b *SpanBatchBuilder) removeLastBatch(prevOriginCheck [20]byte) {
+	b.spanBatch.Batches = b.spanBatch.Batches[:len(b.spanBatch.Batches)-1]
+	b.spanBatch.L1OriginCheck = prevOriginCheck
+}
+
+// GetRawSpanBatch encodes the blocks appended so far into a raw span batch.
+func (b *SpanBatchBuilder) GetRawSpanBatch() (*RawSpanBatch, error) {
+	batches := b.spanBatch.Batches
+	if len(batches) == 0 {
+		return nil, ErrEmptySpanBatch
+	}
+	if batches[0].Timestamp < b.genesisTimestamp {
+		return nil, fmt.Errorf("span batch timestamp %d precedes genesis time %d", batches[0].Timestamp, b.genesisTimestamp)
+	}
+	raw := &RawSpanBatch{
+		spanBatchPrefix: spanBatchPrefix{
+			relTimestamp:  batches[0].Timestamp - b.genesisTimestamp,
+			l1OriginNum:   uint64(batches[len(batches)-1].EpochNum),
+			parentCheck:   b.spanBatch.ParentCheck,
+			l1OriginCheck: b.spanBatch.L1OriginCheck,
+		},
+		spanBatchPayload: spanBatchPayload{
+			blockCount: uint64(len(batches)),
+			originBits: new(big.Int),
+		},
+	}
+	var txs [][]byte
+	for i, batch := range batches {
+		bit := b.originChangedBit
+		if i > 0 {
+			bit = 0
+			if batch.EpochNum != batches[i-1].EpochNum {
+				bit = 1
+			}
+		}
+		raw.originBits.SetBit(raw.originBits, i, bit)
+		raw.blockTxCounts = append(raw.blockTxCounts, uint64(len(batch.Transactions)))
+		for _, tx := range batch.Transactions {
+			txs = append(txs, tx)
+		}
+	}
+	spanTxs, err := newSpanBatchTxs(txs, b.chainID)
+	if err != nil {
+		return nil, err
+	}
+	raw.txs = spanTxs
+	return raw, nil
+}
+
+// GetBlockCount returns the number of blocks in the span batch.
+func (b *SpanBatchBuilder) GetBlockCount() int {
+	return len(b.spanBatch.Batches)
+}
+
+// Reset removes all blocks from the span batch.
+func (b *SpanBatchBuilder) Reset() {
+	b.spanBatch = &SpanBatch{}
+	b.originChangedBit = 0
+}
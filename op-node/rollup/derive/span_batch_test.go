@@ -0,0 +1,183 @@
+package derive
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+// randomSpanBatchTx creates a random signed transaction of the given type, which is supported by span batches.
+func randomSpanBatchTx(t *testing.T, rng *rand.Rand, signer types.Signer, txType int) hexutil.Bytes {
+	var to *common.Address
+	if rng.Intn(4) != 0 {
+		addr := testutils.RandomAddress(rng)
+		to = &addr
+	}
+	var txData types.TxData
+	switch txType {
+	case types.LegacyTxType:
+		txData = &types.LegacyTx{
+			Nonce:    rng.Uint64(),
+			GasPrice: big.NewInt(rng.Int63n(1_000_000_000)),
+			Gas:      rng.Uint64(),
+			To:       to,
+			Value:    big.NewInt(rng.Int63()),
+			Data:     testutils.RandomData(rng, rng.Intn(100)),
+		}
+	case types.AccessListTxType:
+		txData = &types.AccessListTx{
+			ChainID:    signer.ChainID(),
+			Nonce:      rng.Uint64(),
+			GasPrice:   big.NewInt(rng.Int63n(1_000_000_000)),
+			Gas:        rng.Uint64(),
+			To:         to,
+			Value:      big.NewInt(rng.Int63()),
+			Data:       testutils.RandomData(rng, rng.Intn(100)),
+			AccessList: types.AccessList{{Address: testutils.RandomAddress(rng), StorageKeys: []common.Hash{testutils.RandomHash(rng)}}},
+		}
+	default:
+		txData = &types.DynamicFeeTx{
+			ChainID:   signer.ChainID(),
+			Nonce:     rng.Uint64(),
+			GasTipCap: big.NewInt(rng.Int63n(1_000_000_000)),
+			GasFeeCap: big.NewInt(rng.Int63n(1_000_000_000)),
+			Gas:       rng.Uint64(),
+			To:        to,
+			Value:     big.NewInt(rng.Int63()),
+			Data:      testutils.RandomData(rng, rng.Intn(100)),
+		}
+	}
+	tx, err := types.SignNewTx(testutils.RandomKey(), signer, txData)
+	require.NoError(t, err)
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return data
+}
+
+// randomSingularBatches creates consecutive batches, starting at the given timestamp and L1 origin.
+func randomSingularBatches(t *testing.T, rng *rand.Rand, chainID *big.Int, count int, timestamp uint64, blockTime uint64, epochNum uint64) []*BatchV1 {
+	signer := types.NewLondonSigner(chainID)
+	parentHash := testutils.RandomHash(rng)
+	epochHash := testutils.RandomHash(rng)
+	var out []*BatchV1
+	for i := 0; i < count; i++ {
+		if i > 0 && rng.Intn(3) == 0 {
+			epochNum++
+			epochHash = testutils.RandomHash(rng)
+		}
+		var txs []hexutil.Bytes
+		for j := rng.Intn(4); j > 0; j-- {
+			txs = append(txs, randomSpanBatchTx(t, rng, signer, rng.Intn(3)))
+		}
+		out = append(out, &BatchV1{
+			ParentHash:   parentHash,
+			EpochNum:     rollup.Epoch(epochNum),
+			EpochHash:    epochHash,
+			Timestamp:    timestamp + uint64(i)*blockTime,
+			Transactions: txs,
+		})
+		parentHash = testutils.RandomHash(rng)
+	}
+	return out
+}
+
+func TestSpanBatchRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chainID := big.NewInt(901)
+	genesisTime := uint64(100)
+	blockTime := uint64(2)
+	batches := randomSingularBatches(t, rng, chainID, 20, genesisTime+10, blockTime, 42)
+
+	builder := NewSpanBatchBuilder(genesisTime, chainID)
+	for i, batch := range batches {
+		builder.AppendSingularBatch(batch, uint64(i))
+	}
+	require.Equal(t, len(batches), builder.GetBlockCount())
+	raw, err := builder.GetRawSpanBatch()
+	require.NoError(t, err)
+
+	// encode as batch data, and decode again
+	var buf bytes.Buffer
+	require.NoError(t, rlp.Encode(&buf, NewSpanBatchData(raw)))
+	var decoded BatchData
+	require.NoError(t, rlp.Decode(&buf, &decoded))
+	require.True(t, decoded.IsSpanBatch())
+	require.Equal(t, raw, decoded.RawSpanBatch)
+
+	spanBatch, err := decoded.RawSpanBatch.Derive(blockTime, genesisTime, chainID)
+	require.NoError(t, err)
+	require.Equal(t, len(batches), spanBatch.GetBlockCount())
+	require.True(t, spanBatch.CheckParentHash(batches[0].ParentHash))
+	require.True(t, spanBatch.CheckOriginHash(batches[len(batches)-1].EpochHash))
+	require.Equal(t, batches[0].Timestamp, spanBatch.GetTimestamp())
+	for i, batch := range batches {
+		elem := spanBatch.Batches[i]
+		require.Equal(t, batch.Timestamp, elem.Timestamp, "block %d", i)
+		require.Equal(t, batch.EpochNum, elem.EpochNum, "block %d", i)
+		require.Equal(t, batch.Transactions, elem.Transactions, "block %d", i)
+	}
+
+	builder.Reset()
+	require.Zero(t, builder.GetBlockCount())
+	_, err = builder.GetRawSpanBatch()
+	require.ErrorIs(t, err, ErrEmptySpanBatch)
+}
+
+func TestSpanBatchBuilderRejectsUnsupportedTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chainID := big.NewInt(901)
+	batches := randomSingularBatches(t, rng, chainID, 1, 10, 2, 0)
+
+	// transactions signed for another chain cannot be represented in the span batch
+	otherChainTx := randomSpanBatchTx(t, rng, types.NewLondonSigner(big.NewInt(902)), types.DynamicFeeTxType)
+	batches[0].Transactions = append(batches[0].Transactions, otherChainTx)
+	builder := NewSpanBatchBuilder(0, chainID)
+	builder.AppendSingularBatch(batches[0], 0)
+	_, err := builder.GetRawSpanBatch()
+	require.ErrorContains(t, err, "chain ID")
+
+	// neither can unprotected legacy transactions
+	tx, err := types.SignNewTx(testutils.RandomKey(), types.HomesteadSigner{}, &types.LegacyTx{GasPrice: big.NewInt(1), Gas: 21000, Value: big.NewInt(0)})
+	require.NoError(t, err)
+	unprotectedTx, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	batches[0].Transactions = []hexutil.Bytes{unprotectedTx}
+	builder.Reset()
+	builder.AppendSingularBatch(batches[0], 0)
+	_, err = builder.GetRawSpanBatch()
+	require.ErrorContains(t, err, "unprotected")
+}
+
+func TestSpanBatchDecodeInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chainID := big.NewInt(901)
+	builder := NewSpanBatchBuilder(0, chainID)
+	for i, batch := range randomSingularBatches(t, rng, chainID, 5, 10, 2, 3) {
+		builder.AppendSingularBatch(batch, uint64(i))
+	}
+	raw, err := builder.GetRawSpanBatch()
+	require.NoError(t, err)
+	data, err := NewSpanBatchData(raw).MarshalBinary()
+	require.NoError(t, err)
+
+	var batch BatchData
+	require.NoError(t, batch.UnmarshalBinary(data))
+	require.Error(t, batch.UnmarshalBinary(data[:len(data)-1]), "truncated span batch")
+	require.Error(t, batch.UnmarshalBinary(append(data, 0)), "trailing data")
+
+	// a span batch without blocks is invalid
+	empty := []byte{SpanBatchType, 0, 0}       // type, rel timestamp and L1 origin number
+	empty = append(empty, make([]byte, 40)...) // parent check and L1 origin check
+	empty = append(empty, 0)                   // block count
+	require.ErrorIs(t, batch.UnmarshalBinary(empty), ErrEmptySpanBatch)
+}
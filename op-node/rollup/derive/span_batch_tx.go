@@ -0,0 +1,185 @@
+package derive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var ErrTypedTxTooShort = errors.New("typed transaction data too short")
+
+// spanBatchTxData is the part of a transaction that is encoded in the tx_datas of a span batch:
+// everything but the signature, nonce, gas limit and recipient, which are encoded separately.
+type spanBatchTxData interface {
+	txType() byte // returns the type ID
+}
+
+type spanBatchLegacyTxData struct {
+	Value    *big.Int // wei amount
+	GasPrice *big.Int // wei per gas
+	Data     []byte
+}
+
+func (txData *spanBatchLegacyTxData) txType() byte { return types.LegacyTxType }
+
+type spanBatchAccessListTxData struct {
+	Value      *big.Int // wei amount
+	GasPrice   *big.Int // wei per gas
+	Data       []byte
+	AccessList types.AccessList // EIP-2930 access list
+}
+
+func (txData *spanBatchAccessListTxData) txType() byte { return types.AccessListTxType }
+
+type spanBatchDynamicFeeTxData struct {
+	Value      *big.Int
+	GasTipCap  *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *big.Int // a.k.a. maxFeePerGas
+	Data       []byte
+	AccessList types.AccessList
+}
+
+func (txData *spanBatchDynamicFeeTxData) txType() byte { return types.DynamicFeeTxType }
+
+// spanBatchTx is the compact form of a transaction in a span batch.
+type spanBatchTx struct {
+	inner spanBatchTxData
+}
+
+// MarshalBinary returns the canonical encoding of the span batch tx data:
+// rlp([value, gasPrice, data]) for legacy transactions,
+// and the type byte followed by the RLP encoded fields for typed transactions.
+func (tx *spanBatchTx) MarshalBinary() ([]byte, error) {
+	if tx.inner.txType() == types.LegacyTxType {
+		return rlp.EncodeToBytes(tx.inner)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(tx.inner.txType())
+	if err := rlp.Encode(&buf, tx.inner); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the canonical encoding of the span batch tx data.
+func (tx *spanBatchTx) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// It's a legacy transaction.
+		var data spanBatchLegacyTxData
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return fmt.Errorf("failed to decode spanBatchLegacyTxData: %w", err)
+		}
+		tx.inner = &data
+		return nil
+	}
+	if len(b) <= 1 {
+		return ErrTypedTxTooShort
+	}
+	var inner spanBatchTxData
+	switch b[0] {
+	case types.AccessListTxType:
+		var data spanBatchAccessListTxData
+		if err := rlp.DecodeBytes(b[1:], &data); err != nil {
+			return fmt.Errorf("failed to decode spanBatchAccessListTxData: %w", err)
+		}
+		inner = &data
+	case types.DynamicFeeTxType:
+		var data spanBatchDynamicFeeTxData
+		if err := rlp.DecodeBytes(b[1:], &data); err != nil {
+			return fmt.Errorf("failed to decode spanBatchDynamicFeeTxData: %w", err)
+		}
+		inner = &data
+	default:
+		return types.ErrTxTypeNotSupported
+	}
+	tx.inner = inner
+	return nil
+}
+
+// convertToFullTx takes the values that are encoded separately in the span batch,
+// and combines them with the tx data into a full, signed transaction.
+func (tx *spanBatchTx) convertToFullTx(nonce, gas uint64, to *common.Address, chainID, V, R, S *big.Int) (*types.Transaction, error) {
+	var inner types.TxData
+	switch data := tx.inner.(type) {
+	case *spanBatchLegacyTxData:
+		inner = &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: data.GasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    data.Value,
+			Data:     data.Data,
+			V:        V,
+			R:        R,
+			S:        S,
+		}
+	case *spanBatchAccessListTxData:
+		inner = &types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasPrice:   data.GasPrice,
+			Gas:        gas,
+			To:         to,
+			Value:      data.Value,
+			Data:       data.Data,
+			AccessList: data.AccessList,
+			V:          V,
+			R:          R,
+			S:          S,
+		}
+	case *spanBatchDynamicFeeTxData:
+		inner = &types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasTipCap:  data.GasTipCap,
+			GasFeeCap:  data.GasFeeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      data.Value,
+			Data:       data.Data,
+			AccessList: data.AccessList,
+			V:          V,
+			R:          R,
+			S:          S,
+		}
+	default:
+		return nil, fmt.Errorf("invalid tx type: %d", tx.inner.txType())
+	}
+	return types.NewTx(inner), nil
+}
+
+// newSpanBatchTx converts a transaction into the tx data of a span batch.
+func newSpanBatchTx(tx *types.Transaction) (*spanBatchTx, error) {
+	var inner spanBatchTxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		inner = &spanBatchLegacyTxData{
+			GasPrice: tx.GasPrice(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	case types.AccessListTxType:
+		inner = &spanBatchAccessListTxData{
+			GasPrice:   tx.GasPrice(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	case types.DynamicFeeTxType:
+		inner = &spanBatchDynamicFeeTxData{
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("invalid tx type: %d", tx.Type())
+	}
+	return &spanBatchTx{inner: inner}, nil
+}
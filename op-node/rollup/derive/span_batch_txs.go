@@ -0,0 +1,301 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+// spanBatchTxs holds the transactions of all blocks of a span batch, encoded column-wise:
+//
+//	txs = contract_creation_bits ++ y_parity_bits ++ tx_sigs ++ tx_tos ++ tx_datas ++ tx_nonces ++ tx_gases
+//
+// Legacy transactions must be EIP-155 protected, and signed for the chain ID of the L2 chain.
+type spanBatchTxs struct {
+	// totalBlockTxCount is the sum of the tx counts of the blocks, and not encoded in the txs itself.
+	totalBlockTxCount uint64
+
+	contractCreationBits *big.Int
+	yParityBits          *big.Int
+	txSigs               []spanBatchSignature
+	txTos                []common.Address
+	txDatas              []hexutil.Bytes
+	txNonces             []uint64
+	txGases              []uint64
+}
+
+type spanBatchSignature struct {
+	r *uint256.Int
+	s *uint256.Int
+}
+
+// encodeSpanBatchBits writes the bits as a big-endian bitlist of bitLength bits, padded to full bytes.
+func encodeSpanBatchBits(w io.Writer, bitLength uint64, bits *big.Int) error {
+	if bits.BitLen() > int(bitLength) {
+		return fmt.Errorf("bitfield is larger than bitLength: %d > %d", bits.BitLen(), bitLength)
+	}
+	bufLen := bitLength / 8
+	if bitLength%8 != 0 {
+		bufLen++
+	}
+	buf := make([]byte, bufLen)
+	bits.FillBytes(buf)
+	_, err := w.Write(buf)
+	return err
+}
+
+// decodeSpanBatchBits reads a big-endian bitlist of bitLength bits, padded to full bytes.
+func decodeSpanBatchBits(r *bytes.Reader, bitLength uint64) (*big.Int, error) {
+	bufLen := bitLength / 8
+	if bitLength%8 != 0 {
+		bufLen++
+	}
+	if bufLen > MaxSpanBatchSize {
+		return nil, ErrTooBigSpanBatchSize
+	}
+	buf := make([]byte, bufLen)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("failed to read bits: %w", err)
+	}
+	out := new(big.Int).SetBytes(buf)
+	if out.BitLen() > int(bitLength) {
+		return nil, fmt.Errorf("bitfield has %d bits, but bitLength is %d", out.BitLen(), bitLength)
+	}
+	return out, nil
+}
+
+func (btx *spanBatchTxs) contractCreationCount() uint64 {
+	var count uint64
+	for i := 0; i < int(btx.totalBlockTxCount); i++ {
+		count += uint64(btx.contractCreationBits.Bit(i))
+	}
+	return count
+}
+
+func (btx *spanBatchTxs) encode(w io.Writer) error {
+	if err := encodeSpanBatchBits(w, btx.totalBlockTxCount, btx.contractCreationBits); err != nil {
+		return fmt.Errorf("failed to write contract creation bits: %w", err)
+	}
+	if err := encodeSpanBatchBits(w, btx.totalBlockTxCount, btx.yParityBits); err != nil {
+		return fmt.Errorf("failed to write y parity bits: %w", err)
+	}
+	for _, sig := range btx.txSigs {
+		r := sig.r.Bytes32()
+		s := sig.s.Bytes32()
+		if _, err := w.Write(r[:]); err != nil {
+			return fmt.Errorf("failed to write tx sig r: %w", err)
+		}
+		if _, err := w.Write(s[:]); err != nil {
+			return fmt.Errorf("failed to write tx sig s: %w", err)
+		}
+	}
+	for _, to := range btx.txTos {
+		if _, err := w.Write(to[:]); err != nil {
+			return fmt.Errorf("failed to write tx to address: %w", err)
+		}
+	}
+	for _, data := range btx.txDatas {
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write tx data: %w", err)
+		}
+	}
+	var buf [binary.MaxVarintLen64]byte
+	for _, nonce := range btx.txNonces {
+		n := binary.PutUvarint(buf[:], nonce)
+		if _, err := w.Write(buf[:n]); err != nil {
+			return fmt.Errorf("failed to write tx nonce: %w", err)
+		}
+	}
+	for _, gas := range btx.txGases {
+		n := binary.PutUvarint(buf[:], gas)
+		if _, err := w.Write(buf[:n]); err != nil {
+			return fmt.Errorf("failed to write tx gas: %w", err)
+		}
+	}
+	return nil
+}
+
+func (btx *spanBatchTxs) decode(r *bytes.Reader) error {
+	var err error
+	if btx.contractCreationBits, err = decodeSpanBatchBits(r, btx.totalBlockTxCount); err != nil {
+		return fmt.Errorf("failed to read contract creation bits: %w", err)
+	}
+	if btx.yParityBits, err = decodeSpanBatchBits(r, btx.totalBlockTxCount); err != nil {
+		return fmt.Errorf("failed to read y parity bits: %w", err)
+	}
+	btx.txSigs = make([]spanBatchSignature, btx.totalBlockTxCount)
+	var word [32]byte
+	for i := range btx.txSigs {
+		if _, err := io.ReadFull(r, word[:]); err != nil {
+			return fmt.Errorf("failed to read tx sig r: %w", err)
+		}
+		btx.txSigs[i].r = new(uint256.Int).SetBytes32(word[:])
+		if _, err := io.ReadFull(r, word[:]); err != nil {
+			return fmt.Errorf("failed to read tx sig s: %w", err)
+		}
+		btx.txSigs[i].s = new(uint256.Int).SetBytes32(word[:])
+	}
+	btx.txTos = make([]common.Address, btx.totalBlockTxCount-btx.contractCreationCount())
+	for i := range btx.txTos {
+		if _, err := io.ReadFull(r, btx.txTos[i][:]); err != nil {
+			return fmt.Errorf("failed to read tx to address: %w", err)
+		}
+	}
+	btx.txDatas = make([]hexutil.Bytes, btx.totalBlockTxCount)
+	for i := range btx.txDatas {
+		if btx.txDatas[i], err = readSpanBatchTxData(r); err != nil {
+			return fmt.Errorf("failed to read tx data %d: %w", i, err)
+		}
+	}
+	btx.txNonces = make([]uint64, btx.totalBlockTxCount)
+	for i := range btx.txNonces {
+		if btx.txNonces[i], err = binary.ReadUvarint(r); err != nil {
+			return fmt.Errorf("failed to read tx nonce: %w", err)
+		}
+	}
+	btx.txGases = make([]uint64, btx.totalBlockTxCount)
+	for i := range btx.txGases {
+		if btx.txGases[i], err = binary.ReadUvarint(r); err != nil {
+			return fmt.Errorf("failed to read tx gas: %w", err)
+		}
+	}
+	return nil
+}
+
+// readSpanBatchTxData reads the encoding of a single span batch tx data:
+// an RLP list, optionally preceded by a transaction type byte.
+func readSpanBatchTxData(r *bytes.Reader) ([]byte, error) {
+	var txType []byte
+	firstByte, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if firstByte <= 0x7f {
+		txType = []byte{firstByte}
+	} else if err := r.UnreadByte(); err != nil {
+		return nil, err
+	}
+	s := rlp.NewStream(r, uint64(r.Len()))
+	if kind, _, err := s.Kind(); err != nil {
+		return nil, err
+	} else if kind != rlp.List {
+		return nil, fmt.Errorf("tx data must be an RLP list, got %v", kind)
+	}
+	raw, err := s.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return append(txType, raw...), nil
+}
+
+// fullTxs returns the encoded full transactions, signed for the given chain ID.
+func (btx *spanBatchTxs) fullTxs(chainID *big.Int) ([][]byte, error) {
+	var txs [][]byte
+	toIdx := 0
+	for idx := 0; idx < int(btx.totalBlockTxCount); idx++ {
+		var stx spanBatchTx
+		if err := stx.UnmarshalBinary(btx.txDatas[idx]); err != nil {
+			return nil, err
+		}
+		var to *common.Address
+		if btx.contractCreationBits.Bit(idx) == 0 {
+			if toIdx >= len(btx.txTos) {
+				return nil, errors.New("tx to address list too short")
+			}
+			to = &btx.txTos[toIdx]
+			toIdx++
+		}
+		yParity := uint64(btx.yParityBits.Bit(idx))
+		var v *big.Int
+		if stx.inner.txType() == types.LegacyTxType {
+			// EIP-155 protected legacy transaction
+			v = new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), new(big.Int).SetUint64(35+yParity))
+		} else {
+			v = new(big.Int).SetUint64(yParity)
+		}
+		sig := btx.txSigs[idx]
+		tx, err := stx.convertToFullTx(btx.txNonces[idx], btx.txGases[idx], to, chainID, v, sig.r.ToBig(), sig.s.ToBig())
+		if err != nil {
+			return nil, err
+		}
+		encodedTx, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, encodedTx)
+	}
+	return txs, nil
+}
+
+// newSpanBatchTxs converts the encoded full transactions into their span batch form.
+// All transactions must be signed for the given chain ID.
+func newSpanBatchTxs(txs [][]byte, chainID *big.Int) (*spanBatchTxs, error) {
+	totalBlockTxCount := uint64(len(txs))
+	out := &spanBatchTxs{
+		totalBlockTxCount:    totalBlockTxCount,
+		contractCreationBits: new(big.Int),
+		yParityBits:          new(big.Int),
+		txSigs:               make([]spanBatchSignature, 0, totalBlockTxCount),
+		txDatas:              make([]hexutil.Bytes, 0, totalBlockTxCount),
+		txNonces:             make([]uint64, 0, totalBlockTxCount),
+		txGases:              make([]uint64, 0, totalBlockTxCount),
+	}
+	for idx, txBytes := range txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return nil, fmt.Errorf("failed to decode tx %d: %w", idx, err)
+		}
+		if tx.Type() == types.LegacyTxType && !tx.Protected() {
+			return nil, fmt.Errorf("tx %d is an unprotected legacy tx, which is not supported in span batches", idx)
+		}
+		if tx.ChainId().Cmp(chainID) != 0 {
+			return nil, fmt.Errorf("tx %d has chain ID %d, expected %d", idx, tx.ChainId(), chainID)
+		}
+		v, r, s := tx.RawSignatureValues()
+		var yParity uint64
+		if tx.Type() == types.LegacyTxType {
+			yParity = new(big.Int).Sub(v, new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35))).Uint64()
+		} else {
+			yParity = v.Uint64()
+		}
+		if yParity > 1 {
+			return nil, fmt.Errorf("tx %d has invalid y parity %d", idx, yParity)
+		}
+		out.yParityBits.SetBit(out.yParityBits, idx, uint(yParity))
+		rInt, overflow := uint256.FromBig(r)
+		if overflow {
+			return nil, fmt.Errorf("tx %d has invalid signature r", idx)
+		}
+		sInt, overflow := uint256.FromBig(s)
+		if overflow {
+			return nil, fmt.Errorf("tx %d has invalid signature s", idx)
+		}
+		out.txSigs = append(out.txSigs, spanBatchSignature{r: rInt, s: sInt})
+		if tx.To() == nil {
+			out.contractCreationBits.SetBit(out.contractCreationBits, idx, 1)
+		} else {
+			out.txTos = append(out.txTos, *tx.To())
+		}
+		stx, err := newSpanBatchTx(&tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d: %w", idx, err)
+		}
+		data, err := stx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		out.txDatas = append(out.txDatas, data)
+		out.txNonces = append(out.txNonces, tx.Nonce())
+		out.txGases = append(out.txGases, tx.Gas())
+	}
+	return out, nil
+}
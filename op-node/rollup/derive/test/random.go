@@ -1,11 +1,13 @@
 package test
 
 import (
+	"math/big"
 	"math/rand"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -21,3 +23,18 @@ func RandomL2Block(rng *rand.Rand, txCount int) (*types.Block, []*types.Receipt)
 	}
 	return testutils.RandomBlockPrependTxs(rng, txCount, types.NewTx(l1InfoTx))
 }
+
+// L2BlockWithOrigin returns an empty L2 block, with an L1 Info Deposit transaction of the given L1 origin.
+// The extra data distinguishes blocks with the same number and parent.
+func L2BlockWithOrigin(number uint64, parent common.Hash, l1Origin uint64, extra byte) *types.Block {
+	l1Info, err := derive.L1InfoDeposit(0, &testutils.MockBlockInfo{InfoNum: l1Origin, InfoBaseFee: big.NewInt(7)}, eth.SystemConfig{}, true)
+	if err != nil {
+		panic("L1InfoDeposit: " + err.Error())
+	}
+	return types.NewBlock(&types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		ParentHash: parent,
+		BaseFee:    big.NewInt(7),
+		Extra:      []byte{extra},
+	}, []*types.Transaction{types.NewTx(l1Info)}, nil, nil, trie.NewStackTrie(nil))
+}
@@ -1,5 +1,7 @@
 package driver
 
+import "time"
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +22,20 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerTxOrdering is the policy to order the transaction-pool transactions of the sequenced blocks with.
+	// If empty or TxOrderingEngine, the execution engine orders the transactions.
+	SequencerTxOrdering string `json:"sequencer_tx_ordering"`
+
+	// SequencerFairOrderingWindow is the duration of the arrival time batches of the fair ordering policy.
+	SequencerFairOrderingWindow time.Duration `json:"sequencer_fair_ordering_window"`
+}
+
+// Check verifies that the transaction ordering policy is known.
+func (c *Config) Check() error {
+	if c.SequencerTxOrdering == "" || c.SequencerTxOrdering == TxOrderingEngine {
+		return nil
+	}
+	_, err := NewTxOrderingPolicy(c.SequencerTxOrdering, c.SequencerFairOrderingWindow)
+	return err
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -48,6 +49,7 @@ type L2Chain interface {
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
 	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	TxPool
 }
 
 type DerivationPipeline interface {
@@ -63,6 +65,8 @@ type DerivationPipeline interface {
 	Origin() eth.L1BlockRef
 	EngineReady() bool
 	EngineSyncTarget() eth.L2BlockRef
+	DebugInfo() *derive.PipelineDebugInfo
+	ChannelFrames(id derive.ChannelID) (*derive.ChannelFrames, error)
 }
 
 type L1StateIface interface {
@@ -81,6 +85,7 @@ type SequencerIface interface {
 	PlanNextSequencerAction() time.Duration
 	RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayload, error)
 	BuildingOnto() eth.L2BlockRef
+	SetMaxDASize(maxTxSize uint64, maxBlockSize uint64)
 }
 
 type Network interface {
@@ -107,47 +112,71 @@ type AltSync interface {
 	RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error
 }
 
+// SnapSync snap syncs the execution engine before derivation starts, see sync.SnapSync.
+type SnapSync interface {
+	// Step advances the snap sync, it returns sync.ErrSnapSyncing while the engine is syncing,
+	// and an error wrapping sync.ErrSnapSyncFailed if the snap sync failed.
+	Step(ctx context.Context) error
+	// Done returns true when derivation can start.
+	Done() bool
+	Status() *eth.SnapSyncStatus
+}
+
 type SequencerStateListener interface {
 	SequencerStarted() error
 	SequencerStopped() error
 }
 
 // NewDriver composes an events handler that tracks L1 state, triggers L2 derivation, and optionally sequences new L2 blocks.
-func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, syncCfg *sync.Config) *Driver {
+func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, l1Blobs derive.L1BlobsFetcher, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, syncCfg *sync.Config, safeHeadListener derive.SafeHeadListener, checkpoints derive.CheckpointStore, sequencerConductor conductor.SequencerConductor, snapSync SnapSync) *Driver {
 	l1 = NewMeteredL1Fetcher(l1, metrics)
 	l1State := NewL1State(log, metrics)
 	sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, l1State.L1Head, l1)
 	findL1Origin := NewL1OriginSelector(log, cfg, sequencerConfDepth)
 	verifConfDepth := NewConfDepth(driverCfg.VerifierConfDepth, l1State.L1Head, l1)
-	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l2, metrics, syncCfg)
+	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, l2, metrics, syncCfg, safeHeadListener, checkpoints)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	engine := derivationPipeline
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log)
 	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
+	var txOrderer *TxOrderer
+	if driverCfg.SequencerEnabled && driverCfg.SequencerTxOrdering != "" && driverCfg.SequencerTxOrdering != TxOrderingEngine {
+		policy, err := NewTxOrderingPolicy(driverCfg.SequencerTxOrdering, driverCfg.SequencerFairOrderingWindow)
+		if err != nil {
+			log.Error("Invalid transaction ordering policy, leaving the ordering to the engine", "err", err)
+		} else {
+			txOrderer = NewTxOrderer(log, policy, l2, metrics)
+			sequencer.txOrderer = txOrderer
+		}
+	}
 
 	return &Driver{
-		l1State:          l1State,
-		derivation:       derivationPipeline,
-		stateReq:         make(chan chan struct{}),
-		forceReset:       make(chan chan struct{}, 10),
-		startSequencer:   make(chan hashAndErrorChannel, 10),
-		stopSequencer:    make(chan chan hashAndError, 10),
-		sequencerActive:  make(chan chan bool, 10),
-		sequencerNotifs:  sequencerStateListener,
-		config:           cfg,
-		driverConfig:     driverCfg,
-		done:             make(chan struct{}),
-		log:              log,
-		snapshotLog:      snapshotLog,
-		l1:               l1,
-		l2:               l2,
-		sequencer:        sequencer,
-		network:          network,
-		metrics:          metrics,
-		l1HeadSig:        make(chan eth.L1BlockRef, 10),
-		l1SafeSig:        make(chan eth.L1BlockRef, 10),
-		l1FinalizedSig:   make(chan eth.L1BlockRef, 10),
-		unsafeL2Payloads: make(chan *eth.ExecutionPayload, 10),
-		altSync:          altSync,
+		l1State:            l1State,
+		derivation:         derivationPipeline,
+		stateReq:           make(chan chan struct{}),
+		forceReset:         make(chan chan struct{}, 10),
+		startSequencer:     make(chan hashAndErrorChannel, 10),
+		stopSequencer:      make(chan chan hashAndError, 10),
+		sequencerActive:    make(chan chan bool, 10),
+		setMaxDASize:       make(chan maxDASizeReq, 10),
+		sequencerNotifs:    sequencerStateListener,
+		sequencerConductor: sequencerConductor,
+		config:             cfg,
+		driverConfig:       driverCfg,
+		done:               make(chan struct{}),
+		log:                log,
+		snapshotLog:        snapshotLog,
+		l1:                 l1,
+		l2:                 l2,
+		sequencer:          sequencer,
+		txOrderer:          txOrderer,
+		network:            network,
+		metrics:            metrics,
+		l1HeadSig:          make(chan eth.L1BlockRef, 10),
+		l1SafeSig:          make(chan eth.L1BlockRef, 10),
+		l1FinalizedSig:     make(chan eth.L1BlockRef, 10),
+		unsafeL2Payloads:   make(chan *eth.ExecutionPayload, 10),
+		altSync:            altSync,
+		snapSync:           snapSync,
 	}
 }
@@ -27,6 +27,7 @@ type L1OriginSelectorIface interface {
 type SequencerMetrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	TxOrderingMetrics
 }
 
 // Sequencer implements the sequencing interface of the driver: it starts and completes block building jobs.
@@ -45,6 +46,16 @@ type Sequencer struct {
 	timeNow func() time.Time
 
 	nextAction time.Time
+
+	// maxTxDASize and maxBlockDASize limit the data-availability size of the transactions
+	// of the blocks built by the sequencer. Zero means no limit.
+	// The limits have no effect until the execution engine supports them in the payload attributes.
+	maxTxDASize    uint64
+	maxBlockDASize uint64
+
+	// txOrderer is optional, if set the sequencer orders the transaction-pool transactions of its blocks,
+	// instead of the execution engine.
+	txOrderer *TxOrderer
 }
 
 func NewSequencer(log log.Logger, cfg *rollup.Config, engine derive.ResettableEngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics) *Sequencer {
@@ -84,6 +95,7 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	derive.LogForkActivations(d.log, d.config, uint64(attrs.Timestamp))
 
 	// If our next L2 block timestamp is beyond the Sequencer drift threshold, then we must produce
 	// empty blocks (other than the L1 info deposit and any user deposits). We handle this by
@@ -91,9 +103,36 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	// from the transaction pool.
 	attrs.NoTxPool = uint64(attrs.Timestamp) > l1Origin.Time+d.config.MaxSequencerDrift
 
+	// The limits are copied: the attributes must not change when the limits are updated while building.
+	if !attrs.NoTxPool {
+		if d.maxTxDASize != 0 {
+			maxTxDASize := eth.Uint64Quantity(d.maxTxDASize)
+			attrs.MaxTxDASize = &maxTxDASize
+		}
+		if d.maxBlockDASize != 0 {
+			maxBlockDASize := eth.Uint64Quantity(d.maxBlockDASize)
+			attrs.MaxBlockDASize = &maxBlockDASize
+		}
+	}
+
 	d.log.Debug("prepared attributes for new block",
 		"num", l2Head.Number+1, "time", uint64(attrs.Timestamp),
-		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool)
+		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool,
+		"maxTxDASize", d.maxTxDASize, "maxBlockDASize", d.maxBlockDASize)
+
+	if d.txOrderer != nil && !attrs.NoTxPool {
+		ordered, err := d.orderedAttributes(ctx, l2Head, attrs)
+		if err == nil {
+			_, err = d.engine.StartPayload(ctx, l2Head, ordered, false)
+			if err == nil {
+				return nil
+			}
+		}
+		// A transaction that the engine rejects would stall the chain, so fall back to the ordering of the engine.
+		d.log.Warn("Failed to build block with ordered transactions, leaving the ordering to the engine",
+			"policy", d.txOrderer.Policy(), "err", err)
+		d.metrics.RecordTxOrderingFallback(d.txOrderer.Policy())
+	}
 
 	// Start a payload building process.
 	errTyp, err := d.engine.StartPayload(ctx, l2Head, attrs, false)
@@ -103,6 +142,37 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	return nil
 }
 
+// orderedAttributes returns a copy of the attributes that explicitly includes the transaction-pool transactions,
+// selected and ordered by the ordering policy of the sequencer.
+func (d *Sequencer) orderedAttributes(ctx context.Context, l2Head eth.L2BlockRef, attrs *eth.PayloadAttributes) (*eth.PayloadAttributes, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	txs, err := d.txOrderer.SelectTransactions(ctx, l2Head, attrs, d.maxTxDASize, d.maxBlockDASize)
+	if err != nil {
+		return nil, err
+	}
+	ordered := *attrs
+	ordered.Transactions = make([]eth.Data, 0, len(attrs.Transactions)+len(txs))
+	ordered.Transactions = append(ordered.Transactions, attrs.Transactions...)
+	ordered.Transactions = append(ordered.Transactions, txs...)
+	ordered.NoTxPool = true
+	d.log.Debug("Ordered transaction-pool transactions", "policy", d.txOrderer.Policy(), "txs", len(txs))
+	return &ordered, nil
+}
+
+// SetMaxDASize limits the data-availability size of each transaction, and of all transactions,
+// from the transaction-pool in the blocks built by the sequencer. A zero size removes the limit.
+// The limits are passed to the execution engine in the payload attributes, but the engine
+// ignores them until it supports them: block building is not throttled yet.
+func (d *Sequencer) SetMaxDASize(maxTxSize uint64, maxBlockSize uint64) {
+	if maxTxSize != d.maxTxDASize || maxBlockSize != d.maxBlockDASize {
+		d.log.Info("Updated sequencer DA size limits, not enforced by the execution engine yet",
+			"maxTxSize", maxTxSize, "maxBlockSize", maxBlockSize)
+	}
+	d.maxTxDASize = maxTxSize
+	d.maxBlockDASize = maxBlockSize
+}
+
 // CompleteBuildingBlock takes the current block that is being built, and asks the engine to complete the building, seal the block, and persist it as canonical.
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
@@ -376,3 +376,118 @@ func TestSequencerChaosMonkey(t *testing.T) {
 	require.Greater(t, engControl.avgBuildingTime(), time.Second, "With 2 second block time and 1 second error backoff and healthy-on-average errors, building time should at least be a second")
 	require.Greater(t, engControl.avgTxsPerBlock(), 3.0, "We expect at least 1 system tx per block, but with a mocked 0-10 txs we expect an higher avg")
 }
+
+// TestSequencerMaxDASize checks that the DA size limits of the sequencer are passed to the engine
+// in the payload attributes, unless the block does not include any transactions from the transaction-pool.
+func TestSequencerMaxDASize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{
+		BlockTime:         2,
+		MaxSequencerDrift: 30,
+	}
+	l1Origin := testutils.RandomBlockRef(rng)
+	head := testutils.RandomL2BlockRef(rng)
+	head.L1Origin = l1Origin.ID()
+	head.Time = l1Origin.Time
+	engControl := &FakeEngineControl{unsafe: head, cfg: cfg, timeNow: time.Now}
+
+	attrsTime := head.Time + cfg.BlockTime
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		return &eth.PayloadAttributes{Timestamp: eth.Uint64Quantity(attrsTime)}, nil
+	})
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return l1Origin, nil
+	})
+	seq := NewSequencer(testlog.Logger(t, log.LvlError), cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics)
+
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	require.Nil(t, engControl.buildingAttrs.MaxTxDASize, "no limits by default")
+	require.Nil(t, engControl.buildingAttrs.MaxBlockDASize)
+
+	seq.SetMaxDASize(300, 21_000)
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	require.Equal(t, eth.Uint64Quantity(300), *engControl.buildingAttrs.MaxTxDASize)
+	require.Equal(t, eth.Uint64Quantity(21_000), *engControl.buildingAttrs.MaxBlockDASize)
+
+	built := engControl.buildingAttrs
+	seq.SetMaxDASize(400, 42_000)
+	require.Equal(t, eth.Uint64Quantity(300), *built.MaxTxDASize, "attributes of earlier blocks are not changed")
+	require.Equal(t, eth.Uint64Quantity(21_000), *built.MaxBlockDASize)
+
+	attrsTime = l1Origin.Time + cfg.MaxSequencerDrift + 1
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	require.True(t, engControl.buildingAttrs.NoTxPool)
+	require.Nil(t, engControl.buildingAttrs.MaxTxDASize, "no limits without transactions from the transaction-pool")
+
+	seq.SetMaxDASize(0, 0)
+	attrsTime = head.Time + cfg.BlockTime
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	require.Nil(t, engControl.buildingAttrs.MaxTxDASize, "limits are lifted")
+	require.Nil(t, engControl.buildingAttrs.MaxBlockDASize)
+}
+
+// rejectingEngineControl rejects the payload attributes that include transaction-pool transactions explicitly.
+type rejectingEngineControl struct {
+	*FakeEngineControl
+	reject bool
+}
+
+func (m *rejectingEngineControl) StartPayload(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes, updateSafe bool) (derive.BlockInsertionErrType, error) {
+	if m.reject && attrs.NoTxPool && len(attrs.Transactions) > 1 {
+		return derive.BlockInsertTemporaryErr, errors.New("failed to force-include tx")
+	}
+	return m.FakeEngineControl.StartPayload(ctx, parent, attrs, updateSafe)
+}
+
+// TestSequencerTxOrdering checks that the sequencer includes the transactions ordered by its ordering policy,
+// and falls back to the ordering of the engine if the engine rejects them.
+func TestSequencerTxOrdering(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{
+		BlockTime:         2,
+		MaxSequencerDrift: 30,
+	}
+	l1Origin := testutils.RandomBlockRef(rng)
+	head := testutils.RandomL2BlockRef(rng)
+	head.L1Origin = l1Origin.ID()
+	head.Time = l1Origin.Time
+	engControl := &rejectingEngineControl{FakeEngineControl: &FakeEngineControl{unsafe: head, cfg: cfg, timeNow: time.Now}}
+
+	deposit, err := types.NewTx(&types.DepositTx{Gas: 100_000}).MarshalBinary()
+	require.NoError(t, err)
+	gasLimit := eth.Uint64Quantity(1_000_000)
+	attrBuilder := testAttrBuilderFn(func(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (*eth.PayloadAttributes, error) {
+		return &eth.PayloadAttributes{
+			Timestamp:    eth.Uint64Quantity(head.Time + cfg.BlockTime),
+			Transactions: []eth.Data{deposit},
+			GasLimit:     &gasLimit,
+		}, nil
+	})
+	originSelector := testOriginSelectorFn(func(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return l1Origin, nil
+	})
+	logger := testlog.Logger(t, log.LvlError)
+	seq := NewSequencer(logger, cfg, engControl, attrBuilder, originSelector, metrics.NoopMetrics)
+
+	pool := &fakeTxPool{baseFee: big.NewInt(100), pending: map[common.Address][]*types.Transaction{
+		senderA: {testTx(0, 1, 21000, 0)},
+		senderB: {testTx(0, 5, 21000, 0)},
+	}}
+	policy, err := NewTxOrderingPolicy(TxOrderingPriorityFee, 0)
+	require.NoError(t, err)
+	seq.txOrderer = NewTxOrderer(logger, policy, pool, metrics.NoopMetrics)
+
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	attrs := engControl.buildingAttrs
+	require.True(t, attrs.NoTxPool, "the engine does not add transaction-pool transactions")
+	require.Len(t, attrs.Transactions, 3)
+	require.Equal(t, eth.Data(deposit), attrs.Transactions[0], "forced transactions first")
+	expected, err := pool.pending[senderB][0].MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, eth.Data(expected), attrs.Transactions[1], "highest priority fee first")
+
+	engControl.reject = true
+	require.NoError(t, seq.StartBuildingBlock(context.Background()))
+	require.False(t, engControl.buildingAttrs.NoTxPool, "fall back to the ordering of the engine")
+	require.Equal(t, []eth.Data{deposit}, engControl.buildingAttrs.Transactions)
+}
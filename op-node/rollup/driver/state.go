@@ -14,7 +14,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
@@ -25,6 +27,9 @@ type SyncStatus = eth.SyncStatus
 // sealingDuration defines the expected time it takes to seal the block
 const sealingDuration = time.Millisecond * 50
 
+// snapSyncPollInterval is the interval at which the snap sync progress of the engine is checked
+const snapSyncPollInterval = time.Second * 5
+
 type Driver struct {
 	l1State L1StateIface
 
@@ -52,9 +57,16 @@ type Driver struct {
 	// true when the sequencer is active, false when it is not.
 	sequencerActive chan chan bool
 
+	// Upon receiving new DA size limits in this channel, the sequencer applies them to the blocks it builds next.
+	// It tells the caller that the limits were applied by closing the passed in channel.
+	setMaxDASize chan maxDASizeReq
+
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// sequencerConductor determines if this node may sequence, and replicates the sequenced payloads before publishing
+	sequencerConductor conductor.SequencerConductor
+
 	// Rollup config: rollup chain configuration
 	config *rollup.Config
 
@@ -74,6 +86,9 @@ type Driver struct {
 	// Interface to signal the L2 block range to sync.
 	altSync AltSync
 
+	// snapSync is optional, and snap syncs the engine before derivation starts
+	snapSync SnapSync
+
 	// L2 Signals:
 
 	unsafeL2Payloads chan *eth.ExecutionPayload
@@ -81,6 +96,8 @@ type Driver struct {
 	l1        L1Chain
 	l2        L2Chain
 	sequencer SequencerIface
+	// txOrderer is optional, and tracks the transaction-pool for the ordering policy of the sequencer
+	txOrderer *TxOrderer
 	network   Network // may be nil, network for is optional
 
 	metrics     Metrics
@@ -111,6 +128,10 @@ func (s *Driver) Start() error {
 		}
 	}
 
+	if s.txOrderer != nil {
+		s.txOrderer.Start()
+	}
+
 	s.wg.Add(1)
 	go s.eventLoop()
 
@@ -120,6 +141,9 @@ func (s *Driver) Start() error {
 func (s *Driver) Close() error {
 	s.done <- struct{}{}
 	s.wg.Wait()
+	if s.txOrderer != nil {
+		s.txOrderer.Close()
+	}
 	return nil
 }
 
@@ -181,6 +205,9 @@ func (s *Driver) eventLoop() {
 	bOffStrategy := retry.Exponential()
 	stepAttempts := 0
 
+	// a failed snap sync halts derivation and sequencing, but the driver keeps serving its state
+	snapSyncFailed := false
+
 	// step requests a derivation step to be taken. Won't deadlock if the channel is full.
 	step := func() {
 		select {
@@ -229,12 +256,33 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.derivation.UnsafeL2Head()
 
+	// Only the leader of the sequencer cluster sequences, always true without sequencer cluster.
+	sequencerLeader := s.sequencerConductor.Leader(ctx)
+
+	// committing is the sequenced payload that is being committed to the sequencer cluster, if any.
+	// The commit runs off the event loop, and its result is sent to commitResult.
+	// No new block is sequenced until the commit completes.
+	var committing *eth.ExecutionPayload
+	commitResult := make(chan error, 1)
+
 	for {
+		if leader := s.sequencerConductor.Leader(ctx); leader != sequencerLeader {
+			sequencerLeader = leader
+			if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped {
+				s.log.Info("Sequencer leadership changed", "leader", leader)
+				if leader {
+					// the unsafe head may not have changed since the sequencer last ran, so plan explicitly.
+					planSequencerAction()
+				}
+			}
+		}
+
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
-		// And avoid sequencing if the derivation pipeline indicates the engine is not ready.
-		if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped &&
-			s.l1State.L1Head() != (eth.L1BlockRef{}) && s.derivation.EngineReady() {
+		// And avoid sequencing if the derivation pipeline indicates the engine is not ready,
+		// or if another replica of the sequencer cluster is the leader.
+		if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped && sequencerLeader && committing == nil &&
+			s.l1State.L1Head() != (eth.L1BlockRef{}) && s.snapSyncDone() && s.derivation.EngineReady() {
 			if s.driverConfig.SequencerMaxSafeLag > 0 && s.derivation.SafeL2Head().Number+s.driverConfig.SequencerMaxSafeLag <= s.derivation.UnsafeL2Head().Number {
 				// If the safe head has fallen behind by a significant number of blocks, delay creating new blocks
 				// until the safe lag is below SequencerMaxSafeLag.
@@ -271,7 +319,24 @@ func (s *Driver) eventLoop() {
 				s.log.Error("Sequencer critical error", "err", err)
 				return
 			}
-			if s.network != nil && payload != nil {
+			if payload != nil {
+				// The payload is only published once the sequencer cluster accepted it.
+				// Replication may take up to the conductor request timeout, so it does not block the event loop.
+				committing = payload
+				sequencerCh = nil
+				go func() {
+					commitResult <- s.sequencerConductor.CommitUnsafePayload(ctx, payload)
+				}()
+				continue
+			}
+			planSequencerAction() // schedule the next sequencer action to keep the sequencing looping
+		case err := <-commitResult:
+			payload := committing
+			committing = nil
+			if err != nil {
+				// If not accepted, the new leader will sequence a replacement, and this node reorgs to that.
+				s.log.Error("Failed to commit newly created block to the sequencer cluster, not publishing it", "id", payload.ID(), "err", err)
+			} else if s.network != nil {
 				// Publishing of unsafe data via p2p is optional.
 				// Errors are not severe enough to change/halt sequencing but should be logged and metered.
 				if err := s.network.PublishL2Payload(ctx, payload); err != nil {
@@ -280,7 +345,12 @@ func (s *Driver) eventLoop() {
 				}
 			}
 			planSequencerAction() // schedule the next sequencer action to keep the sequencing looping
+		case <-s.sequencerConductor.LeaderUpdates():
+			// the leadership is read again at the start of the loop
 		case <-altSyncTicker.C:
+			if !s.snapSyncDone() {
+				continue // the engine is snap syncing, it does not need any unsafe L2 blocks yet
+			}
 			// Check if there is a gap in the current unsafe payload queue.
 			ctx, cancel := context.WithTimeout(ctx, time.Second*2)
 			err := s.checkForGapInUnsafeQueue(ctx)
@@ -309,6 +379,37 @@ func (s *Driver) eventLoop() {
 			delayedStepReq = nil
 			step()
 		case <-stepReqCh:
+			if !s.snapSyncDone() {
+				if snapSyncFailed {
+					continue // halted, the failure is reported by the snap sync status
+				}
+				ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+				err := s.snapSync.Step(ctx)
+				cancel()
+				if errors.Is(err, sync.ErrSnapSyncing) {
+					stepAttempts = 0
+					s.log.Info("Engine is snap syncing", "target", s.snapSync.Status().Target)
+					if delayedStepReq == nil {
+						delayedStepReq = time.After(snapSyncPollInterval)
+					}
+				} else if errors.Is(err, sync.ErrSnapSyncFailed) {
+					s.log.Error("Snap sync failed, halting the driver", "err", err)
+					snapSyncFailed = true
+				} else if err != nil {
+					stepAttempts += 1
+					s.log.Warn("Snap sync temporary error", "attempts", stepAttempts, "err", err)
+					reqStep()
+				} else {
+					stepAttempts = 0
+					if s.snapSync.Done() {
+						// derivation starts from the heads of the snap synced engine
+						s.derivation.Reset()
+						s.metrics.RecordPipelineReset()
+					}
+					reqStep()
+				}
+				continue
+			}
 			s.metrics.SetDerivationIdle(false)
 			s.log.Debug("Derivation process step", "onto_origin", s.derivation.Origin(), "attempts", stepAttempts)
 			err := s.derivation.Step(context.Background())
@@ -385,6 +486,9 @@ func (s *Driver) eventLoop() {
 			}
 		case respCh := <-s.sequencerActive:
 			respCh <- !s.driverConfig.SequencerStopped
+		case req := <-s.setMaxDASize:
+			s.sequencer.SetMaxDASize(req.maxTxSize, req.maxBlockSize)
+			close(req.done)
 		case <-s.done:
 			return
 		}
@@ -466,10 +570,31 @@ func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	}
 }
 
+// SetMaxDASize limits the data-availability size of the transactions in the blocks built by the sequencer,
+// e.g. to shrink block production while the batcher is behind. A zero size removes the limit.
+// The execution engine does not enforce the limits yet, see Sequencer.SetMaxDASize.
+func (s *Driver) SetMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) error {
+	if !s.driverConfig.SequencerEnabled {
+		return errors.New("sequencer is not enabled")
+	}
+	done := make(chan struct{})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.setMaxDASize <- maxDASizeReq{maxTxSize: maxTxSize, maxBlockSize: maxBlockSize, done: done}:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			return nil
+		}
+	}
+}
+
 // syncStatus returns the current sync status, and should only be called synchronously with
 // the driver event loop to avoid retrieval of an inconsistent status.
 func (s *Driver) syncStatus() *eth.SyncStatus {
-	return &eth.SyncStatus{
+	status := &eth.SyncStatus{
 		CurrentL1:          s.derivation.Origin(),
 		CurrentL1Finalized: s.derivation.FinalizedL1(),
 		HeadL1:             s.l1State.L1Head(),
@@ -481,6 +606,15 @@ func (s *Driver) syncStatus() *eth.SyncStatus {
 		UnsafeL2SyncTarget: s.derivation.UnsafeL2SyncTarget(),
 		EngineSyncTarget:   s.derivation.EngineSyncTarget(),
 	}
+	if s.snapSync != nil {
+		status.SnapSync = s.snapSync.Status()
+	}
+	return status
+}
+
+// snapSyncDone returns true if there is no snap sync in progress that derivation has to wait for.
+func (s *Driver) snapSyncDone() bool {
+	return s.snapSync == nil || s.snapSync.Done()
 }
 
 // SyncStatus blocks the driver event loop and captures the syncing status.
@@ -513,6 +647,34 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// DerivationDebugInfo blocks the driver event loop and captures the internal state of the derivation pipeline stages.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) DerivationDebugInfo(ctx context.Context) (*derive.PipelineDebugInfo, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := s.derivation.DebugInfo()
+		<-wait
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// DerivationChannelFrames blocks the driver event loop and captures the frames of a channel pending in the channel bank.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) DerivationChannelFrames(ctx context.Context, id derive.ChannelID) (*derive.ChannelFrames, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp, err := s.derivation.ChannelFrames(id)
+		<-wait
+		return resp, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
@@ -543,6 +705,12 @@ type hashAndErrorChannel struct {
 	err  chan error
 }
 
+type maxDASizeReq struct {
+	maxTxSize    uint64
+	maxBlockSize uint64
+	done         chan struct{}
+}
+
 // checkForGapInUnsafeQueue checks if there is a gap in the unsafe queue and attempts to retrieve the missing payloads from an alt-sync method.
 // WARNING: This is only an outgoing signal, the blocks are not guaranteed to be retrieved.
 // Results are received through OnUnsafeL2Payload.
@@ -0,0 +1,227 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// fakeDerivation is an idle derivation pipeline, with a ready engine.
+type fakeDerivation struct {
+	unsafe eth.L2BlockRef
+}
+
+func (d *fakeDerivation) Reset()                                         {}
+func (d *fakeDerivation) Step(ctx context.Context) error                 { return io.EOF }
+func (d *fakeDerivation) AddUnsafePayload(payload *eth.ExecutionPayload) {}
+func (d *fakeDerivation) UnsafeL2SyncTarget() eth.L2BlockRef             { return eth.L2BlockRef{} }
+func (d *fakeDerivation) Finalize(ref eth.L1BlockRef)                    {}
+func (d *fakeDerivation) FinalizedL1() eth.L1BlockRef                    { return eth.L1BlockRef{} }
+func (d *fakeDerivation) Finalized() eth.L2BlockRef                      { return eth.L2BlockRef{} }
+func (d *fakeDerivation) SafeL2Head() eth.L2BlockRef                     { return eth.L2BlockRef{} }
+func (d *fakeDerivation) UnsafeL2Head() eth.L2BlockRef                   { return d.unsafe }
+func (d *fakeDerivation) Origin() eth.L1BlockRef                         { return eth.L1BlockRef{} }
+func (d *fakeDerivation) EngineReady() bool                              { return true }
+func (d *fakeDerivation) EngineSyncTarget() eth.L2BlockRef               { return eth.L2BlockRef{} }
+func (d *fakeDerivation) DebugInfo() *derive.PipelineDebugInfo           { return nil }
+func (d *fakeDerivation) ChannelFrames(id derive.ChannelID) (*derive.ChannelFrames, error) {
+	return nil, errors.New("not supported")
+}
+
+// fakeSequencer sequences the given payloads, one per sequencer action.
+type fakeSequencer struct {
+	payloads []*eth.ExecutionPayload
+	runs     chan struct{}
+}
+
+func (s *fakeSequencer) StartBuildingBlock(ctx context.Context) error { return nil }
+func (s *fakeSequencer) CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return nil, nil
+}
+func (s *fakeSequencer) PlanNextSequencerAction() time.Duration {
+	if len(s.payloads) == 0 {
+		return time.Hour
+	}
+	return 0
+}
+func (s *fakeSequencer) RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayload, error) {
+	s.runs <- struct{}{}
+	if len(s.payloads) == 0 {
+		return nil, nil
+	}
+	payload := s.payloads[0]
+	s.payloads = s.payloads[1:]
+	return payload, nil
+}
+
+// BuildingOnto is never the unsafe head, so the next sequencer action is planned whenever sequencing is allowed.
+func (s *fakeSequencer) BuildingOnto() eth.L2BlockRef                       { return eth.L2BlockRef{Number: 1} }
+func (s *fakeSequencer) SetMaxDASize(maxTxSize uint64, maxBlockSize uint64) {}
+
+// blockingConductor is the leader, and commits payloads once released.
+type blockingConductor struct {
+	commits chan *eth.ExecutionPayload
+	results chan error
+}
+
+func (c *blockingConductor) Leader(ctx context.Context) bool { return true }
+func (c *blockingConductor) LeaderUpdates() <-chan struct{}  { return nil }
+func (c *blockingConductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	c.commits <- payload
+	select {
+	case err := <-c.results:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type fakeNetwork struct {
+	published chan *eth.ExecutionPayload
+}
+
+func (n *fakeNetwork) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	n.published <- payload
+	return nil
+}
+
+type driverTest struct {
+	derivation *fakeDerivation
+	sequencer  *fakeSequencer
+	conductor  *blockingConductor
+	network    *fakeNetwork
+}
+
+// failedSnapSync is a snap sync that fails on its first step.
+type failedSnapSync struct {
+	steps chan struct{}
+}
+
+func (s *failedSnapSync) Step(ctx context.Context) error {
+	s.steps <- struct{}{}
+	return fmt.Errorf("%w: engine rejected snap sync target", sync.ErrSnapSyncFailed)
+}
+func (s *failedSnapSync) Done() bool { return false }
+func (s *failedSnapSync) Status() *eth.SnapSyncStatus {
+	return &eth.SnapSyncStatus{Stage: "failed", Error: "snap sync failed"}
+}
+
+// newTestDriver creates a sequencing driver with fake components, see runEventLoop to start it.
+func newTestDriver(t *testing.T, payloads ...*eth.ExecutionPayload) (*Driver, *driverTest) {
+	logger := testlog.Logger(t, log.LvlError)
+	dt := &driverTest{
+		derivation: &fakeDerivation{},
+		sequencer:  &fakeSequencer{payloads: payloads, runs: make(chan struct{}, 10)},
+		conductor:  &blockingConductor{commits: make(chan *eth.ExecutionPayload, 10), results: make(chan error)},
+		network:    &fakeNetwork{published: make(chan *eth.ExecutionPayload, 10)},
+	}
+	l1State := NewL1State(logger, metrics.NoopMetrics)
+	l1State.HandleNewL1HeadBlock(eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 1})
+	d := &Driver{
+		l1State:            l1State,
+		derivation:         dt.derivation,
+		stateReq:           make(chan chan struct{}),
+		l1HeadSig:          make(chan eth.L1BlockRef),
+		sequencerConductor: dt.conductor,
+		config:             &rollup.Config{BlockTime: 3600}, // no alt-sync checks during the test
+		driverConfig:       &Config{SequencerEnabled: true},
+		done:               make(chan struct{}, 1), // closing does not block if the event loop stopped
+		log:                logger,
+		snapshotLog:        logger,
+		sequencer:          dt.sequencer,
+		network:            dt.network,
+		metrics:            metrics.NewMetrics(""),
+	}
+	return d, dt
+}
+
+// runEventLoop runs the event loop of the driver until the end of the test.
+func runEventLoop(t *testing.T, d *Driver) {
+	d.wg.Add(1)
+	go d.eventLoop()
+	t.Cleanup(func() { require.NoError(t, d.Close()) })
+}
+
+// startTestDriver runs the event loop of a sequencing driver, with fake components.
+func startTestDriver(t *testing.T, payloads ...*eth.ExecutionPayload) (*Driver, *driverTest) {
+	d, dt := newTestDriver(t, payloads...)
+	runEventLoop(t, d)
+	return d, dt
+}
+
+func receive[T any](t *testing.T, ch <-chan T, msg string) T {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal(msg)
+		panic("unreachable")
+	}
+}
+
+func TestDriverCommitsOffEventLoop(t *testing.T) {
+	payload := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0x0a}}
+	next := &eth.ExecutionPayload{BlockNumber: 11, BlockHash: common.Hash{0x0b}}
+	replacement := &eth.ExecutionPayload{BlockNumber: 11, BlockHash: common.Hash{0x0c}}
+	d, dt := startTestDriver(t, payload, next, replacement)
+
+	require.Equal(t, payload, receive(t, dt.conductor.commits, "expected payload to be committed"))
+	receive(t, dt.sequencer.runs, "expected sequencer action")
+
+	// the event loop keeps serving requests while the payload is being committed
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := d.SyncStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, dt.network.published, "payload is not published before it is committed")
+	require.Empty(t, dt.sequencer.runs, "no new block is sequenced while committing")
+
+	dt.conductor.results <- nil
+	require.Equal(t, payload, receive(t, dt.network.published, "expected committed payload to be published"))
+
+	// a payload that is not committed is not published, and sequencing continues
+	require.Equal(t, next, receive(t, dt.conductor.commits, "expected next payload to be committed"))
+	dt.conductor.results <- errors.New("no quorum")
+	require.Equal(t, replacement, receive(t, dt.conductor.commits, "expected sequencing to continue after failed commit"))
+	require.Empty(t, dt.network.published, "uncommitted payload is not published")
+}
+
+func TestDriverHaltsOnSnapSyncFailure(t *testing.T) {
+	d, dt := newTestDriver(t, &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0x0a}})
+	snapSync := &failedSnapSync{steps: make(chan struct{}, 10)}
+	d.snapSync = snapSync
+	runEventLoop(t, d)
+
+	receive(t, snapSync.steps, "expected snap sync step")
+
+	// a new L1 head requests a step, but the failed snap sync is not stepped again
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, d.OnL1Head(ctx, eth.L1BlockRef{Hash: common.Hash{0x02}, Number: 2}))
+
+	// the halted event loop keeps serving requests
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		status, err := d.SyncStatus(ctx)
+		cancel()
+		require.NoError(t, err)
+		require.Equal(t, "failed", status.SnapSync.Stage)
+		require.Equal(t, uint64(2), status.HeadL1.Number)
+	}
+	require.Empty(t, snapSync.steps, "failed snap sync is not retried")
+	require.Empty(t, dt.sequencer.runs, "no blocks are sequenced without snap synced engine")
+}
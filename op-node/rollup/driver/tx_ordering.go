@@ -0,0 +1,368 @@
+package driver
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// TxOrderingEngine leaves the selection and ordering of the transaction-pool transactions to the execution engine.
+	TxOrderingEngine = "engine"
+	// TxOrderingFIFO orders the transactions by the time they arrived in the transaction-pool.
+	TxOrderingFIFO = "fifo"
+	// TxOrderingPriorityFee orders the transactions by their effective priority fee, highest first.
+	TxOrderingPriorityFee = "priority-fee"
+	// TxOrderingFair batches the transactions by arrival time, and orders the transactions of a batch
+	// pseudo-randomly, so transactions that arrive close together cannot be ordered by fee or by latency.
+	TxOrderingFair = "fair"
+)
+
+// TxOrderingPolicies lists the supported transaction ordering policies.
+var TxOrderingPolicies = []string{TxOrderingEngine, TxOrderingFIFO, TxOrderingPriorityFee, TxOrderingFair}
+
+const (
+	// arrivalRetention is how long the arrival time of a transaction that is not pending is remembered,
+	// e.g. of a transaction that is queued behind a nonce gap.
+	arrivalRetention = 10 * time.Minute
+	// arrivalPollInterval is the interval of polling the transactions that enter the transaction-pool.
+	arrivalPollInterval = 250 * time.Millisecond
+)
+
+type TxOrderingMetrics interface {
+	RecordTxOrdering(policy string, candidates int, selected int, duration time.Duration)
+	RecordTxOrderingFallback(policy string)
+}
+
+// TxPool is the transaction-pool of the execution engine, as used for ordering transactions in the op-node.
+type TxPool interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	TxPoolPending(ctx context.Context) (map[common.Address][]*types.Transaction, error)
+	NewPendingTransactionFilter(ctx context.Context) (string, error)
+	PendingTransactionFilterChanges(ctx context.Context, id string) ([]common.Hash, error)
+}
+
+// PendingTx is a candidate transaction of the transaction-pool.
+type PendingTx struct {
+	Tx      *types.Transaction
+	Sender  common.Address
+	Arrival time.Time
+}
+
+// OrderingEnv is the block building context of the transactions being ordered.
+type OrderingEnv struct {
+	Parent  eth.L2BlockRef
+	BaseFee *big.Int
+}
+
+// TxOrderingPolicy determines the order of the transaction-pool transactions in a block.
+type TxOrderingPolicy interface {
+	Name() string
+	// Less reports whether transaction a is included before transaction b. It must be a strict total order.
+	// Less is only used to compare the next transactions of different senders:
+	// the transactions of a sender are always included in nonce order.
+	Less(env *OrderingEnv, a, b *PendingTx) bool
+}
+
+// NewTxOrderingPolicy returns the ordering policy with the given name.
+// The fair window is the duration of the arrival time batches of the fair ordering policy.
+func NewTxOrderingPolicy(name string, fairWindow time.Duration) (TxOrderingPolicy, error) {
+	switch name {
+	case TxOrderingFIFO:
+		return fifoPolicy{}, nil
+	case TxOrderingPriorityFee:
+		return priorityFeePolicy{}, nil
+	case TxOrderingFair:
+		if fairWindow <= 0 {
+			return nil, fmt.Errorf("fair ordering window must be positive, got %s", fairWindow)
+		}
+		return fairPolicy{window: fairWindow}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering policy %q", name)
+	}
+}
+
+func lessByArrival(a, b *PendingTx) bool {
+	if !a.Arrival.Equal(b.Arrival) {
+		return a.Arrival.Before(b.Arrival)
+	}
+	ha, hb := a.Tx.Hash(), b.Tx.Hash()
+	return bytes.Compare(ha[:], hb[:]) < 0
+}
+
+type fifoPolicy struct{}
+
+func (fifoPolicy) Name() string {
+	return TxOrderingFIFO
+}
+
+func (fifoPolicy) Less(_ *OrderingEnv, a, b *PendingTx) bool {
+	return lessByArrival(a, b)
+}
+
+type priorityFeePolicy struct{}
+
+func (priorityFeePolicy) Name() string {
+	return TxOrderingPriorityFee
+}
+
+func (priorityFeePolicy) Less(env *OrderingEnv, a, b *PendingTx) bool {
+	if c := a.Tx.EffectiveGasTipCmp(b.Tx, env.BaseFee); c != 0 {
+		return c > 0
+	}
+	return lessByArrival(a, b)
+}
+
+type fairPolicy struct {
+	window time.Duration
+}
+
+func (fairPolicy) Name() string {
+	return TxOrderingFair
+}
+
+func (p fairPolicy) Less(env *OrderingEnv, a, b *PendingTx) bool {
+	if ba, bb := a.Arrival.UnixNano()/int64(p.window), b.Arrival.UnixNano()/int64(p.window); ba != bb {
+		return ba < bb
+	}
+	// Within a batch, order by a hash of the transaction that is not known before the parent block is.
+	ka, kb := fairKey(env.Parent.Hash, a.Tx.Hash()), fairKey(env.Parent.Hash, b.Tx.Hash())
+	return bytes.Compare(ka[:], kb[:]) < 0
+}
+
+func fairKey(parent common.Hash, tx common.Hash) common.Hash {
+	return crypto.Keccak256Hash(parent[:], tx[:])
+}
+
+// txHeads is a heap of the next transaction of each sender, ordered by the policy.
+type txHeads struct {
+	env    *OrderingEnv
+	policy TxOrderingPolicy
+	heads  []*PendingTx
+}
+
+func (h *txHeads) Len() int           { return len(h.heads) }
+func (h *txHeads) Less(i, j int) bool { return h.policy.Less(h.env, h.heads[i], h.heads[j]) }
+func (h *txHeads) Swap(i, j int)      { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+func (h *txHeads) Push(x any)         { h.heads = append(h.heads, x.(*PendingTx)) }
+func (h *txHeads) Pop() any {
+	n := len(h.heads)
+	x := h.heads[n-1]
+	h.heads = h.heads[:n-1]
+	return x
+}
+
+// TxOrderer selects the transaction-pool transactions of the blocks built by the sequencer,
+// and orders them with an ordering policy, instead of leaving the ordering to the execution engine.
+type TxOrderer struct {
+	log     log.Logger
+	policy  TxOrderingPolicy
+	pool    TxPool
+	metrics TxOrderingMetrics
+
+	timeNow func() time.Time
+
+	mu       sync.Mutex
+	arrivals map[common.Hash]time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewTxOrderer(log log.Logger, policy TxOrderingPolicy, pool TxPool, metrics TxOrderingMetrics) *TxOrderer {
+	return &TxOrderer{
+		log:      log,
+		policy:   policy,
+		pool:     pool,
+		metrics:  metrics,
+		timeNow:  time.Now,
+		arrivals: make(map[common.Hash]time.Time),
+	}
+}
+
+func (o *TxOrderer) Policy() string {
+	return o.policy.Name()
+}
+
+// Start tracks the arrival time of the transactions that enter the transaction-pool.
+func (o *TxOrderer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.wg.Add(1)
+	go o.trackArrivals(ctx)
+}
+
+func (o *TxOrderer) Close() {
+	if o.cancel != nil {
+		o.cancel()
+	}
+	o.wg.Wait()
+}
+
+func (o *TxOrderer) trackArrivals(ctx context.Context) {
+	defer o.wg.Done()
+	ticker := time.NewTicker(arrivalPollInterval)
+	defer ticker.Stop()
+	var filterID string
+	// failing suppresses repeated warnings while the transaction-pool cannot be polled
+	failing := false
+	logErr := func(msg string, err error) {
+		if failing {
+			o.log.Debug(msg, "err", err)
+		} else {
+			o.log.Warn(msg, "err", err)
+		}
+		failing = true
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if filterID == "" {
+			id, err := o.pool.NewPendingTransactionFilter(ctx)
+			if err != nil {
+				logErr("Failed to install pending transaction filter", err)
+				continue
+			}
+			filterID = id
+		}
+		hashes, err := o.pool.PendingTransactionFilterChanges(ctx, filterID)
+		if err != nil {
+			// The filter may have expired, a new filter is installed on the next poll.
+			logErr("Failed to poll pending transaction filter", err)
+			filterID = ""
+			continue
+		}
+		failing = false
+		o.recordArrivals(hashes)
+	}
+}
+
+func (o *TxOrderer) recordArrivals(hashes []common.Hash) {
+	now := o.timeNow()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, h := range hashes {
+		if _, ok := o.arrivals[h]; !ok {
+			o.arrivals[h] = now
+		}
+	}
+}
+
+// candidates returns the pending transactions of each sender with their arrival time,
+// and forgets the arrival time of old transactions that are not pending anymore.
+func (o *TxOrderer) candidates(pending map[common.Address][]*types.Transaction) (map[common.Address][]*PendingTx, int) {
+	now := o.timeNow()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make(map[common.Address][]*PendingTx, len(pending))
+	seen := make(map[common.Hash]struct{})
+	for sender, txs := range pending {
+		for _, tx := range txs {
+			h := tx.Hash()
+			arrival, ok := o.arrivals[h]
+			if !ok {
+				arrival = now
+				o.arrivals[h] = now
+			}
+			seen[h] = struct{}{}
+			out[sender] = append(out[sender], &PendingTx{Tx: tx, Sender: sender, Arrival: arrival})
+		}
+	}
+	for h, arrival := range o.arrivals {
+		if _, ok := seen[h]; !ok && now.Sub(arrival) > arrivalRetention {
+			delete(o.arrivals, h)
+		}
+	}
+	return out, len(seen)
+}
+
+// SelectTransactions returns the transaction-pool transactions to include after the forced transactions of the
+// payload attributes, in the order of the policy. The transactions of a sender are included in nonce order without gaps,
+// within the remaining gas of the block and the DA size limits. A zero DA size limit means no limit.
+func (o *TxOrderer) SelectTransactions(ctx context.Context, parent eth.L2BlockRef, attrs *eth.PayloadAttributes, maxTxDASize uint64, maxBlockDASize uint64) ([]eth.Data, error) {
+	start := o.timeNow()
+	parentInfo, err := o.pool.InfoByHash(ctx, parent.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch parent block %s: %w", parent, err)
+	}
+	pending, err := o.pool.TxPoolPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates, count := o.candidates(pending)
+
+	if attrs.GasLimit == nil {
+		return nil, errors.New("payload attributes without gas limit")
+	}
+	gasLimit := uint64(*attrs.GasLimit)
+	var forcedGas uint64
+	for i, data := range attrs.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("failed to decode forced transaction %d: %w", i, err)
+		}
+		forcedGas += tx.Gas()
+	}
+	if forcedGas > gasLimit {
+		gasLimit = 0
+	} else {
+		gasLimit -= forcedGas
+	}
+	// The base fee of the next block is at most 12.5% higher than the base fee of the parent block.
+	// Transactions that may not cover it are not included, as they would fail the block.
+	baseFee := parentInfo.BaseFee()
+	minFeeCap := new(big.Int).Div(new(big.Int).Mul(baseFee, big.NewInt(9)), big.NewInt(8))
+
+	h := &txHeads{env: &OrderingEnv{Parent: parent, BaseFee: baseFee}, policy: o.policy}
+	next := make(map[common.Address]int, len(candidates))
+	for _, txs := range candidates {
+		h.heads = append(h.heads, txs[0])
+	}
+	heap.Init(h)
+
+	var out []eth.Data
+	var daSize uint64
+	for h.Len() > 0 && gasLimit >= params.TxGas {
+		ptx := h.heads[0]
+		size := ptx.Tx.Size()
+		if ptx.Tx.Gas() > gasLimit || ptx.Tx.GasFeeCapIntCmp(minFeeCap) < 0 ||
+			(maxTxDASize != 0 && size > maxTxDASize) || (maxBlockDASize != 0 && daSize+size > maxBlockDASize) {
+			// The later transactions of the sender cannot be included without this one.
+			heap.Pop(h)
+			continue
+		}
+		data, err := ptx.Tx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transaction %s: %w", ptx.Tx.Hash(), err)
+		}
+		out = append(out, data)
+		gasLimit -= ptx.Tx.Gas()
+		daSize += size
+
+		next[ptx.Sender] += 1
+		if txs := candidates[ptx.Sender]; next[ptx.Sender] < len(txs) {
+			h.heads[0] = txs[next[ptx.Sender]]
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	o.metrics.RecordTxOrdering(o.policy.Name(), count, len(out), o.timeNow().Sub(start))
+	return out, nil
+}
@@ -0,0 +1,230 @@
+package driver
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type fakeTxPool struct {
+	baseFee  *big.Int
+	pending  map[common.Address][]*types.Transaction
+	arrivals []common.Hash
+}
+
+func (p *fakeTxPool) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return &testutils.MockBlockInfo{InfoHash: hash, InfoBaseFee: p.baseFee}, nil
+}
+
+func (p *fakeTxPool) TxPoolPending(ctx context.Context) (map[common.Address][]*types.Transaction, error) {
+	return p.pending, nil
+}
+
+func (p *fakeTxPool) NewPendingTransactionFilter(ctx context.Context) (string, error) {
+	return "0x1", nil
+}
+
+func (p *fakeTxPool) PendingTransactionFilterChanges(ctx context.Context, id string) ([]common.Hash, error) {
+	out := p.arrivals
+	p.arrivals = nil
+	return out, nil
+}
+
+// testTxCount makes every test transaction unique
+var testTxCount int64
+
+func testTx(nonce uint64, tip int64, gas uint64, dataSize int) *types.Transaction {
+	testTxCount += 1
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(901),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: big.NewInt(1000 + tip),
+		Gas:       gas,
+		Value:     big.NewInt(testTxCount),
+		Data:      make([]byte, dataSize),
+	})
+}
+
+type orderingTest struct {
+	pool    *fakeTxPool
+	orderer *TxOrderer
+	now     time.Time
+	parent  eth.L2BlockRef
+	attrs   *eth.PayloadAttributes
+}
+
+func setupOrderingTest(t *testing.T, policy string, fairWindow time.Duration) *orderingTest {
+	p, err := NewTxOrderingPolicy(policy, fairWindow)
+	require.NoError(t, err)
+	pool := &fakeTxPool{baseFee: big.NewInt(100), pending: make(map[common.Address][]*types.Transaction)}
+	o := NewTxOrderer(testlog.Logger(t, log.LvlError), p, pool, metrics.NoopMetrics)
+	test := &orderingTest{
+		pool:    pool,
+		orderer: o,
+		now:     time.Unix(1000, 0),
+		parent:  eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 10},
+	}
+	gasLimit := eth.Uint64Quantity(1_000_000)
+	test.attrs = &eth.PayloadAttributes{GasLimit: &gasLimit}
+	o.timeNow = func() time.Time { return test.now }
+	return test
+}
+
+// add adds the transaction to the pool, arriving at the given offset from the start of the test.
+func (o *orderingTest) add(sender common.Address, tx *types.Transaction, arrival time.Duration) {
+	o.pool.pending[sender] = append(o.pool.pending[sender], tx)
+	now := o.now
+	o.now = time.Unix(1000, 0).Add(arrival)
+	o.orderer.recordArrivals([]common.Hash{tx.Hash()})
+	o.now = now
+}
+
+func (o *orderingTest) selected(t *testing.T, maxTxDASize, maxBlockDASize uint64) []common.Hash {
+	txs, err := o.orderer.SelectTransactions(context.Background(), o.parent, o.attrs, maxTxDASize, maxBlockDASize)
+	require.NoError(t, err)
+	out := make([]common.Hash, 0, len(txs))
+	for _, data := range txs {
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(data))
+		out = append(out, tx.Hash())
+	}
+	return out
+}
+
+var (
+	senderA = common.Address{0x0a}
+	senderB = common.Address{0x0b}
+	senderC = common.Address{0x0c}
+)
+
+func TestTxOrderingPolicies(t *testing.T) {
+	a0, a1 := testTx(0, 1, 21000, 0), testTx(1, 5, 21000, 0)
+	b0 := testTx(0, 3, 21000, 0)
+	c0 := testTx(0, 2, 21000, 0)
+	setup := func(t *testing.T, policy string, fairWindow time.Duration) *orderingTest {
+		o := setupOrderingTest(t, policy, fairWindow)
+		o.add(senderA, a0, 0)
+		o.add(senderA, a1, 3*time.Second)
+		o.add(senderB, b0, time.Second)
+		o.add(senderC, c0, 2*time.Second)
+		return o
+	}
+
+	t.Run("fifo", func(t *testing.T) {
+		o := setup(t, TxOrderingFIFO, 0)
+		require.Equal(t, []common.Hash{a0.Hash(), b0.Hash(), c0.Hash(), a1.Hash()}, o.selected(t, 0, 0))
+	})
+	t.Run("priority-fee", func(t *testing.T) {
+		o := setup(t, TxOrderingPriorityFee, 0)
+		require.Equal(t, []common.Hash{b0.Hash(), c0.Hash(), a0.Hash(), a1.Hash()}, o.selected(t, 0, 0),
+			"highest tip first, in nonce order per sender")
+	})
+	t.Run("fair", func(t *testing.T) {
+		o := setup(t, TxOrderingFair, time.Nanosecond)
+		require.Equal(t, []common.Hash{a0.Hash(), b0.Hash(), c0.Hash(), a1.Hash()}, o.selected(t, 0, 0),
+			"batches are ordered by arrival")
+
+		o = setup(t, TxOrderingFair, time.Hour)
+		first := o.selected(t, 0, 0)
+		require.ElementsMatch(t, []common.Hash{a0.Hash(), a1.Hash(), b0.Hash(), c0.Hash()}, first)
+		require.Less(t, indexOf(first, a0.Hash()), indexOf(first, a1.Hash()), "nonce order per sender")
+		require.Equal(t, first, o.selected(t, 0, 0), "deterministic for the same parent")
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, err := NewTxOrderingPolicy("random", 0)
+		require.ErrorContains(t, err, "unknown transaction ordering policy")
+		_, err = NewTxOrderingPolicy(TxOrderingFair, 0)
+		require.ErrorContains(t, err, "must be positive")
+	})
+}
+
+func indexOf(hashes []common.Hash, h common.Hash) int {
+	for i, x := range hashes {
+		if x == h {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestTxOrderingLimits(t *testing.T) {
+	t.Run("gas", func(t *testing.T) {
+		o := setupOrderingTest(t, TxOrderingFIFO, 0)
+		a0, a1 := testTx(0, 1, 500_000, 0), testTx(1, 1, 21000, 0)
+		b0, b1 := testTx(0, 1, 400_000, 0), testTx(1, 1, 200_000, 0)
+		c0 := testTx(0, 1, 50_000, 0)
+		o.add(senderA, a0, 0)
+		o.add(senderB, b0, time.Second)
+		o.add(senderB, b1, 2*time.Second)
+		o.add(senderA, a1, 3*time.Second)
+		o.add(senderC, c0, 4*time.Second)
+		require.Equal(t, []common.Hash{a0.Hash(), b0.Hash(), a1.Hash(), c0.Hash()}, o.selected(t, 0, 0),
+			"b1 exceeds the remaining gas")
+
+		deposit, err := types.NewTx(&types.DepositTx{Gas: 200_000}).MarshalBinary()
+		require.NoError(t, err)
+		o.attrs.Transactions = []eth.Data{deposit}
+		require.Equal(t, []common.Hash{a0.Hash(), a1.Hash(), c0.Hash()}, o.selected(t, 0, 0),
+			"the gas of the forced transactions is reserved, and the later txs of a skipped sender are skipped")
+	})
+	t.Run("fee cap", func(t *testing.T) {
+		o := setupOrderingTest(t, TxOrderingFIFO, 0)
+		o.pool.baseFee = big.NewInt(1000)
+		a0 := testTx(0, 200, 21000, 0)
+		b0 := testTx(0, 100, 21000, 0)
+		o.add(senderA, a0, 0)
+		o.add(senderB, b0, time.Second)
+		require.Equal(t, []common.Hash{a0.Hash()}, o.selected(t, 0, 0),
+			"fee cap must cover the max base fee of the next block")
+	})
+	t.Run("da size", func(t *testing.T) {
+		o := setupOrderingTest(t, TxOrderingFIFO, 0)
+		a0 := testTx(0, 1, 100_000, 1000)
+		b0, b1 := testTx(0, 1, 100_000, 100), testTx(1, 1, 100_000, 100)
+		c0 := testTx(0, 1, 100_000, 100)
+		o.add(senderA, a0, 0)
+		o.add(senderB, b0, time.Second)
+		o.add(senderB, b1, 2*time.Second)
+		o.add(senderC, c0, 3*time.Second)
+		require.Equal(t, []common.Hash{b0.Hash(), b1.Hash(), c0.Hash()}, o.selected(t, 500, 0),
+			"a0 exceeds the tx size limit")
+		blockSize := b0.Size() + b1.Size()
+		require.Equal(t, []common.Hash{b0.Hash(), b1.Hash()}, o.selected(t, 500, blockSize),
+			"c0 exceeds the block size limit")
+	})
+}
+
+func TestTxOrderingArrivals(t *testing.T) {
+	o := setupOrderingTest(t, TxOrderingFIFO, 0)
+	a0 := testTx(0, 1, 21000, 0)
+	b0 := testTx(0, 1, 21000, 0)
+	o.pool.pending[senderA] = []*types.Transaction{a0}
+	o.pool.pending[senderB] = []*types.Transaction{b0}
+	o.now = time.Unix(1000, 0)
+	o.orderer.recordArrivals([]common.Hash{b0.Hash()})
+	o.now = time.Unix(1001, 0)
+	require.Equal(t, []common.Hash{b0.Hash(), a0.Hash()}, o.selected(t, 0, 0),
+		"transactions that were not seen entering the pool arrive when they are first seen pending")
+	require.Equal(t, time.Unix(1001, 0), o.orderer.arrivals[a0.Hash()])
+
+	delete(o.pool.pending, senderB)
+	o.now = time.Unix(1000, 0).Add(arrivalRetention)
+	o.selected(t, 0, 0)
+	require.Contains(t, o.orderer.arrivals, b0.Hash(), "recently seen transactions are remembered")
+	o.now = o.now.Add(time.Second)
+	o.selected(t, 0, 0)
+	require.NotContains(t, o.orderer.arrivals, b0.Hash(), "old transactions that are not pending are forgotten")
+	require.Contains(t, o.orderer.arrivals, a0.Hash())
+}
@@ -0,0 +1,243 @@
+package rollup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ForkName identifies a network upgrade that activates at a timestamp.
+type ForkName string
+
+const (
+	Regolith ForkName = "regolith"
+	Canyon   ForkName = "canyon"
+	Delta    ForkName = "delta"
+	Ecotone  ForkName = "ecotone"
+)
+
+// forkDefinition declares a network upgrade: where its activation time is configured,
+// and the upgrades that must be active before it can activate.
+type forkDefinition struct {
+	name ForkName
+	// activationTime returns the activation time of the upgrade in the Config.
+	activationTime func(c *Config) *uint64
+	// dependencies are the upgrades the upgrade builds on. Upgrades that do not depend
+	// on each other can be scheduled independently, in any order.
+	dependencies []ForkName
+}
+
+// forkDefinitions declares all network upgrades after Bedrock, in the order in which they were introduced.
+// A new network upgrade is added here, with its activation time in the Config: everything else,
+// such as IsActive, the fork schedule and its checks, the logs and metrics, is driven by this table.
+var forkDefinitions = []forkDefinition{
+	{name: Regolith, activationTime: func(c *Config) *uint64 { return c.RegolithTime }},
+	{
+		name:           Canyon,
+		activationTime: func(c *Config) *uint64 { return c.CanyonTime },
+		// Canyon changes the processing of deposits, as introduced by Regolith.
+		dependencies: []ForkName{Regolith},
+	},
+	{name: Delta, activationTime: func(c *Config) *uint64 { return c.DeltaTime }},
+	{
+		name:           Ecotone,
+		activationTime: func(c *Config) *uint64 { return c.EcotoneTime },
+		// Ecotone activates Cancun on L2, which requires Shanghai, as activated by Canyon.
+		dependencies: []ForkName{Canyon},
+	},
+}
+
+// Forks lists all network upgrades after Bedrock, in the order in which they were introduced.
+var Forks = func() []ForkName {
+	out := make([]ForkName, 0, len(forkDefinitions))
+	for _, f := range forkDefinitions {
+		out = append(out, f.name)
+	}
+	return out
+}()
+
+// forkDependencies returns the network upgrades that must be active before the network upgrade can activate.
+func forkDependencies(fork ForkName) []ForkName {
+	for _, f := range forkDefinitions {
+		if f.name == fork {
+			return f.dependencies
+		}
+	}
+	return nil
+}
+
+// Title returns the name of the network upgrade, as it is written in prose.
+func (f ForkName) Title() string {
+	if f == "" {
+		return ""
+	}
+	return strings.ToUpper(string(f[:1])) + string(f[1:])
+}
+
+// ForkActivation is the scheduled activation of a network upgrade.
+type ForkActivation struct {
+	Name ForkName
+	// Time is the activation timestamp, or nil if the upgrade is not scheduled.
+	Time *uint64
+}
+
+// ForkSchedule lists the activation of every network upgrade, in the order of Forks.
+type ForkSchedule []ForkActivation
+
+// ActivationTime returns the activation timestamp of the network upgrade, or nil if it is not scheduled.
+func (s ForkSchedule) ActivationTime(fork ForkName) *uint64 {
+	for _, f := range s {
+		if f.Name == fork {
+			return f.Time
+		}
+	}
+	return nil
+}
+
+// IsActive returns true if the network upgrade is active at or past the given timestamp.
+func (s ForkSchedule) IsActive(fork ForkName, timestamp uint64) bool {
+	t := s.ActivationTime(fork)
+	return t != nil && timestamp >= *t
+}
+
+// Check verifies that the network upgrades activate after the upgrades they depend on:
+// an upgrade can only be scheduled if the upgrades it depends on are scheduled at the same time or earlier.
+func (s ForkSchedule) Check() error {
+	for _, f := range s {
+		if f.Time == nil {
+			continue
+		}
+		for _, dep := range forkDependencies(f.Name) {
+			depTime := s.ActivationTime(dep)
+			if depTime == nil {
+				return fmt.Errorf("fork %s is scheduled at %d, but fork %s it depends on is not scheduled", f.Name, *f.Time, dep)
+			}
+			if *depTime > *f.Time {
+				return fmt.Errorf("fork %s is scheduled at %d, before fork %s it depends on at %d", f.Name, *f.Time, dep, *depTime)
+			}
+		}
+	}
+	return nil
+}
+
+// Upcoming returns the scheduled network upgrades that are not active yet at the given timestamp.
+func (s ForkSchedule) Upcoming(timestamp uint64) ForkSchedule {
+	var out ForkSchedule
+	for _, f := range s {
+		if f.Time != nil && timestamp < *f.Time {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// ActivatedAt returns the network upgrades that activate with the L2 block at the given timestamp,
+// i.e. that are active at the block, but not at its parent block.
+func (s ForkSchedule) ActivatedAt(l2Time uint64, blockTime uint64) []ForkName {
+	var out []ForkName
+	for _, f := range s {
+		if f.Time != nil && l2Time >= *f.Time && l2Time < *f.Time+blockTime {
+			out = append(out, f.Name)
+		}
+	}
+	return out
+}
+
+// LogUpcoming logs the network upgrades that are scheduled after the given timestamp,
+// with the remaining time until their activation.
+func (s ForkSchedule) LogUpcoming(log log.Logger, now uint64) {
+	for _, f := range s.Upcoming(now) {
+		log.Info("Upcoming network upgrade", "fork", f.Name, "timestamp", *f.Time,
+			"activates_in", time.Duration(*f.Time-now)*time.Second)
+	}
+}
+
+// ActivationTime returns the activation timestamp of the network upgrade, or nil if it is not scheduled.
+func (c *Config) ActivationTime(fork ForkName) *uint64 {
+	for _, f := range forkDefinitions {
+		if f.name == fork {
+			return f.activationTime(c)
+		}
+	}
+	return nil
+}
+
+// ForkSchedule returns the activation of every network upgrade, in the order of Forks.
+func (c *Config) ForkSchedule() ForkSchedule {
+	out := make(ForkSchedule, 0, len(forkDefinitions))
+	for _, f := range forkDefinitions {
+		out = append(out, ForkActivation{Name: f.name, Time: f.activationTime(c)})
+	}
+	return out
+}
+
+// IsActive returns true if the network upgrade is active at or past the given timestamp.
+// Ecotone activates on two clocks, see EcotoneTime: reading batches from blobs is checked with the timestamp
+// of the L1 block the data is read from, while the payload version, the Cancun fields of the payload attributes
+// and the engine API methods are checked with the timestamp of the L2 block.
+func (c *Config) IsActive(fork ForkName, timestamp uint64) bool {
+	t := c.ActivationTime(fork)
+	return t != nil && timestamp >= *t
+}
+
+// PayloadVersion returns the format of the L2 execution payload at the given timestamp:
+// Canyon activates Shanghai on L2, and Ecotone activates Cancun.
+func (c *Config) PayloadVersion(timestamp uint64) eth.BlockVersion {
+	if c.IsActive(Ecotone, timestamp) {
+		return eth.BlockV3
+	} else if c.IsActive(Canyon, timestamp) {
+		return eth.BlockV2
+	}
+	return eth.BlockV1
+}
+
+// ForkchoiceUpdatedVersion returns the engine API method to update the forkchoice with.
+// With attributes, the version is selected by the timestamp of the payload to build.
+// Without attributes, the forkchoice update is valid in any version,
+// and the latest version that the engine must support for the scheduled upgrades is used.
+func (c *Config) ForkchoiceUpdatedVersion(attr *eth.PayloadAttributes) eth.EngineAPIMethod {
+	var version eth.BlockVersion
+	if attr != nil {
+		version = c.PayloadVersion(uint64(attr.Timestamp))
+	} else if c.ActivationTime(Ecotone) != nil {
+		version = eth.BlockV3
+	} else if c.ActivationTime(Canyon) != nil {
+		version = eth.BlockV2
+	}
+	switch version {
+	case eth.BlockV3:
+		return eth.FCUV3
+	case eth.BlockV2:
+		return eth.FCUV2
+	default:
+		return eth.FCUV1
+	}
+}
+
+// NewPayloadVersion returns the engine API method to insert the payload with the given timestamp with.
+func (c *Config) NewPayloadVersion(timestamp uint64) eth.EngineAPIMethod {
+	switch c.PayloadVersion(timestamp) {
+	case eth.BlockV3:
+		return eth.NewPayloadV3
+	case eth.BlockV2:
+		return eth.NewPayloadV2
+	default:
+		return eth.NewPayloadV1
+	}
+}
+
+// GetPayloadVersion returns the engine API method to retrieve the payload with the given timestamp with.
+func (c *Config) GetPayloadVersion(timestamp uint64) eth.EngineAPIMethod {
+	switch c.PayloadVersion(timestamp) {
+	case eth.BlockV3:
+		return eth.GetPayloadV3
+	case eth.BlockV2:
+		return eth.GetPayloadV2
+	default:
+		return eth.GetPayloadV1
+	}
+}
@@ -0,0 +1,167 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func u64(v uint64) *uint64 {
+	return &v
+}
+
+func TestForkScheduleCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		schedule    ForkSchedule
+		expectedErr string
+	}{
+		{
+			name:     "none scheduled",
+			schedule: ForkSchedule{{Name: Regolith}, {Name: Canyon}, {Name: Delta}, {Name: Ecotone}},
+		},
+		{
+			name:     "in order",
+			schedule: ForkSchedule{{Name: Regolith, Time: u64(0)}, {Name: Canyon, Time: u64(0)}, {Name: Delta, Time: u64(10)}, {Name: Ecotone, Time: u64(10)}},
+		},
+		{
+			name:     "later forks not scheduled",
+			schedule: ForkSchedule{{Name: Regolith, Time: u64(0)}, {Name: Canyon}, {Name: Delta}, {Name: Ecotone}},
+		},
+		{
+			name:     "independent fork not scheduled",
+			schedule: ForkSchedule{{Name: Regolith, Time: u64(0)}, {Name: Canyon, Time: u64(0)}, {Name: Delta}, {Name: Ecotone, Time: u64(10)}},
+		},
+		{
+			name:     "independent forks out of order",
+			schedule: ForkSchedule{{Name: Regolith, Time: u64(0)}, {Name: Canyon, Time: u64(0)}, {Name: Delta, Time: u64(20)}, {Name: Ecotone, Time: u64(10)}},
+		},
+		{
+			name:        "dependency not scheduled",
+			schedule:    ForkSchedule{{Name: Regolith, Time: u64(0)}, {Name: Canyon}, {Name: Delta}, {Name: Ecotone, Time: u64(10)}},
+			expectedErr: "fork ecotone is scheduled at 10, but fork canyon it depends on is not scheduled",
+		},
+		{
+			name:        "dependency out of order",
+			schedule:    ForkSchedule{{Name: Regolith, Time: u64(20)}, {Name: Canyon, Time: u64(10)}, {Name: Delta}, {Name: Ecotone}},
+			expectedErr: "fork canyon is scheduled at 10, before fork regolith it depends on at 20",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.schedule.Check()
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestForkSchedule(t *testing.T) {
+	config := randConfig()
+	config.RegolithTime = u64(0)
+	config.CanyonTime = u64(0)
+	config.DeltaTime = u64(10)
+	config.EcotoneTime = nil
+	config.BlockTime = 2
+
+	schedule := config.ForkSchedule()
+	require.Len(t, schedule, len(Forks))
+	for i, fork := range Forks {
+		require.Equal(t, fork, schedule[i].Name)
+		require.Equal(t, config.ActivationTime(fork), schedule[i].Time)
+	}
+	require.NoError(t, config.Check())
+
+	require.True(t, config.IsActive(Regolith, 0))
+	require.False(t, config.IsActive(Delta, 9))
+	require.True(t, config.IsActive(Delta, 10))
+	require.True(t, schedule.IsActive(Delta, 11))
+	require.False(t, config.IsActive(Ecotone, 1000), "not scheduled")
+	require.False(t, config.IsActive("unknown", 1000), "unknown fork")
+
+	require.Equal(t, ForkSchedule{{Name: Delta, Time: u64(10)}}, schedule.Upcoming(9))
+	require.Empty(t, schedule.Upcoming(10))
+
+	require.Equal(t, []ForkName{Regolith, Canyon}, schedule.ActivatedAt(0, config.BlockTime))
+	require.Empty(t, schedule.ActivatedAt(8, config.BlockTime))
+	require.Equal(t, []ForkName{Delta}, schedule.ActivatedAt(11, config.BlockTime), "first block at or after the activation time")
+	require.Empty(t, schedule.ActivatedAt(12, config.BlockTime))
+
+	config.EcotoneTime = u64(5)
+	require.NoError(t, config.Check(), "ecotone does not depend on delta")
+
+	config.CanyonTime = u64(6)
+	require.ErrorContains(t, config.Check(), "before fork canyon it depends on")
+
+	config.CanyonTime = nil
+	require.ErrorContains(t, config.Check(), "fork canyon it depends on is not scheduled")
+}
+
+func TestEngineAPIVersions(t *testing.T) {
+	config := randConfig()
+	config.CanyonTime = nil
+	config.EcotoneTime = nil
+	require.Equal(t, eth.BlockV1, config.PayloadVersion(1000))
+	require.Equal(t, eth.FCUV1, config.ForkchoiceUpdatedVersion(nil))
+	require.Equal(t, eth.FCUV1, config.ForkchoiceUpdatedVersion(&eth.PayloadAttributes{Timestamp: 1000}))
+	require.Equal(t, eth.NewPayloadV1, config.NewPayloadVersion(1000))
+	require.Equal(t, eth.GetPayloadV1, config.GetPayloadVersion(1000))
+
+	config.CanyonTime = u64(10)
+	config.EcotoneTime = u64(20)
+	for _, test := range []struct {
+		timestamp  uint64
+		version    eth.BlockVersion
+		fcu        eth.EngineAPIMethod
+		newPayload eth.EngineAPIMethod
+		getPayload eth.EngineAPIMethod
+	}{
+		{9, eth.BlockV1, eth.FCUV1, eth.NewPayloadV1, eth.GetPayloadV1},
+		{10, eth.BlockV2, eth.FCUV2, eth.NewPayloadV2, eth.GetPayloadV2},
+		{19, eth.BlockV2, eth.FCUV2, eth.NewPayloadV2, eth.GetPayloadV2},
+		{20, eth.BlockV3, eth.FCUV3, eth.NewPayloadV3, eth.GetPayloadV3},
+	} {
+		require.Equal(t, test.version, config.PayloadVersion(test.timestamp), "timestamp %d", test.timestamp)
+		attr := &eth.PayloadAttributes{Timestamp: eth.Uint64Quantity(test.timestamp)}
+		require.Equal(t, test.fcu, config.ForkchoiceUpdatedVersion(attr), "timestamp %d", test.timestamp)
+		require.Equal(t, test.newPayload, config.NewPayloadVersion(test.timestamp), "timestamp %d", test.timestamp)
+		require.Equal(t, test.getPayload, config.GetPayloadVersion(test.timestamp), "timestamp %d", test.timestamp)
+	}
+	require.Equal(t, eth.FCUV3, config.ForkchoiceUpdatedVersion(nil), "latest scheduled version without attributes")
+	config.EcotoneTime = nil
+	require.Equal(t, eth.FCUV2, config.ForkchoiceUpdatedVersion(nil))
+}
+
+func TestForkNameTitle(t *testing.T) {
+	require.Equal(t, "Ecotone", Ecotone.Title())
+	require.Equal(t, "", ForkName("").Title())
+}
+
+// TestForkDefinitions ensures that every network upgrade is declared once, with its own activation time,
+// and only depends on upgrades that were introduced before it.
+func TestForkDefinitions(t *testing.T) {
+	config := randConfig()
+	declared := make(map[ForkName]bool)
+	times := make(map[*uint64]ForkName)
+	for i, f := range forkDefinitions {
+		require.False(t, declared[f.name], "fork %s is declared twice", f.name)
+		for _, dep := range f.dependencies {
+			require.True(t, declared[dep], "fork %s depends on fork %s that is not declared before it", f.name, dep)
+		}
+		declared[f.name] = true
+		require.Equal(t, Forks[i], f.name)
+
+		config.RegolithTime, config.CanyonTime, config.DeltaTime, config.EcotoneTime = u64(0), u64(0), u64(0), u64(0)
+		activationTime := f.activationTime(config)
+		require.NotNil(t, activationTime)
+		other, ok := times[activationTime]
+		require.False(t, ok, "fork %s shares the activation time of fork %s", f.name, other)
+		times[activationTime] = f.name
+	}
+}
@@ -1,8 +1,27 @@
 package sync
 
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 type Config struct {
 	// EngineSync is true when the EngineQueue can trigger execution engine P2P sync.
 	EngineSync bool `json:"engine_sync"`
 	// SkipSyncStartCheck skip the sanity check of consistency of L1 origins of the unsafe L2 blocks when determining the sync-starting point. This defers the L1-origin verification, and is recommended to use in when utilizing l2.engine-sync
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
+	// SnapSync is true when the rollup node snap syncs the execution engine before it starts deriving:
+	// the engine snap syncs to the latest L2 output proposed in a finalized L1 block,
+	// and the synced state is verified against the proposed output root.
+	SnapSync bool `json:"snap_sync"`
+	// L2OutputOracleAddr is the L1 address of the L2OutputOracle that the snap sync target is read from.
+	L2OutputOracleAddr common.Address `json:"l2_output_oracle_addr"`
+}
+
+func (c *Config) Check() error {
+	if c.SnapSync && c.L2OutputOracleAddr == (common.Address{}) {
+		return errors.New("snap sync requires the L2OutputOracle address to read the sync target from")
+	}
+	return nil
 }
@@ -0,0 +1,57 @@
+package sync
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// OutputProposal is an L2 output root proposed to L1, for the given L2 block number.
+type OutputProposal struct {
+	OutputRoot    eth.Bytes32
+	L2BlockNumber uint64
+}
+
+// L2Outputs provides the L2 output proposals, as seen by the given L1 block.
+type L2Outputs interface {
+	// LatestOutputIndex returns the index of the latest output proposal.
+	LatestOutputIndex(ctx context.Context, l1Block eth.BlockID) (uint64, error)
+	// OutputProposal returns the output proposal with the given index.
+	OutputProposal(ctx context.Context, l1Block eth.BlockID, index uint64) (OutputProposal, error)
+}
+
+// L2OutputOracle reads the L2 output proposals from the L2OutputOracle contract on L1.
+type L2OutputOracle struct {
+	l2oo *bindings.L2OutputOracleCaller
+}
+
+var _ L2Outputs = (*L2OutputOracle)(nil)
+
+func NewL2OutputOracle(addr common.Address, caller bind.ContractCaller) (*L2OutputOracle, error) {
+	l2oo, err := bindings.NewL2OutputOracleCaller(addr, caller)
+	if err != nil {
+		return nil, err
+	}
+	return &L2OutputOracle{l2oo: l2oo}, nil
+}
+
+func (o *L2OutputOracle) LatestOutputIndex(ctx context.Context, l1Block eth.BlockID) (uint64, error) {
+	index, err := o.l2oo.LatestOutputIndex(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(l1Block.Number)})
+	if err != nil {
+		return 0, err
+	}
+	return index.Uint64(), nil
+}
+
+func (o *L2OutputOracle) OutputProposal(ctx context.Context, l1Block eth.BlockID, index uint64) (OutputProposal, error) {
+	proposal, err := o.l2oo.GetL2Output(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(l1Block.Number)}, new(big.Int).SetUint64(index))
+	if err != nil {
+		return OutputProposal{}, err
+	}
+	return OutputProposal{OutputRoot: proposal.OutputRoot, L2BlockNumber: proposal.L2BlockNumber.Uint64()}, nil
+}
@@ -0,0 +1,265 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Snap sync stages, as reported in the sync status.
+const (
+	SnapSyncFindingTarget = "finding_target"
+	SnapSyncSyncing       = "syncing"
+	SnapSyncVerifying     = "verifying"
+	SnapSyncDone          = "done"
+	SnapSyncSkipped       = "skipped"
+	SnapSyncFailed        = "failed"
+)
+
+// maxOutputLookback limits how many output proposals are inspected,
+// to find the latest one that the sync source has finalized the L2 block of.
+const maxOutputLookback = 10
+
+// ErrSnapSyncing is returned while the execution engine is still snap syncing to the target.
+var ErrSnapSyncing = errors.New("engine is snap syncing")
+
+// ErrSnapSyncFailed is returned when the snap sync cannot complete:
+// the engine rejected the target, or the synced state does not match the output root proposed to L1.
+var ErrSnapSyncFailed = errors.New("snap sync failed")
+
+type SnapSyncL1 interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+}
+
+// SnapSyncSource is the L2 node that the snap sync target block is retrieved from.
+type SnapSyncSource interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+}
+
+type SnapSyncEngine interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error)
+	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+	SyncProgress(ctx context.Context) (*eth.EngineSyncProgress, error)
+}
+
+// SnapSync orchestrates an execution-layer snap sync, before the rollup node starts deriving the L2 chain:
+//
+//  1. The target is the L2 block of the latest output proposal in the finalized L1 chain,
+//     that the sync source has finalized too.
+//  2. The target block is inserted into the engine, and made the head of the chain,
+//     which makes the engine snap sync to it.
+//  3. Once synced, the state of the engine is verified against the output root proposed to L1,
+//     and the target is marked as safe and finalized, for derivation to continue from.
+//
+// If the engine is at or past the target already, the snap sync is skipped.
+// SnapSync is not safe for concurrent use, and is stepped by the driver event loop.
+type SnapSync struct {
+	log     log.Logger
+	l1      SnapSyncL1
+	outputs L2Outputs
+	source  SnapSyncSource
+	engine  SnapSyncEngine
+
+	stage      string
+	target     eth.L2BlockRef
+	outputRoot eth.Bytes32
+	payload    *eth.ExecutionPayload
+	// sentPayload is true when the target payload was inserted into the engine
+	sentPayload bool
+	progress    *eth.EngineSyncProgress
+	lastErr     error
+}
+
+func NewSnapSync(log log.Logger, l1 SnapSyncL1, outputs L2Outputs, source SnapSyncSource, engine SnapSyncEngine) *SnapSync {
+	return &SnapSync{
+		log:     log,
+		l1:      l1,
+		outputs: outputs,
+		source:  source,
+		engine:  engine,
+		stage:   SnapSyncFindingTarget,
+	}
+}
+
+// Done returns true when the snap sync completed or was skipped, and derivation can start.
+func (s *SnapSync) Done() bool {
+	return s.stage == SnapSyncDone || s.stage == SnapSyncSkipped
+}
+
+// Status returns the progress of the snap sync.
+func (s *SnapSync) Status() *eth.SnapSyncStatus {
+	status := &eth.SnapSyncStatus{
+		Stage:            s.stage,
+		Target:           s.target,
+		TargetOutputRoot: s.outputRoot,
+		EngineProgress:   s.progress,
+	}
+	if s.lastErr != nil {
+		status.Error = s.lastErr.Error()
+	}
+	return status
+}
+
+// Step advances the snap sync by a single stage.
+// It returns ErrSnapSyncing while the engine is syncing, and should then be stepped again later to check progress.
+// An error wrapping ErrSnapSyncFailed is returned if the snap sync failed, and cannot be retried.
+// Other errors are temporary.
+func (s *SnapSync) Step(ctx context.Context) error {
+	var err error
+	switch s.stage {
+	case SnapSyncFindingTarget:
+		err = s.findTarget(ctx)
+	case SnapSyncSyncing:
+		err = s.syncTarget(ctx)
+	case SnapSyncVerifying:
+		err = s.verifyTarget(ctx)
+	case SnapSyncFailed:
+		return s.lastErr
+	default:
+		return nil
+	}
+	if errors.Is(err, ErrSnapSyncing) {
+		s.lastErr = nil
+	} else {
+		s.lastErr = err
+	}
+	if errors.Is(err, ErrSnapSyncFailed) {
+		s.stage = SnapSyncFailed
+	}
+	return err
+}
+
+func (s *SnapSync) findTarget(ctx context.Context) error {
+	l1Finalized, err := s.l1.L1BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil {
+		return fmt.Errorf("failed to fetch finalized L1 block: %w", err)
+	}
+	sourceFinalized, err := s.source.L2BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil {
+		return fmt.Errorf("failed to fetch finalized L2 block of sync source: %w", err)
+	}
+	latest, err := s.outputs.LatestOutputIndex(ctx, l1Finalized.ID())
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest output index at finalized L1 block %s: %w", l1Finalized, err)
+	}
+	var proposal OutputProposal
+	found := false
+	for i := uint64(0); i < maxOutputLookback && i <= latest; i++ {
+		proposal, err = s.outputs.OutputProposal(ctx, l1Finalized.ID(), latest-i)
+		if err != nil {
+			return fmt.Errorf("failed to fetch output proposal %d: %w", latest-i, err)
+		}
+		if proposal.L2BlockNumber <= sourceFinalized.Number {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no output proposal in finalized L1 block %s for an L2 block finalized by the sync source, finalized L2 block: %s", l1Finalized, sourceFinalized)
+	}
+
+	head, err := s.engine.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return fmt.Errorf("failed to fetch engine head: %w", err)
+	}
+	if head.Number >= proposal.L2BlockNumber {
+		s.log.Info("Engine is at or past the snap sync target, skipping snap sync", "head", head, "target", proposal.L2BlockNumber)
+		s.stage = SnapSyncSkipped
+		return nil
+	}
+
+	ref, err := s.source.L2BlockRefByNumber(ctx, proposal.L2BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch snap sync target %d from sync source: %w", proposal.L2BlockNumber, err)
+	}
+	payload, err := s.source.PayloadByNumber(ctx, proposal.L2BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch snap sync target payload %d from sync source: %w", proposal.L2BlockNumber, err)
+	}
+	if payload.BlockHash != ref.Hash {
+		return fmt.Errorf("sync source returned payload %s that does not match target %s", payload.ID(), ref)
+	}
+	if actual, ok := payload.CheckBlockHash(); !ok {
+		return fmt.Errorf("sync source returned payload %s with bad block hash, computed %s", payload.ID(), actual)
+	}
+
+	s.log.Info("Found snap sync target", "target", ref, "output_root", proposal.OutputRoot, "l1_finalized", l1Finalized)
+	s.target = ref
+	s.outputRoot = proposal.OutputRoot
+	s.payload = payload
+	s.stage = SnapSyncSyncing
+	return nil
+}
+
+func (s *SnapSync) syncTarget(ctx context.Context) error {
+	if !s.sentPayload {
+		status, err := s.engine.NewPayload(ctx, s.payload)
+		if err != nil {
+			return fmt.Errorf("failed to insert snap sync target %s: %w", s.target, err)
+		}
+		switch status.Status {
+		case eth.ExecutionInvalid, eth.ExecutionInvalidBlockHash:
+			return fmt.Errorf("%w: engine rejected snap sync target %s: %s", ErrSnapSyncFailed, s.target, eth.NewPayloadErr(s.payload, status))
+		}
+		s.sentPayload = true
+	}
+
+	fcRes, err := s.engine.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{HeadBlockHash: s.target.Hash}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to make snap sync target %s the engine head: %w", s.target, err)
+	}
+	switch fcRes.PayloadStatus.Status {
+	case eth.ExecutionValid:
+		s.log.Info("Engine synced to snap sync target", "target", s.target)
+		s.stage = SnapSyncVerifying
+		return nil
+	case eth.ExecutionSyncing, eth.ExecutionAccepted:
+		progress, err := s.engine.SyncProgress(ctx)
+		if err != nil {
+			s.log.Warn("Failed to fetch engine sync progress", "err", err)
+		} else if progress != nil {
+			s.progress = progress
+		}
+		return ErrSnapSyncing
+	default:
+		return fmt.Errorf("%w: engine rejected snap sync target %s as head: %s", ErrSnapSyncFailed, s.target, eth.ForkchoiceUpdateErr(fcRes.PayloadStatus))
+	}
+}
+
+func (s *SnapSync) verifyTarget(ctx context.Context) error {
+	output, err := s.engine.OutputV0AtBlock(ctx, s.target.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch output of snap sync target %s: %w", s.target, err)
+	}
+	if root := eth.OutputRoot(output); root != s.outputRoot {
+		return fmt.Errorf("%w: output root %s of snap sync target %s does not match output root %s proposed to L1",
+			ErrSnapSyncFailed, root, s.target, s.outputRoot)
+	}
+
+	// The target is verified against L1, and derivation continues from it.
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      s.target.Hash,
+		SafeBlockHash:      s.target.Hash,
+		FinalizedBlockHash: s.target.Hash,
+	}
+	fcRes, err := s.engine.ForkchoiceUpdate(ctx, fc, nil)
+	if err != nil {
+		return fmt.Errorf("failed to finalize snap sync target %s: %w", s.target, err)
+	}
+	if fcRes.PayloadStatus.Status != eth.ExecutionValid {
+		return fmt.Errorf("failed to finalize snap sync target %s: %s", s.target, eth.ForkchoiceUpdateErr(fcRes.PayloadStatus))
+	}
+	s.log.Info("Verified snap sync target, starting derivation", "target", s.target, "output_root", s.outputRoot)
+	s.progress = nil
+	s.stage = SnapSyncDone
+	return nil
+}
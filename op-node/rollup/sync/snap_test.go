@@ -0,0 +1,212 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type stubL1 struct {
+	finalized eth.L1BlockRef
+	err       error
+}
+
+func (s *stubL1) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	return s.finalized, s.err
+}
+
+type stubOutputs struct {
+	proposals []OutputProposal
+}
+
+func (s *stubOutputs) LatestOutputIndex(ctx context.Context, l1Block eth.BlockID) (uint64, error) {
+	return uint64(len(s.proposals) - 1), nil
+}
+
+func (s *stubOutputs) OutputProposal(ctx context.Context, l1Block eth.BlockID, index uint64) (OutputProposal, error) {
+	return s.proposals[index], nil
+}
+
+type stubSource struct {
+	finalized eth.L2BlockRef
+	payloads  map[uint64]*eth.ExecutionPayload
+}
+
+func (s *stubSource) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return s.finalized, nil
+}
+
+func (s *stubSource) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	payload := s.payloads[num]
+	return eth.L2BlockRef{Hash: payload.BlockHash, Number: num, ParentHash: payload.ParentHash, Time: uint64(payload.Timestamp)}, nil
+}
+
+func (s *stubSource) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	return s.payloads[number], nil
+}
+
+type stubEngine struct {
+	head       eth.L2BlockRef
+	inserted   []common.Hash
+	fcStatuses []eth.ExecutePayloadStatus
+	forkchoice *eth.ForkchoiceState
+	output     *eth.OutputV0
+	progress   *eth.EngineSyncProgress
+}
+
+func (s *stubEngine) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return s.head, nil
+}
+
+func (s *stubEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	s.inserted = append(s.inserted, payload.BlockHash)
+	return &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil
+}
+
+func (s *stubEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	s.forkchoice = state
+	status := s.fcStatuses[0]
+	if len(s.fcStatuses) > 1 {
+		s.fcStatuses = s.fcStatuses[1:]
+	}
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: status}}, nil
+}
+
+func (s *stubEngine) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	return s.output, nil
+}
+
+func (s *stubEngine) SyncProgress(ctx context.Context) (*eth.EngineSyncProgress, error) {
+	return s.progress, nil
+}
+
+type snapSyncTest struct {
+	l1      *stubL1
+	outputs *stubOutputs
+	source  *stubSource
+	engine  *stubEngine
+	target  *eth.ExecutionPayload
+}
+
+func newSnapSyncTest(t *testing.T) (*SnapSync, *snapSyncTest) {
+	target := &eth.ExecutionPayload{
+		ParentHash:   common.Hash{0x01},
+		BlockNumber:  100,
+		Timestamp:    1000,
+		Transactions: []eth.Data{},
+	}
+	target.BlockHash, _ = target.CheckBlockHash()
+	output := &eth.OutputV0{StateRoot: eth.Bytes32{0x02}, MessagePasserStorageRoot: eth.Bytes32{0x03}, BlockHash: target.BlockHash}
+	st := &snapSyncTest{
+		l1: &stubL1{finalized: eth.L1BlockRef{Hash: common.Hash{0x0a}, Number: 50}},
+		outputs: &stubOutputs{proposals: []OutputProposal{
+			{OutputRoot: eth.Bytes32{0x0b}, L2BlockNumber: 50},
+			{OutputRoot: eth.OutputRoot(output), L2BlockNumber: 100},
+			{OutputRoot: eth.Bytes32{0x0c}, L2BlockNumber: 150}, // not finalized by the sync source yet
+		}},
+		source: &stubSource{
+			finalized: eth.L2BlockRef{Number: 120},
+			payloads:  map[uint64]*eth.ExecutionPayload{100: target},
+		},
+		engine: &stubEngine{output: output},
+		target: target,
+	}
+	return NewSnapSync(testlog.Logger(t, log.LvlError), st.l1, st.outputs, st.source, st.engine), st
+}
+
+func TestSnapSync(t *testing.T) {
+	t.Run("sync to target", func(t *testing.T) {
+		ss, st := newSnapSyncTest(t)
+		st.engine.fcStatuses = []eth.ExecutePayloadStatus{eth.ExecutionSyncing, eth.ExecutionValid}
+		st.engine.progress = &eth.EngineSyncProgress{CurrentBlock: 10, HighestBlock: 100}
+		require.False(t, ss.Done())
+		require.Equal(t, SnapSyncFindingTarget, ss.Status().Stage)
+
+		require.NoError(t, ss.Step(context.Background()))
+		status := ss.Status()
+		require.Equal(t, SnapSyncSyncing, status.Stage)
+		require.Equal(t, st.target.BlockHash, status.Target.Hash)
+		require.Equal(t, st.outputs.proposals[1].OutputRoot, status.TargetOutputRoot)
+
+		require.ErrorIs(t, ss.Step(context.Background()), ErrSnapSyncing)
+		require.Equal(t, []common.Hash{st.target.BlockHash}, st.engine.inserted)
+		require.Equal(t, &eth.ForkchoiceState{HeadBlockHash: st.target.BlockHash}, st.engine.forkchoice)
+		require.Equal(t, st.engine.progress, ss.Status().EngineProgress)
+		require.Empty(t, ss.Status().Error)
+
+		require.NoError(t, ss.Step(context.Background()))
+		require.Equal(t, SnapSyncVerifying, ss.Status().Stage)
+		require.Len(t, st.engine.inserted, 1, "target is inserted only once")
+
+		require.NoError(t, ss.Step(context.Background()))
+		require.True(t, ss.Done())
+		require.Equal(t, SnapSyncDone, ss.Status().Stage)
+		require.Equal(t, &eth.ForkchoiceState{
+			HeadBlockHash:      st.target.BlockHash,
+			SafeBlockHash:      st.target.BlockHash,
+			FinalizedBlockHash: st.target.BlockHash,
+		}, st.engine.forkchoice)
+	})
+
+	t.Run("skip if engine is past target", func(t *testing.T) {
+		ss, st := newSnapSyncTest(t)
+		st.engine.head = eth.L2BlockRef{Number: 100}
+		require.NoError(t, ss.Step(context.Background()))
+		require.True(t, ss.Done())
+		require.Equal(t, SnapSyncSkipped, ss.Status().Stage)
+		require.Empty(t, st.engine.inserted)
+	})
+
+	t.Run("output root mismatch", func(t *testing.T) {
+		ss, st := newSnapSyncTest(t)
+		st.engine.fcStatuses = []eth.ExecutePayloadStatus{eth.ExecutionValid}
+		st.engine.output = &eth.OutputV0{BlockHash: st.target.BlockHash}
+		require.NoError(t, ss.Step(context.Background()))
+		require.NoError(t, ss.Step(context.Background()))
+		err := ss.Step(context.Background())
+		require.ErrorIs(t, err, ErrSnapSyncFailed)
+		require.False(t, ss.Done())
+		require.Equal(t, SnapSyncFailed, ss.Status().Stage)
+		require.Equal(t, err.Error(), ss.Status().Error)
+		require.ErrorIs(t, ss.Step(context.Background()), ErrSnapSyncFailed, "failure is final")
+		require.Equal(t, &eth.ForkchoiceState{HeadBlockHash: st.target.BlockHash}, st.engine.forkchoice,
+			"unverified target is not finalized")
+	})
+
+	t.Run("engine rejects target", func(t *testing.T) {
+		ss, st := newSnapSyncTest(t)
+		st.engine.fcStatuses = []eth.ExecutePayloadStatus{eth.ExecutionInvalid}
+		require.NoError(t, ss.Step(context.Background()))
+		require.ErrorIs(t, ss.Step(context.Background()), ErrSnapSyncFailed)
+		require.Equal(t, SnapSyncFailed, ss.Status().Stage)
+	})
+
+	t.Run("temporary error", func(t *testing.T) {
+		ss, st := newSnapSyncTest(t)
+		st.l1.err = errors.New("l1 unavailable")
+		err := ss.Step(context.Background())
+		require.ErrorContains(t, err, "l1 unavailable")
+		require.NotErrorIs(t, err, ErrSnapSyncFailed)
+		require.Equal(t, SnapSyncFindingTarget, ss.Status().Stage)
+		require.Equal(t, err.Error(), ss.Status().Error)
+
+		st.l1.err = nil
+		require.NoError(t, ss.Step(context.Background()))
+		require.Equal(t, SnapSyncSyncing, ss.Status().Stage)
+		require.Empty(t, ss.Status().Error)
+	})
+
+	t.Run("no finalized output", func(t *testing.T) {
+		ss, st := newSnapSyncTest(t)
+		st.source.finalized = eth.L2BlockRef{Number: 10}
+		require.ErrorContains(t, ss.Step(context.Background()), "no output proposal")
+		require.Equal(t, SnapSyncFindingTarget, ss.Status().Stage)
+	})
+}
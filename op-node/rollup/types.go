@@ -75,6 +75,27 @@ type Config struct {
 	// Active if RegolithTime != nil && L2 block timestamp >= *RegolithTime, inactive otherwise.
 	RegolithTime *uint64 `json:"regolith_time,omitempty"`
 
+	// CanyonTime sets the activation time of the Canyon network-upgrade:
+	// the L2 engine activates Shanghai, L2 blocks carry an (always empty) withdrawals list,
+	// and the engine API V2 methods are used.
+	// Active if CanyonTime != nil && L2 block timestamp >= *CanyonTime, inactive otherwise.
+	CanyonTime *uint64 `json:"canyon_time,omitempty"`
+
+	// DeltaTime sets the activation time of the Delta network-upgrade:
+	// span batches, covering a range of L2 blocks, are accepted in addition to singular batches.
+	// Active if DeltaTime != nil && L2 block timestamp >= *DeltaTime, inactive otherwise.
+	// Span batches are only accepted if both the L1 inclusion block and the first L2 block are past the activation time.
+	DeltaTime *uint64 `json:"delta_time,omitempty"`
+
+	// EcotoneTime sets the activation time of the Ecotone network-upgrade:
+	// batches are additionally read from EIP-4844 blobs sent to the batch inbox.
+	// The activation is based on the L1 origin timestamp: the data source of an L1 block
+	// includes blobs if EcotoneTime != nil && L1 block timestamp >= *EcotoneTime.
+	// The L2 engine activates Cancun at the same time, based on the L2 block timestamp:
+	// L2 blocks carry the blob gas fields and the parent beacon block root of their L1 origin,
+	// and the engine API V3 methods are used.
+	EcotoneTime *uint64 `json:"ecotone_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -244,6 +265,9 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if err := cfg.ForkSchedule().Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -251,11 +275,6 @@ func (c *Config) L1Signer() types.Signer {
 	return types.NewLondonSigner(c.L1ChainID)
 }
 
-// IsRegolith returns true if the Regolith hardfork is active at or past the given timestamp.
-func (c *Config) IsRegolith(timestamp uint64) bool {
-	return c.RegolithTime != nil && timestamp >= *c.RegolithTime
-}
-
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -282,7 +301,9 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  L1 block: %s %d\n", c.Genesis.L1.Hash, c.Genesis.L1.Number)
 	// Report the upgrade configuration
 	banner += "Post-Bedrock Network Upgrades (timestamp based):\n"
-	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
+	for _, f := range c.ForkSchedule() {
+		banner += fmt.Sprintf("  - %s: %s\n", f.Name.Title(), fmtForkTimeOrUnset(f.Time))
+	}
 	return banner
 }
 
@@ -302,10 +323,14 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	if networkL1 == "" {
 		networkL1 = "unknown L1"
 	}
-	log.Info("Rollup Config", "l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
+	ctx := []any{"l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", c.Genesis.L2Time, "l2_block_hash", c.Genesis.L2.Hash.String(),
 		"l2_block_number", c.Genesis.L2.Number, "l1_block_hash", c.Genesis.L1.Hash.String(),
-		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime))
+		"l1_block_number", c.Genesis.L1.Number}
+	for _, f := range c.ForkSchedule() {
+		ctx = append(ctx, string(f.Name)+"_time", fmtForkTimeOrUnset(f.Time))
+	}
+	log.Info("Rollup Config", ctx...)
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
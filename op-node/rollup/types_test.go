@@ -184,17 +184,47 @@ func TestRandomConfigDescription(t *testing.T) {
 func TestRegolithActivation(t *testing.T) {
 	config := randConfig()
 	config.RegolithTime = nil
-	require.False(t, config.IsRegolith(0), "false if nil time, even if checking 0")
-	require.False(t, config.IsRegolith(123456), "false if nil time")
+	require.False(t, config.IsActive(Regolith, 0), "false if nil time, even if checking 0")
+	require.False(t, config.IsActive(Regolith, 123456), "false if nil time")
 	config.RegolithTime = new(uint64)
-	require.True(t, config.IsRegolith(0), "true at zero")
-	require.True(t, config.IsRegolith(123456), "true for any")
+	require.True(t, config.IsActive(Regolith, 0), "true at zero")
+	require.True(t, config.IsActive(Regolith, 123456), "true for any")
 	x := uint64(123)
 	config.RegolithTime = &x
-	require.False(t, config.IsRegolith(0))
-	require.False(t, config.IsRegolith(122))
-	require.True(t, config.IsRegolith(123))
-	require.True(t, config.IsRegolith(124))
+	require.False(t, config.IsActive(Regolith, 0))
+	require.False(t, config.IsActive(Regolith, 122))
+	require.True(t, config.IsActive(Regolith, 123))
+	require.True(t, config.IsActive(Regolith, 124))
+}
+
+// TestDeltaActivation tests the activation condition of the Delta upgrade.
+func TestDeltaActivation(t *testing.T) {
+	config := randConfig()
+	config.DeltaTime = nil
+	require.False(t, config.IsActive(Delta, 0), "false if nil time, even if checking 0")
+	require.False(t, config.IsActive(Delta, 123456), "false if nil time")
+	config.DeltaTime = new(uint64)
+	require.True(t, config.IsActive(Delta, 0), "true at zero")
+	x := uint64(123)
+	config.DeltaTime = &x
+	require.False(t, config.IsActive(Delta, 122))
+	require.True(t, config.IsActive(Delta, 123))
+	require.True(t, config.IsActive(Delta, 124))
+}
+
+// TestEcotoneActivation tests the activation condition of the Ecotone upgrade.
+func TestEcotoneActivation(t *testing.T) {
+	config := randConfig()
+	config.EcotoneTime = nil
+	require.False(t, config.IsActive(Ecotone, 0), "false if nil time, even if checking 0")
+	require.False(t, config.IsActive(Ecotone, 123456), "false if nil time")
+	config.EcotoneTime = new(uint64)
+	require.True(t, config.IsActive(Ecotone, 0), "true at zero")
+	x := uint64(123)
+	config.EcotoneTime = &x
+	require.False(t, config.IsActive(Ecotone, 122))
+	require.True(t, config.IsActive(Ecotone, 123))
+	require.True(t, config.IsActive(Ecotone, 124))
 }
 
 type mockL2Client struct {
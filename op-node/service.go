@@ -3,6 +3,7 @@ package opnode
 import (
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/urfave/cli/v2"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/node"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 )
@@ -50,7 +53,10 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to load p2p config: %w", err)
 	}
 
-	l1Endpoint := NewL1EndpointConfig(ctx)
+	l1Endpoint, err := NewL1EndpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load l1 endpoints info: %w", err)
+	}
 
 	l2Endpoint, err := NewL2EndpointConfig(ctx, log)
 	if err != nil {
@@ -59,18 +65,43 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l2SyncEndpoint := NewL2SyncEndpointConfig(ctx)
 
-	syncConfig := NewSyncConfig(ctx)
+	syncConfig, err := NewSyncConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync config: %w", err)
+	}
+
+	conductorConfig, err := NewConductorConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conductor config: %w", err)
+	}
+
+	screeningConfig, err := commitments.NewConfig(commitments.ReadCLIConfig(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load screening config: %w", err)
+	}
+
+	// The admin JWT secret is configured by the operator, and is not generated if missing.
+	adminJWTSecret, err := loadSharedJWTSecret(ctx.String(flags.RPCAdminJWTSecret.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load admin jwt secret: %w", err)
+	}
 
 	cfg := &node.Config{
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
 		L2Sync: l2SyncEndpoint,
+		Beacon: NewBeaconEndpointConfig(ctx),
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		RPC: node.RPCConfig{
 			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
 			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
 			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			EnableDebug: ctx.Bool(flags.RPCEnableDebug.Name),
+
+			AdminAuthListenAddr: ctx.String(flags.RPCAdminAuthListenAddr.Name),
+			AdminAuthListenPort: ctx.Int(flags.RPCAdminAuthListenPort.Name),
+			AdminJWTSecret:      adminJWTSecret,
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
@@ -90,8 +121,17 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
-		ConfigPersistence: configPersistence,
-		Sync:              *syncConfig,
+		ConfigPersistence:   configPersistence,
+		Sync:                *syncConfig,
+		SafeDBPath:          ctx.String(flags.SafeDBPath.Name),
+		PipelineCheckpoints: NewPipelineCheckpoints(ctx),
+		Conductor:           *conductorConfig,
+		Screening:           screeningConfig,
+		UnsafePayloads: node.UnsafePayloadsConfig{
+			Path:    ctx.String(flags.UnsafePayloadsPath.Name),
+			MaxSize: ctx.Uint64(flags.UnsafePayloadsMaxSize.Name),
+			MaxAge:  ctx.Duration(flags.UnsafePayloadsMaxAge.Name),
+		},
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -104,15 +144,60 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
-func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
+// NewBeaconEndpointConfig returns the beacon endpoint config if the flag is set, otherwise nil.
+func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
+	addr := ctx.String(flags.BeaconAddr.Name)
+	if addr == "" {
+		return nil
+	}
+	return &node.L1BeaconEndpointConfig{
+		BeaconAddr: addr,
+	}
+}
+
+func NewL1EndpointConfig(ctx *cli.Context) (*node.L1EndpointConfig, error) {
+	var fallbacks []node.L1FallbackNode
+	for _, entry := range ctx.StringSlice(flags.L1FallbackAddrs.Name) {
+		fallback, err := parseL1FallbackNode(entry)
+		if err != nil {
+			return nil, err
+		}
+		fallbacks = append(fallbacks, fallback)
+	}
 	return &node.L1EndpointConfig{
-		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
-		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
-		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
-		BatchSize:        ctx.Int(flags.L1RPCMaxBatchSize.Name),
-		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
+		L1NodeAddr:            ctx.String(flags.L1NodeAddr.Name),
+		L1TrustRPC:            ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:             sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
+		RateLimit:             ctx.Float64(flags.L1RPCRateLimit.Name),
+		BatchSize:             ctx.Int(flags.L1RPCMaxBatchSize.Name),
+		HttpPollInterval:      ctx.Duration(flags.L1HTTPPollInterval.Name),
+		L1FallbackNodes:       fallbacks,
+		L1HealthCheckInterval: ctx.Duration(flags.L1HealthCheckInterval.Name),
+		L1HealthCheckTimeout:  ctx.Duration(flags.L1HealthCheckTimeout.Name),
+		L1MaxHeadLag:          ctx.Uint64(flags.L1MaxHeadLag.Name),
+	}, nil
+}
+
+// parseL1FallbackNode parses a [<rpc-kind>=]<rpc-url> entry.
+// The RPC kind is left empty if the entry does not specify it.
+func parseL1FallbackNode(entry string) (node.L1FallbackNode, error) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return node.L1FallbackNode{}, errors.New("empty L1 fallback endpoint")
+	}
+	kind, addr, ok := strings.Cut(entry, "=")
+	// a "=" may also be part of the query of the URL, only a prefix without "/" is an RPC kind
+	if !ok || strings.Contains(kind, "/") {
+		return node.L1FallbackNode{Addr: entry}, nil
 	}
+	k := sources.RPCProviderKind(strings.ToLower(kind))
+	if !sources.ValidRPCProviderKind(k) {
+		return node.L1FallbackNode{}, fmt.Errorf("unknown rpc kind %q of L1 fallback endpoint", kind)
+	}
+	if addr == "" {
+		return node.L1FallbackNode{}, fmt.Errorf("L1 fallback endpoint with rpc kind %q has no address", kind)
+	}
+	return node.L1FallbackNode{Addr: addr, RPCKind: k}, nil
 }
 
 func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
@@ -154,6 +239,14 @@ func NewL2SyncEndpointConfig(ctx *cli.Context) *node.L2SyncEndpointConfig {
 	}
 }
 
+func NewPipelineCheckpoints(ctx *cli.Context) derive.CheckpointStore {
+	file := ctx.String(flags.PipelineCheckpointFile.Name)
+	if file == "" {
+		return node.DisabledPipelineCheckpoints{}
+	}
+	return node.NewPipelineCheckpoints(file, ctx.Duration(flags.PipelineCheckpointInterval.Name))
+}
+
 func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	stateFile := ctx.String(flags.RPCAdminPersistence.Name)
 	if stateFile == "" {
@@ -169,6 +262,9 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerTxOrdering: ctx.String(flags.SequencerTxOrderingFlag.Name),
+
+		SequencerFairOrderingWindow: ctx.Duration(flags.SequencerFairOrderingWindowFlag.Name),
 	}
 }
 
@@ -223,9 +319,69 @@ func NewSnapshotLogger(ctx *cli.Context) (log.Logger, error) {
 	return logger, nil
 }
 
-func NewSyncConfig(ctx *cli.Context) *sync.Config {
-	return &sync.Config{
+func NewSyncConfig(ctx *cli.Context) (*sync.Config, error) {
+	cfg := &sync.Config{
 		EngineSync:         ctx.Bool(flags.L2EngineSyncEnabled.Name),
 		SkipSyncStartCheck: ctx.Bool(flags.SkipSyncStartCheck.Name),
+		SnapSync:           ctx.Bool(flags.L2SnapSyncEnabled.Name),
+	}
+	if addr := ctx.String(flags.L2SnapSyncL2OutputOracle.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid L2OutputOracle address: %q", addr)
+		}
+		cfg.L2OutputOracleAddr = common.HexToAddress(addr)
+	}
+	return cfg, nil
+}
+
+func NewConductorConfig(ctx *cli.Context) (*node.ConductorConfig, error) {
+	peers := make(map[string]string)
+	for _, entry := range ctx.StringSlice(flags.ConductorPeersFlag.Name) {
+		id, url, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || url == "" {
+			return nil, fmt.Errorf("invalid conductor peer %q, expected <server-id>=<rpc-url>", entry)
+		}
+		if _, ok := peers[id]; ok {
+			return nil, fmt.Errorf("duplicate conductor peer %q", id)
+		}
+		peers[id] = url
+	}
+	// The secret is shared by all replicas, and is thus not generated if missing, unlike the engine JWT secret.
+	secret, err := loadSharedJWTSecret(ctx.String(flags.ConductorJWTSecret.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conductor jwt secret: %w", err)
+	}
+	return &node.ConductorConfig{
+		Enabled:           ctx.Bool(flags.ConductorEnabledFlag.Name),
+		ServerID:          ctx.String(flags.ConductorServerIDFlag.Name),
+		Peers:             peers,
+		ListenAddr:        ctx.String(flags.ConductorRPCListenAddr.Name),
+		ListenPort:        ctx.Int(flags.ConductorRPCListenPort.Name),
+		JWTSecret:         secret,
+		StateFile:         ctx.String(flags.ConductorStateFileFlag.Name),
+		HeartbeatInterval: ctx.Duration(flags.ConductorHeartbeatIntervalFlag.Name),
+		ElectionTimeout:   ctx.Duration(flags.ConductorElectionTimeoutFlag.Name),
+		RequestTimeout:    ctx.Duration(flags.ConductorRequestTimeoutFlag.Name),
+		MaxUnsafeLag:      ctx.Duration(flags.ConductorMaxUnsafeLagFlag.Name),
+	}, nil
+}
+
+// loadSharedJWTSecret reads a JWT secret, shared with the clients of an authenticated RPC, from the given file.
+// A zero secret is returned if no file is given.
+func loadSharedJWTSecret(fileName string) ([32]byte, error) {
+	var secret [32]byte
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" {
+		return secret, nil
+	}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return secret, err
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
 	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
 }
@@ -0,0 +1,45 @@
+package sources
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+)
+
+// ConductorClient is the RPC client of a sequencer replica, as peer of the sequencer cluster.
+type ConductorClient struct {
+	rpc client.RPC
+}
+
+var _ conductor.Peer = (*ConductorClient)(nil)
+
+func NewConductorClient(rpc client.RPC) *ConductorClient {
+	return &ConductorClient{rpc}
+}
+
+func (c *ConductorClient) RequestVote(ctx context.Context, req *conductor.VoteRequest) (*conductor.VoteResponse, error) {
+	var resp *conductor.VoteResponse
+	err := c.rpc.CallContext(ctx, &resp, "conductor_requestVote", req)
+	return resp, err
+}
+
+func (c *ConductorClient) AppendPayload(ctx context.Context, req *conductor.AppendRequest) (*conductor.AppendResponse, error) {
+	var resp *conductor.AppendResponse
+	err := c.rpc.CallContext(ctx, &resp, "conductor_appendPayload", req)
+	return resp, err
+}
+
+func (c *ConductorClient) Leader(ctx context.Context) (bool, error) {
+	var leader bool
+	err := c.rpc.CallContext(ctx, &leader, "conductor_leader")
+	return leader, err
+}
+
+func (c *ConductorClient) TransferLeadership(ctx context.Context) error {
+	return c.rpc.CallContext(ctx, nil, "conductor_transferLeadership")
+}
+
+func (c *ConductorClient) Close() {
+	c.rpc.Close()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources/caching"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -42,18 +43,20 @@ func NewEngineClient(client client.RPC, log log.Logger, metrics caching.Metrics,
 
 // ForkchoiceUpdate updates the forkchoice on the execution client. If attributes is not nil, the engine client will also begin building a block
 // based on attributes after the new head block and return the payload ID.
+// The engine API version is selected by the timestamp of the attributes, see rollup.Config.ForkchoiceUpdatedVersion.
 //
 // The RPC may return three types of errors:
 // 1. Processing error: ForkchoiceUpdatedResult.PayloadStatusV1.ValidationError or other non-success PayloadStatusV1,
 // 2. `error` as eth.InputError: the forkchoice state or attributes are not valid.
 // 3. Other types of `error`: temporary RPC errors, like timeouts.
 func (s *EngineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
-	e := s.log.New("state", fc, "attr", attributes)
+	method := s.rollupCfg.ForkchoiceUpdatedVersion(attributes)
+	e := s.log.New("state", fc, "attr", attributes, "method", method)
 	e.Trace("Sharing forkchoice-updated signal")
 	fcCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 	var result eth.ForkchoiceUpdatedResult
-	err := s.client.CallContext(fcCtx, &result, "engine_forkchoiceUpdatedV1", fc, attributes)
+	err := s.client.CallContext(fcCtx, &result, string(method), fc, attributes)
 	if err == nil {
 		e.Trace("Shared forkchoice-updated signal")
 		if attributes != nil { // block building is optional, we only get a payload ID if we are building a block
@@ -81,14 +84,29 @@ func (s *EngineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceS
 // NewPayload executes a full block on the execution engine.
 // This returns a PayloadStatusV1 which encodes any validation/processing error,
 // and this type of error is kept separate from the returned `error` used for RPC errors, like timeouts.
+// The engine API version is selected by the timestamp of the payload, see rollup.Config.NewPayloadVersion.
 func (s *EngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
-	e := s.log.New("block_hash", payload.BlockHash)
+	method := s.rollupCfg.NewPayloadVersion(uint64(payload.Timestamp))
+	e := s.log.New("block_hash", payload.BlockHash, "method", method)
 	e.Trace("sending payload for execution")
 
 	execCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 	var result eth.PayloadStatusV1
-	err := s.client.CallContext(execCtx, &result, "engine_newPayloadV1", payload)
+	var err error
+	switch method {
+	case eth.NewPayloadV3:
+		if payload.ParentBeaconBlockRoot == nil {
+			return nil, fmt.Errorf("cannot execute payload %s without parent beacon block root", payload.ID())
+		}
+		// The parent beacon block root is a separate parameter, and not part of the engine API payload.
+		enginePayload := *payload
+		enginePayload.ParentBeaconBlockRoot = nil
+		// L2 blocks do not contain blob transactions, there are no versioned hashes to verify
+		err = s.client.CallContext(execCtx, &result, string(method), &enginePayload, []common.Hash{}, payload.ParentBeaconBlockRoot)
+	default:
+		err = s.client.CallContext(execCtx, &result, string(method), payload)
+	}
 	e.Trace("Received payload execution result", "status", result.Status, "latestValidHash", result.LatestValidHash, "message", result.ValidationError)
 	if err != nil {
 		e.Error("Payload execution failed", "err", err)
@@ -98,16 +116,28 @@ func (s *EngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPay
 }
 
 // GetPayload gets the execution payload associated with the PayloadId.
+// The engine API version is selected by the timestamp of the payload, see rollup.Config.GetPayloadVersion.
+// The parent beacon block root of the payload info is set on the returned payload,
+// since the engine does not return it with the payload.
 // There may be two types of error:
 // 1. `error` as eth.InputError: the payload ID may be unknown
 // 2. Other types of `error`: temporary RPC errors, like timeouts.
-func (s *EngineClient) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
-	e := s.log.New("payload_id", payloadId)
+func (s *EngineClient) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayload, error) {
+	method := s.rollupCfg.GetPayloadVersion(payloadInfo.Timestamp)
+	e := s.log.New("payload_id", payloadInfo.ID, "method", method)
 	e.Trace("getting payload")
-	var result eth.ExecutionPayload
-	err := s.client.CallContext(ctx, &result, "engine_getPayloadV1", payloadId)
+	var result *eth.ExecutionPayload
+	var err error
+	switch method {
+	case eth.GetPayloadV1:
+		err = s.client.CallContext(ctx, &result, string(method), payloadInfo.ID)
+	default:
+		var envelope eth.ExecutionPayloadEnvelope
+		err = s.client.CallContext(ctx, &envelope, string(method), payloadInfo.ID)
+		result = envelope.ExecutionPayload
+	}
 	if err != nil {
-		e.Warn("Failed to get payload", "payload_id", payloadId, "err", err)
+		e.Warn("Failed to get payload", "payload_id", payloadInfo.ID, "err", err)
 		if rpcErr, ok := err.(rpc.Error); ok {
 			code := eth.ErrorCode(rpcErr.ErrorCode())
 			switch code {
@@ -122,6 +152,12 @@ func (s *EngineClient) GetPayload(ctx context.Context, payloadId eth.PayloadID)
 		}
 		return nil, err
 	}
+	if result == nil {
+		return nil, fmt.Errorf("engine returned no payload for payload ID %s", payloadInfo.ID)
+	}
+	if method == eth.GetPayloadV3 {
+		result.ParentBeaconBlockRoot = payloadInfo.ParentBeaconBlockRoot
+	}
 	e.Trace("Received payload")
-	return &result, nil
+	return result, nil
 }
@@ -0,0 +1,55 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestEngineClient_NewPayloadV3(t *testing.T) {
+	zero := uint64(0)
+	cfg := &rollup.Config{SeqWindowSize: 10, BlockTime: 2, RegolithTime: &zero, CanyonTime: &zero, EcotoneTime: &zero}
+	m := new(mockRPC)
+	s, err := NewEngineClient(m, testlog.Logger(t, log.LvlError), nil, EngineClientDefaultConfig(cfg))
+	require.NoError(t, err)
+
+	root := common.Hash{0x42}
+	blobGasUsed, excessBlobGas := eth.Uint64Quantity(0), eth.Uint64Quantity(0)
+	payload := &eth.ExecutionPayload{
+		Timestamp:             10,
+		Withdrawals:           &types.Withdrawals{},
+		BlobGasUsed:           &blobGasUsed,
+		ExcessBlobGas:         &excessBlobGas,
+		ParentBeaconBlockRoot: &root,
+	}
+	ctx := context.Background()
+	m.On("CallContext", mock.Anything, new(eth.PayloadStatusV1), string(eth.NewPayloadV3), mock.Anything).Run(func(args mock.Arguments) {
+		params := args[3].([]any)
+		require.Len(t, params, 3)
+		data, err := json.Marshal(params[0])
+		require.NoError(t, err)
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(data, &fields))
+		require.NotContains(t, fields, "parentBeaconBlockRoot", "root is not part of the engine API payload")
+		require.Equal(t, []common.Hash{}, params[1])
+		require.Equal(t, &root, params[2])
+		*args[1].(*eth.PayloadStatusV1) = eth.PayloadStatusV1{Status: eth.ExecutionValid}
+	}).Return([]error{nil})
+
+	status, err := s.NewPayload(ctx, payload)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, status.Status)
+	require.Equal(t, &root, payload.ParentBeaconBlockRoot, "payload of the caller is not modified")
+	m.Mock.AssertExpectations(t)
+}
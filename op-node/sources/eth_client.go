@@ -11,6 +11,7 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -64,6 +65,19 @@ type EthClientConfig struct {
 	// till we re-attempt the user-preferred methods.
 	// If this is 0 then the client does not fall back to less optimal but available methods.
 	MethodResetDuration time.Duration
+
+	// RPCProviders optionally identifies which of multiple providers serves the RPC,
+	// if the RPC fails over between providers. The RPCProviderKind is then ignored,
+	// and the available receipts fetching methods are tracked per provider instead.
+	RPCProviders RPCProviderSource
+}
+
+// RPCProviderSource is implemented by RPCs that are served by one of multiple providers at a time.
+type RPCProviderSource interface {
+	// RPCProviderKinds returns the kind of each of the providers.
+	RPCProviderKinds() []RPCProviderKind
+	// ActiveProvider returns the index of the provider that currently serves the RPC.
+	ActiveProvider() int
 }
 
 func (c *EthClientConfig) Check() error {
@@ -85,12 +99,45 @@ func (c *EthClientConfig) Check() error {
 	if c.MaxRequestsPerBatch < 1 {
 		return fmt.Errorf("expected at least 1 request per batch, but max is: %d", c.MaxRequestsPerBatch)
 	}
-	if !ValidRPCProviderKind(c.RPCProviderKind) {
+	if c.RPCProviders != nil {
+		kinds := c.RPCProviders.RPCProviderKinds()
+		if len(kinds) == 0 {
+			return errors.New("expected at least one rpc provider")
+		}
+		for i, kind := range kinds {
+			if !ValidRPCProviderKind(kind) {
+				return fmt.Errorf("unknown rpc provider kind of provider %d: %s", i, kind)
+			}
+		}
+	} else if !ValidRPCProviderKind(c.RPCProviderKind) {
 		return fmt.Errorf("unknown rpc provider kind: %s", c.RPCProviderKind)
 	}
 	return nil
 }
 
+// receiptsMethods tracks the receipts fetching methods that are available with a single RPC provider.
+type receiptsMethods struct {
+	provKind RPCProviderKind
+
+	// available tracks which receipt methods can be used for fetching receipts
+	// This may be modified concurrently, but we don't lock since it's a single
+	// uint64 that's not critical (fine to miss or mix up a modification)
+	available ReceiptsFetchingMethod
+
+	// lastReset tracks when available was last reset.
+	// When receipt-fetching fails it falls back to available methods,
+	// but periodically it will try to reset to the preferred optimal methods.
+	lastReset time.Time
+}
+
+func newReceiptsMethods(kind RPCProviderKind) *receiptsMethods {
+	return &receiptsMethods{
+		provKind:  kind,
+		available: AvailableReceiptsFetchingMethods(kind),
+		lastReset: time.Now(),
+	}
+}
+
 // EthClient retrieves ethereum data with optimized batch requests, cached results, and flag to not trust the RPC.
 type EthClient struct {
 	client client.RPC
@@ -101,8 +148,6 @@ type EthClient struct {
 
 	mustBePostMerge bool
 
-	provKind RPCProviderKind
-
 	log log.Logger
 
 	// cache receipts in bundles per block hash
@@ -122,41 +167,51 @@ type EthClient struct {
 	// common.Hash -> *eth.ExecutionPayload
 	payloadsCache *caching.LRUCache[common.Hash, *eth.ExecutionPayload]
 
-	// availableReceiptMethods tracks which receipt methods can be used for fetching receipts
-	// This may be modified concurrently, but we don't lock since it's a single
-	// uint64 that's not critical (fine to miss or mix up a modification)
-	availableReceiptMethods ReceiptsFetchingMethod
+	// receiptsMethods tracks the available receipts fetching methods per RPC provider
+	receiptsMethods []*receiptsMethods
 
-	// lastMethodsReset tracks when availableReceiptMethods was last reset.
-	// When receipt-fetching fails it falls back to available methods,
-	// but periodically it will try to reset to the preferred optimal methods.
-	lastMethodsReset time.Time
+	// providers identifies the RPC provider that is currently used, if there are multiple
+	providers RPCProviderSource
 
-	// methodResetDuration defines how long we take till we reset lastMethodsReset
+	// methodResetDuration defines how long we take till we reset the available receipts methods
 	methodResetDuration time.Duration
 }
 
+// activeReceiptsMethods returns the receipts fetching methods of the RPC provider that is currently used.
+func (s *EthClient) activeReceiptsMethods() *receiptsMethods {
+	if s.providers == nil {
+		return s.receiptsMethods[0]
+	}
+	i := s.providers.ActiveProvider()
+	if i < 0 || i >= len(s.receiptsMethods) {
+		return s.receiptsMethods[0]
+	}
+	return s.receiptsMethods[i]
+}
+
 func (s *EthClient) PickReceiptsMethod(txCount uint64) ReceiptsFetchingMethod {
-	if now := time.Now(); now.Sub(s.lastMethodsReset) > s.methodResetDuration {
-		m := AvailableReceiptsFetchingMethods(s.provKind)
-		if s.availableReceiptMethods != m {
-			s.log.Warn("resetting back RPC preferences, please review RPC provider kind setting", "kind", s.provKind.String())
+	r := s.activeReceiptsMethods()
+	if now := time.Now(); now.Sub(r.lastReset) > s.methodResetDuration {
+		m := AvailableReceiptsFetchingMethods(r.provKind)
+		if r.available != m {
+			s.log.Warn("resetting back RPC preferences, please review RPC provider kind setting", "kind", r.provKind.String())
 		}
-		s.availableReceiptMethods = m
-		s.lastMethodsReset = now
+		r.available = m
+		r.lastReset = now
 	}
-	return PickBestReceiptsFetchingMethod(s.provKind, s.availableReceiptMethods, txCount)
+	return PickBestReceiptsFetchingMethod(r.provKind, r.available, txCount)
 }
 
 func (s *EthClient) OnReceiptsMethodErr(m ReceiptsFetchingMethod, err error) {
+	r := s.activeReceiptsMethods()
 	if unusableMethod(err) {
 		// clear the bit of the method that errored
-		s.availableReceiptMethods &^= m
+		r.available &^= m
 		s.log.Warn("failed to use selected RPC method for receipt fetching, temporarily falling back to alternatives",
-			"provider_kind", s.provKind, "failed_method", m, "fallback", s.availableReceiptMethods, "err", err)
+			"provider_kind", r.provKind, "failed_method", m, "fallback", r.available, "err", err)
 	} else {
 		s.log.Debug("failed to use selected RPC method for receipt fetching, but method does appear to be available, so we continue to use it",
-			"provider_kind", s.provKind, "failed_method", m, "fallback", s.availableReceiptMethods&^m, "err", err)
+			"provider_kind", r.provKind, "failed_method", m, "fallback", r.available&^m, "err", err)
 	}
 }
 
@@ -167,20 +222,27 @@ func NewEthClient(client client.RPC, log log.Logger, metrics caching.Metrics, co
 		return nil, fmt.Errorf("bad config, cannot create L1 source: %w", err)
 	}
 	client = LimitRPC(client, config.MaxConcurrentRequests)
+	var methods []*receiptsMethods
+	if config.RPCProviders != nil {
+		for _, kind := range config.RPCProviders.RPCProviderKinds() {
+			methods = append(methods, newReceiptsMethods(kind))
+		}
+	} else {
+		methods = []*receiptsMethods{newReceiptsMethods(config.RPCProviderKind)}
+	}
 	return &EthClient{
-		client:                  client,
-		maxBatchSize:            config.MaxRequestsPerBatch,
-		trustRPC:                config.TrustRPC,
-		mustBePostMerge:         config.MustBePostMerge,
-		provKind:                config.RPCProviderKind,
-		log:                     log,
-		receiptsCache:           caching.NewLRUCache[common.Hash, *receiptsFetchingJob](metrics, "receipts", config.ReceiptsCacheSize),
-		transactionsCache:       caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", config.TransactionsCacheSize),
-		headersCache:            caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", config.HeadersCacheSize),
-		payloadsCache:           caching.NewLRUCache[common.Hash, *eth.ExecutionPayload](metrics, "payloads", config.PayloadsCacheSize),
-		availableReceiptMethods: AvailableReceiptsFetchingMethods(config.RPCProviderKind),
-		lastMethodsReset:        time.Now(),
-		methodResetDuration:     config.MethodResetDuration,
+		client:              client,
+		maxBatchSize:        config.MaxRequestsPerBatch,
+		trustRPC:            config.TrustRPC,
+		mustBePostMerge:     config.MustBePostMerge,
+		log:                 log,
+		receiptsCache:       caching.NewLRUCache[common.Hash, *receiptsFetchingJob](metrics, "receipts", config.ReceiptsCacheSize),
+		transactionsCache:   caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", config.TransactionsCacheSize),
+		headersCache:        caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", config.HeadersCacheSize),
+		payloadsCache:       caching.NewLRUCache[common.Hash, *eth.ExecutionPayload](metrics, "payloads", config.PayloadsCacheSize),
+		receiptsMethods:     methods,
+		providers:           config.RPCProviders,
+		methodResetDuration: config.MethodResetDuration,
 	}, nil
 }
 
@@ -292,6 +354,23 @@ func (s *EthClient) ChainID(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&id), nil
 }
 
+// SyncProgress returns the sync progress of the node, or nil if the node is not syncing.
+func (s *EthClient) SyncProgress(ctx context.Context) (*eth.EngineSyncProgress, error) {
+	var raw json.RawMessage
+	if err := s.client.CallContext(ctx, &raw, "eth_syncing"); err != nil {
+		return nil, err
+	}
+	var syncing bool
+	if err := json.Unmarshal(raw, &syncing); err == nil {
+		return nil, nil // the node reports false when it is not syncing
+	}
+	var progress eth.EngineSyncProgress
+	if err := json.Unmarshal(raw, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode sync progress: %w", err)
+	}
+	return &progress, nil
+}
+
 func (s *EthClient) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
 	if header, ok := s.headersCache.Get(hash); ok {
 		return header, nil
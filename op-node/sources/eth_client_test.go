@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	crand "crypto/rand"
+	"encoding/json"
 	"math/big"
 	"testing"
 
@@ -177,3 +178,30 @@ func TestEthClient_WrongInfoByHash(t *testing.T) {
 	require.Error(t, err, "cannot accept the wrong block")
 	m.Mock.AssertExpectations(t)
 }
+
+func TestEthClient_SyncProgress(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		name     string
+		response string
+		expected *eth.EngineSyncProgress
+	}{
+		{"not syncing", `false`, nil},
+		{"syncing", `{"startingBlock":"0x0","currentBlock":"0x10","highestBlock":"0x64","syncedAccounts":"0x3e8","syncedStorage":"0x7d0","healedTrienodes":"0x0","healingTrienodes":"0x0"}`,
+			&eth.EngineSyncProgress{CurrentBlock: 16, HighestBlock: 100, SyncedAccounts: 1000, SyncedStorage: 2000}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mockRPC)
+			m.On("CallContext", ctx, new(json.RawMessage), "eth_syncing", []any(nil)).Run(func(args mock.Arguments) {
+				*args[1].(*json.RawMessage) = json.RawMessage(tc.response)
+			}).Return([]error{nil})
+			s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+			require.NoError(t, err)
+			progress, err := s.SyncProgress(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, progress)
+			m.Mock.AssertExpectations(t)
+		})
+	}
+}
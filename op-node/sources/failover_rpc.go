@@ -0,0 +1,491 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+)
+
+// ErrEndpointSwitched is returned on the error channel of subscriptions,
+// when the RPC fails over to a different endpoint, to trigger a resubscription.
+var ErrEndpointSwitched = errors.New("rpc endpoint switched")
+
+// FailoverEndpoint is a single RPC provider that a FailoverRPC may route requests to.
+type FailoverEndpoint struct {
+	// Name identifies the endpoint in logs and metrics. This should not contain the RPC URL,
+	// since provider URLs commonly contain API keys.
+	Name string
+	RPC  client.RPC
+	// Kind identifies the kind of RPC provider, to inform the receipts fetching per provider.
+	Kind RPCProviderKind
+}
+
+type FailoverConfig struct {
+	// HealthCheckInterval is the interval between health checks of all endpoints.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout limits the duration of the health check requests to an endpoint.
+	HealthCheckTimeout time.Duration
+	// MaxHeadLag is the number of blocks the head of an endpoint may differ from the median head of all endpoints,
+	// before it is considered unhealthy.
+	MaxHeadLag uint64
+}
+
+func (c *FailoverConfig) Check() error {
+	if c.HealthCheckInterval <= 0 {
+		return fmt.Errorf("invalid health check interval: %s", c.HealthCheckInterval)
+	}
+	if c.HealthCheckTimeout <= 0 || c.HealthCheckTimeout >= c.HealthCheckInterval {
+		return fmt.Errorf("invalid health check timeout: %s, must be shorter than the health check interval %s",
+			c.HealthCheckTimeout, c.HealthCheckInterval)
+	}
+	return nil
+}
+
+type FailoverMetrics interface {
+	RecordRPCEndpointHealth(name string, healthy bool, head uint64)
+	RecordRPCEndpointActive(name string, active bool)
+	RecordRPCEndpointFailover(name string)
+}
+
+type failoverEndpoint struct {
+	FailoverEndpoint
+	healthy atomic.Bool
+}
+
+// FailoverRPC routes requests to the first healthy endpoint, in order of priority.
+// Endpoints are marked unhealthy when requests fail on the transport level,
+// and by a periodic health check that rejects endpoints which are unreachable, lag behind the other endpoints,
+// or disagree with the majority of the endpoints on the block hash at a common height.
+// Requests that fail on the transport level are retried once, after failing over to the next healthy endpoint.
+// Higher priority endpoints are recovered to when they are healthy again.
+type FailoverRPC struct {
+	log     log.Logger
+	metrics FailoverMetrics
+	cfg     FailoverConfig
+
+	endpoints []*failoverEndpoint
+
+	// mu guards switching of the active endpoint and the set of subscriptions.
+	mu     sync.Mutex
+	active atomic.Int32
+	subs   map[*failoverSubscription]struct{}
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	wg        sync.WaitGroup
+}
+
+var _ client.RPC = (*FailoverRPC)(nil)
+
+var _ RPCProviderSource = (*FailoverRPC)(nil)
+
+// NewFailoverRPC creates an RPC that fails over between the given endpoints, in order of priority.
+// The health of the endpoints is checked in the background until the RPC is closed.
+func NewFailoverRPC(log log.Logger, metrics FailoverMetrics, cfg *FailoverConfig, endpoints ...FailoverEndpoint) (*FailoverRPC, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid failover config: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("expected at least one endpoint")
+	}
+	f := &FailoverRPC{
+		log:     log,
+		metrics: metrics,
+		cfg:     *cfg,
+		subs:    make(map[*failoverSubscription]struct{}),
+		closing: make(chan struct{}),
+	}
+	for i, e := range endpoints {
+		if !ValidRPCProviderKind(e.Kind) {
+			return nil, fmt.Errorf("unknown rpc provider kind of endpoint %d: %s", i, e.Kind)
+		}
+		fe := &failoverEndpoint{FailoverEndpoint: e}
+		fe.healthy.Store(true)
+		f.endpoints = append(f.endpoints, fe)
+		metrics.RecordRPCEndpointActive(e.Name, i == 0)
+	}
+	f.wg.Add(1)
+	go f.healthLoop()
+	return f, nil
+}
+
+// RPCProviderKinds returns the kind of each of the endpoints, in order of priority.
+func (f *FailoverRPC) RPCProviderKinds() []RPCProviderKind {
+	out := make([]RPCProviderKind, len(f.endpoints))
+	for i, e := range f.endpoints {
+		out[i] = e.Kind
+	}
+	return out
+}
+
+// ActiveProvider returns the index of the endpoint that currently serves requests.
+func (f *FailoverRPC) ActiveProvider() int {
+	return int(f.active.Load())
+}
+
+func (f *FailoverRPC) current() *failoverEndpoint {
+	return f.endpoints[f.active.Load()]
+}
+
+// switchTo makes the endpoint with the given index the active endpoint,
+// and ends all subscriptions to the previous endpoint.
+func (f *FailoverRPC) switchTo(i int, reason string) {
+	f.mu.Lock()
+	prev := int(f.active.Load())
+	if prev == i {
+		f.mu.Unlock()
+		return
+	}
+	f.active.Store(int32(i))
+	subs := f.subs
+	f.subs = make(map[*failoverSubscription]struct{})
+	f.mu.Unlock()
+
+	f.log.Warn("switched RPC endpoint", "from", f.endpoints[prev].Name, "to", f.endpoints[i].Name, "reason", reason)
+	f.metrics.RecordRPCEndpointActive(f.endpoints[prev].Name, false)
+	f.metrics.RecordRPCEndpointActive(f.endpoints[i].Name, true)
+	f.metrics.RecordRPCEndpointFailover(f.endpoints[i].Name)
+	for sub := range subs {
+		sub.fail(ErrEndpointSwitched)
+	}
+}
+
+// selectEndpoint switches to the healthy endpoint with the highest priority.
+// If no endpoint is healthy, then the next endpoint after the given fallback index is used.
+// A negative fallback index keeps the active endpoint if no endpoint is healthy.
+func (f *FailoverRPC) selectEndpoint(fallback int, reason string) {
+	for i, e := range f.endpoints {
+		if e.healthy.Load() {
+			f.switchTo(i, reason)
+			return
+		}
+	}
+	if fallback >= 0 {
+		f.switchTo((fallback+1)%len(f.endpoints), reason)
+	}
+}
+
+func (f *FailoverRPC) markUnhealthy(e *failoverEndpoint, err error) {
+	if e.healthy.Swap(false) {
+		f.log.Warn("RPC endpoint is unhealthy", "endpoint", e.Name, "err", err)
+	}
+	// only fail over if the unhealthy endpoint is still the active endpoint
+	for i, other := range f.endpoints {
+		if other == e && int(f.active.Load()) == i {
+			f.selectEndpoint(i, err.Error())
+			return
+		}
+	}
+}
+
+// transportErr returns true if the error indicates that the endpoint itself failed,
+// rather than the request being invalid or the result not being available.
+func transportErr(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == 429
+	}
+	return true
+}
+
+// withEndpoint runs the request against the active endpoint,
+// and retries it once against the next endpoint if the active endpoint fails.
+func (f *FailoverRPC) withEndpoint(ctx context.Context, fn func(e *failoverEndpoint) error) error {
+	e := f.current()
+	err := fn(e)
+	if !transportErr(ctx, err) {
+		return err
+	}
+	f.markUnhealthy(e, err)
+	if next := f.current(); next != e {
+		return fn(next)
+	}
+	return err
+}
+
+func (f *FailoverRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return f.withEndpoint(ctx, func(e *failoverEndpoint) error {
+		return e.RPC.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (f *FailoverRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.withEndpoint(ctx, func(e *failoverEndpoint) error {
+		return e.RPC.BatchCallContext(ctx, b)
+	})
+}
+
+// EthSubscribe subscribes with the active endpoint.
+// The subscription fails with ErrEndpointSwitched when the RPC fails over to a different endpoint,
+// such that it can be re-established with the new endpoint.
+func (f *FailoverRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub *failoverSubscription
+	err := f.withEndpoint(ctx, func(e *failoverEndpoint) error {
+		inner, err := e.RPC.EthSubscribe(ctx, channel, args...)
+		if err != nil {
+			return err
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		sub = newFailoverSubscription(f, inner)
+		if f.endpoints[f.active.Load()] != e {
+			// the endpoint was switched while subscribing
+			go sub.fail(ErrEndpointSwitched)
+		} else {
+			f.subs[sub] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (f *FailoverRPC) removeSubscription(sub *failoverSubscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subs, sub)
+}
+
+// Close stops the health checks, and closes all endpoints.
+func (f *FailoverRPC) Close() {
+	f.closeOnce.Do(func() {
+		close(f.closing)
+		f.wg.Wait()
+		for _, e := range f.endpoints {
+			e.RPC.Close()
+		}
+	})
+}
+
+func (f *FailoverRPC) healthLoop() {
+	defer f.wg.Done()
+	ticker := time.NewTicker(f.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.checkHealth()
+		case <-f.closing:
+			return
+		}
+	}
+}
+
+// headResult is the subset of a block that is needed to compare the chain of different endpoints.
+type headResult struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+func (f *FailoverRPC) fetchHead(e *failoverEndpoint, blockArg string) (*headResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.cfg.HealthCheckTimeout)
+	defer cancel()
+	var head *headResult
+	if err := e.RPC.CallContext(ctx, &head, "eth_getBlockByNumber", blockArg, false); err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, ethereum.NotFound
+	}
+	return head, nil
+}
+
+// fetchAll runs fetchHead for every given endpoint concurrently.
+// Endpoints that fail to respond are marked unhealthy, and are excluded from the results.
+func (f *FailoverRPC) fetchAll(indices []int, blockArg string) map[int]*headResult {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[int]*headResult, len(indices))
+	for _, i := range indices {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e := f.endpoints[i]
+			head, err := f.fetchHead(e, blockArg)
+			if err != nil {
+				f.log.Warn("RPC endpoint failed health check", "endpoint", e.Name, "block", blockArg, "err", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = head
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// checkHealth determines the health of all endpoints, and switches to the healthy endpoint with the highest priority.
+func (f *FailoverRPC) checkHealth() {
+	all := make([]int, len(f.endpoints))
+	for i := range all {
+		all[i] = i
+	}
+	heads := f.fetchAll(all, "latest")
+
+	healthy := make([]bool, len(f.endpoints))
+	// The median head is the reference height, so a single endpoint that reports a head far ahead,
+	// e.g. on a fork or by a bug, cannot mark all other endpoints as lagging.
+	reference := medianHead(heads)
+	// reject endpoints that lag behind or run ahead, and find the common height of the remaining endpoints
+	commonHeight := reference
+	var candidates []int
+	for _, i := range all {
+		head, ok := heads[i]
+		if !ok {
+			continue
+		}
+		number := uint64(head.Number)
+		if number+f.cfg.MaxHeadLag < reference {
+			f.log.Warn("RPC endpoint lags behind", "endpoint", f.endpoints[i].Name, "head", number, "median", reference)
+			continue
+		}
+		if number > reference+f.cfg.MaxHeadLag {
+			f.log.Warn("RPC endpoint is ahead of the other endpoints", "endpoint", f.endpoints[i].Name, "head", number, "median", reference)
+			continue
+		}
+		candidates = append(candidates, i)
+		if uint64(head.Number) < commonHeight {
+			commonHeight = uint64(head.Number)
+		}
+	}
+
+	// reject endpoints that disagree with the majority on the block hash at the common height
+	if len(candidates) > 0 {
+		hashes := make(map[int]*headResult, len(candidates))
+		for _, i := range candidates {
+			if uint64(heads[i].Number) == commonHeight {
+				hashes[i] = heads[i]
+			}
+		}
+		var refetch []int
+		for _, i := range candidates {
+			if _, ok := hashes[i]; !ok {
+				refetch = append(refetch, i)
+			}
+		}
+		for i, res := range f.fetchAll(refetch, hexutil.EncodeUint64(commonHeight)) {
+			hashes[i] = res
+		}
+		votes := make(map[common.Hash]int)
+		for _, res := range hashes {
+			votes[res.Hash] += 1
+		}
+		var canonical common.Hash
+		best := 0
+		for _, i := range candidates { // iterate in order of priority, for a deterministic choice on ties
+			res, ok := hashes[i]
+			if !ok {
+				continue
+			}
+			if n := votes[res.Hash]; n > best {
+				best = n
+				canonical = res.Hash
+			}
+		}
+		// on a tie, prefer the view of the active endpoint, to not switch between chains needlessly
+		if res, ok := hashes[int(f.active.Load())]; ok && votes[res.Hash] == best {
+			canonical = res.Hash
+		}
+		for i, res := range hashes {
+			if res.Hash != canonical {
+				f.log.Warn("RPC endpoint disagrees on block hash", "endpoint", f.endpoints[i].Name,
+					"number", commonHeight, "hash", res.Hash, "canonical", canonical)
+				continue
+			}
+			healthy[i] = true
+		}
+	}
+
+	for i, e := range f.endpoints {
+		if healthy[i] && !e.healthy.Load() {
+			f.log.Info("RPC endpoint recovered", "endpoint", e.Name)
+		}
+		e.healthy.Store(healthy[i])
+		var head uint64
+		if res, ok := heads[i]; ok {
+			head = uint64(res.Number)
+		}
+		f.metrics.RecordRPCEndpointHealth(e.Name, healthy[i], head)
+	}
+	f.selectEndpoint(-1, "health check")
+}
+
+// medianHead returns the median head number of the endpoints, or the lower of the two middle heads
+// for an even number of endpoints, so a head that only half of the endpoints reach is not the reference.
+func medianHead(heads map[int]*headResult) uint64 {
+	if len(heads) == 0 {
+		return 0
+	}
+	numbers := make([]uint64, 0, len(heads))
+	for _, head := range heads {
+		numbers = append(numbers, uint64(head.Number))
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return numbers[(len(numbers)-1)/2]
+}
+
+// failoverSubscription wraps the subscription of an endpoint, and fails when the RPC switches endpoints.
+type failoverSubscription struct {
+	f     *FailoverRPC
+	inner ethereum.Subscription
+	once  sync.Once
+	err   chan error
+}
+
+func newFailoverSubscription(f *FailoverRPC, inner ethereum.Subscription) *failoverSubscription {
+	sub := &failoverSubscription{f: f, inner: inner, err: make(chan error, 1)}
+	go func() {
+		// the inner error channel is closed when the inner subscription is unsubscribed
+		if err, ok := <-inner.Err(); ok && err != nil {
+			sub.fail(err)
+		}
+	}()
+	return sub
+}
+
+func (s *failoverSubscription) fail(err error) {
+	s.once.Do(func() {
+		s.inner.Unsubscribe()
+		s.err <- err
+		close(s.err)
+	})
+	s.f.removeSubscription(s)
+}
+
+func (s *failoverSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.inner.Unsubscribe()
+		close(s.err)
+	})
+	s.f.removeSubscription(s)
+}
+
+func (s *failoverSubscription) Err() <-chan error {
+	return s.err
+}
@@ -0,0 +1,281 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type testRPCErr struct{}
+
+func (testRPCErr) Error() string  { return "execution reverted" }
+func (testRPCErr) ErrorCode() int { return -32000 }
+
+var errConnRefused = errors.New("connection refused")
+
+// fakeEndpoint serves a chain of blocks, with the hashes of the blocks determined by the fork byte.
+type fakeEndpoint struct {
+	mu     sync.Mutex
+	err    error
+	head   uint64
+	fork   byte
+	closed bool
+	sub    event.Subscription
+}
+
+func (e *fakeEndpoint) set(fn func(e *fakeEndpoint)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	fn(e)
+}
+
+func (e *fakeEndpoint) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	switch method {
+	case "eth_getBlockByNumber":
+		num := e.head
+		if args[0] != "latest" {
+			n, err := hexutil.DecodeUint64(args[0].(string))
+			if err != nil {
+				return err
+			}
+			if n > e.head {
+				return nil
+			}
+			num = n
+		}
+		*result.(**headResult) = &headResult{Number: hexutil.Uint64(num), Hash: common.Hash{e.fork, byte(num)}}
+		return nil
+	case "test_revert":
+		return testRPCErr{}
+	default:
+		return nil
+	}
+}
+
+func (e *fakeEndpoint) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+func (e *fakeEndpoint) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	e.sub = event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+	return e.sub, nil
+}
+
+func (e *fakeEndpoint) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+}
+
+var _ client.RPC = (*fakeEndpoint)(nil)
+
+type testFailoverMetrics struct {
+	mu        sync.Mutex
+	healthy   map[string]bool
+	failovers map[string]int
+}
+
+func (m *testFailoverMetrics) RecordRPCEndpointHealth(name string, healthy bool, head uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.healthy[name] = healthy
+}
+
+func (m *testFailoverMetrics) RecordRPCEndpointActive(name string, active bool) {}
+
+func (m *testFailoverMetrics) RecordRPCEndpointFailover(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failovers[name] += 1
+}
+
+func setupFailover(t *testing.T, n int) (*FailoverRPC, []*fakeEndpoint, *testFailoverMetrics) {
+	m := &testFailoverMetrics{healthy: make(map[string]bool), failovers: make(map[string]int)}
+	fakes := make([]*fakeEndpoint, n)
+	endpoints := make([]FailoverEndpoint, n)
+	for i := range fakes {
+		fakes[i] = &fakeEndpoint{head: 100}
+		endpoints[i] = FailoverEndpoint{Name: string(rune('a' + i)), RPC: fakes[i], Kind: RPCKindBasic}
+	}
+	endpoints[1].Kind = RPCKindAlchemy
+	// the interval is long enough to not run health checks in the background during the test
+	f, err := NewFailoverRPC(testlog.Logger(t, log.LvlInfo), m, &FailoverConfig{
+		HealthCheckInterval: time.Hour,
+		HealthCheckTimeout:  time.Second,
+		MaxHeadLag:          2,
+	}, endpoints...)
+	require.NoError(t, err)
+	t.Cleanup(f.Close)
+	return f, fakes, m
+}
+
+func TestFailoverRPC_TransportError(t *testing.T) {
+	f, fakes, m := setupFailover(t, 3)
+	ctx := context.Background()
+
+	require.ErrorIs(t, f.CallContext(ctx, nil, "test_revert"), testRPCErr{}, "rpc errors are returned")
+	require.Equal(t, 0, f.ActiveProvider(), "rpc errors do not fail over")
+
+	fakes[0].set(func(e *fakeEndpoint) { e.err = errConnRefused })
+	require.NoError(t, f.CallContext(ctx, nil, "test_ok"), "request is retried with next endpoint")
+	require.Equal(t, 1, f.ActiveProvider())
+	require.Equal(t, 1, m.failovers["b"])
+
+	fakes[1].set(func(e *fakeEndpoint) { e.err = errConnRefused })
+	require.NoError(t, f.BatchCallContext(ctx, nil))
+	require.Equal(t, 2, f.ActiveProvider())
+
+	fakes[2].set(func(e *fakeEndpoint) { e.err = errConnRefused })
+	require.ErrorIs(t, f.CallContext(ctx, nil, "test_ok"), errConnRefused, "all endpoints are down")
+
+	// the health check recovers to the endpoint with the highest priority
+	fakes[1].set(func(e *fakeEndpoint) { e.err = nil })
+	f.checkHealth()
+	require.Equal(t, 1, f.ActiveProvider())
+	fakes[0].set(func(e *fakeEndpoint) { e.err = nil })
+	f.checkHealth()
+	require.Equal(t, 0, f.ActiveProvider())
+	require.True(t, m.healthy["a"])
+	require.False(t, m.healthy["c"])
+}
+
+func TestFailoverConfig_Check(t *testing.T) {
+	cfg := FailoverConfig{HealthCheckInterval: 6 * time.Second, HealthCheckTimeout: 3 * time.Second}
+	require.NoError(t, cfg.Check())
+	cfg.HealthCheckTimeout = 0
+	require.Error(t, cfg.Check(), "timeout is required")
+	cfg.HealthCheckTimeout = cfg.HealthCheckInterval
+	require.Error(t, cfg.Check(), "timeout must be shorter than the interval")
+}
+
+func TestFailoverRPC_CanceledRequest(t *testing.T) {
+	f, fakes, _ := setupFailover(t, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fakes[0].set(func(e *fakeEndpoint) { e.err = context.Canceled })
+	require.ErrorIs(t, f.CallContext(ctx, nil, "test_ok"), context.Canceled)
+	require.Equal(t, 0, f.ActiveProvider(), "canceled requests do not fail over")
+}
+
+func TestFailoverRPC_HealthCheckLag(t *testing.T) {
+	f, fakes, m := setupFailover(t, 3)
+	fakes[0].set(func(e *fakeEndpoint) { e.head = 97 })
+	fakes[2].set(func(e *fakeEndpoint) { e.head = 101 })
+	f.checkHealth()
+	require.Equal(t, 1, f.ActiveProvider(), "lagging endpoint is rejected")
+	require.False(t, m.healthy["a"])
+	require.True(t, m.healthy["b"], "endpoint within max lag is healthy")
+	require.True(t, m.healthy["c"])
+
+	fakes[0].set(func(e *fakeEndpoint) { e.head = 101 })
+	f.checkHealth()
+	require.Equal(t, 0, f.ActiveProvider(), "caught up endpoint is recovered")
+}
+
+func TestFailoverRPC_HealthCheckAhead(t *testing.T) {
+	f, fakes, m := setupFailover(t, 3)
+	fakes[0].set(func(e *fakeEndpoint) { e.head = 1000 })
+	fakes[2].set(func(e *fakeEndpoint) { e.head = 99 })
+	f.checkHealth()
+	require.Equal(t, 1, f.ActiveProvider(), "endpoint far ahead of the median is rejected")
+	require.False(t, m.healthy["a"])
+	require.True(t, m.healthy["b"], "endpoints at the median head are not lagging")
+	require.True(t, m.healthy["c"])
+}
+
+func TestFailoverRPC_HealthCheckHashMismatch(t *testing.T) {
+	f, fakes, m := setupFailover(t, 3)
+	fakes[0].set(func(e *fakeEndpoint) { e.fork = 1 })
+	fakes[1].set(func(e *fakeEndpoint) { e.head = 99 })
+	f.checkHealth()
+	require.Equal(t, 1, f.ActiveProvider(), "endpoint that disagrees with the majority is rejected")
+	require.False(t, m.healthy["a"])
+	require.True(t, m.healthy["b"])
+	require.True(t, m.healthy["c"])
+}
+
+func TestFailoverRPC_HealthCheckTie(t *testing.T) {
+	f, fakes, m := setupFailover(t, 2)
+	fakes[1].set(func(e *fakeEndpoint) { e.fork = 1 })
+	f.checkHealth()
+	require.Equal(t, 0, f.ActiveProvider(), "the active endpoint is preferred on a tie")
+	require.True(t, m.healthy["a"])
+	require.False(t, m.healthy["b"])
+}
+
+func TestFailoverRPC_Subscription(t *testing.T) {
+	f, fakes, _ := setupFailover(t, 2)
+	sub, err := f.EthSubscribe(context.Background(), make(chan struct{}), "newHeads")
+	require.NoError(t, err)
+
+	fakes[0].set(func(e *fakeEndpoint) { e.err = errConnRefused })
+	require.NoError(t, f.CallContext(context.Background(), nil, "test_ok"))
+	select {
+	case err := <-sub.Err():
+		require.ErrorIs(t, err, ErrEndpointSwitched)
+	case <-time.After(time.Second):
+		t.Fatal("expected subscription to end on failover")
+	}
+
+	sub, err = f.EthSubscribe(context.Background(), make(chan struct{}), "newHeads")
+	require.NoError(t, err)
+	require.NotNil(t, fakes[1].sub, "resubscribed with next endpoint")
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	require.False(t, ok, "error channel is closed on unsubscribe")
+}
+
+func TestFailoverRPC_Close(t *testing.T) {
+	f, fakes, _ := setupFailover(t, 2)
+	f.Close()
+	f.Close()
+	for _, e := range fakes {
+		require.True(t, e.closed)
+	}
+}
+
+func TestEthClient_ReceiptsMethodsPerProvider(t *testing.T) {
+	f, fakes, _ := setupFailover(t, 2)
+	cfg := *testEthClientConfig
+	cfg.MethodResetDuration = time.Hour
+	cfg.RPCProviders = f
+	s, err := NewEthClient(f, testlog.Logger(t, log.LvlInfo), nil, &cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, EthGetTransactionReceiptBatch, s.PickReceiptsMethod(100))
+	s.OnReceiptsMethodErr(EthGetTransactionReceiptBatch, errors.New("method not found"))
+	require.Equal(t, EthGetTransactionReceiptBatch, s.PickReceiptsMethod(100), "basic provider has no alternative")
+
+	fakes[0].set(func(e *fakeEndpoint) { e.err = errConnRefused })
+	require.NoError(t, f.CallContext(context.Background(), nil, "test_ok"))
+	require.Equal(t, AlchemyGetTransactionReceipts, s.PickReceiptsMethod(100), "methods of the active provider kind are used")
+}
@@ -0,0 +1,177 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	genesisMethod        = "eth/v1/beacon/genesis"
+	specMethod           = "eth/v1/config/spec"
+	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+)
+
+// TimeToSlotFn converts an L1 block timestamp to the beacon slot of the block.
+type TimeToSlotFn func(timestamp uint64) (uint64, error)
+
+// L1BeaconClient is a client of the beacon API, to fetch the blobs that were
+// committed to in L1 blocks. The KZG commitments and proofs of the fetched blobs are verified.
+type L1BeaconClient struct {
+	cl   *http.Client
+	addr string
+
+	initLock     sync.Mutex
+	timeToSlotFn TimeToSlotFn
+}
+
+// NewL1BeaconClient returns a client of the beacon API at the given address.
+func NewL1BeaconClient(cl *http.Client, addr string) *L1BeaconClient {
+	return &L1BeaconClient{cl: cl, addr: addr}
+}
+
+func (cl *L1BeaconClient) apiReq(ctx context.Context, dest any, method string, query url.Values) error {
+	base, err := url.Parse(cl.addr)
+	if err != nil {
+		return fmt.Errorf("failed to parse beacon address %q: %w", cl.addr, err)
+	}
+	reqURL := base.JoinPath(method)
+	reqURL.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := cl.cl.Do(req)
+	if err != nil {
+		return fmt.Errorf("http Get failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// e.g. the block is unknown to the beacon node, or its blobs were pruned
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%w: %s", ethereum.NotFound, strings.TrimSpace(string(body)))
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed request with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", method, err)
+	}
+	return nil
+}
+
+// GetTimeToSlotFn returns a function that converts an L1 block timestamp to the beacon slot.
+// The genesis time and slot time are fetched once, and cached after.
+func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.timeToSlotFn != nil {
+		return cl.timeToSlotFn, nil
+	}
+
+	var genesisResp eth.APIGenesisResponse
+	if err := cl.apiReq(ctx, &genesisResp, genesisMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon genesis: %w", err)
+	}
+	var configResp eth.APIConfigResponse
+	if err := cl.apiReq(ctx, &configResp, specMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon config: %w", err)
+	}
+
+	genesisTime := uint64(genesisResp.Data.GenesisTime)
+	secondsPerSlot := uint64(configResp.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return nil, fmt.Errorf("got bad value for seconds per slot: %v", configResp.Data.SecondsPerSlot)
+	}
+	cl.timeToSlotFn = func(timestamp uint64) (uint64, error) {
+		if timestamp < genesisTime {
+			return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, genesisTime)
+		}
+		return (timestamp - genesisTime) / secondsPerSlot, nil
+	}
+	return cl.timeToSlotFn, nil
+}
+
+// GetBlobSidecars fetches the sidecars of the blobs with the given hashes, that were committed to in the L1 block.
+// The sidecars are returned in the order of the hashes. The sidecars are not verified.
+func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, nil
+	}
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time to slot function: %w", err)
+	}
+	slot, err := slotFn(ref.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
+	}
+
+	query := url.Values{}
+	for _, h := range hashes {
+		query.Add("indices", strconv.FormatUint(h.Index, 10))
+	}
+	var resp eth.APIGetBlobSidecarsResponse
+	if err := cl.apiReq(ctx, &resp, sidecarsMethodPrefix+strconv.FormatUint(slot, 10), query); err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, err)
+	}
+
+	byIndex := make(map[uint64]*eth.BlobSidecar, len(resp.Data))
+	for _, sc := range resp.Data {
+		byIndex[uint64(sc.Index)] = sc
+	}
+	out := make([]*eth.BlobSidecar, 0, len(hashes))
+	for _, h := range hashes {
+		sc, ok := byIndex[h.Index]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing blob sidecar %d of block %v", ethereum.NotFound, h.Index, ref)
+		}
+		out = append(out, sc)
+	}
+	return out, nil
+}
+
+// GetBlobs fetches the blobs with the given hashes, that were committed to in the L1 block.
+// The blobs are returned in the order of the hashes, and verified against the versioned hashes.
+func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := cl.GetBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*eth.Blob, 0, len(hashes))
+	for i, sc := range sidecars {
+		if err := VerifyBlobSidecar(sc, hashes[i].Hash); err != nil {
+			return nil, fmt.Errorf("blob %d of block %v failed verification: %w", hashes[i].Index, ref, err)
+		}
+		out = append(out, &sc.Blob)
+	}
+	return out, nil
+}
+
+var ErrBlobHashMismatch = errors.New("KZG commitment does not match versioned hash")
+
+// VerifyBlobSidecar verifies the KZG commitment of the sidecar matches the versioned hash,
+// and that the KZG proof of the blob is valid for the commitment.
+func VerifyBlobSidecar(sc *eth.BlobSidecar, versionedHash common.Hash) error {
+	if eth.KZGToVersionedHash(kzg4844.Commitment(sc.KZGCommitment)) != versionedHash {
+		return ErrBlobHashMismatch
+	}
+	if err := sc.VerifyBlobProof(); err != nil {
+		return fmt.Errorf("invalid KZG proof: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils/fakebeacon"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestL1BeaconClient(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	beacon := fakebeacon.NewBeacon(logger, 1000, 12)
+	require.NoError(t, beacon.Start("127.0.0.1:0"))
+	t.Cleanup(func() {
+		_ = beacon.Close()
+	})
+
+	var blobA, blobB eth.Blob
+	require.NoError(t, blobA.FromData(eth.Data("hello")))
+	require.NoError(t, blobB.FromData(eth.Data("world")))
+	ref := eth.L1BlockRef{Number: 3, Time: 1000 + 12*3}
+	hashes, err := beacon.StoreBlobs(ref.Time, []*eth.Blob{&blobA, &blobB})
+	require.NoError(t, err)
+
+	cl := NewL1BeaconClient(http.DefaultClient, beacon.BeaconAddr())
+	ctx := context.Background()
+
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	require.NoError(t, err)
+	slot, err := slotFn(ref.Time)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), slot)
+	_, err = slotFn(999)
+	require.Error(t, err)
+
+	t.Run("all", func(t *testing.T) {
+		blobs, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 0, Hash: hashes[0]}, {Index: 1, Hash: hashes[1]}})
+		require.NoError(t, err)
+		require.Equal(t, []*eth.Blob{&blobA, &blobB}, blobs)
+	})
+	t.Run("subset", func(t *testing.T) {
+		blobs, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 1, Hash: hashes[1]}})
+		require.NoError(t, err)
+		require.Equal(t, []*eth.Blob{&blobB}, blobs)
+	})
+	t.Run("none", func(t *testing.T) {
+		blobs, err := cl.GetBlobs(ctx, ref, nil)
+		require.NoError(t, err)
+		require.Empty(t, blobs)
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 0, Hash: hashes[1]}})
+		require.ErrorIs(t, err, ErrBlobHashMismatch)
+	})
+	t.Run("missing", func(t *testing.T) {
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 2, Hash: common.Hash{0x01}}})
+		require.ErrorContains(t, err, "missing blob sidecar")
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+}
+
+func TestL1BeaconClientNotFound(t *testing.T) {
+	// serve the beacon config, but no blob sidecars, like a beacon node that pruned them
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 1000}}))
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 12}}))
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"code":404,"message":"Block not found"}`, http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	cl := NewL1BeaconClient(http.DefaultClient, srv.URL)
+	ref := eth.L1BlockRef{Number: 3, Time: 1000 + 12*3}
+	_, err := cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{{Index: 0, Hash: common.Hash{0x01}}})
+	require.ErrorIs(t, err, ethereum.NotFound)
+	require.ErrorContains(t, err, "Block not found")
+}
@@ -3,11 +3,13 @@ package sources
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
@@ -194,3 +196,41 @@ func (s *L2Client) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (
 		BlockHash:                blockHash,
 	}, nil
 }
+
+// TxPoolPending returns the executable transactions in the transaction-pool of the execution engine,
+// by sender and ordered by nonce.
+func (s *L2Client) TxPoolPending(ctx context.Context) (map[common.Address][]*types.Transaction, error) {
+	var content struct {
+		Pending map[common.Address]map[string]*types.Transaction `json:"pending"`
+	}
+	if err := s.client.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction-pool content: %w", err)
+	}
+	out := make(map[common.Address][]*types.Transaction, len(content.Pending))
+	for sender, txs := range content.Pending {
+		list := make([]*types.Transaction, 0, len(txs))
+		for _, tx := range txs {
+			list = append(list, tx)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Nonce() < list[j].Nonce()
+		})
+		out[sender] = list
+	}
+	return out, nil
+}
+
+// NewPendingTransactionFilter installs a filter for the hashes of the transactions that enter the transaction-pool.
+func (s *L2Client) NewPendingTransactionFilter(ctx context.Context) (string, error) {
+	var id string
+	err := s.client.CallContext(ctx, &id, "eth_newPendingTransactionFilter")
+	return id, err
+}
+
+// PendingTransactionFilterChanges returns the hashes of the transactions that entered the transaction-pool
+// since the previous poll of the filter.
+func (s *L2Client) PendingTransactionFilterChanges(ctx context.Context, id string) ([]common.Hash, error) {
+	var hashes []common.Hash
+	err := s.client.CallContext(ctx, &hashes, "eth_getFilterChanges", id)
+	return hashes, err
+}
@@ -25,6 +25,12 @@ func (r *RollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth
 	return output, err
 }
 
+func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadAtL1Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
@@ -58,3 +64,13 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	err := r.rpc.CallContext(ctx, &result, "admin_sequencerActive")
 	return result, err
 }
+
+func (r *RollupClient) SetMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) error {
+	return r.rpc.CallContext(ctx, nil, "admin_setMaxDASize", hexutil.Uint64(maxTxSize), hexutil.Uint64(maxBlockSize))
+}
+
+func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload, signature []byte) (eth.L2BlockRef, error) {
+	var unsafeHead eth.L2BlockRef
+	err := r.rpc.CallContext(ctx, &unsafeHead, "admin_postUnsafePayload", payload, hexutil.Bytes(signature))
+	return unsafeHead, err
+}
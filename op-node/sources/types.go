@@ -6,7 +6,6 @@ import (
 	"math/big"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/holiman/uint256"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -34,11 +33,11 @@ type CallContextFn func(ctx context.Context, result any, method string, args ...
 //
 // This way we minimize RPC calls, enable batching, and can choose to verify what the RPC gives us.
 
-// headerInfo is a conversion type of types.Header turning it into a
+// headerInfo is a conversion type of eth.Header turning it into a
 // BlockInfo, but using a cached hash value.
 type headerInfo struct {
 	hash common.Hash
-	*types.Header
+	*eth.Header
 }
 
 var _ eth.BlockInfo = (*headerInfo)(nil)
@@ -83,8 +82,12 @@ func (h headerInfo) GasUsed() uint64 {
 	return h.Header.GasUsed
 }
 
+func (h headerInfo) ParentBeaconRoot() *common.Hash {
+	return h.Header.ParentBeaconRoot
+}
+
 func (h headerInfo) HeaderRLP() ([]byte, error) {
-	return rlp.EncodeToBytes(h.Header)
+	return h.Header.RLP()
 }
 
 type rpcHeader struct {
@@ -110,6 +113,15 @@ type rpcHeader struct {
 	// WithdrawalsRoot was added by EIP-4895 and is ignored in legacy headers.
 	WithdrawalsRoot *common.Hash `json:"withdrawalsRoot"`
 
+	// BlobGasUsed was added by EIP-4844 and is ignored in legacy headers.
+	BlobGasUsed *hexutil.Uint64 `json:"blobGasUsed"`
+
+	// ExcessBlobGas was added by EIP-4844 and is ignored in legacy headers.
+	ExcessBlobGas *hexutil.Uint64 `json:"excessBlobGas"`
+
+	// ParentBeaconRoot was added by EIP-4788 and is ignored in legacy headers.
+	ParentBeaconRoot *common.Hash `json:"parentBeaconBlockRoot"`
+
 	// untrusted info included by RPC, may have to be checked
 	Hash common.Hash `json:"hash"`
 }
@@ -138,12 +150,12 @@ func (hdr *rpcHeader) checkPostMerge() error {
 }
 
 func (hdr *rpcHeader) computeBlockHash() common.Hash {
-	gethHeader := hdr.createGethHeader()
-	return gethHeader.Hash()
+	header := hdr.createHeader()
+	return header.Hash()
 }
 
-func (hdr *rpcHeader) createGethHeader() *types.Header {
-	return &types.Header{
+func (hdr *rpcHeader) createHeader() *eth.Header {
+	return &eth.Header{
 		ParentHash:      hdr.ParentHash,
 		UncleHash:       hdr.UncleHash,
 		Coinbase:        hdr.Coinbase,
@@ -161,6 +173,10 @@ func (hdr *rpcHeader) createGethHeader() *types.Header {
 		Nonce:           hdr.Nonce,
 		BaseFee:         (*big.Int)(hdr.BaseFee),
 		WithdrawalsHash: hdr.WithdrawalsRoot,
+
+		BlobGasUsed:      (*uint64)(hdr.BlobGasUsed),
+		ExcessBlobGas:    (*uint64)(hdr.ExcessBlobGas),
+		ParentBeaconRoot: hdr.ParentBeaconRoot,
 	}
 }
 
@@ -175,12 +191,13 @@ func (hdr *rpcHeader) Info(trustCache bool, mustBePostMerge bool) (eth.BlockInfo
 			return nil, fmt.Errorf("failed to verify block hash: computed %s but RPC said %s", computed, hdr.Hash)
 		}
 	}
-	return &headerInfo{hdr.Hash, hdr.createGethHeader()}, nil
+	return &headerInfo{hdr.Hash, hdr.createHeader()}, nil
 }
 
 type rpcBlock struct {
 	rpcHeader
 	Transactions []*types.Transaction `json:"transactions"`
+	Withdrawals  *types.Withdrawals   `json:"withdrawals,omitempty"`
 }
 
 func (block *rpcBlock) verify() error {
@@ -190,6 +207,16 @@ func (block *rpcBlock) verify() error {
 	if computed := types.DeriveSha(types.Transactions(block.Transactions), trie.NewStackTrie(nil)); block.TxHash != computed {
 		return fmt.Errorf("failed to verify transactions list: computed %s but RPC said %s", computed, block.TxHash)
 	}
+	if block.WithdrawalsRoot != nil {
+		if block.Withdrawals == nil {
+			return fmt.Errorf("expected withdrawals list for withdrawals root %s", block.WithdrawalsRoot)
+		}
+		if computed := types.DeriveSha(*block.Withdrawals, trie.NewStackTrie(nil)); *block.WithdrawalsRoot != computed {
+			return fmt.Errorf("failed to verify withdrawals list: computed %s but RPC said %s", computed, block.WithdrawalsRoot)
+		}
+	} else if block.Withdrawals != nil {
+		return fmt.Errorf("expected no withdrawals without withdrawals root, but got %d", len(*block.Withdrawals))
+	}
 	return nil
 }
 
@@ -252,6 +279,11 @@ func (block *rpcBlock) ExecutionPayload(trustCache bool) (*eth.ExecutionPayload,
 		BaseFeePerGas: baseFee,
 		BlockHash:     block.Hash,
 		Transactions:  opaqueTxs,
+		Withdrawals:   block.Withdrawals,
+		BlobGasUsed:   block.BlobGasUsed,
+		ExcessBlobGas: block.ExcessBlobGas,
+
+		ParentBeaconBlockRoot: block.ParentBeaconRoot,
 	}, nil
 }
 
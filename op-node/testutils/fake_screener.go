@@ -0,0 +1,44 @@
+package testutils
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FakeScreener is a commitment screener that rejects the blocks with the configured hashes.
+// The unsafe block signer at each L1 block is derived from the L1 block number, see FakeScreenerSigner.
+type FakeScreener struct {
+	Rejected map[common.Hash]bool
+	// Screened counts the screenings
+	Screened int
+	// ScreenedAt records the L1 block number and sequencer each block was last screened against
+	ScreenedAt map[common.Hash]FakeScreening
+}
+
+// FakeScreening is a screening, as recorded by the FakeScreener.
+type FakeScreening struct {
+	L1BlockNum uint64
+	Sequencer  common.Address
+}
+
+// FakeScreenerSigner returns the unsafe block signer of the FakeScreener at the given L1 block number.
+func FakeScreenerSigner(l1BlockNum uint64) common.Address {
+	return common.BigToAddress(new(big.Int).SetUint64(0xaa + l1BlockNum))
+}
+
+func (s *FakeScreener) Screen(ctx context.Context, l1BlockNum *big.Int, sequencer common.Address, payload *eth.ExecutionPayload) (bool, error) {
+	s.Screened++
+	if s.ScreenedAt == nil {
+		s.ScreenedAt = make(map[common.Hash]FakeScreening)
+	}
+	s.ScreenedAt[payload.BlockHash] = FakeScreening{L1BlockNum: l1BlockNum.Uint64(), Sequencer: sequencer}
+	return !s.Rejected[payload.BlockHash], nil
+}
+
+func (s *FakeScreener) UnsafeBlockSigner(ctx context.Context, l1BlockNum *big.Int) (common.Address, error) {
+	return FakeScreenerSigner(l1BlockNum.Uint64()), nil
+}
@@ -0,0 +1,157 @@
+// Package fakebeacon provides a stand-in for the beacon API of an L1 consensus client,
+// serving only the endpoints that the op-node uses to fetch blobs.
+package fakebeacon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const sidecarsPathPrefix = "/eth/v1/beacon/blob_sidecars/"
+
+// FakeBeacon serves the blobs that were stored with it, by slot.
+// The slot of an L1 block is derived from the block time, the genesis time and the block time of the chain.
+type FakeBeacon struct {
+	log log.Logger
+
+	genesisTime uint64
+	blockTime   uint64
+
+	mu    sync.Mutex
+	blobs map[uint64][]*eth.BlobSidecar
+
+	listener net.Listener
+	srv      *http.Server
+}
+
+func NewBeacon(log log.Logger, genesisTime uint64, blockTime uint64) *FakeBeacon {
+	return &FakeBeacon{
+		log:         log,
+		genesisTime: genesisTime,
+		blockTime:   blockTime,
+		blobs:       make(map[uint64][]*eth.BlobSidecar),
+	}
+}
+
+// Start serves the beacon API on the given address, e.g. "127.0.0.1:0".
+func (f *FakeBeacon) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to open tcp listener for http beacon api server: %w", err)
+	}
+	f.listener = listener
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, &eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: eth.Uint64String(f.genesisTime)}})
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, &eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: eth.Uint64String(f.blockTime)}})
+	})
+	mux.HandleFunc(sidecarsPathPrefix, f.handleBlobSidecars)
+	f.srv = &http.Server{
+		Handler:           mux,
+		ReadTimeout:       time.Second * 20,
+		ReadHeaderTimeout: time.Second * 20,
+		WriteTimeout:      time.Second * 20,
+		IdleTimeout:       time.Second * 20,
+	}
+	go func() {
+		if err := f.srv.Serve(f.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			f.log.Error("failed to serve fake beacon api", "err", err)
+		}
+	}()
+	return nil
+}
+
+func (f *FakeBeacon) handleBlobSidecars(w http.ResponseWriter, r *http.Request) {
+	slot, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, sidecarsPathPrefix), 10, 64)
+	if err != nil {
+		http.Error(w, "bad slot", http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	sidecars := f.blobs[slot]
+	f.mu.Unlock()
+
+	query := r.URL.Query()["indices"]
+	if len(query) == 0 {
+		f.writeJSON(w, &eth.APIGetBlobSidecarsResponse{Data: sidecars})
+		return
+	}
+	out := make([]*eth.BlobSidecar, 0, len(query))
+	for _, q := range query {
+		ix, err := strconv.ParseUint(q, 10, 64)
+		if err != nil {
+			http.Error(w, "bad index", http.StatusBadRequest)
+			return
+		}
+		for _, sc := range sidecars {
+			if uint64(sc.Index) == ix {
+				out = append(out, sc)
+			}
+		}
+	}
+	f.writeJSON(w, &eth.APIGetBlobSidecarsResponse{Data: out})
+}
+
+func (f *FakeBeacon) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.log.Error("failed to encode beacon api response", "err", err)
+	}
+}
+
+// StoreBlobs computes the KZG commitments and proofs of the blobs of the L1 block with the given time,
+// and stores them to be served. The blobs are indexed in order, the versioned hashes are returned.
+func (f *FakeBeacon) StoreBlobs(blockTime uint64, blobs []*eth.Blob) ([]common.Hash, error) {
+	if blockTime < f.genesisTime {
+		return nil, fmt.Errorf("block time %d precedes genesis time %d", blockTime, f.genesisTime)
+	}
+	sidecars := make([]*eth.BlobSidecar, 0, len(blobs))
+	hashes := make([]common.Hash, 0, len(blobs))
+	for i, b := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(*b.KZGBlob())
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute commitment of blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(*b.KZGBlob(), commitment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute proof of blob %d: %w", i, err)
+		}
+		sidecars = append(sidecars, &eth.BlobSidecar{
+			Index:         eth.Uint64String(i),
+			Blob:          *b,
+			KZGCommitment: eth.Bytes48(commitment),
+			KZGProof:      eth.Bytes48(proof),
+		})
+		hashes = append(hashes, eth.KZGToVersionedHash(commitment))
+	}
+	slot := (blockTime - f.genesisTime) / f.blockTime
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blobs[slot] = sidecars
+	return hashes, nil
+}
+
+// BeaconAddr returns the HTTP address of the beacon API.
+func (f *FakeBeacon) BeaconAddr() string {
+	return "http://" + f.listener.Addr().String()
+}
+
+func (f *FakeBeacon) Close() error {
+	return f.srv.Close()
+}
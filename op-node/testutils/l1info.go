@@ -24,6 +24,8 @@ type MockBlockInfo struct {
 	InfoReceiptRoot common.Hash
 	InfoGasUsed     uint64
 	InfoHeaderRLP   []byte
+
+	InfoParentBeaconRoot *common.Hash
 }
 
 func (l *MockBlockInfo) Hash() common.Hash {
@@ -66,6 +68,10 @@ func (l *MockBlockInfo) GasUsed() uint64 {
 	return l.InfoGasUsed
 }
 
+func (l *MockBlockInfo) ParentBeaconRoot() *common.Hash {
+	return l.InfoParentBeaconRoot
+}
+
 func (l *MockBlockInfo) ID() eth.BlockID {
 	return eth.BlockID{Hash: l.InfoHash, Number: l.InfoNum}
 }
@@ -10,13 +10,13 @@ type MockEngine struct {
 	MockL2Client
 }
 
-func (m *MockEngine) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
-	out := m.Mock.MethodCalled("GetPayload", payloadId)
+func (m *MockEngine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayload, error) {
+	out := m.Mock.MethodCalled("GetPayload", payloadInfo)
 	return out[0].(*eth.ExecutionPayload), *out[1].(*error)
 }
 
-func (m *MockEngine) ExpectGetPayload(payloadId eth.PayloadID, payload *eth.ExecutionPayload, err error) {
-	m.Mock.On("GetPayload", payloadId).Once().Return(payload, &err)
+func (m *MockEngine) ExpectGetPayload(payloadInfo eth.PayloadInfo, payload *eth.ExecutionPayload, err error) {
+	m.Mock.On("GetPayload", payloadInfo).Once().Return(payload, &err)
 }
 
 func (m *MockEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
@@ -39,10 +39,10 @@ func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracl
 	}
 }
 
-func (p *PreimageOracle) headerByBlockHash(blockHash common.Hash) *types.Header {
+func (p *PreimageOracle) headerByBlockHash(blockHash common.Hash) *eth.Header {
 	p.hint.Hint(BlockHeaderHint(blockHash))
 	headerRlp := p.oracle.Get(preimage.Keccak256Key(blockHash))
-	var header types.Header
+	var header eth.Header
 	if err := rlp.DecodeBytes(headerRlp, &header); err != nil {
 		panic(fmt.Errorf("invalid block header %s: %w", blockHash, err))
 	}
@@ -50,7 +50,7 @@ func (p *PreimageOracle) headerByBlockHash(blockHash common.Hash) *types.Header
 }
 
 func (p *PreimageOracle) HeaderByBlockHash(blockHash common.Hash) eth.BlockInfo {
-	return eth.HeaderBlockInfo(p.headerByBlockHash(blockHash))
+	return eth.HeaderInfo(p.headerByBlockHash(blockHash))
 }
 
 func (p *PreimageOracle) TransactionsByBlockHash(blockHash common.Hash) (eth.BlockInfo, types.Transactions) {
@@ -66,7 +66,7 @@ func (p *PreimageOracle) TransactionsByBlockHash(blockHash common.Hash) (eth.Blo
 		panic(fmt.Errorf("failed to decode list of txs: %w", err))
 	}
 
-	return eth.HeaderBlockInfo(header), txs
+	return eth.HeaderInfo(header), txs
 }
 
 func (p *PreimageOracle) ReceiptsByBlockHash(blockHash common.Hash) (eth.BlockInfo, types.Receipts) {
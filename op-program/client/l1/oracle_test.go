@@ -1,8 +1,8 @@
 package l1
 
 import (
-	"encoding/json"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -60,11 +60,10 @@ func testBlock(t *testing.T, block *types.Block, receipts []*types.Receipt) {
 	gotHeader := po.HeaderByBlockHash(block.Hash())
 	hints.AssertExpectations(t)
 
-	got, err := json.MarshalIndent(gotHeader, "  ", "  ")
+	require.Equal(t, block.Hash(), gotHeader.Hash())
+	gotHdrBytes, err := gotHeader.HeaderRLP()
 	require.NoError(t, err)
-	expected, err := json.MarshalIndent(block.Header(), "  ", "  ")
-	require.NoError(t, err)
-	require.Equal(t, expected, got, "expecting matching headers")
+	require.Equal(t, hdrBytes, gotHdrBytes, "expecting matching headers")
 
 	// Check if blocks with txs work
 	hints.On("hint", BlockHeaderHint(block.Hash()).Hint()).Once().Return()
@@ -93,6 +92,36 @@ func testBlock(t *testing.T, block *types.Block, receipts []*types.Receipt) {
 	}
 }
 
+func TestPreimageOracleCancunHeader(t *testing.T) {
+	blobGasUsed, excessBlobGas := uint64(0), uint64(0)
+	header := &eth.Header{
+		UncleHash:        types.EmptyUncleHash,
+		TxHash:           types.EmptyTxsHash,
+		ReceiptHash:      types.EmptyReceiptsHash,
+		Difficulty:       common.Big0,
+		Number:           big.NewInt(100),
+		Time:             1000,
+		BaseFee:          big.NewInt(7),
+		WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+		BlobGasUsed:      &blobGasUsed,
+		ExcessBlobGas:    &excessBlobGas,
+		ParentBeaconRoot: &common.Hash{0x42},
+	}
+	hdrBytes, err := header.RLP()
+	require.NoError(t, err)
+	po := &PreimageOracle{
+		oracle: preimage.OracleFn(func(key preimage.Key) []byte {
+			require.Equal(t, preimage.Keccak256Key(header.Hash()).PreimageKey(), key.PreimageKey())
+			return hdrBytes
+		}),
+		hint: preimage.HinterFn(func(v preimage.Hint) {}),
+	}
+
+	info := po.HeaderByBlockHash(header.Hash())
+	require.Equal(t, header.Hash(), info.Hash())
+	require.Equal(t, header.ParentBeaconRoot, info.ParentBeaconRoot())
+}
+
 func TestPreimageOracleBlockByHash(t *testing.T) {
 	rng := rand.New(rand.NewSource(123))
 
@@ -56,7 +56,10 @@ func TestFetchL1BlockHeader(t *testing.T) {
 
 		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
 		result := oracle.HeaderByBlockHash(hash)
-		require.Equal(t, eth.HeaderBlockInfo(block.Header()), result)
+		require.Equal(t, hash, result.Hash())
+		resultRLP, err := result.HeaderRLP()
+		require.NoError(t, err)
+		require.Equal(t, pre, resultRLP)
 	})
 
 	t.Run("Unknown", func(t *testing.T) {
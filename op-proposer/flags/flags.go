@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -50,6 +51,18 @@ var (
 		Usage:   "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
 		EnvVars: prefixEnvVars("ALLOW_NON_FINALIZED"),
 	}
+	CommitmentVerificationFlag = &cli.BoolFlag{
+		Name: "commitment-verification",
+		Usage: "Verify every L2 block in the proposal interval satisfied the sequencer commitments, " +
+			"and refuse to propose outputs covering a violation. Violations are only reported if the screening mode is observe. " +
+			"Requires --l2-eth-rpc.",
+		EnvVars: prefixEnvVars("COMMITMENT_VERIFICATION"),
+	}
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:    "l2-eth-rpc",
+		Usage:   "HTTP provider URL for L2 execution engine, used to fetch the L2 blocks for commitment verification",
+		EnvVars: prefixEnvVars("L2_ETH_RPC"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -63,6 +76,8 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
+	CommitmentVerificationFlag,
+	L2EthRpcFlag,
 	L2OutputHDPathFlag,
 }
 
@@ -72,6 +87,7 @@ func init() {
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, commitments.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -2,7 +2,9 @@ package metrics
 
 import (
 	"context"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,6 +29,11 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+
+	RecordCommitmentsVerified(block eth.BlockID)
+	RecordCommitmentViolation(block eth.BlockID)
+	RecordProposalRefused()
+	commitments.Metricer
 }
 
 type Metrics struct {
@@ -39,6 +46,13 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	commitmentsVerifiedNumber prometheus.Gauge
+	commitmentViolations      opmetrics.Event
+	commitmentViolationNumber prometheus.Gauge
+	proposalsRefused          opmetrics.Event
+	screeningResults          *prometheus.CounterVec
+	screeningDuration         prometheus.Histogram
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -72,6 +86,30 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+
+		commitmentsVerifiedNumber: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "commitments_verified_block_number",
+			Help:      "Number of the last L2 block that was verified to satisfy the sequencer commitments.",
+		}),
+		commitmentViolations: opmetrics.NewEvent(factory, ns, "", "commitment_violation", "Commitment violation"),
+		commitmentViolationNumber: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "commitment_violation_block_number",
+			Help:      "Number of the last L2 block that violated the sequencer commitments.",
+		}),
+		proposalsRefused: opmetrics.NewEvent(factory, ns, "", "proposal_refused", "Proposal refused on a commitment violation"),
+		screeningResults: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "screening_results_total",
+			Help:      "Count of commitment screening results.",
+		}, []string{"result"}),
+		screeningDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "screening_duration_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of commitment screening durations.",
+		}),
 	}
 }
 
@@ -105,6 +143,30 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordCommitmentsVerified should be called when an L2 block is verified
+// to satisfy the sequencer commitments.
+func (m *Metrics) RecordCommitmentsVerified(block eth.BlockID) {
+	m.commitmentsVerifiedNumber.Set(float64(block.Number))
+}
+
+// RecordCommitmentViolation should be called when an L2 block is found
+// to violate the sequencer commitments.
+func (m *Metrics) RecordCommitmentViolation(block eth.BlockID) {
+	m.commitmentViolations.Record()
+	m.commitmentViolationNumber.Set(float64(block.Number))
+}
+
+// RecordProposalRefused should be called when a proposal is refused,
+// because its interval covers a commitment violation.
+func (m *Metrics) RecordProposalRefused() {
+	m.proposalsRefused.Record()
+}
+
+func (m *Metrics) RecordScreeningResult(result string, duration time.Duration) {
+	m.screeningResults.WithLabelValues(result).Inc()
+	m.screeningDuration.Observe(duration.Seconds())
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
@@ -17,3 +19,8 @@ func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
+
+func (*noopMetrics) RecordCommitmentsVerified(block eth.BlockID) {}
+func (*noopMetrics) RecordCommitmentViolation(block eth.BlockID) {}
+func (*noopMetrics) RecordProposalRefused()                      {}
+func (*noopMetrics) RecordScreeningResult(string, time.Duration) {}
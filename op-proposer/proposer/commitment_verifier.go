@@ -0,0 +1,132 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrCommitmentViolation is returned when an L2 block in the proposal interval violates the sequencer commitments.
+var ErrCommitmentViolation = errors.New("L2 block violates sequencer commitments")
+
+// L2BlockSource fetches the L2 blocks to screen.
+type L2BlockSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// commitmentVerifier verifies that every L2 block in a proposal interval satisfied the sequencer commitments.
+// Verification progress is retained across polls, so each block is only screened once,
+// unless the L2 chain reorgs underneath the verified blocks.
+// Each block is screened against the sequencer and L1 state at its own L1 origin,
+// so the interval may span unsafe block signer changes.
+// In observe mode violations are reported, but do not refuse the proposal.
+type commitmentVerifier struct {
+	log      log.Logger
+	metr     metrics.Metricer
+	screener commitments.Screener
+	l2       L2BlockSource
+	genesis  *rollup.Genesis
+	observe  bool
+
+	// verified is the last block that was verified, all blocks before it up to the start of
+	// the interval were verified as well.
+	verified eth.BlockID
+	// violation is the last block that was found to violate the commitments, if any.
+	violation *eth.BlockID
+}
+
+func newCommitmentVerifier(l log.Logger, m metrics.Metricer, screener commitments.Screener, l2 L2BlockSource, genesis *rollup.Genesis, mode commitments.Mode) *commitmentVerifier {
+	return &commitmentVerifier{
+		log:      l,
+		metr:     m,
+		screener: screener,
+		l2:       l2,
+		genesis:  genesis,
+		observe:  mode == commitments.ModeObserve,
+	}
+}
+
+// Verify screens the L2 blocks from start up to and including end.
+// It returns an ErrCommitmentViolation if any block violates the commitments,
+// or another error if the blocks could not be screened (yet), in which case the proposal should be delayed.
+func (v *commitmentVerifier) Verify(ctx context.Context, start uint64, end eth.BlockID) error {
+	if v.violation != nil && v.violation.Number >= start && v.violation.Number <= end.Number {
+		block, err := v.l2.BlockByNumber(ctx, new(big.Int).SetUint64(v.violation.Number))
+		if err != nil {
+			return fmt.Errorf("fetching L2 block %d: %w", v.violation.Number, err)
+		}
+		if block.Hash() == v.violation.Hash {
+			return fmt.Errorf("%w: block %s", ErrCommitmentViolation, v.violation)
+		}
+		v.log.Warn("Commitment violation was reorged out", "violation", v.violation, "block", eth.ToBlockID(block))
+		v.violation = nil
+	}
+
+	next := start
+	var parent common.Hash
+	if v.verified != (eth.BlockID{}) && v.verified.Number+1 >= start &&
+		(v.verified.Number < end.Number || v.verified == end) {
+		next = v.verified.Number + 1
+		parent = v.verified.Hash
+	}
+	if next > end.Number {
+		return nil
+	}
+	for n := next; n <= end.Number; n++ {
+		block, err := v.l2.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return fmt.Errorf("fetching L2 block %d: %w", n, err)
+		}
+		id := eth.ToBlockID(block)
+		if parent != (common.Hash{}) && block.ParentHash() != parent {
+			// The L2 chain reorged underneath the verified blocks, start over from the beginning of the interval.
+			v.log.Warn("L2 reorg detected while verifying commitments, restarting", "block", id, "parent", block.ParentHash(), "verified", v.verified)
+			v.verified = eth.BlockID{}
+			return fmt.Errorf("L2 block %s does not build on verified block %s", id, parent)
+		}
+		if n == end.Number && id.Hash != end.Hash {
+			return fmt.Errorf("L2 block %s does not match proposal block %s", id, end)
+		}
+
+		ref, err := derive.L2BlockToBlockRef(block, v.genesis)
+		if err != nil {
+			return fmt.Errorf("determining L1 origin of L2 block %s: %w", id, err)
+		}
+		payload, err := eth.BlockAsPayload(block)
+		if err != nil {
+			return fmt.Errorf("converting L2 block %s to payload: %w", id, err)
+		}
+		sequencer, satisfied, err := commitments.ScreenAtOrigin(ctx, v.screener, ref.L1Origin, payload)
+		if err != nil {
+			return fmt.Errorf("screening L2 block %s: %w", id, err)
+		}
+		if !satisfied && !v.observe {
+			v.violation = &id
+			v.log.Error("L2 block violates sequencer commitments", "block", id, "l1_origin", ref.L1Origin, "sequencer", sequencer)
+			v.metr.RecordCommitmentViolation(id)
+			return fmt.Errorf("%w: block %s", ErrCommitmentViolation, id)
+		}
+		if !satisfied {
+			v.log.Warn("L2 block violates sequencer commitments, ignoring in observe mode", "block", id, "l1_origin", ref.L1Origin, "sequencer", sequencer)
+			v.metr.RecordCommitmentViolation(id)
+		} else {
+			v.log.Debug("L2 block satisfied sequencer commitments", "block", id)
+		}
+		v.verified = id
+		parent = id.Hash
+		v.metr.RecordCommitmentsVerified(id)
+	}
+	v.log.Info("Verified commitments of proposal interval", "start", start, "end", end)
+	return nil
+}
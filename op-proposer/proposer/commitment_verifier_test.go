@@ -0,0 +1,114 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var verifierTestGenesis = &rollup.Genesis{L2: eth.BlockID{Number: 0}}
+
+// fakeL2 serves the canonical L2 chain by number
+type fakeL2 map[uint64]*types.Block
+
+func (f fakeL2) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	b, ok := f[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return b, nil
+}
+
+// newVerifierTestChain creates a chain of n L2 blocks, with a new L1 origin every two blocks.
+func newVerifierTestChain(n uint64, extra byte) fakeL2 {
+	chain := make(fakeL2)
+	parent := common.Hash{}
+	for i := uint64(1); i <= n; i++ {
+		b := test.L2BlockWithOrigin(i, parent, i/2, extra)
+		chain[i] = b
+		parent = b.Hash()
+	}
+	return chain
+}
+
+func TestCommitmentVerifierScreensEachBlockOnce(t *testing.T) {
+	chain := newVerifierTestChain(10, 0)
+	screener := &testutils.FakeScreener{}
+	v := newCommitmentVerifier(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, chain, verifierTestGenesis, commitments.ModeEnforce)
+	ctx := context.Background()
+
+	require.NoError(t, v.Verify(ctx, 1, eth.ToBlockID(chain[5])))
+	require.Equal(t, 5, screener.Screened)
+
+	// verifying the same interval again does not screen again
+	require.NoError(t, v.Verify(ctx, 1, eth.ToBlockID(chain[5])))
+	require.Equal(t, 5, screener.Screened)
+
+	// the next interval continues from the verified blocks
+	require.NoError(t, v.Verify(ctx, 6, eth.ToBlockID(chain[10])))
+	require.Equal(t, 10, screener.Screened)
+
+	// each block was screened against the signer and L1 state of its own L1 origin
+	for i := uint64(1); i <= 10; i++ {
+		require.Equal(t, testutils.FakeScreening{L1BlockNum: i / 2, Sequencer: testutils.FakeScreenerSigner(i / 2)}, screener.ScreenedAt[chain[i].Hash()])
+	}
+}
+
+func TestCommitmentVerifierRefusesViolation(t *testing.T) {
+	chain := newVerifierTestChain(6, 0)
+	screener := &testutils.FakeScreener{Rejected: map[common.Hash]bool{chain[3].Hash(): true}}
+	v := newCommitmentVerifier(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, chain, verifierTestGenesis, commitments.ModeEnforce)
+	ctx := context.Background()
+
+	require.ErrorIs(t, v.Verify(ctx, 1, eth.ToBlockID(chain[6])), ErrCommitmentViolation)
+	require.Equal(t, 3, screener.Screened)
+
+	// stays refused, without screening the violation again
+	require.ErrorIs(t, v.Verify(ctx, 1, eth.ToBlockID(chain[6])), ErrCommitmentViolation)
+	require.Equal(t, 3, screener.Screened)
+
+	// once the violation is reorged out, the interval is verified on the new chain
+	alt := newVerifierTestChain(6, 1)
+	v.l2 = alt
+	err := v.Verify(ctx, 1, eth.ToBlockID(alt[6]))
+	require.Error(t, err, "the verified blocks were reorged out")
+	require.NotErrorIs(t, err, ErrCommitmentViolation)
+	require.NoError(t, v.Verify(ctx, 1, eth.ToBlockID(alt[6])))
+}
+
+func TestCommitmentVerifierRejectsMismatchedProposal(t *testing.T) {
+	chain := newVerifierTestChain(3, 0)
+	alt := newVerifierTestChain(3, 1)
+	screener := &testutils.FakeScreener{}
+	v := newCommitmentVerifier(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, chain, verifierTestGenesis, commitments.ModeEnforce)
+
+	err := v.Verify(context.Background(), 1, eth.ToBlockID(alt[3]))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCommitmentViolation)
+}
+
+func TestCommitmentVerifierObserveMode(t *testing.T) {
+	chain := newVerifierTestChain(6, 0)
+	screener := &testutils.FakeScreener{Rejected: map[common.Hash]bool{chain[3].Hash(): true}}
+	v := newCommitmentVerifier(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, screener, chain, verifierTestGenesis, commitments.ModeObserve)
+	ctx := context.Background()
+
+	// the violation is reported, but does not refuse the proposal
+	require.NoError(t, v.Verify(ctx, 1, eth.ToBlockID(chain[5])))
+	require.Equal(t, 5, screener.Screened)
+	require.Nil(t, v.violation)
+}
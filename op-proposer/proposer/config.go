@@ -1,15 +1,18 @@
 package proposer
 
 import (
+	"errors"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -27,6 +30,16 @@ type Config struct {
 	L1Client           *ethclient.Client
 	RollupClient       *sources.RollupClient
 	AllowNonFinalized  bool
+
+	// Screener, L2Client and RollupGenesis are optional. If all are set, every L2 block in the proposal interval
+	// is verified to satisfy the sequencer commitments before the output is proposed.
+	// RollupGenesis is used to determine the L1 origin of the L2 blocks, which they are screened against.
+	Screener      commitments.Screener
+	L2Client      L2BlockSource
+	RollupGenesis *rollup.Genesis
+	// ScreeningMode determines how violations are handled: in observe mode they are only reported.
+	// Defaults to enforce if not set.
+	ScreeningMode commitments.Mode
 }
 
 // CLIConfig is a well typed config that is parsed from the CLI params.
@@ -52,6 +65,14 @@ type CLIConfig struct {
 	// for L2 blocks derived from non-finalized L1 data.
 	AllowNonFinalized bool
 
+	// CommitmentVerification enables the verification of the sequencer commitments
+	// of every L2 block in the proposal interval.
+	CommitmentVerification bool
+
+	// L2EthRpc is the HTTP provider URL for the L2 execution engine.
+	// It is required if CommitmentVerification is enabled.
+	L2EthRpc string
+
 	TxMgrConfig txmgr.CLIConfig
 
 	RPCConfig oprpc.CLIConfig
@@ -61,9 +82,14 @@ type CLIConfig struct {
 	MetricsConfig opmetrics.CLIConfig
 
 	PprofConfig oppprof.CLIConfig
+
+	ScreeningConfig commitments.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
+	if c.CommitmentVerification && c.L2EthRpc == "" {
+		return errors.New("commitment verification requires an L2 execution engine RPC")
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -79,6 +105,9 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.ScreeningConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -92,10 +121,13 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
-		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
-		RPCConfig:         oprpc.ReadCLIConfig(ctx),
-		LogConfig:         oplog.ReadCLIConfig(ctx),
-		MetricsConfig:     opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:       oppprof.ReadCLIConfig(ctx),
+		AllowNonFinalized:      ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		CommitmentVerification: ctx.Bool(flags.CommitmentVerificationFlag.Name),
+		L2EthRpc:               ctx.String(flags.L2EthRpcFlag.Name),
+		RPCConfig:              oprpc.ReadCLIConfig(ctx),
+		LogConfig:              oplog.ReadCLIConfig(ctx),
+		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:            oppprof.ReadCLIConfig(ctx),
+		ScreeningConfig:        commitments.ReadCLIConfig(ctx),
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/commitments"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
@@ -130,6 +131,9 @@ type L2OutputSubmitter struct {
 	// is never valid on an alternative L1 chain that would produce different L2 data.
 	// This option is not necessary when higher proposal latency is acceptable and L1 is healthy.
 	allowNonFinalized bool
+	// verifier is optional, if set every L2 block in the proposal interval
+	// is verified to satisfy the sequencer commitments before it is proposed.
+	verifier *commitmentVerifier
 	// How frequently to poll L2 for new finalized outputs
 	pollInterval   time.Duration
 	networkTimeout time.Duration
@@ -167,7 +171,7 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		return nil, err
 	}
 
-	return &Config{
+	proposerCfg := &Config{
 		L2OutputOracleAddr: l2ooAddress,
 		PollInterval:       cfg.PollInterval,
 		NetworkTimeout:     cfg.TxMgrConfig.NetworkTimeout,
@@ -175,7 +179,39 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		RollupClient:       rollupClient,
 		AllowNonFinalized:  cfg.AllowNonFinalized,
 		TxManager:          txManager,
-	}, nil
+	}
+
+	screeningCfg, err := commitments.NewConfig(cfg.ScreeningConfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading screening config: %w", err)
+	}
+	if cfg.CommitmentVerification && screeningCfg.Mode == commitments.ModeDisabled {
+		l.Warn("commitment verification is enabled, but screening is disabled")
+	} else if cfg.CommitmentVerification {
+		l2Client, err := opclient.DialEthClientWithTimeout(opclient.DefaultDialTimeout, l, cfg.L2EthRpc)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.TxMgrConfig.NetworkTimeout)
+		defer cancel()
+		rcfg, err := rollupClient.RollupConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("querying rollup config: %w", err)
+		}
+		screener, err := commitments.NewSystemConfigScreener(rcfg.L1SystemConfigAddress, l1Client)
+		if err != nil {
+			return nil, fmt.Errorf("creating commitment screener: %w", err)
+		}
+		if screeningCfg.StateManifest != nil {
+			screener = screener.WithStateProofs(commitments.NewEthStateProofSource(l2Client), screeningCfg.StateManifest)
+		}
+		proposerCfg.Screener = screener
+		proposerCfg.L2Client = l2Client
+		proposerCfg.RollupGenesis = &rcfg.Genesis
+		proposerCfg.ScreeningMode = screeningCfg.Mode
+	}
+
+	return proposerCfg, nil
 
 }
 
@@ -204,6 +240,12 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 		return nil, err
 	}
 
+	var verifier *commitmentVerifier
+	if cfg.Screener != nil && cfg.L2Client != nil && cfg.RollupGenesis != nil {
+		l.Info("commitment verification enabled", "mode", cfg.ScreeningMode)
+		verifier = newCommitmentVerifier(l, m, commitments.WithMetrics(cfg.Screener, m), cfg.L2Client, cfg.RollupGenesis, cfg.ScreeningMode)
+	}
+
 	return &L2OutputSubmitter{
 		txMgr:  cfg.TxManager,
 		done:   make(chan struct{}),
@@ -219,6 +261,7 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 		l2ooABI:          parsed,
 
 		allowNonFinalized: cfg.AllowNonFinalized,
+		verifier:          verifier,
 		pollInterval:      cfg.PollInterval,
 		networkTimeout:    cfg.NetworkTimeout,
 	}, nil
@@ -272,7 +315,43 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 		return nil, false, nil
 	}
 
-	return l.fetchOutput(ctx, nextCheckpointBlock)
+	output, shouldPropose, err := l.fetchOutput(ctx, nextCheckpointBlock)
+	if err != nil || !shouldPropose || l.verifier == nil {
+		return output, shouldPropose, err
+	}
+	if err := l.verifyCommitments(ctx, output); err != nil {
+		return nil, false, err
+	}
+	return output, true, nil
+}
+
+// verifyCommitments verifies every L2 block since the latest proposal up to and including the output block
+// satisfied the sequencer commitments. Proposals covering a violation are refused.
+func (l *L2OutputSubmitter) verifyCommitments(ctx context.Context, output *eth.OutputResponse) error {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	latestBlock, err := l.l2ooContract.LatestBlockNumber(&bind.CallOpts{
+		From:    l.txMgr.From(),
+		Context: cCtx,
+	})
+	if err != nil {
+		l.log.Error("proposer unable to get latest block number", "err", err)
+		return err
+	}
+	start := latestBlock.Uint64() + 1
+
+	err = l.verifier.Verify(ctx, start, output.BlockRef.ID())
+	if errors.Is(err, ErrCommitmentViolation) {
+		l.log.Error("refusing to propose output covering a commitment violation",
+			"err", err, "start", start, "l2_proposal", output.BlockRef)
+		l.metr.RecordProposalRefused()
+		return err
+	} else if err != nil {
+		l.log.Warn("delaying proposal, unable to verify commitments",
+			"err", err, "start", start, "l2_proposal", output.BlockRef)
+		return err
+	}
+	return nil
 }
 
 func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*eth.OutputResponse, bool, error) {
@@ -0,0 +1,103 @@
+// Package commitments screens L2 blocks against the commitments of their sequencer,
+// as registered with the L1 SystemConfig contract.
+package commitments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrFailedScreening is returned when a block does not satisfy the sequencer commitments.
+var ErrFailedScreening = errors.New("Failed_Screening")
+
+// BlockTarget is the commitment target that L2 blocks are screened against.
+// This is the zero target: the op-node historically derived it from a 0x-prefixed hex literal,
+// which does not decode, and the registered commitments depend on it.
+var BlockTarget [32]byte
+
+// Screener screens L2 blocks against the commitments of the sequencer.
+type Screener interface {
+	// Screen returns whether the payload satisfies the commitments of the sequencer.
+	// The commitments are evaluated at the given L1 block number, or at the latest L1 block if nil.
+	Screen(ctx context.Context, l1BlockNum *big.Int, sequencer common.Address, payload *eth.ExecutionPayload) (bool, error)
+	// UnsafeBlockSigner returns the sequencer that signs unsafe blocks, as configured at the given L1 block number,
+	// or at the latest L1 block if nil.
+	UnsafeBlockSigner(ctx context.Context, l1BlockNum *big.Int) (common.Address, error)
+}
+
+// ScreenAtOrigin screens the payload against the commitments of the sequencer that was configured
+// at the L1 origin of the payload, evaluated in the L1 state of that same L1 block.
+// This attributes the payload to the sequencer that produced it, even if the signer changed since.
+// It returns the sequencer the payload was screened against.
+func ScreenAtOrigin(ctx context.Context, s Screener, l1Origin eth.BlockID, payload *eth.ExecutionPayload) (common.Address, bool, error) {
+	l1BlockNum := new(big.Int).SetUint64(l1Origin.Number)
+	sequencer, err := s.UnsafeBlockSigner(ctx, l1BlockNum)
+	if err != nil {
+		return common.Address{}, false, fmt.Errorf("getting sequencer address at L1 origin %s: %w", l1Origin, err)
+	}
+	satisfied, err := s.Screen(ctx, l1BlockNum, sequencer, payload)
+	return sequencer, satisfied, err
+}
+
+// SystemConfigScreener screens L2 blocks by calling the L1 SystemConfig contract.
+type SystemConfigScreener struct {
+	sysCfg *bindings.SystemConfigCaller
+
+	// proofs and manifest are optional, if set the payload is encoded together with
+	// proofs of the L2 state that the commitments in the manifest depend on.
+	proofs   StateProofSource
+	manifest *StateManifest
+}
+
+var _ Screener = (*SystemConfigScreener)(nil)
+
+func NewSystemConfigScreener(systemConfigAddr common.Address, caller bind.ContractCaller) (*SystemConfigScreener, error) {
+	sysCfg, err := bindings.NewSystemConfigCaller(systemConfigAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+	return &SystemConfigScreener{sysCfg: sysCfg}, nil
+}
+
+func (s *SystemConfigScreener) Screen(ctx context.Context, l1BlockNum *big.Int, sequencer common.Address, payload *eth.ExecutionPayload) (bool, error) {
+	var payloadBytes []byte
+	var err error
+	if s.manifest != nil && len(s.manifest.Commitments) > 0 && s.proofs != nil {
+		payloadBytes, err = s.encodePayloadWithState(ctx, payload)
+	} else {
+		payloadBytes, err = EncodePayload(payload)
+	}
+	if err != nil {
+		return false, err
+	}
+	return s.sysCfg.Screen(&bind.CallOpts{Context: ctx, BlockNumber: l1BlockNum}, sequencer, BlockTarget, payloadBytes)
+}
+
+// WithStateProofs returns a copy of the screener that attaches proofs of the L2 state
+// requested by the commitments in the manifest, fetched from the given source.
+func (s *SystemConfigScreener) WithStateProofs(proofs StateProofSource, manifest *StateManifest) *SystemConfigScreener {
+	out := *s
+	out.proofs = proofs
+	out.manifest = manifest
+	return &out
+}
+
+func (s *SystemConfigScreener) encodePayloadWithState(ctx context.Context, payload *eth.ExecutionPayload) ([]byte, error) {
+	parentRoot, proofs, err := FetchStateProofs(ctx, s.proofs, payload.ParentHash, s.manifest.Requests())
+	if err != nil {
+		return nil, err
+	}
+	return EncodePayloadWithState(payload, parentRoot, proofs)
+}
+
+func (s *SystemConfigScreener) UnsafeBlockSigner(ctx context.Context, l1BlockNum *big.Int) (common.Address, error) {
+	return s.sysCfg.UnsafeBlockSigner(&bind.CallOpts{Context: ctx, BlockNumber: l1BlockNum})
+}
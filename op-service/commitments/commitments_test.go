@@ -0,0 +1,141 @@
+package commitments
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	// returnSlotZero returns the value of storage slot 0 for any call,
+	// standing in for the SystemConfig screen and unsafeBlockSigner methods.
+	// PUSH1 0 SLOAD PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	returnSlotZero = common.FromHex("0x60005460005260206000f3")
+	// revertAll reverts any call.
+	// PUSH1 0 DUP1 REVERT
+	revertAll = common.FromHex("0x600080fd")
+
+	satisfiedAddr = common.Address{0x01}
+	violatedAddr  = common.Address{0x02}
+	revertAddr    = common.Address{0x03}
+)
+
+func newSimulatedBackend(t *testing.T) *backends.SimulatedBackend {
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		satisfiedAddr: {Code: returnSlotZero, Storage: map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(1))}, Balance: new(big.Int)},
+		violatedAddr:  {Code: returnSlotZero, Balance: new(big.Int)},
+		revertAddr:    {Code: revertAll, Balance: new(big.Int)},
+	}, 30_000_000)
+	t.Cleanup(func() {
+		_ = backend.Close()
+	})
+	return backend
+}
+
+func testPayload(rng *rand.Rand) *eth.ExecutionPayload {
+	return &eth.ExecutionPayload{
+		ParentHash:   testutils.RandomHash(rng),
+		FeeRecipient: testutils.RandomAddress(rng),
+		BlockNumber:  eth.Uint64Quantity(rng.Uint64()),
+		BlockHash:    testutils.RandomHash(rng),
+		Transactions: []eth.Data{{0xaa, 0xbb}},
+	}
+}
+
+func TestSystemConfigScreener(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	backend := newSimulatedBackend(t)
+	ctx := context.Background()
+	payload := testPayload(rng)
+	sequencer := testutils.RandomAddress(rng)
+
+	satisfied, err := NewSystemConfigScreener(satisfiedAddr, backend)
+	require.NoError(t, err)
+	ok, err := satisfied.Screen(ctx, nil, sequencer, payload)
+	require.NoError(t, err)
+	require.True(t, ok)
+	signer, err := satisfied.UnsafeBlockSigner(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, common.BigToAddress(big.NewInt(1)), signer)
+
+	violated, err := NewSystemConfigScreener(violatedAddr, backend)
+	require.NoError(t, err)
+	ok, err = violated.Screen(ctx, nil, sequencer, payload)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	reverted, err := NewSystemConfigScreener(revertAddr, backend)
+	require.NoError(t, err)
+	_, err = reverted.Screen(ctx, nil, sequencer, payload)
+	require.Error(t, err)
+}
+
+func TestSystemConfigScreenerWithStateProofs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	backend := newSimulatedBackend(t)
+	ctx := context.Background()
+	payload := testPayload(rng)
+	sequencer := testutils.RandomAddress(rng)
+
+	screener, err := NewSystemConfigScreener(satisfiedAddr, backend)
+	require.NoError(t, err)
+	src := &stubProofSource{info: testutils.RandomBlockInfo(rng), err: errors.New("unavailable")}
+
+	// without commitments in the manifest, no state is fetched
+	ok, err := screener.WithStateProofs(src, &StateManifest{}).Screen(ctx, nil, sequencer, payload)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	manifest := &StateManifest{Commitments: map[common.Address][]SlotRequest{
+		{0x01}: {{Account: common.Address{0xaa}}},
+	}}
+	_, err = screener.WithStateProofs(src, manifest).Screen(ctx, nil, sequencer, payload)
+	require.ErrorIs(t, err, src.err)
+
+	// the original screener is not modified
+	ok, err = screener.Screen(ctx, nil, sequencer, payload)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+type recordingMetrics struct {
+	results []string
+}
+
+func (m *recordingMetrics) RecordScreeningResult(result string, duration time.Duration) {
+	m.results = append(m.results, result)
+}
+
+func TestWithMetrics(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	backend := newSimulatedBackend(t)
+	ctx := context.Background()
+	payload := testPayload(rng)
+	m := &recordingMetrics{}
+
+	for _, addr := range []common.Address{satisfiedAddr, violatedAddr, revertAddr} {
+		s, err := NewSystemConfigScreener(addr, backend)
+		require.NoError(t, err)
+		_, _ = WithMetrics(s, m).Screen(ctx, nil, common.Address{}, payload)
+	}
+	require.Equal(t, []string{ResultSatisfied, ResultViolated, ResultFailed}, m.results)
+}
+
+func TestResult(t *testing.T) {
+	require.Equal(t, ResultSatisfied, Result(true, nil))
+	require.Equal(t, ResultViolated, Result(false, nil))
+	require.Equal(t, ResultViolated, Result(false, ErrFailedScreening))
+	require.Equal(t, ResultTimeout, Result(false, context.DeadlineExceeded))
+	require.Equal(t, ResultFailed, Result(false, errors.New("boom")))
+}
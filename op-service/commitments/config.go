@@ -0,0 +1,120 @@
+package commitments
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	ModeFlagName          = "screening.mode"
+	StateManifestFlagName = "screening.state-manifest"
+)
+
+// Mode determines how L2 blocks are screened against the sequencer commitments.
+type Mode string
+
+const (
+	// ModeEnforce drops L2 blocks that violate the sequencer commitments.
+	ModeEnforce Mode = "enforce"
+	// ModeObserve screens L2 blocks and reports violations, but does not drop them.
+	ModeObserve Mode = "observe"
+	// ModeDisabled does not screen L2 blocks.
+	ModeDisabled Mode = "disabled"
+)
+
+var Modes = []Mode{
+	ModeEnforce,
+	ModeObserve,
+	ModeDisabled,
+}
+
+func (m Mode) String() string {
+	return string(m)
+}
+
+// Check verifies the screening mode is known. The zero value is accepted, and defaults to enforce.
+func (m Mode) Check() error {
+	if m == "" {
+		return nil
+	}
+	for _, k := range Modes {
+		if k == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown screening mode: %q", string(m))
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    ModeFlagName,
+			Usage:   "How L2 blocks are screened against the sequencer commitments. Valid options: enforce, observe, disabled",
+			Value:   ModeEnforce.String(),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "SCREENING_MODE"),
+		},
+		&cli.StringFlag{
+			Name: StateManifestFlagName,
+			Usage: "Path to a JSON manifest of the L2 accounts and storage slots that commitments depend on. " +
+				"If set, screened payloads carry proofs of the requested state against the parent state root",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "SCREENING_STATE_MANIFEST"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	Mode          string // Screening mode: enforce, observe, disabled. Capitals are accepted too.
+	StateManifest string // Path to the state manifest, optional
+}
+
+func (c CLIConfig) Check() error {
+	return Mode(strings.ToLower(c.Mode)).Check()
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Mode:          ctx.String(ModeFlagName),
+		StateManifest: ctx.String(StateManifestFlagName),
+	}
+}
+
+// Config is the screening configuration, as used by the services.
+type Config struct {
+	// Mode determines how L2 blocks are screened. Defaults to enforce if not set.
+	Mode Mode
+	// StateManifest is optional, if set screened payloads carry proofs
+	// of the L2 state that the commitments in the manifest depend on.
+	StateManifest *StateManifest
+}
+
+func (c *Config) Check() error {
+	if err := c.Mode.Check(); err != nil {
+		return err
+	}
+	if c.StateManifest != nil {
+		if err := c.StateManifest.Check(); err != nil {
+			return fmt.Errorf("state manifest error: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewConfig loads the screening configuration from the CLI config.
+func NewConfig(c CLIConfig) (Config, error) {
+	if err := c.Check(); err != nil {
+		return Config{}, err
+	}
+	cfg := Config{Mode: Mode(strings.ToLower(c.Mode))}
+	if c.StateManifest != "" {
+		m, err := LoadStateManifest(c.StateManifest)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.StateManifest = m
+	}
+	return cfg, nil
+}
@@ -0,0 +1,31 @@
+package commitments
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	cfg, err := NewConfig(CLIConfig{Mode: "Observe"})
+	require.NoError(t, err)
+	require.Equal(t, ModeObserve, cfg.Mode)
+	require.Nil(t, cfg.StateManifest)
+
+	_, err = NewConfig(CLIConfig{Mode: "strict"})
+	require.ErrorContains(t, err, "unknown screening mode")
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"commitments": {
+		"0x0000000000000000000000000000000000000001": [{"account": "0x00000000000000000000000000000000000000aa"}]
+	}}`), 0644))
+	cfg, err = NewConfig(CLIConfig{Mode: "enforce", StateManifest: path})
+	require.NoError(t, err)
+	require.NoError(t, cfg.Check())
+	require.Len(t, cfg.StateManifest.Requests(), 1)
+
+	_, err = NewConfig(CLIConfig{StateManifest: filepath.Join(t.TempDir(), "missing.json")})
+	require.Error(t, err)
+}
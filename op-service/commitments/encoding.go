@@ -0,0 +1,85 @@
+package commitments
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var payloadType = func() abi.Type {
+	structExecutionPayload, _ := abi.NewType("tuple", "struct ExecutionPayload", []abi.ArgumentMarshaling{
+		{Name: "ParentHash", Type: "bytes32"},
+		{Name: "FeeRecipient", Type: "address"},
+		{Name: "StateRoot", Type: "bytes32"},
+		{Name: "ReceiptsRoot", Type: "bytes32"},
+		{Name: "LogsBloom", Type: "bytes"},
+		{Name: "PrevRandao", Type: "bytes32"},
+		{Name: "BlockNumber", Type: "uint64"},
+		{Name: "GasLimit", Type: "uint64"},
+		{Name: "GasUsed", Type: "uint64"},
+		{Name: "Timestamp", Type: "uint64"},
+		{Name: "ExtraData", Type: "bytes"},
+		{Name: "BaseFeePerGas", Type: "bytes32"},
+		{Name: "BlockHash", Type: "bytes32"},
+		{Name: "Transactions", Type: "bytes"},
+	})
+	return structExecutionPayload
+}()
+
+var payloadArgs = abi.Arguments{
+	{Type: payloadType, Name: "param_one"},
+}
+
+type payloadArg struct {
+	ParentHash    common.Hash
+	FeeRecipient  common.Address
+	StateRoot     [32]byte
+	ReceiptsRoot  [32]byte
+	LogsBloom     []byte
+	PrevRandao    [32]byte
+	BlockNumber   hexutil.Uint64
+	GasLimit      hexutil.Uint64
+	GasUsed       hexutil.Uint64
+	Timestamp     hexutil.Uint64
+	ExtraData     []byte
+	BaseFeePerGas [32]byte
+	BlockHash     common.Hash
+	Transactions  []byte
+}
+
+func toPayloadArg(payload *eth.ExecutionPayload) *payloadArg {
+	return &payloadArg{
+		payload.ParentHash,
+		payload.FeeRecipient,
+		payload.StateRoot,
+		payload.ReceiptsRoot,
+		[]byte(payload.LogsBloom.String()),
+		payload.PrevRandao,
+		hexutil.Uint64(payload.BlockNumber),
+		hexutil.Uint64(payload.GasLimit),
+		hexutil.Uint64(payload.GasUsed),
+		hexutil.Uint64(payload.Timestamp),
+		payload.ExtraData,
+		payload.BaseFeePerGas.Bytes32(),
+		payload.BlockHash,
+		encodeTransactions(payload.Transactions),
+	}
+}
+
+// EncodePayload ABI-encodes the payload, as expected by the commitments.
+func EncodePayload(payload *eth.ExecutionPayload) ([]byte, error) {
+	return payloadArgs.Pack(toPayloadArg(payload))
+}
+
+func encodeTransactions(txs []eth.Data) []byte {
+	var res [][]byte
+	for _, v := range txs {
+		res = append(res, v)
+	}
+
+	return bytes.Join(res, nil)
+}
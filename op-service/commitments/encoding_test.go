@@ -0,0 +1,62 @@
+package commitments
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type decodedPayload struct {
+	ParentHash    [32]byte
+	FeeRecipient  common.Address
+	StateRoot     [32]byte
+	ReceiptsRoot  [32]byte
+	LogsBloom     []byte
+	PrevRandao    [32]byte
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte
+	BaseFeePerGas [32]byte
+	BlockHash     [32]byte
+	Transactions  []byte
+}
+
+func TestEncodePayload(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	payload := &eth.ExecutionPayload{
+		ParentHash:   testutils.RandomHash(rng),
+		FeeRecipient: testutils.RandomAddress(rng),
+		StateRoot:    eth.Bytes32(testutils.RandomHash(rng)),
+		BlockNumber:  eth.Uint64Quantity(rng.Uint64()),
+		GasLimit:     eth.Uint64Quantity(30_000_000),
+		Timestamp:    eth.Uint64Quantity(rng.Uint64()),
+		ExtraData:    eth.BytesMax32{0x01, 0x02},
+		BlockHash:    testutils.RandomHash(rng),
+		Transactions: []eth.Data{{0xaa, 0xbb}, {0xcc}},
+	}
+
+	encoded, err := EncodePayload(payload)
+	require.NoError(t, err)
+
+	values, err := payloadArgs.Unpack(encoded)
+	require.NoError(t, err)
+	decoded := abi.ConvertType(values[0], new(decodedPayload)).(*decodedPayload)
+
+	require.Equal(t, [32]byte(payload.ParentHash), decoded.ParentHash)
+	require.Equal(t, payload.FeeRecipient, decoded.FeeRecipient)
+	require.Equal(t, [32]byte(payload.StateRoot), decoded.StateRoot)
+	require.Equal(t, uint64(payload.BlockNumber), decoded.BlockNumber)
+	require.Equal(t, uint64(payload.GasLimit), decoded.GasLimit)
+	require.Equal(t, uint64(payload.Timestamp), decoded.Timestamp)
+	require.Equal(t, []byte{0x01, 0x02}, decoded.ExtraData)
+	require.Equal(t, [32]byte(payload.BlockHash), decoded.BlockHash)
+	require.Equal(t, []byte{0xaa, 0xbb, 0xcc}, decoded.Transactions, "transactions are concatenated")
+}
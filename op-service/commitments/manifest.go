@@ -0,0 +1,91 @@
+package commitments
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SlotRequest requests a proof of an L2 account, and optionally of some of its storage slots.
+type SlotRequest struct {
+	Account common.Address `json:"account"`
+	Slots   []common.Hash  `json:"slots,omitempty"`
+}
+
+// StateManifest lists the L2 state that commitments depend on, keyed by the address of the commitment contract.
+// All commitments of a sequencer are evaluated in a single screening,
+// so the screened payload carries the proofs requested by all commitments in the manifest.
+type StateManifest struct {
+	Commitments map[common.Address][]SlotRequest `json:"commitments"`
+}
+
+// LoadStateManifest reads a JSON state manifest from the given path.
+func LoadStateManifest(path string) (*StateManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state manifest: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m StateManifest
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode state manifest: %w", err)
+	}
+	if err := m.Check(); err != nil {
+		return nil, fmt.Errorf("invalid state manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Check verifies the manifest does not contain empty requests.
+func (m *StateManifest) Check() error {
+	for commitment, requests := range m.Commitments {
+		if len(requests) == 0 {
+			return fmt.Errorf("commitment %s does not request any state", commitment)
+		}
+		for _, req := range requests {
+			if req.Account == (common.Address{}) {
+				return fmt.Errorf("commitment %s requests the state of the zero account", commitment)
+			}
+		}
+	}
+	return nil
+}
+
+// Requests merges the requests of all commitments, one request per account.
+// Accounts and slots are sorted, so commitments can locate their proofs deterministically.
+func (m *StateManifest) Requests() []SlotRequest {
+	slots := make(map[common.Address]map[common.Hash]struct{})
+	for _, requests := range m.Commitments {
+		for _, req := range requests {
+			set, ok := slots[req.Account]
+			if !ok {
+				set = make(map[common.Hash]struct{})
+				slots[req.Account] = set
+			}
+			for _, slot := range req.Slots {
+				set[slot] = struct{}{}
+			}
+		}
+	}
+
+	out := make([]SlotRequest, 0, len(slots))
+	for account, set := range slots {
+		req := SlotRequest{Account: account}
+		for slot := range set {
+			req.Slots = append(req.Slots, slot)
+		}
+		sort.Slice(req.Slots, func(i, j int) bool {
+			return bytes.Compare(req.Slots[i][:], req.Slots[j][:]) < 0
+		})
+		out = append(out, req)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].Account[:], out[j].Account[:]) < 0
+	})
+	return out
+}
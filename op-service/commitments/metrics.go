@@ -0,0 +1,64 @@
+package commitments
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Screening results, as recorded in the metrics.
+const (
+	ResultSatisfied = "satisfied"
+	ResultViolated  = "violated"
+	ResultTimeout   = "timeout"
+	ResultFailed    = "failed"
+)
+
+// Metricer records the outcome of screenings.
+type Metricer interface {
+	RecordScreeningResult(result string, duration time.Duration)
+}
+
+type noopMetrics struct{}
+
+var NoopMetrics Metricer = new(noopMetrics)
+
+func (*noopMetrics) RecordScreeningResult(result string, duration time.Duration) {}
+
+// Result classifies the outcome of a screening.
+func Result(satisfied bool, err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ResultTimeout
+	case errors.Is(err, ErrFailedScreening):
+		return ResultViolated
+	case err != nil:
+		return ResultFailed
+	case !satisfied:
+		return ResultViolated
+	default:
+		return ResultSatisfied
+	}
+}
+
+type meteredScreener struct {
+	Screener
+	m Metricer
+}
+
+// WithMetrics returns a screener that records the outcome and duration of every screening.
+func WithMetrics(s Screener, m Metricer) Screener {
+	return &meteredScreener{Screener: s, m: m}
+}
+
+func (s *meteredScreener) Screen(ctx context.Context, l1BlockNum *big.Int, sequencer common.Address, payload *eth.ExecutionPayload) (bool, error) {
+	start := time.Now()
+	satisfied, err := s.Screener.Screen(ctx, l1BlockNum, sequencer, payload)
+	s.m.RecordScreeningResult(Result(satisfied, err), time.Since(start))
+	return satisfied, err
+}
@@ -0,0 +1,167 @@
+package commitments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// StateProofSource fetches proofs of the L2 state, e.g. the sources.L2Client.
+type StateProofSource interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// ethStateProofSource fetches proofs of the L2 state through a plain L2 execution engine RPC client,
+// for services that do not use the sources.L2Client.
+type ethStateProofSource struct {
+	client *ethclient.Client
+}
+
+// NewEthStateProofSource returns a StateProofSource backed by the given L2 execution engine RPC client.
+func NewEthStateProofSource(client *ethclient.Client) StateProofSource {
+	return &ethStateProofSource{client: client}
+}
+
+func (s *ethStateProofSource) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	header, err := s.client.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return eth.HeaderBlockInfo(header), nil
+}
+
+func (s *ethStateProofSource) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	var res *eth.AccountResult
+	if err := s.client.Client().CallContext(ctx, &res, "eth_getProof", address, storage, blockTag); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ethereum.NotFound
+	}
+	if len(res.StorageProof) != len(storage) {
+		return nil, fmt.Errorf("missing storage proof data, got %d proof entries but requested %d storage keys", len(res.StorageProof), len(storage))
+	}
+	return res, nil
+}
+
+// ErrParentStateUnavailable is returned when the state of the parent block is not available yet,
+// e.g. when the engine has not processed the parent of a gossiped payload while catching up.
+// It wraps ethereum.NotFound, and the screening may be retried later.
+var ErrParentStateUnavailable = fmt.Errorf("parent state is not available: %w", ethereum.NotFound)
+
+var stateProofType = func() abi.Type {
+	structStateProof, _ := abi.NewType("tuple[]", "struct StateProof[]", []abi.ArgumentMarshaling{
+		{Name: "Account", Type: "address"},
+		{Name: "Nonce", Type: "uint64"},
+		{Name: "Balance", Type: "uint256"},
+		{Name: "StorageHash", Type: "bytes32"},
+		{Name: "CodeHash", Type: "bytes32"},
+		{Name: "AccountProof", Type: "bytes[]"},
+		{Name: "Storage", Type: "tuple[]", InternalType: "struct StorageProof[]", Components: []abi.ArgumentMarshaling{
+			{Name: "Key", Type: "bytes32"},
+			{Name: "Value", Type: "uint256"},
+			{Name: "Proof", Type: "bytes[]"},
+		}},
+	})
+	return structStateProof
+}()
+
+var bytes32Type, _ = abi.NewType("bytes32", "", nil)
+
+var payloadWithStateArgs = abi.Arguments{
+	{Type: payloadType, Name: "payload"},
+	{Type: bytes32Type, Name: "parentStateRoot"},
+	{Type: stateProofType, Name: "proofs"},
+}
+
+type storageProofArg struct {
+	Key   [32]byte
+	Value *big.Int
+	Proof [][]byte
+}
+
+type stateProofArg struct {
+	Account      common.Address
+	Nonce        uint64
+	Balance      *big.Int
+	StorageHash  [32]byte
+	CodeHash     [32]byte
+	AccountProof [][]byte
+	Storage      []storageProofArg
+}
+
+func toStateProofArg(res *eth.AccountResult) stateProofArg {
+	out := stateProofArg{
+		Account:      res.Address,
+		Nonce:        uint64(res.Nonce),
+		Balance:      new(big.Int),
+		StorageHash:  res.StorageHash,
+		CodeHash:     res.CodeHash,
+		AccountProof: make([][]byte, 0, len(res.AccountProof)),
+		Storage:      make([]storageProofArg, 0, len(res.StorageProof)),
+	}
+	if res.Balance != nil {
+		out.Balance = res.Balance.ToInt()
+	}
+	for _, node := range res.AccountProof {
+		out.AccountProof = append(out.AccountProof, node)
+	}
+	for _, entry := range res.StorageProof {
+		proof := make([][]byte, 0, len(entry.Proof))
+		for _, node := range entry.Proof {
+			proof = append(proof, node)
+		}
+		out.Storage = append(out.Storage, storageProofArg{
+			Key:   entry.Key,
+			Value: entry.Value.ToInt(),
+			Proof: proof,
+		})
+	}
+	return out
+}
+
+// EncodePayloadWithState ABI-encodes the payload together with proofs of the L2 state it executes on,
+// as expected by commitments that depend on L2 state:
+// abi.encode(ExecutionPayload payload, bytes32 parentStateRoot, StateProof[] proofs).
+// The proofs are made against the state root of the parent block.
+func EncodePayloadWithState(payload *eth.ExecutionPayload, parentStateRoot common.Hash, proofs []*eth.AccountResult) ([]byte, error) {
+	args := make([]stateProofArg, 0, len(proofs))
+	for _, res := range proofs {
+		args = append(args, toStateProofArg(res))
+	}
+	return payloadWithStateArgs.Pack(toPayloadArg(payload), parentStateRoot, args)
+}
+
+// FetchStateProofs fetches the proofs of the requested L2 state at the given parent block,
+// and verifies them against the state root of the parent block, which is returned alongside the proofs.
+// An error wrapping ErrParentStateUnavailable is returned if the parent block is not known yet.
+func FetchStateProofs(ctx context.Context, src StateProofSource, parentHash common.Hash, requests []SlotRequest) (common.Hash, []*eth.AccountResult, error) {
+	parent, err := src.InfoByHash(ctx, parentHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return common.Hash{}, nil, fmt.Errorf("%w: parent block %s", ErrParentStateUnavailable, parentHash)
+	} else if err != nil {
+		return common.Hash{}, nil, fmt.Errorf("failed to fetch parent block %s: %w", parentHash, err)
+	}
+	stateRoot := parent.Root()
+	proofs := make([]*eth.AccountResult, 0, len(requests))
+	for _, req := range requests {
+		res, err := src.GetProof(ctx, req.Account, req.Slots, parentHash.String())
+		if err != nil {
+			return common.Hash{}, nil, fmt.Errorf("failed to fetch state proof of %s at block %s: %w", req.Account, parentHash, err)
+		}
+		if err := res.Verify(stateRoot); err != nil {
+			return common.Hash{}, nil, fmt.Errorf("invalid state proof of %s at block %s: %w", req.Account, parentHash, err)
+		}
+		proofs = append(proofs, res)
+	}
+	return stateRoot, proofs, nil
+}
@@ -0,0 +1,167 @@
+package commitments
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestStateManifestRequests(t *testing.T) {
+	accA := common.Address{0xaa}
+	accB := common.Address{0xbb}
+	m := &StateManifest{Commitments: map[common.Address][]SlotRequest{
+		{0x01}: {{Account: accB, Slots: []common.Hash{{0x02}, {0x01}}}},
+		{0x02}: {{Account: accB, Slots: []common.Hash{{0x01}, {0x03}}}, {Account: accA}},
+	}}
+	require.NoError(t, m.Check())
+	require.Equal(t, []SlotRequest{
+		{Account: accA},
+		{Account: accB, Slots: []common.Hash{{0x01}, {0x02}, {0x03}}},
+	}, m.Requests())
+}
+
+func TestLoadStateManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"commitments": {
+		"0x0000000000000000000000000000000000000001": [
+			{"account": "0x00000000000000000000000000000000000000aa", "slots": ["0x0000000000000000000000000000000000000000000000000000000000000002"]}
+		]
+	}}`), 0644))
+	m, err := LoadStateManifest(path)
+	require.NoError(t, err)
+	require.Equal(t, []SlotRequest{{
+		Account: common.HexToAddress("0xaa"),
+		Slots:   []common.Hash{common.HexToHash("0x02")},
+	}}, m.Requests())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"commitments": {"0x0000000000000000000000000000000000000001": []}}`), 0644))
+	_, err = LoadStateManifest(path)
+	require.ErrorContains(t, err, "does not request any state")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"commitment": {}}`), 0644))
+	_, err = LoadStateManifest(path)
+	require.Error(t, err, "unknown fields are rejected")
+}
+
+type decodedStorageProof struct {
+	Key   [32]byte
+	Value *big.Int
+	Proof [][]byte
+}
+
+type decodedStateProof struct {
+	Account      common.Address
+	Nonce        uint64
+	Balance      *big.Int
+	StorageHash  [32]byte
+	CodeHash     [32]byte
+	AccountProof [][]byte
+	Storage      []decodedStorageProof
+}
+
+func TestEncodePayloadWithState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	payload := &eth.ExecutionPayload{
+		ParentHash:   testutils.RandomHash(rng),
+		FeeRecipient: testutils.RandomAddress(rng),
+		BlockNumber:  eth.Uint64Quantity(rng.Uint64()),
+		BlockHash:    testutils.RandomHash(rng),
+		Transactions: []eth.Data{{0xaa, 0xbb}},
+	}
+	parentRoot := testutils.RandomHash(rng)
+	proof := &eth.AccountResult{
+		AccountProof: []hexutil.Bytes{{0x01, 0x02}, {0x03}},
+		Address:      testutils.RandomAddress(rng),
+		Balance:      (*hexutil.Big)(big.NewInt(1000)),
+		CodeHash:     testutils.RandomHash(rng),
+		Nonce:        7,
+		StorageHash:  testutils.RandomHash(rng),
+		StorageProof: []eth.StorageProofEntry{{
+			Key:   common.Hash{0x01},
+			Value: hexutil.Big(*big.NewInt(42)),
+			Proof: []hexutil.Bytes{{0x04}},
+		}},
+	}
+
+	encoded, err := EncodePayloadWithState(payload, parentRoot, []*eth.AccountResult{proof})
+	require.NoError(t, err)
+
+	values, err := payloadWithStateArgs.Unpack(encoded)
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	decoded := abi.ConvertType(values[0], new(decodedPayload)).(*decodedPayload)
+	require.Equal(t, [32]byte(payload.BlockHash), decoded.BlockHash)
+	require.Equal(t, [32]byte(parentRoot), values[1].([32]byte))
+
+	proofs := *abi.ConvertType(values[2], new([]decodedStateProof)).(*[]decodedStateProof)
+	require.Len(t, proofs, 1)
+	require.Equal(t, proof.Address, proofs[0].Account)
+	require.Equal(t, uint64(7), proofs[0].Nonce)
+	require.Equal(t, int64(1000), proofs[0].Balance.Int64())
+	require.Equal(t, [32]byte(proof.StorageHash), proofs[0].StorageHash)
+	require.Equal(t, [][]byte{{0x01, 0x02}, {0x03}}, proofs[0].AccountProof)
+	require.Len(t, proofs[0].Storage, 1)
+	require.Equal(t, [32]byte{0x01}, proofs[0].Storage[0].Key)
+	require.Equal(t, int64(42), proofs[0].Storage[0].Value.Int64())
+	require.Equal(t, [][]byte{{0x04}}, proofs[0].Storage[0].Proof)
+}
+
+type stubProofSource struct {
+	info  eth.BlockInfo
+	proof *eth.AccountResult
+	err   error
+}
+
+func (s *stubProofSource) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return s.info, s.err
+}
+
+func (s *stubProofSource) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	return s.proof, nil
+}
+
+func TestFetchStateProofs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	info := testutils.RandomBlockInfo(rng)
+	src := &stubProofSource{
+		info: info,
+		proof: &eth.AccountResult{
+			Address:      common.Address{0xaa},
+			Balance:      (*hexutil.Big)(big.NewInt(1)),
+			AccountProof: []hexutil.Bytes{{0x01}},
+		},
+	}
+
+	root, proofs, err := FetchStateProofs(context.Background(), src, info.Hash(), nil)
+	require.NoError(t, err)
+	require.Equal(t, info.Root(), root)
+	require.Empty(t, proofs)
+
+	_, _, err = FetchStateProofs(context.Background(), src, info.Hash(), []SlotRequest{{Account: common.Address{0xaa}}})
+	require.ErrorContains(t, err, "invalid state proof")
+
+	src.err = errors.New("connection refused")
+	_, _, err = FetchStateProofs(context.Background(), src, info.Hash(), nil)
+	require.ErrorIs(t, err, src.err)
+	require.NotErrorIs(t, err, ErrParentStateUnavailable)
+
+	// a parent that is not known yet can be retried
+	src.err = ethereum.NotFound
+	_, _, err = FetchStateProofs(context.Background(), src, info.Hash(), nil)
+	require.ErrorIs(t, err, ErrParentStateUnavailable)
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
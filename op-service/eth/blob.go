@@ -0,0 +1,180 @@
+package eth
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	BlobSize        = 4096 * 32
+	MaxBlobDataSize = 4096*31 - 4
+	EncodingVersion = 0
+	VersionOffset   = 0
+)
+
+var (
+	ErrBlobInvalidFieldElement    = errors.New("invalid field element")
+	ErrBlobInvalidEncodingVersion = errors.New("invalid encoding version")
+	ErrBlobInvalidLength          = errors.New("invalid length for blob")
+	ErrBlobInputTooLarge          = errors.New("too much data to encode in one blob")
+	ErrBlobExtraneousData         = errors.New("non-zero data encountered where blob should be empty")
+)
+
+// VersionedHashVersionKZG is the version byte of the versioned hash of a KZG commitment, see EIP-4844.
+const VersionedHashVersionKZG = 0x01
+
+type Blob [BlobSize]byte
+
+func (b *Blob) KZGBlob() *kzg4844.Blob {
+	return (*kzg4844.Blob)(b)
+}
+
+func (b *Blob) UnmarshalJSON(text []byte) error {
+	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(b), text, b[:])
+}
+
+func (b *Blob) UnmarshalText(text []byte) error {
+	return hexutil.UnmarshalFixedText("Blob", text, b[:])
+}
+
+func (b *Blob) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+func (b *Blob) String() string {
+	return hexutil.Encode(b[:])
+}
+
+// TerminalString implements log.TerminalStringer, formatting a string for console
+// output during logging.
+func (b *Blob) TerminalString() string {
+	return fmt.Sprintf("%x..%x", b[:3], b[BlobSize-3:])
+}
+
+// FromData encodes the data into the blob. Every field element holds 31 bytes of data,
+// the first byte of each field element is kept zero, to stay below the BLS12-381 modulus.
+// The first field element starts with the encoding version and the 3-byte big-endian length of the data.
+func (b *Blob) FromData(data Data) error {
+	if len(data) > MaxBlobDataSize {
+		return fmt.Errorf("%w: len=%v", ErrBlobInputTooLarge, len(data))
+	}
+	b.Clear()
+	input := make([]byte, 4+len(data))
+	input[VersionOffset] = EncodingVersion
+	input[1] = byte(len(data) >> 16)
+	input[2] = byte(len(data) >> 8)
+	input[3] = byte(len(data))
+	copy(input[4:], data)
+	for i := 0; len(input) > 0; i++ {
+		n := copy(b[i*32+1:(i+1)*32], input)
+		input = input[n:]
+	}
+	return nil
+}
+
+// ToData decodes the blob into the data that was encoded with FromData.
+func (b *Blob) ToData() (Data, error) {
+	for i := 0; i < BlobSize; i += 32 {
+		if b[i] != 0 {
+			return nil, fmt.Errorf("%w: field element %d", ErrBlobInvalidFieldElement, i/32)
+		}
+	}
+	if b[1+VersionOffset] != EncodingVersion {
+		return nil, fmt.Errorf("%w: expected version %d, got %d", ErrBlobInvalidEncodingVersion, EncodingVersion, b[1+VersionOffset])
+	}
+	length := int(b[2])<<16 | int(b[3])<<8 | int(b[4])
+	if length > MaxBlobDataSize {
+		return nil, fmt.Errorf("%w: %d", ErrBlobInvalidLength, length)
+	}
+	output := make(Data, 0, 4+length)
+	for i := 0; i < BlobSize; i += 32 {
+		output = append(output, b[i+1:i+32]...)
+	}
+	for _, v := range output[4+length:] {
+		if v != 0 {
+			return nil, ErrBlobExtraneousData
+		}
+	}
+	return output[4 : 4+length], nil
+}
+
+func (b *Blob) Clear() {
+	for i := 0; i < BlobSize; i++ {
+		b[i] = 0
+	}
+}
+
+type Bytes48 [48]byte
+
+func (b *Bytes48) UnmarshalJSON(text []byte) error {
+	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(b), text, b[:])
+}
+
+func (b *Bytes48) UnmarshalText(text []byte) error {
+	return hexutil.UnmarshalFixedText("Bytes48", text, b[:])
+}
+
+func (b Bytes48) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+func (b Bytes48) String() string {
+	return hexutil.Encode(b[:])
+}
+
+// TerminalString implements log.TerminalStringer, formatting a string for console
+// output during logging.
+func (b Bytes48) TerminalString() string {
+	return fmt.Sprintf("%x..%x", b[:3], b[45:])
+}
+
+// Uint64String is a decimal string encoded uint64, as used by the beacon API.
+type Uint64String uint64
+
+func (v Uint64String) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(v), 10)), nil
+}
+
+func (v *Uint64String) UnmarshalText(b []byte) error {
+	n, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return err
+	}
+	*v = Uint64String(n)
+	return nil
+}
+
+// BlobSidecar is a blob with its KZG commitment and proof, as served by the beacon API.
+type BlobSidecar struct {
+	Index         Uint64String `json:"index"`
+	Blob          Blob         `json:"blob"`
+	KZGCommitment Bytes48      `json:"kzg_commitment"`
+	KZGProof      Bytes48      `json:"kzg_proof"`
+}
+
+// VerifyBlobProof verifies the KZG proof of the blob against the commitment.
+func (sc *BlobSidecar) VerifyBlobProof() error {
+	return kzg4844.VerifyBlobProof(*sc.Blob.KZGBlob(), kzg4844.Commitment(sc.KZGCommitment), kzg4844.Proof(sc.KZGProof))
+}
+
+// IndexedBlobHash is the versioned hash of a blob, and the index of the blob within the L1 block.
+type IndexedBlobHash struct {
+	Index uint64
+	Hash  common.Hash
+}
+
+// KZGToVersionedHash computes the versioned hash of a KZG commitment, see EIP-4844.
+func KZGToVersionedHash(commitment kzg4844.Commitment) (out common.Hash) {
+	hasher := sha256.New()
+	hasher.Write(commitment[:])
+	hasher.Sum(out[:0])
+	out[0] = VersionedHashVersionKZG
+	return out
+}
@@ -0,0 +1,80 @@
+package eth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobEncodeDecode(t *testing.T) {
+	cases := []string{
+		"",
+		"this is a test of blob encoding/decoding",
+		string(make([]byte, 31)),
+		string(make([]byte, 32)),
+		string(make([]byte, MaxBlobDataSize)),
+	}
+	var b Blob
+	for _, c := range cases {
+		data := Data(c)
+		require.NoError(t, b.FromData(data))
+		decoded, err := b.ToData()
+		require.NoError(t, err)
+		require.Equal(t, c, string(decoded))
+	}
+}
+
+func TestBlobEncodeTooLarge(t *testing.T) {
+	var b Blob
+	require.ErrorIs(t, b.FromData(make(Data, MaxBlobDataSize+1)), ErrBlobInputTooLarge)
+}
+
+func TestBlobDecodeInvalid(t *testing.T) {
+	var b Blob
+	require.NoError(t, b.FromData(Data("hello")))
+
+	invalidFieldElement := b
+	invalidFieldElement[32*100] = 1
+	_, err := invalidFieldElement.ToData()
+	require.ErrorIs(t, err, ErrBlobInvalidFieldElement)
+
+	invalidVersion := b
+	invalidVersion[1] = 1
+	_, err = invalidVersion.ToData()
+	require.ErrorIs(t, err, ErrBlobInvalidEncodingVersion)
+
+	invalidLength := b
+	invalidLength[2] = 0xff
+	_, err = invalidLength.ToData()
+	require.ErrorIs(t, err, ErrBlobInvalidLength)
+
+	extraneousData := b
+	extraneousData[BlobSize-1] = 1
+	_, err = extraneousData.ToData()
+	require.ErrorIs(t, err, ErrBlobExtraneousData)
+}
+
+func TestBlobSidecarJSON(t *testing.T) {
+	var b Blob
+	require.NoError(t, b.FromData(Data("hello")))
+	commitment, err := kzg4844.BlobToCommitment(*b.KZGBlob())
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*b.KZGBlob(), commitment)
+	require.NoError(t, err)
+	sidecar := &BlobSidecar{Index: 3, Blob: b, KZGCommitment: Bytes48(commitment), KZGProof: Bytes48(proof)}
+	require.NoError(t, sidecar.VerifyBlobProof())
+
+	data, err := json.Marshal(sidecar)
+	require.NoError(t, err)
+	var out BlobSidecar
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Equal(t, *sidecar, out)
+
+	out.KZGProof[0] ^= 1
+	require.Error(t, out.VerifyBlobProof())
+
+	h := KZGToVersionedHash(commitment)
+	require.Equal(t, byte(VersionedHashVersionKZG), h[0])
+}
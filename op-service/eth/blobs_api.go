@@ -0,0 +1,26 @@
+package eth
+
+// APIGetBlobSidecarsResponse is the response of the beacon API /eth/v1/beacon/blob_sidecars/{block_id} endpoint.
+type APIGetBlobSidecarsResponse struct {
+	Data []*BlobSidecar `json:"data"`
+}
+
+type ReducedGenesisData struct {
+	GenesisTime Uint64String `json:"genesis_time"`
+}
+
+// APIGenesisResponse is the response of the beacon API /eth/v1/beacon/genesis endpoint,
+// reduced to the fields used by the op-node.
+type APIGenesisResponse struct {
+	Data ReducedGenesisData `json:"data"`
+}
+
+type ReducedConfigData struct {
+	SecondsPerSlot Uint64String `json:"SECONDS_PER_SLOT"`
+}
+
+// APIConfigResponse is the response of the beacon API /eth/v1/config/spec endpoint,
+// reduced to the fields used by the op-node.
+type APIConfigResponse struct {
+	Data ReducedConfigData `json:"data"`
+}
@@ -20,6 +20,8 @@ type BlockInfo interface {
 	BaseFee() *big.Int
 	ReceiptHash() common.Hash
 	GasUsed() uint64
+	// ParentBeaconRoot of the block, added in Cancun (EIP-4788). Nil before Cancun.
+	ParentBeaconRoot() *common.Hash
 
 	// HeaderRLP returns the RLP of the block header as per consensus rules
 	// Returns an error if the header RLP could not be written
@@ -47,9 +49,14 @@ func ToBlockID(b NumberAndHash) BlockID {
 	}
 }
 
-// blockInfo is a conversion type of types.Block turning it into a BlockInfo
+// blockInfo is a conversion type of types.Block turning it into a BlockInfo.
+// The geth block type of this version has no Cancun header fields: use HeaderInfo for Cancun blocks.
 type blockInfo struct{ *types.Block }
 
+func (b blockInfo) ParentBeaconRoot() *common.Hash {
+	return nil // the geth header type of this version has no parent beacon root
+}
+
 func (b blockInfo) HeaderRLP() ([]byte, error) {
 	return rlp.EncodeToBytes(b.Header())
 }
@@ -61,7 +68,8 @@ func BlockToInfo(b *types.Block) BlockInfo {
 var _ BlockInfo = (*blockInfo)(nil)
 
 // headerBlockInfo is a conversion type of types.Header turning it into a
-// BlockInfo.
+// BlockInfo. The geth header type of this version has no Cancun fields:
+// use HeaderInfo for headers that may be Cancun headers.
 type headerBlockInfo struct{ *types.Header }
 
 func (h headerBlockInfo) ParentHash() common.Hash {
@@ -100,6 +108,10 @@ func (h headerBlockInfo) GasUsed() uint64 {
 	return h.Header.GasUsed
 }
 
+func (h headerBlockInfo) ParentBeaconRoot() *common.Hash {
+	return nil // the geth header type of this version has no parent beacon root
+}
+
 func (h headerBlockInfo) HeaderRLP() ([]byte, error) {
 	return rlp.EncodeToBytes(h.Header)
 }
@@ -108,3 +120,63 @@ func (h headerBlockInfo) HeaderRLP() ([]byte, error) {
 func HeaderBlockInfo(h *types.Header) BlockInfo {
 	return headerBlockInfo{h}
 }
+
+// headerInfo is a conversion type of Header turning it into a BlockInfo,
+// with the block hash computed once.
+type headerInfo struct {
+	hash common.Hash
+	*Header
+}
+
+func (h headerInfo) Hash() common.Hash {
+	return h.hash
+}
+
+func (h headerInfo) ParentHash() common.Hash {
+	return h.Header.ParentHash
+}
+
+func (h headerInfo) Coinbase() common.Address {
+	return h.Header.Coinbase
+}
+
+func (h headerInfo) Root() common.Hash {
+	return h.Header.Root
+}
+
+func (h headerInfo) NumberU64() uint64 {
+	return h.Header.Number.Uint64()
+}
+
+func (h headerInfo) Time() uint64 {
+	return h.Header.Time
+}
+
+func (h headerInfo) MixDigest() common.Hash {
+	return h.Header.MixDigest
+}
+
+func (h headerInfo) BaseFee() *big.Int {
+	return h.Header.BaseFee
+}
+
+func (h headerInfo) ReceiptHash() common.Hash {
+	return h.Header.ReceiptHash
+}
+
+func (h headerInfo) GasUsed() uint64 {
+	return h.Header.GasUsed
+}
+
+func (h headerInfo) ParentBeaconRoot() *common.Hash {
+	return h.Header.ParentBeaconRoot
+}
+
+func (h headerInfo) HeaderRLP() ([]byte, error) {
+	return h.Header.RLP()
+}
+
+// HeaderInfo returns h as a BlockInfo implementation, including the Cancun header fields.
+func HeaderInfo(h *Header) BlockInfo {
+	return headerInfo{hash: h.Hash(), Header: h}
+}
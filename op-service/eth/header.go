@@ -0,0 +1,58 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Header is an execution-layer block header, including the Cancun (EIP-4844, EIP-4788) fields,
+// which are not supported yet by the geth header type of the geth version we depend on.
+// The header RLP-encodes like the block header of the consensus rules:
+// the optional fields are only encoded if they, and all optional fields before them, are set.
+type Header struct {
+	ParentHash  common.Hash
+	UncleHash   common.Hash
+	Coinbase    common.Address
+	Root        common.Hash
+	TxHash      common.Hash
+	ReceiptHash common.Hash
+	Bloom       types.Bloom
+	Difficulty  *big.Int
+	Number      *big.Int
+	GasLimit    uint64
+	GasUsed     uint64
+	Time        uint64
+	Extra       []byte
+	MixDigest   common.Hash
+	Nonce       types.BlockNonce
+
+	// BaseFee was added by EIP-1559 and is ignored in legacy headers.
+	BaseFee *big.Int `rlp:"optional"`
+	// WithdrawalsHash was added by EIP-4895 (Shanghai) and is ignored in legacy headers.
+	WithdrawalsHash *common.Hash `rlp:"optional"`
+	// BlobGasUsed was added by EIP-4844 (Cancun) and is ignored in legacy headers.
+	BlobGasUsed *uint64 `rlp:"optional"`
+	// ExcessBlobGas was added by EIP-4844 (Cancun) and is ignored in legacy headers.
+	ExcessBlobGas *uint64 `rlp:"optional"`
+	// ParentBeaconRoot was added by EIP-4788 (Cancun) and is ignored in legacy headers.
+	ParentBeaconRoot *common.Hash `rlp:"optional"`
+}
+
+// RLP returns the RLP encoding of the header, as per consensus rules.
+func (h *Header) RLP() ([]byte, error) {
+	return rlp.EncodeToBytes(h)
+}
+
+// Hash returns the block hash of the header, the keccak256 hash of its RLP encoding.
+func (h *Header) Hash() common.Hash {
+	data, err := h.RLP()
+	if err != nil {
+		// all header fields are encodable, this is unreachable
+		panic(err)
+	}
+	return crypto.Keccak256Hash(data)
+}
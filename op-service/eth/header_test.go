@@ -0,0 +1,143 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestHeaderHash(t *testing.T) {
+	gethHeader := &types.Header{
+		ParentHash:  common.Hash{0x01},
+		UncleHash:   types.EmptyUncleHash,
+		Coinbase:    common.Address{0x02},
+		Root:        common.Hash{0x03},
+		TxHash:      types.EmptyTxsHash,
+		ReceiptHash: types.EmptyReceiptsHash,
+		Difficulty:  common.Big0,
+		Number:      big.NewInt(100),
+		GasLimit:    30_000_000,
+		GasUsed:     21000,
+		Time:        1000,
+		Extra:       []byte{0x04},
+		MixDigest:   common.Hash{0x05},
+	}
+	header := &Header{
+		ParentHash:  gethHeader.ParentHash,
+		UncleHash:   gethHeader.UncleHash,
+		Coinbase:    gethHeader.Coinbase,
+		Root:        gethHeader.Root,
+		TxHash:      gethHeader.TxHash,
+		ReceiptHash: gethHeader.ReceiptHash,
+		Difficulty:  gethHeader.Difficulty,
+		Number:      gethHeader.Number,
+		GasLimit:    gethHeader.GasLimit,
+		GasUsed:     gethHeader.GasUsed,
+		Time:        gethHeader.Time,
+		Extra:       gethHeader.Extra,
+		MixDigest:   gethHeader.MixDigest,
+	}
+	require.Equal(t, gethHeader.Hash(), header.Hash(), "legacy header")
+
+	gethHeader.BaseFee = big.NewInt(7)
+	header.BaseFee = gethHeader.BaseFee
+	require.Equal(t, gethHeader.Hash(), header.Hash(), "London header")
+
+	gethHeader.WithdrawalsHash = &types.EmptyWithdrawalsHash
+	header.WithdrawalsHash = gethHeader.WithdrawalsHash
+	require.Equal(t, gethHeader.Hash(), header.Hash(), "Shanghai header")
+
+	shanghaiHash := header.Hash()
+	blobGasUsed, excessBlobGas := uint64(0), uint64(0)
+	header.BlobGasUsed = &blobGasUsed
+	header.ExcessBlobGas = &excessBlobGas
+	header.ParentBeaconRoot = &common.Hash{}
+	require.NotEqual(t, shanghaiHash, header.Hash(), "Cancun fields are part of the hash")
+}
+
+func TestHeaderInfo(t *testing.T) {
+	blobGasUsed, excessBlobGas := uint64(0), uint64(0)
+	header := &Header{
+		UncleHash:        types.EmptyUncleHash,
+		Difficulty:       common.Big0,
+		Number:           big.NewInt(100),
+		Time:             1000,
+		BaseFee:          big.NewInt(7),
+		WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+		BlobGasUsed:      &blobGasUsed,
+		ExcessBlobGas:    &excessBlobGas,
+		ParentBeaconRoot: &common.Hash{0x42},
+	}
+	info := HeaderInfo(header)
+	require.Equal(t, header.Hash(), info.Hash())
+	require.Equal(t, uint64(100), info.NumberU64())
+	require.Equal(t, header.ParentBeaconRoot, info.ParentBeaconRoot())
+	data, err := info.HeaderRLP()
+	require.NoError(t, err)
+	expected, err := header.RLP()
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+}
+
+func TestCheckBlockHash(t *testing.T) {
+	header := &types.Header{
+		ParentHash:      common.Hash{0x01},
+		UncleHash:       types.EmptyUncleHash,
+		TxHash:          types.EmptyTxsHash,
+		Difficulty:      common.Big0,
+		Number:          big.NewInt(100),
+		GasLimit:        30_000_000,
+		Time:            1000,
+		BaseFee:         big.NewInt(7),
+		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+	}
+	block := types.NewBlockWithHeader(header).WithBody(nil, nil).WithWithdrawals(types.Withdrawals{})
+	payload, err := BlockAsPayload(block)
+	require.NoError(t, err)
+	require.Equal(t, BlockV2, payload.Version())
+	require.NotNil(t, payload.Withdrawals)
+	_, ok := payload.CheckBlockHash()
+	require.True(t, ok, "Shanghai block hash matches")
+
+	payload.Withdrawals = nil
+	_, ok = payload.CheckBlockHash()
+	require.False(t, ok, "withdrawals root is part of the block hash")
+
+	payload.Withdrawals = &types.Withdrawals{}
+	blobGasUsed, excessBlobGas := Uint64Quantity(0), Uint64Quantity(0)
+	payload.BlobGasUsed = &blobGasUsed
+	payload.ExcessBlobGas = &excessBlobGas
+	payload.ParentBeaconBlockRoot = &common.Hash{0xaa}
+	payload.BlockHash, _ = payload.CheckBlockHash()
+	_, ok = payload.CheckBlockHash()
+	require.True(t, ok)
+	payload.ParentBeaconBlockRoot = &common.Hash{0xbb}
+	_, ok = payload.CheckBlockHash()
+	require.False(t, ok, "parent beacon block root is part of the block hash")
+}
+
+func TestCheckVersion(t *testing.T) {
+	blobGasUsed := Uint64Quantity(0)
+	payload := &ExecutionPayload{}
+	require.NoError(t, payload.CheckVersion(BlockV1))
+	require.ErrorContains(t, payload.CheckVersion(BlockV2), "missing withdrawals")
+
+	payload.Withdrawals = &types.Withdrawals{}
+	require.ErrorContains(t, payload.CheckVersion(BlockV1), "unexpected withdrawals")
+	require.NoError(t, payload.CheckVersion(BlockV2))
+	require.ErrorContains(t, payload.CheckVersion(BlockV3), "missing blob gas fields")
+
+	payload.Withdrawals = &types.Withdrawals{{Index: 1}}
+	require.ErrorContains(t, payload.CheckVersion(BlockV2), "expected empty withdrawals")
+
+	payload.Withdrawals = &types.Withdrawals{}
+	payload.BlobGasUsed = &blobGasUsed
+	payload.ExcessBlobGas = &blobGasUsed
+	payload.ParentBeaconBlockRoot = &common.Hash{}
+	require.NoError(t, payload.CheckVersion(BlockV3))
+	require.ErrorContains(t, payload.CheckVersion(BlockV2), "unexpected blob gas fields")
+}
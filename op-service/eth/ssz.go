@@ -7,18 +7,50 @@ import (
 	"io"
 	"math"
 	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // ExecutionPayload is the only SSZ type we have to marshal/unmarshal,
 // so instead of importing a SSZ lib we implement the bare minimum.
 // This is more efficient than RLP, and matches the L1 consensus-layer encoding of ExecutionPayload.
+//
+// The encoding depends on the payload version: V2 (Capella) appends the withdrawals,
+// and V3 (Deneb) appends the blob gas fields. V3 additionally appends the parent beacon block root,
+// which is not part of the L1 consensus-layer payload, but is required to reproduce the L2 block hash.
+// The version is implied by the offset of the first dynamic field, the extra data,
+// which is the size of the fixed part of the version.
 
 // All fields (4s are offsets to dynamic data)
 const executionPayloadFixedPart = 32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 4
 
+// V2 adds the offset to the withdrawals
+const executionPayloadFixedPartV2 = executionPayloadFixedPart + 4
+
+// V3 adds the blob gas used, the excess blob gas and the parent beacon block root
+const executionPayloadFixedPartV3 = executionPayloadFixedPartV2 + 8 + 8 + 32
+
+// Withdrawal fields: index, validator index, address, amount
+const withdrawalSize = 8 + 8 + 20 + 8
+
 // MAX_TRANSACTIONS_PER_PAYLOAD in consensus spec
 const maxTransactionsPerPayload = 1 << 20
 
+// MAX_WITHDRAWALS_PER_PAYLOAD in consensus spec
+const maxWithdrawalsPerPayload = 1 << 4
+
+func executionPayloadFixedPartSize(version BlockVersion) uint32 {
+	switch version {
+	case BlockV2:
+		return executionPayloadFixedPartV2
+	case BlockV3:
+		return executionPayloadFixedPartV3
+	default:
+		return executionPayloadFixedPart
+	}
+}
+
 // ErrExtraDataTooLarge occurs when the ExecutionPayload's ExtraData field
 // is too large to be properly represented in SSZ.
 var ErrExtraDataTooLarge = errors.New("extra data too large")
@@ -32,14 +64,20 @@ var payloadBufPool = sync.Pool{New: func() any {
 
 var ErrBadTransactionOffset = errors.New("transactions offset is smaller than extra data offset, aborting")
 
+// ErrIncompletePayload occurs when a Cancun execution payload does not have all the Cancun fields.
+var ErrIncompletePayload = errors.New("incomplete post-Cancun execution payload")
+
 func (payload *ExecutionPayload) SizeSSZ() (full uint32) {
-	full = executionPayloadFixedPart + uint32(len(payload.ExtraData))
+	full = executionPayloadFixedPartSize(payload.Version()) + uint32(len(payload.ExtraData))
 	// One offset to each transaction
 	full += uint32(len(payload.Transactions)) * 4
 	// Each transaction
 	for _, tx := range payload.Transactions {
 		full += uint32(len(tx))
 	}
+	if payload.Withdrawals != nil {
+		full += uint32(len(*payload.Withdrawals)) * withdrawalSize
+	}
 	return full
 }
 
@@ -60,13 +98,19 @@ func unmarshalBytes32LE(in []byte, z *Uint256Quantity) {
 	z[3] = binary.LittleEndian.Uint64(in[24:32])
 }
 
-// MarshalSSZ encodes the ExecutionPayload as SSZ type
+// MarshalSSZ encodes the ExecutionPayload as SSZ type, in the format of the payload version.
 func (payload *ExecutionPayload) MarshalSSZ(w io.Writer) (n int, err error) {
+	version := payload.Version()
+	fixedPart := executionPayloadFixedPartSize(version)
 	// Cast to uint32 to enable 32-bit MIPS support where math.MaxUint32-executionPayloadFixedPart is too big for int
 	// In that case, len(payload.ExtraData) can't be longer than an int so this is always false anyway.
-	if uint32(len(payload.ExtraData)) > math.MaxUint32-uint32(executionPayloadFixedPart) {
+	if uint32(len(payload.ExtraData)) > math.MaxUint32-fixedPart {
 		return 0, ErrExtraDataTooLarge
 	}
+	if version == BlockV3 && (payload.Withdrawals == nil || payload.BlobGasUsed == nil ||
+		payload.ExcessBlobGas == nil || payload.ParentBeaconBlockRoot == nil) {
+		return 0, ErrIncompletePayload
+	}
 
 	scope := payload.SizeSSZ()
 
@@ -100,26 +144,67 @@ func (payload *ExecutionPayload) MarshalSSZ(w io.Writer) (n int, err error) {
 	binary.LittleEndian.PutUint64(buf[offset:offset+8], uint64(payload.Timestamp))
 	offset += 8
 	// offset to ExtraData
-	binary.LittleEndian.PutUint32(buf[offset:offset+4], executionPayloadFixedPart)
+	binary.LittleEndian.PutUint32(buf[offset:offset+4], fixedPart)
 	offset += 4
 	marshalBytes32LE(buf[offset:offset+32], &payload.BaseFeePerGas)
 	offset += 32
 	copy(buf[offset:offset+32], payload.BlockHash[:])
 	offset += 32
 	// offset to Transactions
-	binary.LittleEndian.PutUint32(buf[offset:offset+4], executionPayloadFixedPart+uint32(len(payload.ExtraData)))
+	transactionsOffset := fixedPart + uint32(len(payload.ExtraData))
+	binary.LittleEndian.PutUint32(buf[offset:offset+4], transactionsOffset)
 	offset += 4
-	if offset != executionPayloadFixedPart {
+	withdrawalsOffset := transactionsOffset + transactionsSize(payload.Transactions)
+	if version >= BlockV2 {
+		// offset to Withdrawals
+		binary.LittleEndian.PutUint32(buf[offset:offset+4], withdrawalsOffset)
+		offset += 4
+	}
+	if version >= BlockV3 {
+		binary.LittleEndian.PutUint64(buf[offset:offset+8], uint64(*payload.BlobGasUsed))
+		offset += 8
+		binary.LittleEndian.PutUint64(buf[offset:offset+8], uint64(*payload.ExcessBlobGas))
+		offset += 8
+		copy(buf[offset:offset+32], payload.ParentBeaconBlockRoot[:])
+		offset += 32
+	}
+	if offset != fixedPart {
 		panic("fixed part size is inconsistent")
 	}
 	// dynamic value 1: ExtraData
 	copy(buf[offset:offset+uint32(len(payload.ExtraData))], payload.ExtraData[:])
 	offset += uint32(len(payload.ExtraData))
 	// dynamic value 2: Transactions
-	marshalTransactions(buf[offset:], payload.Transactions)
+	marshalTransactions(buf[offset:withdrawalsOffset], payload.Transactions)
+	// dynamic value 3: Withdrawals
+	if version >= BlockV2 {
+		marshalWithdrawals(buf[withdrawalsOffset:], *payload.Withdrawals)
+	}
 	return w.Write(buf)
 }
 
+func transactionsSize(txs []Data) uint32 {
+	size := uint32(len(txs)) * 4
+	for _, tx := range txs {
+		size += uint32(len(tx))
+	}
+	return size
+}
+
+func marshalWithdrawals(out []byte, withdrawals types.Withdrawals) {
+	offset := uint32(0)
+	for _, w := range withdrawals {
+		binary.LittleEndian.PutUint64(out[offset:offset+8], w.Index)
+		offset += 8
+		binary.LittleEndian.PutUint64(out[offset:offset+8], w.Validator)
+		offset += 8
+		copy(out[offset:offset+20], w.Address[:])
+		offset += 20
+		binary.LittleEndian.PutUint64(out[offset:offset+8], w.Amount)
+		offset += 8
+	}
+}
+
 func marshalTransactions(out []byte, txs []Data) {
 	offset := uint32(0)
 	txOffset := uint32(len(txs)) * 4
@@ -132,7 +217,8 @@ func marshalTransactions(out []byte, txs []Data) {
 	}
 }
 
-// UnmarshalSSZ decodes the ExecutionPayload as SSZ type
+// UnmarshalSSZ decodes the ExecutionPayload as SSZ type, in the format of any payload version.
+// The caller should check that the decoded version is the expected version, see ExecutionPayload.CheckVersion.
 func (payload *ExecutionPayload) UnmarshalSSZ(scope uint32, r io.Reader) error {
 	if scope < executionPayloadFixedPart {
 		return fmt.Errorf("scope too small to decode execution payload: %d", scope)
@@ -171,9 +257,20 @@ func (payload *ExecutionPayload) UnmarshalSSZ(scope uint32, r io.Reader) error {
 	payload.Timestamp = Uint64Quantity(binary.LittleEndian.Uint64(buf[offset : offset+8]))
 	offset += 8
 	extraDataOffset := binary.LittleEndian.Uint32(buf[offset : offset+4])
-	if extraDataOffset != executionPayloadFixedPart {
+	var version BlockVersion
+	switch extraDataOffset {
+	case executionPayloadFixedPart:
+		version = BlockV1
+	case executionPayloadFixedPartV2:
+		version = BlockV2
+	case executionPayloadFixedPartV3:
+		version = BlockV3
+	default:
 		return fmt.Errorf("unexpected extra data offset: %d <> %d", extraDataOffset, executionPayloadFixedPart)
 	}
+	if scope < extraDataOffset {
+		return fmt.Errorf("scope too small to decode %s execution payload: %d", version, scope)
+	}
 	offset += 4
 	unmarshalBytes32LE(buf[offset:offset+32], &payload.BaseFeePerGas)
 	offset += 32
@@ -184,7 +281,30 @@ func (payload *ExecutionPayload) UnmarshalSSZ(scope uint32, r io.Reader) error {
 		return ErrBadTransactionOffset
 	}
 	offset += 4
-	if offset != executionPayloadFixedPart {
+	withdrawalsOffset := scope
+	if version >= BlockV2 {
+		withdrawalsOffset = binary.LittleEndian.Uint32(buf[offset : offset+4])
+		if withdrawalsOffset < transactionsOffset || withdrawalsOffset > scope {
+			return fmt.Errorf("bad withdrawals offset: %d, transactions offset is %d, scope is %d", withdrawalsOffset, transactionsOffset, scope)
+		}
+		offset += 4
+	}
+	payload.BlobGasUsed = nil
+	payload.ExcessBlobGas = nil
+	payload.ParentBeaconBlockRoot = nil
+	if version >= BlockV3 {
+		blobGasUsed := Uint64Quantity(binary.LittleEndian.Uint64(buf[offset : offset+8]))
+		payload.BlobGasUsed = &blobGasUsed
+		offset += 8
+		excessBlobGas := Uint64Quantity(binary.LittleEndian.Uint64(buf[offset : offset+8]))
+		payload.ExcessBlobGas = &excessBlobGas
+		offset += 8
+		var parentBeaconBlockRoot common.Hash
+		copy(parentBeaconBlockRoot[:], buf[offset:offset+32])
+		payload.ParentBeaconBlockRoot = &parentBeaconBlockRoot
+		offset += 32
+	}
+	if offset != extraDataOffset {
 		panic("fixed part size is inconsistent")
 	}
 	if transactionsOffset > extraDataOffset+32 || transactionsOffset > scope {
@@ -193,14 +313,47 @@ func (payload *ExecutionPayload) UnmarshalSSZ(scope uint32, r io.Reader) error {
 	extraDataSize := transactionsOffset - extraDataOffset
 	payload.ExtraData = make(BytesMax32, extraDataSize)
 	copy(payload.ExtraData, buf[extraDataOffset:transactionsOffset])
-	txs, err := unmarshalTransactions(buf[transactionsOffset:])
+	txs, err := unmarshalTransactions(buf[transactionsOffset:withdrawalsOffset])
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal transactions list: %w", err)
 	}
 	payload.Transactions = txs
+	payload.Withdrawals = nil
+	if version >= BlockV2 {
+		withdrawals, err := unmarshalWithdrawals(buf[withdrawalsOffset:])
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal withdrawals list: %w", err)
+		}
+		payload.Withdrawals = &withdrawals
+	}
 	return nil
 }
 
+func unmarshalWithdrawals(in []byte) (types.Withdrawals, error) {
+	if len(in)%withdrawalSize != 0 {
+		return nil, fmt.Errorf("withdrawals size %d is not a multiple of the withdrawal size", len(in))
+	}
+	count := len(in) / withdrawalSize
+	if count > maxWithdrawalsPerPayload {
+		return nil, fmt.Errorf("too many withdrawals: %d > %d", count, maxWithdrawalsPerPayload)
+	}
+	withdrawals := make(types.Withdrawals, 0, count)
+	offset := 0
+	for i := 0; i < count; i++ {
+		w := &types.Withdrawal{}
+		w.Index = binary.LittleEndian.Uint64(in[offset : offset+8])
+		offset += 8
+		w.Validator = binary.LittleEndian.Uint64(in[offset : offset+8])
+		offset += 8
+		copy(w.Address[:], in[offset:offset+20])
+		offset += 20
+		w.Amount = binary.LittleEndian.Uint64(in[offset : offset+8])
+		offset += 8
+		withdrawals = append(withdrawals, w)
+	}
+	return withdrawals, nil
+}
+
 func unmarshalTransactions(in []byte) (txs []Data, err error) {
 	scope := uint32(len(in))
 	if scope == 0 { // empty txs list
@@ -3,6 +3,7 @@ package eth
 import (
 	"bytes"
 	"encoding/binary"
+	"io"
 	"math"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // FuzzExecutionPayloadUnmarshal checks that our SSZ decoding never panics
@@ -21,6 +23,10 @@ func FuzzExecutionPayloadUnmarshal(f *testing.F) {
 			// not every input is a valid ExecutionPayload, that's ok. Should just not panic.
 			return
 		}
+		// any successfully decoded payload, of any version, must be encodable again
+		if _, err := payload.MarshalSSZ(io.Discard); err != nil {
+			t.Fatalf("failed to re-encode decoded payload: %v", err)
+		}
 	})
 }
 
@@ -82,6 +88,63 @@ func FuzzExecutionPayloadMarshalUnmarshal(f *testing.F) {
 	})
 }
 
+// FuzzExecutionPayloadVersionsMarshalUnmarshal checks that the SSZ encoding>decoding of
+// the V2 (withdrawals) and V3 (blob gas and parent beacon block root) payloads round trips properly,
+// and that the decoded payload is of the same version.
+func FuzzExecutionPayloadVersionsMarshalUnmarshal(f *testing.F) {
+	f.Add(uint8(BlockV2), []byte{}, uint64(0), uint64(0), []byte{})
+	f.Add(uint8(BlockV3), make([]byte, 3*withdrawalSize), uint64(131072), uint64(262144), common.Hash{0x01}.Bytes())
+	f.Fuzz(func(t *testing.T, version uint8, withdrawalsData []byte, blobGasUsed, excessBlobGas uint64, root []byte) {
+		v := BlockVersion(version%2) + BlockV2
+		payload := ExecutionPayload{
+			ParentHash:   common.Hash{0x01},
+			BlockNumber:  Uint64Quantity(blobGasUsed),
+			Timestamp:    Uint64Quantity(excessBlobGas),
+			ExtraData:    BytesMax32{},
+			BlockHash:    common.Hash{0x02},
+			Transactions: []Data{{0x7e}},
+		}
+		withdrawals := make(types.Withdrawals, 0)
+		for len(withdrawalsData) >= withdrawalSize && len(withdrawals) < maxWithdrawalsPerPayload {
+			w := &types.Withdrawal{
+				Index:     binary.LittleEndian.Uint64(withdrawalsData[0:8]),
+				Validator: binary.LittleEndian.Uint64(withdrawalsData[8:16]),
+				Amount:    binary.LittleEndian.Uint64(withdrawalsData[36:44]),
+			}
+			copy(w.Address[:], withdrawalsData[16:36])
+			withdrawals = append(withdrawals, w)
+			withdrawalsData = withdrawalsData[withdrawalSize:]
+		}
+		payload.Withdrawals = &withdrawals
+		if v == BlockV3 {
+			blobGas, excess := Uint64Quantity(blobGasUsed), Uint64Quantity(excessBlobGas)
+			payload.BlobGasUsed = &blobGas
+			payload.ExcessBlobGas = &excess
+			var beaconRoot common.Hash
+			copy(beaconRoot[:], root)
+			payload.ParentBeaconBlockRoot = &beaconRoot
+		}
+		var buf bytes.Buffer
+		if _, err := payload.MarshalSSZ(&buf); err != nil {
+			t.Fatalf("failed to marshal ExecutionPayload: %v", err)
+		}
+		if uint32(buf.Len()) != payload.SizeSSZ() {
+			t.Fatalf("encoded size %d does not match expected size %d", buf.Len(), payload.SizeSSZ())
+		}
+		var roundTripped ExecutionPayload
+		err := roundTripped.UnmarshalSSZ(uint32(len(buf.Bytes())), bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("failed to decode previously marshalled payload: %v", err)
+		}
+		if roundTripped.Version() != v {
+			t.Fatalf("decoded payload version %s does not match encoded version %s", roundTripped.Version(), v)
+		}
+		if diff := cmp.Diff(payload, roundTripped); diff != "" {
+			t.Fatalf("The data did not round trip correctly:\n%s", diff)
+		}
+	})
+}
+
 func FuzzOBP01(f *testing.F) {
 	payload := &ExecutionPayload{
 		ExtraData: make([]byte, 32),
@@ -147,3 +210,61 @@ func TestOPB04(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, ErrExtraDataTooLarge, err)
 }
+
+func TestExecutionPayloadSSZVersions(t *testing.T) {
+	blobGasUsed, excessBlobGas := Uint64Quantity(131072), Uint64Quantity(262144)
+	newPayload := func(version BlockVersion) *ExecutionPayload {
+		payload := &ExecutionPayload{
+			ParentHash:   common.Hash{0x01},
+			BlockNumber:  123,
+			Timestamp:    1000,
+			ExtraData:    BytesMax32{0x42},
+			BlockHash:    common.Hash{0x02},
+			Transactions: []Data{{0x7e, 0x01}, {0x02, 0x03, 0x04}},
+		}
+		if version >= BlockV2 {
+			payload.Withdrawals = &types.Withdrawals{{Index: 1, Validator: 2, Address: common.Address{0x03}, Amount: 4}}
+		}
+		if version >= BlockV3 {
+			payload.BlobGasUsed = &blobGasUsed
+			payload.ExcessBlobGas = &excessBlobGas
+			payload.ParentBeaconBlockRoot = &common.Hash{0x05}
+		}
+		return payload
+	}
+	for _, version := range []BlockVersion{BlockV1, BlockV2, BlockV3} {
+		version := version
+		t.Run(version.String(), func(t *testing.T) {
+			payload := newPayload(version)
+			require.Equal(t, version, payload.Version())
+			var buf bytes.Buffer
+			_, err := payload.MarshalSSZ(&buf)
+			require.NoError(t, err)
+			require.Equal(t, int(payload.SizeSSZ()), buf.Len())
+			require.Equal(t, executionPayloadFixedPartSize(version), binary.LittleEndian.Uint32(buf.Bytes()[436:440]),
+				"extra data offset is the size of the fixed part of the version")
+
+			var roundTripped ExecutionPayload
+			require.NoError(t, roundTripped.UnmarshalSSZ(uint32(buf.Len()), bytes.NewReader(buf.Bytes())))
+			require.Equal(t, payload, &roundTripped)
+			require.Equal(t, version, roundTripped.Version())
+		})
+	}
+
+	t.Run("incomplete v3", func(t *testing.T) {
+		payload := newPayload(BlockV3)
+		payload.ExcessBlobGas = nil
+		_, err := payload.MarshalSSZ(new(bytes.Buffer))
+		require.ErrorIs(t, err, ErrIncompletePayload)
+	})
+	t.Run("bad withdrawals", func(t *testing.T) {
+		payload := newPayload(BlockV2)
+		var buf bytes.Buffer
+		_, err := payload.MarshalSSZ(&buf)
+		require.NoError(t, err)
+		data := buf.Bytes()[:buf.Len()-1]
+		var roundTripped ExecutionPayload
+		err = roundTripped.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data))
+		require.ErrorContains(t, err, "not a multiple of the withdrawal size")
+	})
+}
@@ -38,4 +38,44 @@ type SyncStatus struct {
 	// EngineSyncTarget points to the L2 block that the execution engine is syncing to.
 	// If it is ahead from UnsafeL2, the engine is in progress of P2P sync.
 	EngineSyncTarget L2BlockRef `json:"engine_sync_target"`
+	// ScreenedL2 is the last unsafe L2 block that was screened against the sequencer commitments,
+	// regardless of the outcome. It may be zeroed if no block has been screened yet.
+	ScreenedL2 BlockID `json:"screened_l2"`
+	// LastViolationL2 is the last unsafe L2 block that failed the screening against the sequencer commitments.
+	// It may be zeroed if no violation has been observed.
+	LastViolationL2 BlockID `json:"last_violation_l2"`
+	// ScreeningMode is the mode in which unsafe L2 blocks are screened against the sequencer commitments.
+	ScreeningMode string `json:"screening_mode"`
+	// SnapSync is the progress of the execution-layer snap sync orchestrated by the rollup node.
+	// It is nil if snap sync is not enabled.
+	SnapSync *SnapSyncStatus `json:"snap_sync,omitempty"`
+}
+
+// SnapSyncStatus is the progress of the execution-layer snap sync orchestrated by the rollup node,
+// before it starts deriving the L2 chain.
+type SnapSyncStatus struct {
+	// Stage is the current stage of the snap sync:
+	// "finding_target", "syncing", "verifying", "done", "skipped" or "failed".
+	Stage string `json:"stage"`
+	// Target is the finalized L2 block that the execution engine snap syncs to.
+	// It is zeroed while the target is being found.
+	Target L2BlockRef `json:"target"`
+	// TargetOutputRoot is the output root of the target, as proposed to L1.
+	TargetOutputRoot Bytes32 `json:"target_output_root"`
+	// EngineProgress is the sync progress of the execution engine, as last reported while syncing.
+	// It is nil if the engine did not report any progress.
+	EngineProgress *EngineSyncProgress `json:"engine_progress,omitempty"`
+	// Error is the last error of the snap sync, empty if the last step succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// EngineSyncProgress is the sync progress of an execution engine, as reported by eth_syncing.
+type EngineSyncProgress struct {
+	StartingBlock    Uint64Quantity `json:"startingBlock"`
+	CurrentBlock     Uint64Quantity `json:"currentBlock"`
+	HighestBlock     Uint64Quantity `json:"highestBlock"`
+	SyncedAccounts   Uint64Quantity `json:"syncedAccounts"`
+	SyncedStorage    Uint64Quantity `json:"syncedStorage"`
+	HealedTrienodes  Uint64Quantity `json:"healedTrienodes"`
+	HealingTrienodes Uint64Quantity `json:"healingTrienodes"`
 }
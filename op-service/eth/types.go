@@ -2,6 +2,7 @@ package eth
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -142,6 +143,82 @@ type ExecutionPayload struct {
 	// Array of transaction objects, each object is a byte list (DATA) representing
 	// TransactionType || TransactionPayload or LegacyTransaction as defined in EIP-2718
 	Transactions []Data `json:"transactions"`
+	// Withdrawals were added in Shanghai (engine API V2), and are nil before Shanghai.
+	// There are no validator withdrawals on L2, the list is always empty after Shanghai.
+	Withdrawals *types.Withdrawals `json:"withdrawals,omitempty"`
+	// BlobGasUsed was added in Cancun (engine API V3), and is nil before Cancun.
+	BlobGasUsed *Uint64Quantity `json:"blobGasUsed,omitempty"`
+	// ExcessBlobGas was added in Cancun (engine API V3), and is nil before Cancun.
+	ExcessBlobGas *Uint64Quantity `json:"excessBlobGas,omitempty"`
+	// ParentBeaconBlockRoot is part of the block header since Cancun, and nil before Cancun.
+	// It is not part of the execution payload in the engine API: the engine takes it as separate
+	// engine_newPayloadV3 parameter, and does not return it with engine_getPayloadV3.
+	// It is part of the JSON encoding, so payloads exchanged between nodes keep it.
+	ParentBeaconBlockRoot *common.Hash `json:"parentBeaconBlockRoot,omitempty"`
+}
+
+// BlockVersion identifies the format of an execution payload, which changes with the engine API version.
+type BlockVersion int
+
+const (
+	// BlockV1 is the Bedrock (Bellatrix) execution payload.
+	BlockV1 BlockVersion = iota
+	// BlockV2 is the Shanghai (Capella) execution payload, with withdrawals.
+	BlockV2
+	// BlockV3 is the Cancun (Deneb) execution payload, with blob gas fields and the parent beacon block root.
+	BlockV3
+)
+
+func (v BlockVersion) String() string {
+	switch v {
+	case BlockV1:
+		return "v1"
+	case BlockV2:
+		return "v2"
+	case BlockV3:
+		return "v3"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(v))
+	}
+}
+
+// Version returns the format of the execution payload, based on the fields that are set.
+func (payload *ExecutionPayload) Version() BlockVersion {
+	if payload.BlobGasUsed != nil || payload.ExcessBlobGas != nil || payload.ParentBeaconBlockRoot != nil {
+		return BlockV3
+	}
+	if payload.Withdrawals != nil {
+		return BlockV2
+	}
+	return BlockV1
+}
+
+// CheckVersion checks that exactly the fields of the given payload version are set.
+func (payload *ExecutionPayload) CheckVersion(version BlockVersion) error {
+	hasCancun := payload.BlobGasUsed != nil && payload.ExcessBlobGas != nil && payload.ParentBeaconBlockRoot != nil
+	switch version {
+	case BlockV1:
+		if payload.Withdrawals != nil {
+			return errors.New("unexpected withdrawals in pre-Shanghai payload")
+		}
+	case BlockV2, BlockV3:
+		if payload.Withdrawals == nil {
+			return errors.New("missing withdrawals in post-Shanghai payload")
+		}
+		if len(*payload.Withdrawals) != 0 {
+			return fmt.Errorf("expected empty withdrawals list, but got %d withdrawals", len(*payload.Withdrawals))
+		}
+	default:
+		return fmt.Errorf("unknown payload version %d", version)
+	}
+	if version == BlockV3 {
+		if !hasCancun {
+			return errors.New("missing blob gas fields or parent beacon block root in post-Cancun payload")
+		}
+	} else if payload.Version() == BlockV3 {
+		return errors.New("unexpected blob gas fields or parent beacon block root in pre-Cancun payload")
+	}
+	return nil
 }
 
 func (payload *ExecutionPayload) ID() BlockID {
@@ -168,7 +245,7 @@ func (payload *ExecutionPayload) CheckBlockHash() (actual common.Hash, ok bool)
 	hasher := trie.NewStackTrie(nil)
 	txHash := types.DeriveSha(rawTransactions(payload.Transactions), hasher)
 
-	header := types.Header{
+	header := Header{
 		ParentHash:  payload.ParentHash,
 		UncleHash:   types.EmptyUncleHash,
 		Coinbase:    payload.FeeRecipient,
@@ -185,6 +262,14 @@ func (payload *ExecutionPayload) CheckBlockHash() (actual common.Hash, ok bool)
 		MixDigest:   common.Hash(payload.PrevRandao),
 		Nonce:       types.BlockNonce{}, // zeroed, proof-of-work legacy
 		BaseFee:     payload.BaseFeePerGas.ToBig(),
+
+		BlobGasUsed:      (*uint64)(payload.BlobGasUsed),
+		ExcessBlobGas:    (*uint64)(payload.ExcessBlobGas),
+		ParentBeaconRoot: payload.ParentBeaconBlockRoot,
+	}
+	if payload.Withdrawals != nil {
+		withdrawalsHash := types.DeriveSha(*payload.Withdrawals, trie.NewStackTrie(nil))
+		header.WithdrawalsHash = &withdrawalsHash
 	}
 	blockHash := header.Hash()
 	return blockHash, blockHash == payload.BlockHash
@@ -203,6 +288,15 @@ func BlockAsPayload(bl *types.Block) (*ExecutionPayload, error) {
 		}
 		opaqueTxs[i] = otx
 	}
+	// the geth block type of this version does not support the Cancun fields yet
+	var withdrawals *types.Withdrawals
+	if bl.Header().WithdrawalsHash != nil {
+		w := bl.Withdrawals()
+		if w == nil {
+			w = types.Withdrawals{}
+		}
+		withdrawals = &w
+	}
 	return &ExecutionPayload{
 		ParentHash:    bl.ParentHash(),
 		FeeRecipient:  bl.Coinbase(),
@@ -218,6 +312,7 @@ func BlockAsPayload(bl *types.Block) (*ExecutionPayload, error) {
 		BaseFeePerGas: Uint256Quantity(*baseFee),
 		BlockHash:     bl.Hash(),
 		Transactions:  opaqueTxs,
+		Withdrawals:   withdrawals,
 	}, nil
 }
 
@@ -234,6 +329,51 @@ type PayloadAttributes struct {
 	NoTxPool bool `json:"noTxPool,omitempty"`
 	// GasLimit override
 	GasLimit *Uint64Quantity `json:"gasLimit,omitempty"`
+	// MaxTxDASize limits the estimated data-availability size of each transaction from the transaction-pool.
+	// Only used by the sequencer, to throttle block building when the batcher falls behind.
+	// Note: the pinned op-geth version does not support this field yet, and ignores it.
+	MaxTxDASize *Uint64Quantity `json:"maxTxDASize,omitempty"`
+	// MaxBlockDASize limits the estimated data-availability size of all transactions from the transaction-pool.
+	// Note: the pinned op-geth version does not support this field yet, and ignores it.
+	MaxBlockDASize *Uint64Quantity `json:"maxBlockDASize,omitempty"`
+	// Withdrawals to include in the block, required since Shanghai (engine API V2). Always empty on L2.
+	Withdrawals *types.Withdrawals `json:"withdrawals,omitempty"`
+	// ParentBeaconBlockRoot of the block, required since Cancun (engine API V3).
+	ParentBeaconBlockRoot *common.Hash `json:"parentBeaconBlockRoot,omitempty"`
+}
+
+// PayloadInfo identifies a payload that is being built by the engine.
+type PayloadInfo struct {
+	ID PayloadID
+	// Timestamp of the payload, which determines the engine API version to retrieve the payload with.
+	Timestamp uint64
+	// ParentBeaconBlockRoot of the payload attributes, nil before Cancun.
+	// The engine does not return it with the payload, but it is part of the block header.
+	ParentBeaconBlockRoot *common.Hash
+}
+
+// EngineAPIMethod is a versioned engine API method.
+type EngineAPIMethod string
+
+const (
+	FCUV1 EngineAPIMethod = "engine_forkchoiceUpdatedV1"
+	FCUV2 EngineAPIMethod = "engine_forkchoiceUpdatedV2"
+	FCUV3 EngineAPIMethod = "engine_forkchoiceUpdatedV3"
+
+	NewPayloadV1 EngineAPIMethod = "engine_newPayloadV1"
+	NewPayloadV2 EngineAPIMethod = "engine_newPayloadV2"
+	NewPayloadV3 EngineAPIMethod = "engine_newPayloadV3"
+
+	GetPayloadV1 EngineAPIMethod = "engine_getPayloadV1"
+	GetPayloadV2 EngineAPIMethod = "engine_getPayloadV2"
+	GetPayloadV3 EngineAPIMethod = "engine_getPayloadV3"
+)
+
+// ExecutionPayloadEnvelope is the response of engine_getPayloadV2 and engine_getPayloadV3,
+// which wraps the execution payload. The block value and the blobs bundle are ignored:
+// there is no builder market on L2, and L2 blocks carry no blob transactions.
+type ExecutionPayloadEnvelope struct {
+	ExecutionPayload *ExecutionPayload `json:"executionPayload"`
 }
 
 type ExecutePayloadStatus string
@@ -1,10 +1,14 @@
 package eth
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 func TestInputError(t *testing.T) {
@@ -18,3 +22,28 @@ func TestInputError(t *testing.T) {
 	}
 	require.ErrorIs(t, err, InputError{}, "need to detect input error with errors.Is")
 }
+
+func TestExecutionPayloadV3JSON(t *testing.T) {
+	blobGasUsed, excessBlobGas := Uint64Quantity(0x20000), Uint64Quantity(0x40000)
+	payload := &ExecutionPayload{
+		ParentHash:            common.Hash{0x01},
+		BlockNumber:           100,
+		Timestamp:             1000,
+		ExtraData:             BytesMax32{0x05},
+		BlockHash:             common.Hash{0x02},
+		Transactions:          []Data{{0x03}},
+		Withdrawals:           &types.Withdrawals{},
+		BlobGasUsed:           &blobGasUsed,
+		ExcessBlobGas:         &excessBlobGas,
+		ParentBeaconBlockRoot: &common.Hash{0x04},
+	}
+	require.NoError(t, payload.CheckVersion(BlockV3))
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	var got ExecutionPayload
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, BlockV3, got.Version())
+	require.NoError(t, got.CheckVersion(BlockV3))
+	require.Equal(t, payload, &got)
+}
@@ -0,0 +1,219 @@
+package cheat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+)
+
+// storageVar locates a state variable in the storage of a contract.
+type storageVar struct {
+	Slot common.Hash
+	// Offset is the offset in bytes of the variable in the slot, counted from the low-order end.
+	Offset uint
+	Type   solc.StorageLayoutType
+}
+
+// lookupStorageVar returns where the state variable with the given label is stored,
+// and checks it has the expected type encoding and size.
+func lookupStorageVar(layout *solc.StorageLayout, label string, encoding string, size uint) (storageVar, error) {
+	entry, err := layout.GetStorageLayoutEntry(label)
+	if err != nil {
+		return storageVar{}, err
+	}
+	ty, err := layout.GetStorageLayoutType(entry.Type)
+	if err != nil {
+		return storageVar{}, err
+	}
+	if ty.Encoding != encoding || ty.NumberOfBytes != size {
+		return storageVar{}, fmt.Errorf("%s has unexpected type %s: encoding %s of %d bytes", label, ty.Label, ty.Encoding, ty.NumberOfBytes)
+	}
+	return storageVar{Slot: common.BigToHash(new(big.Int).SetUint64(uint64(entry.Slot))), Offset: entry.Offset, Type: ty}, nil
+}
+
+// systemConfigCommitmentManager returns where SystemConfig.commitmentManager is stored, according to the
+// generated storage layout. It is packed together with the Initializable flags.
+func systemConfigCommitmentManager() (storageVar, error) {
+	layout, err := bindings.GetStorageLayout("SystemConfig")
+	if err != nil {
+		return storageVar{}, err
+	}
+	return lookupStorageVar(layout, "commitmentManager", "inplace", common.AddressLength)
+}
+
+// FeeRecipientCommitment storage: slot 0 is the l2OutputOracle from CommitmentBase,
+// followed by the feeRecipientIsSet and feeRecipientSet mappings.
+var (
+	feeRecipientIsSetSlot = common.BigToHash(big.NewInt(1))
+	feeRecipientSetSlot   = common.BigToHash(big.NewInt(2))
+)
+
+// CommitmentLayout describes where the CommitmentManager stores the commitments of an account.
+// The commitments are a mapping(address account => mapping(bytes32 target => address[] commitments)),
+// where each commitment is the address of the contract with the indicator function,
+// as emitted in the CommitmentCreated event.
+// The CommitmentManager is not part of op-bindings, so its layout is loaded from the compiler output.
+type CommitmentLayout struct {
+	CommitmentsSlot common.Hash
+}
+
+// NewCommitmentLayout locates the commitments mapping in the storage layout of the CommitmentManager.
+func NewCommitmentLayout(layout *solc.StorageLayout) (CommitmentLayout, error) {
+	v, err := lookupStorageVar(layout, "commitments", "mapping", 32)
+	if err != nil {
+		return CommitmentLayout{}, fmt.Errorf("invalid CommitmentManager storage layout: %w", err)
+	}
+	if v.Type.Key != "t_address" {
+		return CommitmentLayout{}, fmt.Errorf("invalid CommitmentManager storage layout: commitments mapping has key %s, expected an address", v.Type.Key)
+	}
+	return CommitmentLayout{CommitmentsSlot: v.Slot}, nil
+}
+
+// LoadCommitmentLayout loads the storage layout of the CommitmentManager, as output by
+// `forge inspect CommitmentManager storage-layout`, and locates the commitments mapping in it.
+func LoadCommitmentLayout(path string) (CommitmentLayout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CommitmentLayout{}, fmt.Errorf("failed to read CommitmentManager storage layout: %w", err)
+	}
+	var layout solc.StorageLayout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return CommitmentLayout{}, fmt.Errorf("failed to decode CommitmentManager storage layout: %w", err)
+	}
+	return NewCommitmentLayout(&layout)
+}
+
+// commitmentsArraySlot returns the slot of the length of the commitments array of the account and target.
+func (l CommitmentLayout) commitmentsArraySlot(account common.Address, target common.Hash) common.Hash {
+	outer := crypto.Keccak256Hash(common.LeftPadBytes(account[:], 32), l.CommitmentsSlot[:])
+	return crypto.Keccak256Hash(target[:], outer[:])
+}
+
+// CommitmentSet is the set of commitments of an account for a target.
+type CommitmentSet struct {
+	Manager     common.Address   `json:"manager"`
+	Account     common.Address   `json:"account"`
+	Target      common.Hash      `json:"target"`
+	Commitments []common.Address `json:"commitments"`
+}
+
+func readCommitments(headState *state.StateDB, manager common.Address, layout CommitmentLayout, account common.Address, target common.Hash) []common.Address {
+	lengthSlot := layout.commitmentsArraySlot(account, target)
+	length := headState.GetState(manager, lengthSlot).Big().Uint64()
+	base := crypto.Keccak256Hash(lengthSlot[:]).Big()
+	out := make([]common.Address, 0, length)
+	for i := uint64(0); i < length; i++ {
+		slot := common.BigToHash(new(big.Int).Add(base, new(big.Int).SetUint64(i)))
+		out = append(out, common.BytesToAddress(headState.GetState(manager, slot).Bytes()))
+	}
+	return out
+}
+
+func writeCommitments(headState *state.StateDB, manager common.Address, layout CommitmentLayout, account common.Address, target common.Hash, commitments []common.Address) {
+	lengthSlot := layout.commitmentsArraySlot(account, target)
+	prevLength := headState.GetState(manager, lengthSlot).Big().Uint64()
+	base := crypto.Keccak256Hash(lengthSlot[:]).Big()
+	for i := uint64(0); i < prevLength || i < uint64(len(commitments)); i++ {
+		slot := common.BigToHash(new(big.Int).Add(base, new(big.Int).SetUint64(i)))
+		var value common.Hash
+		if i < uint64(len(commitments)) {
+			value = common.BytesToHash(commitments[i][:])
+		}
+		headState.SetState(manager, slot, value)
+	}
+	headState.SetState(manager, lengthSlot, common.BigToHash(new(big.Int).SetUint64(uint64(len(commitments)))))
+}
+
+func printCommitments(headState *state.StateDB, w io.Writer, manager common.Address, layout CommitmentLayout, account common.Address, target common.Hash) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(CommitmentSet{
+		Manager:     manager,
+		Account:     account,
+		Target:      target,
+		Commitments: readCommitments(headState, manager, layout, account, target),
+	})
+}
+
+// PrintCommitments writes the commitments of the account for the target as JSON.
+func PrintCommitments(manager common.Address, layout CommitmentLayout, account common.Address, target common.Hash, w io.Writer) HeadFn {
+	return func(headState *state.StateDB) error {
+		return printCommitments(headState, w, manager, layout, account, target)
+	}
+}
+
+// AddCommitment appends a commitment of the account for the target, and writes the resulting commitment set as JSON.
+func AddCommitment(manager common.Address, layout CommitmentLayout, account common.Address, target common.Hash, commitment common.Address, w io.Writer) HeadFn {
+	return func(headState *state.StateDB) error {
+		commitments := readCommitments(headState, manager, layout, account, target)
+		for _, c := range commitments {
+			if c == commitment {
+				return fmt.Errorf("account %s already has commitment %s for target %s", account, commitment, target)
+			}
+		}
+		writeCommitments(headState, manager, layout, account, target, append(commitments, commitment))
+		return printCommitments(headState, w, manager, layout, account, target)
+	}
+}
+
+// RemoveCommitment removes a commitment of the account for the target, and writes the resulting commitment set as JSON.
+// Like a Solidity array removal, the last commitment takes the place of the removed one.
+func RemoveCommitment(manager common.Address, layout CommitmentLayout, account common.Address, target common.Hash, commitment common.Address, w io.Writer) HeadFn {
+	return func(headState *state.StateDB) error {
+		commitments := readCommitments(headState, manager, layout, account, target)
+		for i, c := range commitments {
+			if c == commitment {
+				last := len(commitments) - 1
+				commitments[i] = commitments[last]
+				writeCommitments(headState, manager, layout, account, target, commitments[:last])
+				return printCommitments(headState, w, manager, layout, account, target)
+			}
+		}
+		return fmt.Errorf("account %s has no commitment %s for target %s", account, commitment, target)
+	}
+}
+
+// SetFeeRecipientCommitment sets the fee recipient that the sequencer committed to for the given L2 block,
+// in the storage of a FeeRecipientCommitment contract. A zero fee recipient clears the commitment.
+func SetFeeRecipientCommitment(commitment common.Address, sequencer common.Address, blockNumber uint64, feeRecipient common.Address) HeadFn {
+	return func(headState *state.StateDB) error {
+		key := common.BigToHash(new(big.Int).SetUint64(blockNumber))
+		isSetInner := crypto.Keccak256Hash(common.LeftPadBytes(sequencer[:], 32), feeRecipientIsSetSlot[:])
+		setInner := crypto.Keccak256Hash(common.LeftPadBytes(sequencer[:], 32), feeRecipientSetSlot[:])
+		var isSet common.Hash
+		if feeRecipient != (common.Address{}) {
+			isSet = common.BigToHash(big.NewInt(1))
+		}
+		headState.SetState(commitment, crypto.Keccak256Hash(key[:], isSetInner[:]), isSet)
+		headState.SetState(commitment, crypto.Keccak256Hash(key[:], setInner[:]), common.BytesToHash(feeRecipient[:]))
+		return nil
+	}
+}
+
+// SetScreener sets the CommitmentManager that the SystemConfig screens L2 blocks with,
+// and writes the previous manager, so it can be restored. A zero address disables the screener.
+func SetScreener(systemConfig common.Address, manager common.Address, w io.Writer) HeadFn {
+	return func(headState *state.StateDB) error {
+		v, err := systemConfigCommitmentManager()
+		if err != nil {
+			return fmt.Errorf("failed to locate SystemConfig commitment manager: %w", err)
+		}
+		value := headState.GetState(systemConfig, v.Slot)
+		end := common.HashLength - v.Offset
+		start := end - common.AddressLength
+		prev := common.BytesToAddress(value[start:end])
+		copy(value[start:end], manager[:])
+		headState.SetState(systemConfig, v.Slot, value)
+		_, err = fmt.Fprintf(w, "screener changed from %s to %s\n", prev, manager)
+		return err
+	}
+}
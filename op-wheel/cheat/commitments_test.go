@@ -0,0 +1,94 @@
+package cheat
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+)
+
+// commitmentManagerLayoutJSON is the shape of the CommitmentManager storage layout, as output by forge.
+const commitmentManagerLayoutJSON = `{
+	"storage": [
+		{"astId": 1, "contract": "src/CommitmentManager.sol:CommitmentManager", "label": "screener", "offset": 0, "slot": "0", "type": "t_address"},
+		{"astId": 2, "contract": "src/CommitmentManager.sol:CommitmentManager", "label": "commitments", "offset": 0, "slot": "3",
+			"type": "t_mapping(t_address,t_mapping(t_bytes32,t_array(t_address)dyn_storage))"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_array(t_address)dyn_storage": {"encoding": "dynamic_array", "label": "address[]", "numberOfBytes": "32", "base": "t_address"},
+		"t_bytes32": {"encoding": "inplace", "label": "bytes32", "numberOfBytes": "32"},
+		"t_mapping(t_address,t_mapping(t_bytes32,t_array(t_address)dyn_storage))": {"encoding": "mapping", "label": "mapping(address => mapping(bytes32 => address[]))",
+			"numberOfBytes": "32", "key": "t_address", "value": "t_mapping(t_bytes32,t_array(t_address)dyn_storage)"},
+		"t_mapping(t_bytes32,t_array(t_address)dyn_storage)": {"encoding": "mapping", "label": "mapping(bytes32 => address[])",
+			"numberOfBytes": "32", "key": "t_bytes32", "value": "t_array(t_address)dyn_storage"}
+	}
+}`
+
+func newTestState(t *testing.T) *state.StateDB {
+	st, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	return st
+}
+
+func TestSystemConfigCommitmentManager(t *testing.T) {
+	v, err := systemConfigCommitmentManager()
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, v.Slot)
+	require.Equal(t, uint(0), v.Offset)
+
+	// the Initializable flags packed into the same slot are preserved
+	st := newTestState(t)
+	sysCfg := common.Address{0x5c}
+	initialized := common.Hash{}
+	initialized[common.HashLength-common.AddressLength-1] = 1
+	st.SetState(sysCfg, v.Slot, initialized)
+
+	manager := common.Address{0xaa, 0xbb}
+	require.NoError(t, SetScreener(sysCfg, manager, new(bytes.Buffer))(st))
+	value := st.GetState(sysCfg, v.Slot)
+	require.Equal(t, manager, common.BytesToAddress(value[common.HashLength-common.AddressLength:]))
+	require.Equal(t, byte(1), value[common.HashLength-common.AddressLength-1])
+}
+
+func TestCommitmentLayout(t *testing.T) {
+	var layout solc.StorageLayout
+	require.NoError(t, json.Unmarshal([]byte(commitmentManagerLayoutJSON), &layout))
+	l, err := NewCommitmentLayout(&layout)
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(3)), l.CommitmentsSlot)
+
+	st := newTestState(t)
+	manager := common.Address{0xcc}
+	account := common.Address{0xdd}
+	target := common.Hash{0xee}
+	commitment := common.Address{0xff}
+	require.NoError(t, AddCommitment(manager, l, account, target, commitment, new(bytes.Buffer))(st))
+	require.Equal(t, []common.Address{commitment}, readCommitments(st, manager, l, account, target))
+
+	// the array length is stored at keccak256(target . keccak256(account . slot))
+	outer := crypto.Keccak256Hash(common.LeftPadBytes(account[:], 32), l.CommitmentsSlot[:])
+	lengthSlot := crypto.Keccak256Hash(target[:], outer[:])
+	require.Equal(t, common.BigToHash(big.NewInt(1)), st.GetState(manager, lengthSlot))
+
+	t.Run("missing mapping", func(t *testing.T) {
+		layout := solc.StorageLayout{Storage: layout.Storage[:1], Types: layout.Types}
+		_, err := NewCommitmentLayout(&layout)
+		require.ErrorContains(t, err, "commitments not found")
+	})
+	t.Run("not a mapping of accounts", func(t *testing.T) {
+		storage := append([]solc.StorageLayoutEntry{}, layout.Storage...)
+		storage[1].Type = "t_mapping(t_bytes32,t_array(t_address)dyn_storage)"
+		_, err := NewCommitmentLayout(&solc.StorageLayout{Storage: storage, Types: layout.Types})
+		require.ErrorContains(t, err, "expected an address")
+	})
+}
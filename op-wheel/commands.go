@@ -111,6 +111,19 @@ func CheatAction(readOnly bool, fn func(ctx *cli.Context, ch *cheat.Cheater) err
 	}
 }
 
+// CommitmentsAction loads the storage layout of the CommitmentManager, before opening the geth db.
+func CommitmentsAction(readOnly bool, fn func(ctx *cli.Context, ch *cheat.Cheater, layout cheat.CommitmentLayout) error) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		layout, err := cheat.LoadCommitmentLayout(ctx.Path(CommitmentsLayoutFlag.Name))
+		if err != nil {
+			return err
+		}
+		return CheatAction(readOnly, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			return fn(ctx, ch, layout)
+		})(ctx)
+	}
+}
+
 func CheatRawDBAction(readOnly bool, fn func(ctx *cli.Context, db ethdb.Database) error) cli.ActionFunc {
 	return func(ctx *cli.Context) error {
 		dataDir := ctx.String(DataDirFlag.Name)
@@ -361,6 +374,106 @@ var (
 			return enc.Encode(rawdb.ReadHeadHeader(db))
 		}),
 	}
+	CommitmentTargetFlag = &cli.GenericFlag{
+		Name:    "target",
+		Usage:   "Commitment target, defaults to the target that L2 blocks are screened against",
+		EnvVars: prefixEnvVars("TARGET"),
+		Value:   &TextFlag[*common.Hash]{Value: new(common.Hash)},
+	}
+	CommitmentsLayoutFlag = &cli.PathFlag{
+		Name:      "commitments-layout",
+		Usage:     "Storage layout JSON of the CommitmentManager, as output by `forge inspect CommitmentManager storage-layout`",
+		EnvVars:   prefixEnvVars("COMMITMENTS_LAYOUT"),
+		Required:  true,
+		TakesFile: true,
+	}
+	CheatCommitmentsListCmd = &cli.Command{
+		Name:  "list",
+		Usage: "Print the commitments of an account as JSON",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("manager", "Address of the CommitmentManager"),
+			addrFlag("account", "Account that entered into the commitments, e.g. the sequencer"),
+			CommitmentTargetFlag, CommitmentsLayoutFlag,
+		},
+		Action: CommitmentsAction(true, func(ctx *cli.Context, ch *cheat.Cheater, layout cheat.CommitmentLayout) error {
+			return ch.RunAndClose(cheat.PrintCommitments(addrFlagValue("manager", ctx), layout,
+				addrFlagValue("account", ctx), hashFlagValue(CommitmentTargetFlag.Name, ctx), ctx.App.Writer))
+		}),
+	}
+	CheatCommitmentsAddCmd = &cli.Command{
+		Name:  "add",
+		Usage: "Add a commitment for an account, and print the resulting commitments",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("manager", "Address of the CommitmentManager"),
+			addrFlag("account", "Account to enter into the commitment, e.g. the sequencer"),
+			addrFlag("commitment", "Address of the commitment contract to add"),
+			CommitmentTargetFlag, CommitmentsLayoutFlag,
+		},
+		Action: CommitmentsAction(false, func(ctx *cli.Context, ch *cheat.Cheater, layout cheat.CommitmentLayout) error {
+			return ch.RunAndClose(cheat.AddCommitment(addrFlagValue("manager", ctx), layout,
+				addrFlagValue("account", ctx), hashFlagValue(CommitmentTargetFlag.Name, ctx), addrFlagValue("commitment", ctx), ctx.App.Writer))
+		}),
+	}
+	CheatCommitmentsRemoveCmd = &cli.Command{
+		Name:  "remove",
+		Usage: "Remove a commitment of an account, and print the resulting commitments",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("manager", "Address of the CommitmentManager"),
+			addrFlag("account", "Account that entered into the commitment, e.g. the sequencer"),
+			addrFlag("commitment", "Address of the commitment contract to remove"),
+			CommitmentTargetFlag, CommitmentsLayoutFlag,
+		},
+		Action: CommitmentsAction(false, func(ctx *cli.Context, ch *cheat.Cheater, layout cheat.CommitmentLayout) error {
+			return ch.RunAndClose(cheat.RemoveCommitment(addrFlagValue("manager", ctx), layout,
+				addrFlagValue("account", ctx), hashFlagValue(CommitmentTargetFlag.Name, ctx), addrFlagValue("commitment", ctx), ctx.App.Writer))
+		}),
+	}
+	CheatCommitmentsFeeRecipientCmd = &cli.Command{
+		Name:  "fee-recipient",
+		Usage: "Set the fee recipient a sequencer committed to for an L2 block, in a FeeRecipientCommitment",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("commitment", "Address of the FeeRecipientCommitment"),
+			addrFlag("sequencer", "Sequencer that committed to the fee recipient"),
+			&cli.Uint64Flag{
+				Name:     "block",
+				Usage:    "L2 block number the fee recipient is committed for",
+				Required: true,
+				EnvVars:  prefixEnvVars("BLOCK"),
+			},
+			addrFlag("fee-recipient", "Committed fee recipient, the zero address clears the commitment"),
+		},
+		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			return ch.RunAndClose(cheat.SetFeeRecipientCommitment(addrFlagValue("commitment", ctx),
+				addrFlagValue("sequencer", ctx), ctx.Uint64("block"), addrFlagValue("fee-recipient", ctx)))
+		}),
+	}
+	CheatCommitmentsScreenerCmd = &cli.Command{
+		Name:  "screener",
+		Usage: "Set the CommitmentManager the SystemConfig screens L2 blocks with, and print the previous one",
+		Flags: []cli.Flag{
+			DataDirFlag,
+			addrFlag("system-config", "Address of the SystemConfig"),
+			addrFlag("manager", "Address of the CommitmentManager, the zero address disables screening"),
+		},
+		Action: CheatAction(false, func(ctx *cli.Context, ch *cheat.Cheater) error {
+			return ch.RunAndClose(cheat.SetScreener(addrFlagValue("system-config", ctx), addrFlagValue("manager", ctx), ctx.App.Writer))
+		}),
+	}
+	CheatCommitmentsCmd = &cli.Command{
+		Name:  "commitments",
+		Usage: "Manipulate the sequencer commitments, without redeploying contracts",
+		Subcommands: []*cli.Command{
+			CheatCommitmentsListCmd,
+			CheatCommitmentsAddCmd,
+			CheatCommitmentsRemoveCmd,
+			CheatCommitmentsFeeRecipientCmd,
+			CheatCommitmentsScreenerCmd,
+		},
+	}
 	EngineBlockCmd = &cli.Command{
 		Name:  "block",
 		Usage: "build the next block using the Engine API",
@@ -524,6 +637,7 @@ var CheatCmd = &cli.Command{
 		CheatSetCodeCmd,
 		CheatSetNonceCmd,
 		CheatOvmOwnersCmd,
+		CheatCommitmentsCmd,
 		CheatPrintHeadBlock,
 		CheatPrintHeadHeader,
 	},